@@ -0,0 +1,43 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeAdminConfig      = "admin/config"
+	routeAdminConfigAudit = "admin/config/audit"
+)
+
+// GetConfig returns the parameters of the node that can be changed at runtime.
+func (api *GoShimmerAPI) GetConfig() (*jsonmodels.GetConfigResponse, error) {
+	res := &jsonmodels.GetConfigResponse{}
+	if err := api.do(http.MethodGet, routeAdminConfig, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetConfig changes a parameter of the node at runtime and optionally writes it back to the config file of the node.
+func (api *GoShimmerAPI) SetConfig(parameter string, value interface{}, writeBack bool) (*jsonmodels.SetConfigResponse, error) {
+	res := &jsonmodels.SetConfigResponse{}
+	if err := api.do(http.MethodPost, routeAdminConfig, &jsonmodels.SetConfigRequest{
+		Parameter: parameter,
+		Value:     value,
+		WriteBack: writeBack,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetConfigAudit returns the latest changes of parameters of the node at runtime.
+func (api *GoShimmerAPI) GetConfigAudit() (*jsonmodels.GetConfigAuditResponse, error) {
+	res := &jsonmodels.GetConfigAuditResponse{}
+	if err := api.do(http.MethodGet, routeAdminConfigAudit, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -2,12 +2,15 @@ package client
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
 
 const (
-	routeData = "data"
+	routeData       = "data"
+	routeTaggedData = "data/tagged"
 )
 
 // Data sends the given data (payload) by creating a message in the backend.
@@ -20,3 +23,34 @@ func (api *GoShimmerAPI) Data(data []byte) (string, error) {
 
 	return res.ID, nil
 }
+
+// TaggedData sends the given data with the given tag by creating a message in the backend. The message can later be
+// found with GetTaggedData.
+func (api *GoShimmerAPI) TaggedData(tag string, data []byte) (string, error) {
+	res := &jsonmodels.DataResponse{}
+	if err := api.do(http.MethodPost, routeData,
+		&jsonmodels.DataRequest{Data: data, Tag: tag}, res); err != nil {
+		return "", err
+	}
+
+	return res.ID, nil
+}
+
+// GetTaggedData returns a page of the messages with the given tag ordered by their issuing time. The cursor is the
+// NextCursor of the previous page (or empty for the first page), a limit of 0 uses the default page size of the node.
+func (api *GoShimmerAPI) GetTaggedData(tag string, cursor string, limit int) (*jsonmodels.GetTaggedDataResponse, error) {
+	query := url.Values{"tag": {tag}}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit != 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	res := &jsonmodels.GetTaggedDataResponse{}
+	if err := api.do(http.MethodGet, routeTaggedData+"?"+query.Encode(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeEvents = "events"
+)
+
+// EventFilter defines the topics and the filters of a subscription to the event stream. Empty fields don't restrict
+// the events.
+type EventFilter struct {
+	Topics       []string
+	Addresses    []string
+	Colors       []string
+	PayloadTypes []uint32
+}
+
+// query returns the query parameters of the EventFilter.
+func (f EventFilter) query() url.Values {
+	query := make(url.Values)
+	for _, topic := range f.Topics {
+		query.Add("topic", topic)
+	}
+	for _, address := range f.Addresses {
+		query.Add("address", address)
+	}
+	for _, color := range f.Colors {
+		query.Add("color", color)
+	}
+	for _, payloadType := range f.PayloadTypes {
+		query.Add("payloadType", strconv.FormatUint(uint64(payloadType), 10))
+	}
+
+	return query
+}
+
+// SubscribeEvents subscribes to the events of the node that pass the given filter. The returned channel receives the
+// events and is closed when the context is canceled or the connection to the node is closed.
+func (api *GoShimmerAPI) SubscribeEvents(ctx context.Context, filter EventFilter) (<-chan *jsonmodels.Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s?%s", api.baseURL, routeEvents, filter.query().Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	api.authorize(req)
+
+	res, err := api.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, interpretBody(res, nil)
+	}
+
+	events := make(chan *jsonmodels.Event)
+	go func() {
+		defer close(events)
+		defer res.Body.Close()
+
+		scanner := bufio.NewScanner(res.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !strings.HasPrefix(line, "data: ") {
+				continue
+			}
+
+			event := &jsonmodels.Event{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), event); err != nil {
+				continue
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -2,6 +2,8 @@ package client
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -11,12 +13,14 @@ const (
 	// basic routes
 	routeGetAddresses     = "ledgerstate/addresses/"
 	routeGetBranches      = "ledgerstate/branches/"
+	routeGetColors        = "ledgerstate/colors/"
 	routeGetOutputs       = "ledgerstate/outputs/"
 	routeGetTransactions  = "ledgerstate/transactions/"
 	routePostTransactions = "ledgerstate/transactions"
 
 	// route path modifiers
 	pathUnspentOutputs = "/unspentOutputs"
+	pathTransactions   = "/transactions"
 	pathChildren       = "/children"
 	pathConflicts      = "/conflicts"
 	pathConsumers      = "/consumers"
@@ -24,6 +28,8 @@ const (
 	pathInclusionState = "/inclusionState"
 	pathConsensus      = "/consensus"
 	pathAttachments    = "/attachments"
+	pathBurns          = "/burns"
+	pathDryRun         = "/dryRun"
 )
 
 // GetAddressOutputs gets the spent and unspent outputs of an address.
@@ -48,6 +54,40 @@ func (api *GoShimmerAPI) GetAddressUnspentOutputs(base58EncodedAddress string) (
 	return res, nil
 }
 
+// GetAddressTransactions gets a page of the Transactions that sent funds to or spent funds from an address, newest
+// first. The next page is requested with the NextCursor of the response. The result can optionally be filtered by direction ("incoming" or "outgoing") and inclusion state ("pending",
+// "confirmed" or "rejected").
+func (api *GoShimmerAPI) GetAddressTransactions(base58EncodedAddress string, request *jsonmodels.GetAddressTransactionsRequest) (*jsonmodels.GetAddressTransactionsResponse, error) {
+	res := &jsonmodels.GetAddressTransactionsResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		query := url.Values{}
+		if request != nil {
+			if request.Direction != "" {
+				query.Set("direction", request.Direction)
+			}
+			if request.InclusionState != "" {
+				query.Set("inclusionState", request.InclusionState)
+			}
+			if request.Cursor != "" {
+				query.Set("cursor", request.Cursor)
+			}
+			if request.Limit != 0 {
+				query.Set("limit", strconv.Itoa(request.Limit))
+			}
+		}
+
+		route := strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathTransactions}, "")
+		if len(query) != 0 {
+			route += "?" + query.Encode()
+		}
+
+		return route
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // PostAddressUnspentOutputs gets the unspent outputs of several addresses.
 func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
 	res := &jsonmodels.PostAddressesUnspentOutputsResponse{}
@@ -92,6 +132,28 @@ func (api *GoShimmerAPI) GetBranchConflicts(base58EncodedBranchID string) (*json
 	return res, nil
 }
 
+// GetColor gets the supply information of a color.
+func (api *GoShimmerAPI) GetColor(base58EncodedColor string) (*jsonmodels.ColorSupply, error) {
+	res := &jsonmodels.ColorSupply{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetColors, base58EncodedColor}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetColorBurns gets the burn history of a color.
+func (api *GoShimmerAPI) GetColorBurns(base58EncodedColor string) (*jsonmodels.GetColorBurnsResponse, error) {
+	res := &jsonmodels.GetColorBurnsResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return strings.Join([]string{routeGetColors, base58EncodedColor, pathBurns}, "")
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetOutput gets the output corresponding to OutputID.
 func (api *GoShimmerAPI) GetOutput(base58EncodedOutputID string) (*jsonmodels.Output, error) {
 	res := &jsonmodels.Output{}
@@ -190,3 +252,15 @@ func (api *GoShimmerAPI) PostTransaction(transactionBytes []byte) (*jsonmodels.P
 
 	return res, nil
 }
+
+// DryRunTransaction validates the transaction(bytes) against the current ledger state of the node without issuing it
+// and returns all the violations that would cause the transaction to be rejected.
+func (api *GoShimmerAPI) DryRunTransaction(transactionBytes []byte) (*jsonmodels.DryRunTransactionResponse, error) {
+	res := &jsonmodels.DryRunTransactionResponse{}
+	if err := api.do(http.MethodPost, routePostTransactions+pathDryRun,
+		&jsonmodels.PostTransactionRequest{TransactionBytes: transactionBytes}, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -21,6 +21,10 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrUnauthorized defines the "unauthorized" error.
 	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden defines the "forbidden" error.
+	ErrForbidden = errors.New("forbidden")
+	// ErrTooManyRequests defines the "too many requests" error.
+	ErrTooManyRequests = errors.New("too many requests")
 	// ErrUnknownError defines the "unknown error" error.
 	ErrUnknownError = errors.New("unknown error")
 	// ErrNotImplemented defines the "operation not implemented/supported/available" error.
@@ -52,6 +56,13 @@ func WithBasicAuth(username, password string) Option {
 	}
 }
 
+// WithAPIToken sets the API token that is sent with every request.
+func WithAPIToken(token string) Option {
+	return func(g *GoShimmerAPI) {
+		g.apiToken = token
+	}
+}
+
 // WithHTTPClient sets the http Client.
 func WithHTTPClient(c http.Client) Option {
 	return func(g *GoShimmerAPI) {
@@ -85,6 +96,7 @@ type GoShimmerAPI struct {
 	baseURL    string
 	httpClient http.Client
 	basicAuth  BasicAuth
+	apiToken   string
 }
 
 type errorresponse struct {
@@ -116,6 +128,10 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 		return fmt.Errorf("%w: %s", ErrBadRequest, errRes.Error)
 	case http.StatusUnauthorized:
 		return fmt.Errorf("%w: %s", ErrUnauthorized, errRes.Error)
+	case http.StatusForbidden:
+		return fmt.Errorf("%w: %s", ErrForbidden, errRes.Error)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s", ErrTooManyRequests, errRes.Error)
 	case http.StatusNotImplemented:
 		return fmt.Errorf("%w: %s", ErrNotImplemented, errRes.Error)
 	}
@@ -149,10 +165,7 @@ func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, res
 		req.Header.Set("Content-Type", contentTypeJSON)
 	}
 
-	// if enabled, add the basic-auth
-	if api.basicAuth.IsEnabled() {
-		req.SetBasicAuth(api.basicAuth.Credentials())
-	}
+	api.authorize(req)
 
 	// make the request
 	res, err := api.httpClient.Do(req)
@@ -171,6 +184,19 @@ func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, res
 	return nil
 }
 
+// authorize adds the configured credentials to the request.
+func (api *GoShimmerAPI) authorize(req *http.Request) {
+	// if enabled, add the basic-auth
+	if api.basicAuth.IsEnabled() {
+		req.SetBasicAuth(api.basicAuth.Credentials())
+	}
+
+	// if set, add the API token
+	if api.apiToken != "" {
+		req.Header.Set("Authorization", "Bearer "+api.apiToken)
+	}
+}
+
 // BaseURL returns the baseURL of the API.
 func (api *GoShimmerAPI) BaseURL() string {
 	return api.baseURL
@@ -19,6 +19,11 @@ const (
 	routePastConsensusVector      = "mana/consensus/past"
 	routePastConsensusEventLogs   = "mana/consensus/logs"
 	routeAllowedPledgeNodeIDs     = "mana/allowedManaPledge"
+	routeManaHistory              = "mana/analytics/history"
+	routeManaPledgers             = "mana/analytics/pledgers"
+	routeManaPledgees             = "mana/analytics/pledgees"
+	routeManaEpochs               = "mana/analytics/epochs"
+	routeManaGini                 = "mana/analytics/gini"
 )
 
 // GetOwnMana returns the access and consensus mana of the node this api client is communicating with.
@@ -177,3 +182,66 @@ func (api *GoShimmerAPI) GetAllowedManaPledgeNodeIDs() (*jsonmodels.AllowedManaP
 
 	return res, nil
 }
+
+// GetManaHistory returns the pledge and revoke history of the given node for the given mana type ("Access" or
+// "Consensus") within [startTime, endTime]. A zero startTime or endTime leaves the corresponding side open.
+func (api *GoShimmerAPI) GetManaHistory(fullNodeID, manaType string, startTime, endTime int64) (*jsonmodels.GetManaHistoryResponse, error) {
+	res := &jsonmodels.GetManaHistoryResponse{}
+	if err := api.do(http.MethodGet, routeManaHistory, &jsonmodels.GetManaHistoryRequest{
+		NodeID:    fullNodeID,
+		ManaType:  manaType,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaPledgers returns the n addresses that pledged the most mana to the given node, sorted in descending order.
+func (api *GoShimmerAPI) GetManaPledgers(fullNodeID, manaType string, n uint) (*jsonmodels.GetManaPledgersResponse, error) {
+	res := &jsonmodels.GetManaPledgersResponse{}
+	if err := api.do(http.MethodGet, routeManaPledgers, &jsonmodels.GetManaPledgersRequest{
+		NodeID:   fullNodeID,
+		ManaType: manaType,
+		Number:   n,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaPledgees returns the n nodes that received the most mana from the given address, sorted in descending order.
+func (api *GoShimmerAPI) GetManaPledgees(base58EncodedAddress, manaType string, n uint) (*jsonmodels.GetManaPledgeesResponse, error) {
+	res := &jsonmodels.GetManaPledgeesResponse{}
+	if err := api.do(http.MethodGet, routeManaPledgees, &jsonmodels.GetManaPledgeesRequest{
+		Address:  base58EncodedAddress,
+		ManaType: manaType,
+		Number:   n,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaEpochs returns the distribution of pledged mana in the given epochs. All epochs known to the node are
+// returned if none are specified.
+func (api *GoShimmerAPI) GetManaEpochs(manaType string, epochs ...int64) (*jsonmodels.GetManaEpochsResponse, error) {
+	res := &jsonmodels.GetManaEpochsResponse{}
+	if err := api.do(http.MethodGet, routeManaEpochs, &jsonmodels.GetManaEpochsRequest{
+		ManaType: manaType,
+		Epochs:   epochs,
+	}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetManaGini returns the Gini coefficients of the access and consensus mana distributions.
+func (api *GoShimmerAPI) GetManaGini() (*jsonmodels.GetManaGiniResponse, error) {
+	res := &jsonmodels.GetManaGiniResponse{}
+	if err := api.do(http.MethodGet, routeManaGini, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
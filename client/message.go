@@ -2,6 +2,8 @@ package client
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
@@ -10,6 +12,7 @@ const (
 	routeMessage         = "messages/"
 	routeMessageMetadata = "/metadata"
 	routeSendPayload     = "messages/payload"
+	routeMessageIndex    = "messages/index"
 )
 
 // GetMessage is the handler for the /messages/:messageID endpoint.
@@ -54,3 +57,32 @@ func (api *GoShimmerAPI) SendPayload(payload []byte) (string, error) {
 
 	return res.ID, nil
 }
+
+// GetMessageIndex returns a page of the messages of a payload type (and optionally an issuer and a time range) from
+// the message index of the node. The NextCursor of the response can be passed as the Cursor of the request to fetch
+// the next page.
+func (api *GoShimmerAPI) GetMessageIndex(request jsonmodels.GetMessageIndexRequest) (*jsonmodels.GetMessageIndexResponse, error) {
+	query := url.Values{"payloadType": {request.PayloadType}}
+	if request.Issuer != "" {
+		query.Set("issuer", request.Issuer)
+	}
+	if request.From != 0 {
+		query.Set("from", strconv.FormatInt(request.From, 10))
+	}
+	if request.To != 0 {
+		query.Set("to", strconv.FormatInt(request.To, 10))
+	}
+	if request.Cursor != "" {
+		query.Set("cursor", request.Cursor)
+	}
+	if request.Limit != 0 {
+		query.Set("limit", strconv.Itoa(request.Limit))
+	}
+
+	res := &jsonmodels.GetMessageIndexResponse{}
+	if err := api.do(http.MethodGet, routeMessageIndex+"?"+query.Encode(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
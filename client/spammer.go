@@ -3,6 +3,7 @@ package client
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
@@ -24,3 +25,30 @@ func (api *GoShimmerAPI) ToggleSpammer(enable bool, mpm int) (*jsonmodels.Spamme
 	}
 	return res, nil
 }
+
+// StartSpammerScenario starts the node internal spammer with the given scenario of its scenario file.
+func (api *GoShimmerAPI) StartSpammerScenario(scenario string) (*jsonmodels.SpammerResponse, error) {
+	res := &jsonmodels.SpammerResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?cmd=start&scenario=%s", routeSpammer, url.QueryEscape(scenario)), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSpammerScenarios returns the scenarios the node internal spammer can run.
+func (api *GoShimmerAPI) GetSpammerScenarios() (*jsonmodels.SpammerResponse, error) {
+	res := &jsonmodels.SpammerResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?cmd=scenarios", routeSpammer), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetSpammerStats returns the issuance and confirmation statistics of the scenarios run by the node internal spammer.
+func (api *GoShimmerAPI) GetSpammerStats() (*jsonmodels.SpammerResponse, error) {
+	res := &jsonmodels.SpammerResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s?cmd=stats", routeSpammer), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
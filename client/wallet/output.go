@@ -115,7 +115,8 @@ func (o OutputsByAddressAndOutputID) ConditionalOutputsOnly() OutputsByAddressAn
 			if output.Object.Type() == ledgerstate.ExtendedLockedOutputType {
 				casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
 				_, fallbackDeadline := casted.FallbackOptions()
-				if !fallbackDeadline.IsZero() && addy.Address().Equals(casted.UnlockAddressNow(now)) {
+				// hash-locked outputs can't be unlocked by a signature alone
+				if !fallbackDeadline.IsZero() && !casted.HashLockedNow(now) && addy.Address().Equals(casted.UnlockAddressNow(now)) {
 					if _, addressExists := result[addy]; !addressExists {
 						result[addy] = make(map[ledgerstate.OutputID]*Output)
 					}
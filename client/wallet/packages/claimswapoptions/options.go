@@ -0,0 +1,99 @@
+package claimswapoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// ClaimSwapOption is a function that provides an option.
+type ClaimSwapOption func(options *ClaimSwapOptions) error
+
+// OutputID is an option for the ClaimSwap call that defines which hash-time-locked output is claimed.
+func OutputID(outputID string) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		parsed, err := ledgerstate.OutputIDFromBase58(outputID)
+		if err != nil {
+			return err
+		}
+		options.OutputID = parsed
+		return nil
+	}
+}
+
+// Preimage is an option for the ClaimSwap call that defines the secret that unlocks the hash lock of the output.
+func Preimage(preimage []byte) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		if len(preimage) == 0 || len(preimage) > ledgerstate.MaxPreimageSize {
+			return errors.Errorf("preimage size (%d) must be within [1, %d]", len(preimage), ledgerstate.MaxPreimageSize)
+		}
+		options.Preimage = preimage
+		return nil
+	}
+}
+
+// ToAddress is an option for the ClaimSwap call that defines where the claimed funds are sent to.
+func ToAddress(addr address.Address) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		options.ToAddress = addr
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for ClaimSwap call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for ClaimSwap call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) ClaimSwapOption {
+	return func(options *ClaimSwapOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// ClaimSwapOptions is a struct that is used to aggregate the optional parameters in the ClaimSwap call.
+type ClaimSwapOptions struct {
+	OutputID              ledgerstate.OutputID
+	Preimage              []byte
+	ToAddress             address.Address
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build is a utility function that constructs the ClaimSwapOptions.
+func Build(options ...ClaimSwapOption) (result *ClaimSwapOptions, err error) {
+	// create options to collect the arguments provided
+	result = &ClaimSwapOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	// sanitize parameters
+	if result.OutputID == ledgerstate.EmptyOutputID {
+		return nil, errors.New("an OutputID must be specified to claim a swap")
+	}
+	if len(result.Preimage) == 0 {
+		return nil, errors.New("a Preimage must be specified to claim a swap")
+	}
+
+	return
+}
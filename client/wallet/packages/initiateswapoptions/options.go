@@ -0,0 +1,156 @@
+package initiateswapoptions
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// InitiateSwapOption is a function that provides an option.
+type InitiateSwapOption func(options *InitiateSwapOptions) error
+
+// Destination is an option for the InitiateSwap call that defines the counterparty of the swap and the funds that are
+// locked for it.
+func Destination(addr address.Address, amount uint64, optionalColor ...ledgerstate.Color) InitiateSwapOption {
+	var outputColor ledgerstate.Color
+	switch len(optionalColor) {
+	case 0:
+		outputColor = ledgerstate.ColorIOTA
+	case 1:
+		outputColor = optionalColor[0]
+	default:
+		return optionError(errors.New("providing more than one color for the swapped funds is forbidden"))
+	}
+	if outputColor == ledgerstate.ColorMint {
+		return optionError(errors.New("minting new tokens in a swap is not supported"))
+	}
+	if amount == 0 {
+		return optionError(errors.New("the amount provided in the destination needs to be larger than 0"))
+	}
+
+	return func(options *InitiateSwapOptions) error {
+		if options.Amount != nil {
+			return errors.New("a swap can only have a single destination")
+		}
+		options.Destination = addr
+		options.Amount = map[ledgerstate.Color]uint64{outputColor: amount}
+		return nil
+	}
+}
+
+// Deadline is an option for the InitiateSwap call that defines until when the counterparty can claim the funds. After
+// the deadline the funds can be refunded by the wallet.
+func Deadline(deadline time.Time) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		if deadline.Before(time.Now()) {
+			return errors.Errorf("invalid swap deadline: %s is in the past", deadline.String())
+		}
+		options.Deadline = deadline
+		return nil
+	}
+}
+
+// Preimage is an option for the InitiateSwap call that defines the secret whose hash locks the funds. If neither a
+// Preimage nor a HashLock is provided, a random preimage is generated.
+func Preimage(preimage []byte) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		if len(preimage) == 0 || len(preimage) > ledgerstate.MaxPreimageSize {
+			return errors.Errorf("preimage size (%d) must be within [1, %d]", len(preimage), ledgerstate.MaxPreimageSize)
+		}
+		options.Preimage = preimage
+		options.HashLock = ledgerstate.NewHashLock(preimage)
+		return nil
+	}
+}
+
+// HashLock is an option for the InitiateSwap call that locks the funds with the hash of a secret that is only known to
+// the counterparty (i.e. when responding to a swap that was initiated by somebody else).
+func HashLock(hashLock ledgerstate.HashLock) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		if hashLock == ledgerstate.EmptyHashLock {
+			return errors.New("empty hash lock provided")
+		}
+		options.Preimage = nil
+		options.HashLock = hashLock
+		return nil
+	}
+}
+
+// Remainder is an option for the InitiateSwap call that allows us to specify the remainder address that is supposed to
+// be used in the corresponding transaction.
+func Remainder(addr address.Address) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		options.RemainderAddress = addr
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for InitiateSwap call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for InitiateSwap call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// InitiateSwapOptions is a struct that is used to aggregate the optional parameters in the InitiateSwap call.
+type InitiateSwapOptions struct {
+	Destination           address.Address
+	Amount                map[ledgerstate.Color]uint64
+	Deadline              time.Time
+	Preimage              []byte
+	HashLock              ledgerstate.HashLock
+	RemainderAddress      address.Address
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build is a utility function that constructs the InitiateSwapOptions.
+func Build(options ...InitiateSwapOption) (result *InitiateSwapOptions, err error) {
+	// create options to collect the arguments provided
+	result = &InitiateSwapOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	// sanitize parameters
+	if result.Amount == nil {
+		return nil, errors.New("you need to provide a Destination to initiate a swap")
+	}
+	if result.Deadline.IsZero() {
+		return nil, errors.New("you need to provide a Deadline to initiate a swap")
+	}
+
+	return
+}
+
+// optionError is a utility function that returns a Option that returns the error provided in the argument.
+func optionError(err error) InitiateSwapOption {
+	return func(options *InitiateSwapOptions) error {
+		return err
+	}
+}
@@ -0,0 +1,83 @@
+package refundswapoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// RefundSwapOption is a function that provides an option.
+type RefundSwapOption func(options *RefundSwapOptions) error
+
+// OutputID is an option for the RefundSwap call that defines which expired hash-time-locked output is refunded.
+func OutputID(outputID string) RefundSwapOption {
+	return func(options *RefundSwapOptions) error {
+		parsed, err := ledgerstate.OutputIDFromBase58(outputID)
+		if err != nil {
+			return err
+		}
+		options.OutputID = parsed
+		return nil
+	}
+}
+
+// ToAddress is an option for the RefundSwap call that defines where the refunded funds are sent to.
+func ToAddress(addr address.Address) RefundSwapOption {
+	return func(options *RefundSwapOptions) error {
+		options.ToAddress = addr
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for RefundSwap call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) RefundSwapOption {
+	return func(options *RefundSwapOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for RefundSwap call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) RefundSwapOption {
+	return func(options *RefundSwapOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) RefundSwapOption {
+	return func(options *RefundSwapOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// RefundSwapOptions is a struct that is used to aggregate the optional parameters in the RefundSwap call.
+type RefundSwapOptions struct {
+	OutputID              ledgerstate.OutputID
+	ToAddress             address.Address
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build is a utility function that constructs the RefundSwapOptions.
+func Build(options ...RefundSwapOption) (result *RefundSwapOptions, err error) {
+	// create options to collect the arguments provided
+	result = &RefundSwapOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	if result.OutputID == ledgerstate.EmptyOutputID {
+		return nil, errors.New("an OutputID must be specified to refund a swap")
+	}
+
+	return
+}
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"crypto/rand"
 	"reflect"
 	"time"
 	"unsafe"
@@ -13,12 +14,15 @@ import (
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/claimconditionaloptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/claimswapoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/consolidateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/createnftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/deposittonftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/destroynftoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/initiateswapoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/reclaimoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refundswapoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sweepnftownednftsoptions"
@@ -39,6 +43,8 @@ const (
 	milliSeconds               = 1000   // miliseconds in a second
 	// DefaultAssetRegistryNetwork is the default asset registry network.
 	DefaultAssetRegistryNetwork = "test"
+	// swapPreimageSize is the size of the randomly generated preimages of atomic swaps.
+	swapPreimageSize = 32
 )
 
 // ErrTooManyOutputs is an error returned when the number of outputs/inputs exceeds the protocol wide constant
@@ -331,6 +337,217 @@ func (wallet *Wallet) ClaimConditionalFunds(options ...claimconditionaloptions.C
 
 // endregion //////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region AtomicSwaps //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// InitiateSwap locks funds in a hash-time-locked output for the counterparty of an atomic swap. The counterparty can
+// claim the funds by revealing the preimage of the hash lock before the deadline, after the deadline the funds can be
+// refunded by the wallet with RefundSwap. If no preimage or hash lock is provided, a random preimage is generated and
+// returned.
+func (wallet *Wallet) InitiateSwap(options ...initiateswapoptions.InitiateSwapOption) (tx *ledgerstate.Transaction, preimage []byte, hashLock ledgerstate.HashLock, err error) {
+	swapOptions, err := initiateswapoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	preimage, hashLock = swapOptions.Preimage, swapOptions.HashLock
+	if hashLock == ledgerstate.EmptyHashLock {
+		preimage = make([]byte, swapPreimageSize)
+		if _, err = rand.Read(preimage); err != nil {
+			err = errors.Errorf("failed to generate swap preimage: %w", err)
+			return
+		}
+		hashLock = ledgerstate.NewHashLock(preimage)
+	}
+
+	consumedOutputs, err := wallet.collectOutputsForFunding(swapOptions.Amount)
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Errorf("consolidate funds and try again: %w", err)
+		}
+		return
+	}
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(swapOptions.AccessManaPledgeID, swapOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	// build inputs from consumed outputs
+	inputs := wallet.buildInputs(consumedOutputs)
+	// aggregate all the funds we consume from inputs
+	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
+	for color, balance := range swapOptions.Amount {
+		totalConsumedFunds[color] -= balance
+		if totalConsumedFunds[color] == 0 {
+			delete(totalConsumedFunds, color)
+		}
+	}
+	// the fallback address must not be spent in this transaction so that we can refund from it later
+	fallbackAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	swapOutput := ledgerstate.NewExtendedLockedOutput(swapOptions.Amount, swapOptions.Destination.Address()).
+		WithFallbackOptions(fallbackAddress.Address(), swapOptions.Deadline).
+		WithHashLock(hashLock)
+	outputsSlice := []ledgerstate.Output{swapOutput}
+	if len(totalConsumedFunds) != 0 {
+		remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, swapOptions.RemainderAddress)
+		outputsSlice = append(outputsSlice, ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(totalConsumedFunds), remainderAddress.Address()))
+	}
+	outputs := ledgerstate.NewOutputs(outputsSlice...)
+
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
+	outputsByID := consumedOutputs.OutputsByID()
+
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(inputs, outputsByID, txEssence)
+
+	tx, err = wallet.issueSwapTransaction(txEssence, unlockBlocks, inputsAsOutputsInOrder, consumedOutputs, swapOptions.WaitForConfirmation)
+
+	return tx, preimage, hashLock, err
+}
+
+// ClaimSwap claims the funds of a hash-time-locked output that the wallet is the counterparty of by revealing the
+// preimage of its hash lock.
+func (wallet *Wallet) ClaimSwap(options ...claimswapoptions.ClaimSwapOption) (tx *ledgerstate.Transaction, err error) {
+	claimOptions, err := claimswapoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	addy, output, err := wallet.findUnspentSwapOutput(claimOptions.OutputID)
+	if err != nil {
+		return
+	}
+	casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
+	now := time.Now()
+	if !casted.HashLockedNow(now) {
+		return nil, errors.Errorf("the deadline of swap output %s has passed", claimOptions.OutputID.Base58())
+	}
+	if !casted.Address().Equals(addy.Address()) {
+		return nil, errors.Errorf("wallet is not the counterparty of swap output %s", claimOptions.OutputID.Base58())
+	}
+	if casted.TimeLockedNow(now) {
+		return nil, errors.Errorf("swap output %s is timelocked until %s", claimOptions.OutputID.Base58(), casted.TimeLock().String())
+	}
+	if !casted.HashLock().Unlocks(claimOptions.Preimage) {
+		return nil, errors.Errorf("preimage does not match the hash lock of swap output %s", claimOptions.OutputID.Base58())
+	}
+
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(claimOptions.AccessManaPledgeID, claimOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	consumedOutputs := OutputsByAddressAndOutputID{addy: {claimOptions.OutputID: output}}
+	toAddress := wallet.chooseToAddress(consumedOutputs, claimOptions.ToAddress)
+	txEssence := ledgerstate.NewTransactionEssence(0, now, aPledgeID, cPledgeID,
+		ledgerstate.NewInputs(casted.Input()),
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(casted.Balances(), toAddress.Address())),
+	)
+
+	keyPair := wallet.Seed().KeyPair(addy.Index)
+	signature := ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(txEssence.Bytes()))
+	unlockBlocks := ledgerstate.UnlockBlocks{ledgerstate.NewHashLockUnlockBlock(claimOptions.Preimage, signature)}
+
+	return wallet.issueSwapTransaction(txEssence, unlockBlocks, ledgerstate.Outputs{casted}, consumedOutputs, claimOptions.WaitForConfirmation)
+}
+
+// RefundSwap sends the funds of a hash-time-locked output that was not claimed by the counterparty before its deadline
+// back to the wallet.
+func (wallet *Wallet) RefundSwap(options ...refundswapoptions.RefundSwapOption) (tx *ledgerstate.Transaction, err error) {
+	refundOptions, err := refundswapoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	addy, output, err := wallet.findUnspentSwapOutput(refundOptions.OutputID)
+	if err != nil {
+		return
+	}
+	casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
+	now := time.Now()
+	if casted.HashLockedNow(now) {
+		_, fallbackDeadline := casted.FallbackOptions()
+		return nil, errors.Errorf("swap output %s can not be refunded before %s", refundOptions.OutputID.Base58(), fallbackDeadline.String())
+	}
+	if !casted.UnlockAddressNow(now).Equals(addy.Address()) {
+		return nil, errors.Errorf("wallet is not the fallback address of swap output %s", refundOptions.OutputID.Base58())
+	}
+	if casted.TimeLockedNow(now) {
+		return nil, errors.Errorf("swap output %s is timelocked until %s", refundOptions.OutputID.Base58(), casted.TimeLock().String())
+	}
+
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(refundOptions.AccessManaPledgeID, refundOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	consumedOutputs := OutputsByAddressAndOutputID{addy: {refundOptions.OutputID: output}}
+	toAddress := wallet.chooseToAddress(consumedOutputs, refundOptions.ToAddress)
+	inputs := ledgerstate.NewInputs(casted.Input())
+	txEssence := ledgerstate.NewTransactionEssence(0, now, aPledgeID, cPledgeID, inputs,
+		ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(casted.Balances(), toAddress.Address())),
+	)
+
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(inputs, consumedOutputs.OutputsByID(), txEssence)
+
+	return wallet.issueSwapTransaction(txEssence, unlockBlocks, inputsAsOutputsInOrder, consumedOutputs, refundOptions.WaitForConfirmation)
+}
+
+// findUnspentSwapOutput looks up an unspent hash-time-locked output of the wallet.
+func (wallet *Wallet) findUnspentSwapOutput(outputID ledgerstate.OutputID) (addy address.Address, output *Output, err error) {
+	if err = wallet.outputManager.Refresh(); err != nil {
+		return
+	}
+	for outputAddress, outputsOnAddress := range wallet.outputManager.UnspentOutputs(false) {
+		unspentOutput, exists := outputsOnAddress[outputID]
+		if !exists {
+			continue
+		}
+		casted, ok := unspentOutput.Object.(*ledgerstate.ExtendedLockedOutput)
+		if !ok || casted.HashLock() == ledgerstate.EmptyHashLock {
+			return address.AddressEmpty, nil, errors.Errorf("output %s is not a hash-time-locked output", outputID.Base58())
+		}
+		return outputAddress, unspentOutput, nil
+	}
+	return address.AddressEmpty, nil, errors.Errorf("failed to find confirmed unspent swap output %s in wallet", outputID.Base58())
+}
+
+// issueSwapTransaction checks the validity of a swap transaction and sends it to the network.
+func (wallet *Wallet) issueSwapTransaction(
+	txEssence *ledgerstate.TransactionEssence,
+	unlockBlocks ledgerstate.UnlockBlocks,
+	inputsAsOutputsInOrder ledgerstate.Outputs,
+	consumedOutputs OutputsByAddressAndOutputID,
+	waitForConfirmation bool,
+) (tx *ledgerstate.Transaction, err error) {
+	tx = ledgerstate.NewTransaction(txEssence, unlockBlocks)
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(inputsAsOutputsInOrder, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if waitForConfirmation {
+		err = wallet.WaitForTxConfirmation(tx.ID())
+	}
+	return tx, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CreateAsset //////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CreateAsset creates a new colored token with the given details.
@@ -1631,6 +1848,10 @@ func (wallet *Wallet) AvailableBalance(refresh ...bool) (confirmedBalance, pendi
 					// timelocked funds are not available
 					continue
 				}
+				if casted.HashLockedNow(now) {
+					// hash-locked funds can only be claimed with ClaimSwap
+					continue
+				}
 				unlockAddyNow := casted.UnlockAddressNow(now)
 				if addy.Address().Equals(unlockAddyNow) {
 					// we own this output now
@@ -1728,7 +1949,7 @@ func (wallet *Wallet) ConditionalBalances(refresh ...bool) (confirmed, pending T
 			}
 			casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
 			_, fallbackDeadline := casted.FallbackOptions()
-			if !fallbackDeadline.IsZero() && addy.Address().Equals(casted.UnlockAddressNow(now)) {
+			if !fallbackDeadline.IsZero() && !casted.HashLockedNow(now) && addy.Address().Equals(casted.UnlockAddressNow(now)) {
 				// fallback option is set and currently we are the unlock address
 				cBal := &TimedBalance{
 					Balance: casted.Balances().Map(),
@@ -2112,7 +2333,7 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Co
 			}
 			if output.Object.Type() == ledgerstate.ExtendedLockedOutputType {
 				casted := output.Object.(*ledgerstate.ExtendedLockedOutput)
-				if casted.TimeLockedNow(now) || !casted.UnlockAddressNow(now).Equals(addy.Address()) {
+				if casted.TimeLockedNow(now) || casted.HashLockedNow(now) || !casted.UnlockAddressNow(now).Equals(addy.Address()) {
 					// skip the output because we wouldn't be able to unlock it
 					continue
 				}
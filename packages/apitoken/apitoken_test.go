@@ -0,0 +1,109 @@
+package apitoken
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueParse(t *testing.T) {
+	secret := []byte("secret")
+	claims, err := NewClaims("integrator", Scopes{ScopeRead, ScopeIssue}, time.Hour, 60)
+	require.NoError(t, err)
+
+	token, err := Issue(secret, claims)
+	require.NoError(t, err)
+
+	parsed, err := Parse(secret, token)
+	require.NoError(t, err)
+	assert.Equal(t, claims, parsed)
+	assert.True(t, parsed.Scopes.Allows(ScopeRead))
+	assert.False(t, parsed.Scopes.Allows(ScopeAdmin))
+
+	// wrong secret
+	_, err = Parse([]byte("other"), token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// tampered claims
+	parts := strings.Split(token, ".")
+	adminClaims := *claims
+	adminClaims.Scopes = Scopes{ScopeAdmin}
+	adminToken, err := Issue([]byte("other"), &adminClaims)
+	require.NoError(t, err)
+	_, err = Parse(secret, parts[0]+"."+strings.Split(adminToken, ".")[1]+"."+parts[2])
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// expired token
+	claims.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	expiredToken, err := Issue(secret, claims)
+	require.NoError(t, err)
+	_, err = Parse(secret, expiredToken)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+}
+
+func TestScopesFromString(t *testing.T) {
+	scopes, err := ScopesFromString("read, faucet")
+	require.NoError(t, err)
+	assert.Equal(t, Scopes{ScopeRead, ScopeFaucet}, scopes)
+	assert.True(t, Scopes{ScopeAdmin}.Allows(ScopeFaucet))
+
+	_, err = ScopesFromString("read,root")
+	assert.ErrorIs(t, err, ErrUnknownScope)
+	_, err = ScopesFromString("")
+	assert.ErrorIs(t, err, ErrUnknownScope)
+}
+
+func TestRevocationList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "revoked")
+
+	revocationList, err := NewRevocationList(path)
+	require.NoError(t, err)
+	assert.False(t, revocationList.IsRevoked("a"))
+	require.NoError(t, revocationList.Revoke("a"))
+	assert.True(t, revocationList.IsRevoked("a"))
+
+	// revocations of another process are picked up after the reload interval
+	otherRevocationList, err := NewRevocationList(path)
+	require.NoError(t, err)
+	assert.True(t, otherRevocationList.IsRevoked("a"))
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString("b\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	assert.Eventually(t, func() bool {
+		return otherRevocationList.IsRevoked("b")
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestRateLimiter(t *testing.T) {
+	rateLimiter := NewRateLimiter()
+	for i := 0; i < 3; i++ {
+		assert.True(t, rateLimiter.Allow("a", 3))
+	}
+	assert.False(t, rateLimiter.Allow("a", 3))
+	assert.True(t, rateLimiter.Allow("b", 3))
+	assert.True(t, rateLimiter.Allow("a", 0))
+}
+
+func TestRateLimiter_EvictIdleBuckets(t *testing.T) {
+	rateLimiter := NewRateLimiter()
+	now := time.Now()
+	assert.True(t, rateLimiter.allow("a", 1, now))
+	assert.False(t, rateLimiter.allow("a", 1, now))
+	assert.True(t, rateLimiter.allow("b", 1, now.Add(30*time.Second)))
+	assert.Len(t, rateLimiter.buckets, 2)
+
+	// the idle bucket of a is evicted while b is still in use
+	assert.True(t, rateLimiter.allow("c", 1, now.Add(bucketIdleTimeout+10*time.Second)))
+	assert.Len(t, rateLimiter.buckets, 2)
+	assert.NotContains(t, rateLimiter.buckets, "a")
+}
@@ -0,0 +1,92 @@
+package apitoken
+
+import (
+	"sync"
+	"time"
+)
+
+// bucketIdleTimeout defines after which time without requests the bucket of a token is evicted. A bucket refills
+// completely within a minute, so an evicted bucket is recreated in the same (full) state.
+const bucketIdleTimeout = time.Minute
+
+// RateLimiter enforces a per-token limit of requests per minute using a token bucket per token ID.
+type RateLimiter struct {
+	buckets      map[string]*bucket
+	lastEviction time.Time
+	mutex        sync.Mutex
+}
+
+// NewRateLimiter creates a new RateLimiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow returns true if another request of the token with the given ID is allowed. A limit of 0 (or less) disables the
+// rate limiting.
+func (r *RateLimiter) Allow(id string, requestsPerMinute int) bool {
+	return r.allow(id, requestsPerMinute, time.Now())
+}
+
+// allow implements Allow for the given point in time.
+func (r *RateLimiter) allow(id string, requestsPerMinute int, now time.Time) bool {
+	if requestsPerMinute <= 0 {
+		return true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.evictIdleBuckets(now)
+
+	b, exists := r.buckets[id]
+	if !exists || b.capacity != float64(requestsPerMinute) {
+		b = &bucket{
+			capacity:   float64(requestsPerMinute),
+			tokens:     float64(requestsPerMinute),
+			lastRefill: now,
+		}
+		r.buckets[id] = b
+	}
+
+	return b.take(now)
+}
+
+// evictIdleBuckets removes the buckets that have not been used for the bucketIdleTimeout. The buckets are checked at
+// most once per bucketIdleTimeout, so the costs are amortized over the requests.
+func (r *RateLimiter) evictIdleBuckets(now time.Time) {
+	if now.Sub(r.lastEviction) < bucketIdleTimeout {
+		return
+	}
+	r.lastEviction = now
+
+	for id, b := range r.buckets {
+		if now.Sub(b.lastRefill) >= bucketIdleTimeout {
+			delete(r.buckets, id)
+		}
+	}
+}
+
+// bucket is a token bucket that refills its capacity once per minute.
+type bucket struct {
+	capacity   float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+// take refills the bucket and consumes a token if one is available.
+func (b *bucket) take(now time.Time) bool {
+	b.tokens += now.Sub(b.lastRefill).Minutes() * b.capacity
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+
+	return true
+}
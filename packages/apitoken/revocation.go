@@ -0,0 +1,125 @@
+package apitoken
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// revocationListReloadInterval defines how often the RevocationList checks its file for changes.
+const revocationListReloadInterval = time.Second
+
+// RevocationList keeps track of revoked token IDs. The IDs are stored in a file (one ID per line), so tokens can be
+// revoked by an external tool while the node is running - the file is reloaded whenever it changes.
+type RevocationList struct {
+	path       string
+	revokedIDs map[string]struct{}
+	modTime    time.Time
+	lastCheck  time.Time
+	mutex      sync.Mutex
+}
+
+// NewRevocationList creates a RevocationList that is backed by the file with the given path. An empty path creates an
+// in-memory RevocationList.
+func NewRevocationList(path string) (revocationList *RevocationList, err error) {
+	revocationList = &RevocationList{
+		path:       path,
+		revokedIDs: make(map[string]struct{}),
+	}
+
+	if path == "" {
+		return revocationList, nil
+	}
+
+	if err = revocationList.reload(); err != nil {
+		return nil, err
+	}
+
+	return revocationList, nil
+}
+
+// Revoke adds the given token ID to the RevocationList and persists it.
+func (r *RevocationList) Revoke(id string) (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, revoked := r.revokedIDs[id]; revoked {
+		return nil
+	}
+
+	if r.path != "" {
+		file, openErr := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if openErr != nil {
+			return errors.Errorf("failed to open revocation list %s: %w", r.path, openErr)
+		}
+		defer file.Close()
+
+		if _, err = file.WriteString(id + "\n"); err != nil {
+			return errors.Errorf("failed to write to revocation list %s: %w", r.path, err)
+		}
+		if err = file.Sync(); err != nil {
+			return errors.Errorf("failed to sync revocation list %s: %w", r.path, err)
+		}
+	}
+
+	r.revokedIDs[id] = struct{}{}
+
+	return nil
+}
+
+// IsRevoked returns true if the token with the given ID was revoked.
+func (r *RevocationList) IsRevoked(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.path != "" && time.Since(r.lastCheck) >= revocationListReloadInterval {
+		// keep the last known state if the file can not be read
+		_ = r.reload()
+	}
+
+	_, revoked := r.revokedIDs[id]
+
+	return revoked
+}
+
+// reload reads the revoked IDs from the file if it changed since the last time it was read.
+func (r *RevocationList) reload() (err error) {
+	r.lastCheck = time.Now()
+
+	fileInfo, err := os.Stat(r.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Errorf("failed to stat revocation list %s: %w", r.path, err)
+	}
+	if fileInfo.ModTime().Equal(r.modTime) {
+		return nil
+	}
+
+	file, err := os.Open(r.path)
+	if err != nil {
+		return errors.Errorf("failed to open revocation list %s: %w", r.path, err)
+	}
+	defer file.Close()
+
+	revokedIDs := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" && !strings.HasPrefix(id, "#") {
+			revokedIDs[id] = struct{}{}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return errors.Errorf("failed to read revocation list %s: %w", r.path, err)
+	}
+
+	r.revokedIDs = revokedIDs
+	r.modTime = fileInfo.ModTime()
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package apitoken
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ScopeRead allows to query the state of the node (e.g. messages, ledger state, mana).
+	ScopeRead Scope = "read"
+
+	// ScopeIssue allows to issue messages, data and transactions.
+	ScopeIssue Scope = "issue"
+
+	// ScopeFaucet allows to request funds from the faucet.
+	ScopeFaucet Scope = "faucet"
+
+	// ScopeAdmin allows to use all endpoints including the ones that manage the node (e.g. manual peering, spammer,
+	// snapshots and the diagnostic tools).
+	ScopeAdmin Scope = "admin"
+)
+
+// ErrUnknownScope is returned if a Scope can not be parsed.
+var ErrUnknownScope = errors.New("unknown scope")
+
+// Scope represents a permission that can be granted to an API token.
+type Scope string
+
+// ScopeFromString parses a Scope from its string representation.
+func ScopeFromString(scope string) (Scope, error) {
+	switch parsed := Scope(strings.ToLower(strings.TrimSpace(scope))); parsed {
+	case ScopeRead, ScopeIssue, ScopeFaucet, ScopeAdmin:
+		return parsed, nil
+	default:
+		return "", errors.Errorf("failed to parse scope '%s': %w", scope, ErrUnknownScope)
+	}
+}
+
+// Scopes represents a collection of Scope objects.
+type Scopes []Scope
+
+// ScopesFromString parses a comma separated list of Scopes.
+func ScopesFromString(scopes string) (parsedScopes Scopes, err error) {
+	for _, scope := range strings.Split(scopes, ",") {
+		if strings.TrimSpace(scope) == "" {
+			continue
+		}
+
+		parsedScope, parseErr := ScopeFromString(scope)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		parsedScopes = append(parsedScopes, parsedScope)
+	}
+
+	if len(parsedScopes) == 0 {
+		return nil, errors.Errorf("at least one scope needs to be provided: %w", ErrUnknownScope)
+	}
+
+	return parsedScopes, nil
+}
+
+// Allows returns true if the Scopes grant the given Scope. The ScopeAdmin grants every Scope.
+func (s Scopes) Allows(scope Scope) bool {
+	for _, grantedScope := range s {
+		if grantedScope == scope || grantedScope == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns a human readable version of the Scopes.
+func (s Scopes) String() string {
+	scopes := make([]string, len(s))
+	for i, scope := range s {
+		scopes[i] = string(scope)
+	}
+
+	return strings.Join(scopes, ",")
+}
@@ -0,0 +1,144 @@
+package apitoken
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	// ErrInvalidToken is returned if a token is malformed or its signature does not match.
+	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenExpired is returned if a token is no longer valid.
+	ErrTokenExpired = errors.New("token expired")
+
+	// ErrTokenRevoked is returned if a token was revoked.
+	ErrTokenRevoked = errors.New("token revoked")
+)
+
+// tokenHeader is the (fixed) JOSE header of the tokens. The tokens are JWTs that are signed with HMAC-SHA256.
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims contains the information that is encoded in an API token.
+type Claims struct {
+	// ID is the unique identifier of the token that is used to revoke it.
+	ID string `json:"jti"`
+	// Subject is a human readable name of the owner of the token.
+	Subject string `json:"sub,omitempty"`
+	// IssuedAt is the unix timestamp of the time the token was issued.
+	IssuedAt int64 `json:"iat"`
+	// ExpiresAt is the unix timestamp after which the token is no longer valid (0 = never).
+	ExpiresAt int64 `json:"exp,omitempty"`
+	// Scopes are the permissions that are granted to the owner of the token.
+	Scopes Scopes `json:"scopes"`
+	// RateLimit is the maximum number of requests per minute that are accepted for the token (0 = unlimited).
+	RateLimit int `json:"rateLimit,omitempty"`
+}
+
+// NewClaims creates new Claims with a random ID. A ttl of 0 creates a token that does not expire.
+func NewClaims(subject string, scopes Scopes, ttl time.Duration, rateLimit int) (claims *Claims, err error) {
+	id := make([]byte, 16)
+	if _, err = rand.Read(id); err != nil {
+		return nil, errors.Errorf("failed to generate token ID: %w", err)
+	}
+
+	now := time.Now()
+	claims = &Claims{
+		ID:        hex.EncodeToString(id),
+		Subject:   subject,
+		IssuedAt:  now.Unix(),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+	}
+	if ttl > 0 {
+		claims.ExpiresAt = now.Add(ttl).Unix()
+	}
+
+	return claims, nil
+}
+
+// Expired returns true if the token is no longer valid at the given time.
+func (c *Claims) Expired(now time.Time) bool {
+	return c.ExpiresAt != 0 && now.Unix() >= c.ExpiresAt
+}
+
+// Issue creates a signed token from the given Claims.
+func Issue(secret []byte, claims *Claims) (token string, err error) {
+	if len(secret) == 0 {
+		return "", errors.New("the secret must not be empty")
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Errorf("failed to marshal claims: %w", err)
+	}
+
+	signingInput := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign(secret, signingInput)), nil
+}
+
+// Parse verifies the signature and the expiry of the given token and returns its Claims.
+func Parse(secret []byte, token string) (claims *Claims, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return nil, errors.Errorf("malformed token: %w", ErrInvalidToken)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Errorf("failed to decode signature (%v): %w", err, ErrInvalidToken)
+	}
+	if !hmac.Equal(signature, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, errors.Errorf("signature mismatch: %w", ErrInvalidToken)
+	}
+
+	if claims, err = ParseUnverified(token); err != nil {
+		return nil, err
+	}
+	if claims.Expired(time.Now()) {
+		return nil, errors.Errorf("token %s expired at %s: %w", claims.ID, time.Unix(claims.ExpiresAt, 0), ErrTokenExpired)
+	}
+
+	return claims, nil
+}
+
+// ParseUnverified decodes the Claims of the given token without verifying its signature. It is used by tools that only
+// need to know the ID of a token (e.g. to revoke it).
+func ParseUnverified(token string) (claims *Claims, err error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.Errorf("malformed token: %w", ErrInvalidToken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.Errorf("failed to decode claims (%v): %w", err, ErrInvalidToken)
+	}
+
+	claims = &Claims{}
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return nil, errors.Errorf("failed to unmarshal claims (%v): %w", err, ErrInvalidToken)
+	}
+	if claims.ID == "" {
+		return nil, errors.Errorf("token has no ID: %w", ErrInvalidToken)
+	}
+
+	return claims, nil
+}
+
+// sign computes the HMAC-SHA256 of the given signing input.
+func sign(secret []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte(signingInput))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,79 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// DriftMonitor tracks the drift between the SyncedTime and a reference time (e.g. the TangleTime) and raises an alarm
+// when the drift exceeds a threshold.
+type DriftMonitor struct {
+	referenceTime func() time.Time
+	threshold     time.Duration
+
+	drift      time.Duration
+	alarm      bool
+	alarmCount uint64
+	mutex      sync.RWMutex
+}
+
+// NewDriftMonitor creates a DriftMonitor for the given reference time. Zero reference times are ignored.
+func NewDriftMonitor(referenceTime func() time.Time, threshold time.Duration) *DriftMonitor {
+	return &DriftMonitor{
+		referenceTime: referenceTime,
+		threshold:     threshold,
+	}
+}
+
+// Measure determines the current drift. It triggers the DriftExceeded event when the absolute drift exceeds the
+// threshold and the DriftRecovered event when it falls back below it.
+func (d *DriftMonitor) Measure() time.Duration {
+	reference := d.referenceTime()
+	if reference.IsZero() {
+		return d.Drift()
+	}
+	drift := SyncedTime().Sub(reference)
+
+	d.mutex.Lock()
+	d.drift = drift
+	exceeded := drift > d.threshold || drift < -d.threshold
+	changed := exceeded != d.alarm
+	d.alarm = exceeded
+	if changed && exceeded {
+		d.alarmCount++
+	}
+	d.mutex.Unlock()
+
+	switch {
+	case changed && exceeded:
+		Events().DriftExceeded.Trigger(drift)
+	case changed:
+		Events().DriftRecovered.Trigger(drift)
+	}
+
+	return drift
+}
+
+// Drift returns the last measured drift.
+func (d *DriftMonitor) Drift() time.Duration {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.drift
+}
+
+// Alarm returns whether the last measured drift exceeded the threshold.
+func (d *DriftMonitor) Alarm() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.alarm
+}
+
+// AlarmCount returns how often the drift exceeded the threshold.
+func (d *DriftMonitor) AlarmCount() uint64 {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.alarmCount
+}
@@ -0,0 +1,43 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+)
+
+var (
+	clockEvents     *CollectionEvents
+	clockEventsOnce sync.Once
+)
+
+// CollectionEvents defines the events of the clock package.
+type CollectionEvents struct {
+	// Synchronized is triggered after the offset was synchronized with the NTP sources.
+	Synchronized *events.Event
+	// DriftExceeded is triggered when the drift of a DriftMonitor exceeds its threshold.
+	DriftExceeded *events.Event
+	// DriftRecovered is triggered when the drift of a DriftMonitor falls back below its threshold.
+	DriftRecovered *events.Event
+}
+
+// Events returns the events defined in the package.
+func Events() *CollectionEvents {
+	clockEventsOnce.Do(func() {
+		clockEvents = &CollectionEvents{
+			Synchronized:   events.NewEvent(syncResultCaller),
+			DriftExceeded:  events.NewEvent(durationCaller),
+			DriftRecovered: events.NewEvent(durationCaller),
+		}
+	})
+	return clockEvents
+}
+
+func syncResultCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*SyncResult))(params[0].(*SyncResult))
+}
+
+func durationCaller(handler interface{}, params ...interface{}) {
+	handler.(func(time.Duration))(params[0].(time.Duration))
+}
@@ -1,6 +1,7 @@
 package clock
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -9,35 +10,127 @@ import (
 	"github.com/beevik/ntp"
 )
 
-// ErrNTPQueryFailed is returned if an NTP query failed.
-var ErrNTPQueryFailed = errors.New("NTP query failed")
+var (
+	// ErrNTPQueryFailed is returned if an NTP query failed.
+	ErrNTPQueryFailed = errors.New("NTP query failed")
+	// ErrNotEnoughSources is returned if the majority of the NTP sources did not agree on the offset.
+	ErrNotEnoughSources = errors.New("not enough agreeing NTP sources")
+)
+
+// backwardSlewRate is the fraction of the elapsed local time by which a decreasing offset is reduced. While the clock
+// is slewed back, the synced time advances at 90% of the speed of the local time instead of jumping back.
+const backwardSlewRate = 0.1
 
 // difference between network time and node's local time.
 var (
-	offset      time.Duration
+	// offset is the offset at offsetTime.
+	offset time.Duration
+	// offsetTime is the local time at which the clock started to be slewed back.
+	offsetTime time.Time
+	// slewTarget is the offset that the clock is slewed back to (equal to offset if no slew is in progress).
+	slewTarget  time.Duration
+	offsetSet   bool
 	offsetMutex sync.RWMutex
 )
 
+// queryOffset queries the offset of the local clock from the given NTP host.
+var queryOffset = func(host string) (time.Duration, error) {
+	resp, err := ntp.Query(host)
+	if err != nil {
+		return 0, errors.Errorf("NTP query error (%v): %w", err, ErrNTPQueryFailed)
+	}
+	if err = resp.Validate(); err != nil {
+		return 0, errors.Errorf("invalid NTP response (%v): %w", err, ErrNTPQueryFailed)
+	}
+
+	return resp.ClockOffset, nil
+}
+
 // FetchTimeOffset establishes the difference in local vs network time.
 // This difference is stored in offset so that it can be used to adjust the local clock.
 func FetchTimeOffset(host string) error {
-	resp, err := ntp.Query(host)
+	hostOffset, err := queryOffset(host)
 	if err != nil {
-		return errors.Errorf("NTP query error (%v): %w", err, ErrNTPQueryFailed)
+		return err
 	}
 	offsetMutex.Lock()
 	defer offsetMutex.Unlock()
-	offset = resp.ClockOffset
+	offset = hostOffset
+	slewTarget = hostOffset
+	offsetSet = true
 
 	return nil
 }
 
+// SyncResult contains the outcome of a synchronization with several NTP sources.
+type SyncResult struct {
+	// Offsets contains the offsets reported by the sources that responded.
+	Offsets map[string]time.Duration
+	// Failed contains the errors of the sources that could not be queried.
+	Failed map[string]error
+	// Rejected contains the sources whose offset was rejected as an outlier.
+	Rejected []string
+	// Target is the median offset of the accepted sources.
+	Target time.Duration
+	// Offset is the offset that the clock is moved to after limiting the slew.
+	Offset time.Duration
+}
+
+// Synchronize queries all the given NTP hosts and moves the offset towards the median of the reported offsets. Offsets
+// that deviate from the median by more than maxDeviation are rejected as outliers and the majority of the responding
+// sources needs to be accepted. Except for the first synchronization, the offset changes by at most maxSlew (0 means no
+// limit), so that the synced time does not jump, and a decreasing offset is applied by slowing down the synced time
+// instead of setting it back, so that the synced time never goes backwards.
+func Synchronize(hosts []string, maxDeviation time.Duration, maxSlew time.Duration) (result *SyncResult, err error) {
+	result = &SyncResult{
+		Offsets: make(map[string]time.Duration),
+		Failed:  make(map[string]error),
+	}
+
+	var wg sync.WaitGroup
+	var resultMutex sync.Mutex
+	for _, host := range hosts {
+		wg.Add(1)
+		go func(host string) {
+			defer wg.Done()
+
+			hostOffset, queryErr := queryOffset(host)
+
+			resultMutex.Lock()
+			defer resultMutex.Unlock()
+			if queryErr != nil {
+				result.Failed[host] = queryErr
+				return
+			}
+			result.Offsets[host] = hostOffset
+		}(host)
+	}
+	wg.Wait()
+
+	if result.Target, result.Rejected, err = medianOffset(result.Offsets, maxDeviation); err != nil {
+		return result, err
+	}
+	result.Offset = adjustOffset(result.Target, maxSlew, time.Now())
+	Events().Synchronized.Trigger(result)
+
+	return result, nil
+}
+
+// Offset returns the current difference between the network time and the local time.
+func Offset() time.Duration {
+	offsetMutex.RLock()
+	defer offsetMutex.RUnlock()
+
+	return currentOffset(time.Now())
+}
+
 // SyncedTime gets the synchronized time (according to the network) of a node.
 func SyncedTime() time.Time {
 	offsetMutex.RLock()
 	defer offsetMutex.RUnlock()
 
-	return time.Now().Add(offset)
+	now := time.Now()
+	return now.Add(currentOffset(now))
 }
 
 // Since returns the time elapsed since t.
@@ -45,3 +138,89 @@ func SyncedTime() time.Time {
 func Since(t time.Time) time.Duration {
 	return SyncedTime().Sub(t)
 }
+
+// medianOffset returns the median of the offsets that deviate by at most maxDeviation from the median of all offsets,
+// and the sources that were rejected as outliers.
+func medianOffset(offsets map[string]time.Duration, maxDeviation time.Duration) (target time.Duration, rejected []string, err error) {
+	if len(offsets) == 0 {
+		return 0, nil, errors.Errorf("no NTP source responded: %w", ErrNotEnoughSources)
+	}
+
+	all := make([]time.Duration, 0, len(offsets))
+	for _, hostOffset := range offsets {
+		all = append(all, hostOffset)
+	}
+	center := median(all)
+
+	accepted := make([]time.Duration, 0, len(offsets))
+	for host, hostOffset := range offsets {
+		if deviation := hostOffset - center; deviation > maxDeviation || deviation < -maxDeviation {
+			rejected = append(rejected, host)
+			continue
+		}
+		accepted = append(accepted, hostOffset)
+	}
+	sort.Strings(rejected)
+
+	if 2*len(accepted) <= len(offsets) {
+		return 0, rejected, errors.Errorf("%d of %d NTP sources agree: %w", len(accepted), len(offsets), ErrNotEnoughSources)
+	}
+
+	return median(accepted), rejected, nil
+}
+
+// median returns the median of the given (non-empty) durations.
+func median(durations []time.Duration) time.Duration {
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] < durations[j]
+	})
+
+	middle := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[middle-1] + durations[middle]) / 2
+	}
+
+	return durations[middle]
+}
+
+// adjustOffset moves the offset towards the target by at most maxSlew (unless it is the first adjustment) and returns
+// the offset that the clock is moved to. An increasing offset is applied immediately, while a decreasing offset is
+// slewed in at the backwardSlewRate, so that the synced time never goes backwards.
+func adjustOffset(target time.Duration, maxSlew time.Duration, now time.Time) time.Duration {
+	offsetMutex.Lock()
+	defer offsetMutex.Unlock()
+
+	if !offsetSet {
+		offset, slewTarget, offsetTime, offsetSet = target, target, now, true
+		return target
+	}
+
+	current := currentOffset(now)
+	goal := target
+	if step := target - current; maxSlew > 0 && step > maxSlew {
+		goal = current + maxSlew
+	} else if maxSlew > 0 && step < -maxSlew {
+		goal = current - maxSlew
+	}
+
+	offset, slewTarget, offsetTime = current, goal, now
+	if goal > current {
+		offset = goal
+	}
+
+	return goal
+}
+
+// currentOffset returns the offset at the given local time, taking a running backward slew into account. The caller
+// has to hold the offsetMutex.
+func currentOffset(now time.Time) time.Duration {
+	if slewTarget >= offset {
+		return offset
+	}
+
+	if slewed := offset - time.Duration(float64(now.Sub(offsetTime))*backwardSlewRate); slewed > slewTarget {
+		return slewed
+	}
+
+	return slewTarget
+}
@@ -0,0 +1,125 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMedianOffset(t *testing.T) {
+	target, rejected, err := medianOffset(map[string]time.Duration{
+		"a": 10 * time.Millisecond,
+		"b": 20 * time.Millisecond,
+		"c": 30 * time.Millisecond,
+		"d": 5 * time.Second,
+	}, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Millisecond, target)
+	assert.Equal(t, []string{"d"}, rejected)
+
+	_, _, err = medianOffset(map[string]time.Duration{
+		"a": 0,
+		"b": time.Second,
+	}, 100*time.Millisecond)
+	assert.ErrorIs(t, err, ErrNotEnoughSources)
+
+	_, _, err = medianOffset(map[string]time.Duration{}, 100*time.Millisecond)
+	assert.ErrorIs(t, err, ErrNotEnoughSources)
+}
+
+func TestSynchronize(t *testing.T) {
+	defer resetOffset()
+	defer func(query func(string) (time.Duration, error)) { queryOffset = query }(queryOffset)
+	hostOffsets := map[string]time.Duration{
+		"a": 2 * time.Second,
+		"b": 2*time.Second + 10*time.Millisecond,
+		"c": -time.Minute,
+	}
+	queryOffset = func(host string) (time.Duration, error) {
+		if hostOffset, exists := hostOffsets[host]; exists {
+			return hostOffset, nil
+		}
+		return 0, errors.Errorf("unknown host %s: %w", host, ErrNTPQueryFailed)
+	}
+
+	// the first synchronization is not limited by the slew
+	result, err := Synchronize([]string{"a", "b", "c", "d"}, 100*time.Millisecond, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, result.Rejected)
+	assert.Contains(t, result.Failed, "d")
+	assert.Equal(t, 2*time.Second+5*time.Millisecond, result.Offset)
+	assert.Equal(t, result.Offset, Offset())
+
+	// later synchronizations move the offset by at most the slew
+	hostOffsets["a"], hostOffsets["b"] = 3*time.Second, 3*time.Second
+	result, err = Synchronize([]string{"a", "b"}, 100*time.Millisecond, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, result.Target)
+	assert.Equal(t, 2*time.Second+105*time.Millisecond, result.Offset)
+}
+
+func TestAdjustOffset(t *testing.T) {
+	defer resetOffset()
+	now := time.Now()
+
+	assert.Equal(t, time.Second, adjustOffset(time.Second, 0, now))
+	assert.Equal(t, time.Second, currentOffset(now))
+
+	// an increasing offset is applied immediately
+	assert.Equal(t, 2*time.Second, adjustOffset(3*time.Second, time.Second, now))
+	assert.Equal(t, 2*time.Second, currentOffset(now))
+
+	// a decreasing offset is slewed in by slowing down the synced time
+	assert.Equal(t, time.Second, adjustOffset(0, time.Second, now))
+	assert.Equal(t, 2*time.Second, currentOffset(now))
+	assert.Equal(t, 1500*time.Millisecond, currentOffset(now.Add(5*time.Second)))
+	assert.Equal(t, time.Second, currentOffset(now.Add(10*time.Second)))
+	assert.Equal(t, time.Second, currentOffset(now.Add(time.Minute)))
+
+	// the synced time never goes backwards
+	syncedTime := now.Add(currentOffset(now))
+	for elapsed := time.Duration(0); elapsed <= 15*time.Second; elapsed += 100 * time.Millisecond {
+		nextSyncedTime := now.Add(elapsed).Add(currentOffset(now.Add(elapsed)))
+		assert.False(t, nextSyncedTime.Before(syncedTime))
+		syncedTime = nextSyncedTime
+	}
+
+	// a new adjustment during a slew starts from the current offset
+	adjustOffset(0, 0, now.Add(time.Minute))
+	adjustOffset(-time.Second, 0, now.Add(time.Minute+5*time.Second))
+	assert.Equal(t, 500*time.Millisecond, currentOffset(now.Add(time.Minute+5*time.Second)))
+	assert.Equal(t, -time.Second, currentOffset(now.Add(2*time.Minute)))
+}
+
+func TestDriftMonitor(t *testing.T) {
+	defer resetOffset()
+	reference := time.Now()
+	monitor := NewDriftMonitor(func() time.Time { return reference }, time.Minute)
+
+	monitor.Measure()
+	assert.False(t, monitor.Alarm())
+
+	reference = time.Now().Add(-2 * time.Minute)
+	assert.Greater(t, int64(monitor.Measure()), int64(time.Minute))
+	assert.True(t, monitor.Alarm())
+	assert.EqualValues(t, 1, monitor.AlarmCount())
+
+	// zero reference times are ignored
+	reference = time.Time{}
+	monitor.Measure()
+	assert.True(t, monitor.Alarm())
+
+	reference = time.Now()
+	monitor.Measure()
+	assert.False(t, monitor.Alarm())
+	assert.EqualValues(t, 1, monitor.AlarmCount())
+}
+
+func resetOffset() {
+	offsetMutex.Lock()
+	defer offsetMutex.Unlock()
+	offset, slewTarget, offsetTime, offsetSet = 0, 0, time.Time{}, false
+}
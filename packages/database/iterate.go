@@ -0,0 +1,46 @@
+package database
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// IterateKeysFrom iterates over the keys with the given prefix that are greater than or equal to start, in ascending
+// order. The KVStore only supports prefix iterations, so the range after start is split into the prefixes that cover
+// it: first the keys that share start, then the keys that differ from start in its last byte, then in the byte before
+// that and so on. Every prefix is a single seek in the underlying database, which makes it possible to resume a page
+// without iterating the keys before start. The number of seeks grows with the length of start beyond the prefix, so
+// start should only be as long as needed to locate the page (e.g. a timestamp) - the caller skips the remaining keys
+// that share it.
+//
+// The keys are only returned in ascending order by stores that iterate in order (the persistent databases and the
+// store returned by NewSortedMapDB).
+func IterateKeysFrom(store kvstore.KVStore, prefix kvstore.KeyPrefix, start kvstore.Key, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	if !bytes.HasPrefix(start, prefix) {
+		return errors.Errorf("start key %x does not have the prefix %x", start, prefix)
+	}
+
+	aborted := false
+	iterate := func(keyPrefix kvstore.KeyPrefix) error {
+		return store.IterateKeys(keyPrefix, func(key kvstore.Key) bool {
+			aborted = !consumerFunc(key)
+			return !aborted
+		})
+	}
+
+	if err := iterate(start); err != nil || aborted {
+		return err
+	}
+	for i := len(start) - 1; i >= len(prefix); i-- {
+		for nextByte := int(start[i]) + 1; nextByte <= 0xff; nextByte++ {
+			if err := iterate(byteutils.ConcatBytes(start[:i], []byte{byte(nextByte)})); err != nil || aborted {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterateKeysFrom(t *testing.T) {
+	store := NewSortedMapDB()
+	for _, key := range [][]byte{{1, 0, 0}, {1, 0, 5}, {1, 2, 0}, {1, 2, 7}, {1, 2, 9}, {1, 3, 1}, {1, 0xff, 0}, {2, 0, 0}} {
+		require.NoError(t, store.Set(key, nil))
+	}
+
+	collect := func(start []byte, limit int) (keys [][]byte) {
+		require.NoError(t, IterateKeysFrom(store, []byte{1}, start, func(key kvstore.Key) bool {
+			keys = append(keys, key)
+			return len(keys) < limit
+		}))
+		return keys
+	}
+
+	assert.Equal(t, [][]byte{{1, 0, 0}, {1, 0, 5}, {1, 2, 0}, {1, 2, 7}, {1, 2, 9}, {1, 3, 1}, {1, 0xff, 0}}, collect([]byte{1}, 10))
+	assert.Equal(t, [][]byte{{1, 2, 7}, {1, 2, 9}, {1, 3, 1}, {1, 0xff, 0}}, collect([]byte{1, 2, 1}, 10))
+	assert.Equal(t, [][]byte{{1, 2, 0}, {1, 2, 7}}, collect([]byte{1, 1}, 2))
+	assert.Empty(t, collect([]byte{1, 0xff, 1}, 10))
+
+	assert.Error(t, IterateKeysFrom(store, []byte{1}, []byte{2}, func(kvstore.Key) bool { return true }))
+}
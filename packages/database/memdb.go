@@ -2,16 +2,16 @@ package database
 
 import (
 	"github.com/iotaledger/hive.go/kvstore"
-	"github.com/iotaledger/hive.go/kvstore/mapdb"
 )
 
 type memDB struct {
 	kvstore.KVStore
 }
 
-// NewMemDB returns a new in-memory (not persisted) DB object.
+// NewMemDB returns a new in-memory (not persisted) DB object. Like the persistent DB, it iterates its keys in ascending
+// order.
 func NewMemDB() (DB, error) {
-	return &memDB{KVStore: mapdb.NewMapDB()}, nil
+	return &memDB{KVStore: NewSortedMapDB()}, nil
 }
 
 func (db *memDB) NewStore() kvstore.KVStore {
@@ -24,4 +24,16 @@ const (
 
 	// PrefixEpochs defines the storage prefix for the epochs package.
 	PrefixEpochs
+
+	// PrefixTXStream defines the storage prefix for the txstream transaction log.
+	PrefixTXStream
+
+	// PrefixManualPeering defines the storage prefix for the known peers of the manual peering.
+	PrefixManualPeering
+
+	// PrefixIssuerRegistry defines the storage prefix for the issuer registry.
+	PrefixIssuerRegistry
+
+	// PrefixAnalysisHistory defines the storage prefix for the heartbeat history of the analysis server.
+	PrefixAnalysisHistory
 )
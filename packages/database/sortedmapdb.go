@@ -0,0 +1,77 @@
+package database
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+// sortedMapDB is an in-memory KVStore that iterates its keys in ascending order, just like the persistent databases
+// do. This allows the code that relies on the order of the keys (see IterateKeysFrom) to run on top of it.
+type sortedMapDB struct {
+	kvstore.KVStore
+}
+
+// NewSortedMapDB returns a new in-memory KVStore that iterates its keys in ascending order.
+func NewSortedMapDB() kvstore.KVStore {
+	return &sortedMapDB{KVStore: mapdb.NewMapDB()}
+}
+
+// WithRealm is a factory method for using the same underlying storage with a different realm.
+func (s *sortedMapDB) WithRealm(realm kvstore.Realm) kvstore.KVStore {
+	return &sortedMapDB{KVStore: s.KVStore.WithRealm(realm)}
+}
+
+// Iterate iterates over all keys and values with the provided prefix in ascending order of the keys.
+func (s *sortedMapDB) Iterate(prefix kvstore.KeyPrefix, consumerFunc kvstore.IteratorKeyValueConsumerFunc) error {
+	var keys, values [][]byte
+	if err := s.KVStore.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+		keys = append(keys, key)
+		values = append(values, value)
+		return true
+	}); err != nil {
+		return err
+	}
+
+	for _, index := range sortedIndices(keys) {
+		if !consumerFunc(keys[index], values[index]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// IterateKeys iterates over all keys with the provided prefix in ascending order.
+func (s *sortedMapDB) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstore.IteratorKeyConsumerFunc) error {
+	var keys [][]byte
+	if err := s.KVStore.IterateKeys(prefix, func(key kvstore.Key) bool {
+		keys = append(keys, key)
+		return true
+	}); err != nil {
+		return err
+	}
+
+	for _, index := range sortedIndices(keys) {
+		if !consumerFunc(keys[index]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// sortedIndices returns the indices of the given keys in ascending order of the keys.
+func sortedIndices(keys [][]byte) []int {
+	indices := make([]int, len(keys))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return bytes.Compare(keys[indices[i]], keys[indices[j]]) < 0
+	})
+
+	return indices
+}
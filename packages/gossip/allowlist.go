@@ -0,0 +1,61 @@
+package gossip
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/types"
+)
+
+// Allowlist is the set of the public keys of the peers that are allowed to become neighbors in a private network. It
+// consists of a static part (e.g. from the config) and a dynamic part that can be changed at runtime (e.g. by the
+// manual peering).
+type Allowlist struct {
+	static map[identity.ID]types.Empty
+
+	dynamic      map[identity.ID]types.Empty
+	dynamicMutex sync.RWMutex
+}
+
+// NewAllowlist creates a new Allowlist that statically allows the given public keys.
+func NewAllowlist(keys ...ed25519.PublicKey) *Allowlist {
+	a := &Allowlist{
+		static:  make(map[identity.ID]types.Empty, len(keys)),
+		dynamic: make(map[identity.ID]types.Empty),
+	}
+	for _, key := range keys {
+		a.static[identity.NewID(key)] = types.Void
+	}
+
+	return a
+}
+
+// Add adds the given public key to the dynamic part of the Allowlist.
+func (a *Allowlist) Add(key ed25519.PublicKey) {
+	a.dynamicMutex.Lock()
+	defer a.dynamicMutex.Unlock()
+
+	a.dynamic[identity.NewID(key)] = types.Void
+}
+
+// Remove removes the given public key from the dynamic part of the Allowlist. Statically allowed keys stay allowed.
+func (a *Allowlist) Remove(key ed25519.PublicKey) {
+	a.dynamicMutex.Lock()
+	defer a.dynamicMutex.Unlock()
+
+	delete(a.dynamic, identity.NewID(key))
+}
+
+// Allowed returns true if the peer with the given ID is allowed to become a neighbor.
+func (a *Allowlist) Allowed(id identity.ID) bool {
+	if _, allowed := a.static[id]; allowed {
+		return true
+	}
+
+	a.dynamicMutex.RLock()
+	defer a.dynamicMutex.RUnlock()
+
+	_, allowed := a.dynamic[id]
+	return allowed
+}
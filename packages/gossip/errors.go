@@ -13,6 +13,8 @@ var (
 	ErrDuplicateNeighbor = errors.New("already connected")
 	// ErrInvalidPacket is returned when the gossip manager receives an invalid packet.
 	ErrInvalidPacket = errors.New("invalid packet")
+	// ErrNotAllowlisted is returned when a peer that is not on the allowlist of a private network is added as a neighbor.
+	ErrNotAllowlisted = errors.New("peer is not allowlisted")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
 )
@@ -41,6 +41,7 @@ type Manager struct {
 	local           *peer.Local
 	loadMessageFunc LoadMessageFunc
 	log             *logger.Logger
+	allowlist       *Allowlist
 	events          Events
 	neighborsEvents map[NeighborsGroup]NeighborsEvents
 
@@ -58,8 +59,19 @@ type Manager struct {
 	messageRequestWorkerPool *workerpool.WorkerPool
 }
 
+// ManagerOption defines an option of the Manager.
+type ManagerOption func(m *Manager)
+
+// WithAllowlist returns a ManagerOption that turns the Manager into the Manager of a private network that only accepts
+// the peers of the given Allowlist as neighbors.
+func WithAllowlist(allowlist *Allowlist) ManagerOption {
+	return func(m *Manager) {
+		m.allowlist = allowlist
+	}
+}
+
 // NewManager creates a new Manager.
-func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		local:           local,
 		loadMessageFunc: f,
@@ -74,6 +86,9 @@ func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manag
 		neighbors: map[identity.ID]*Neighbor{},
 		server:    nil,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
 
 	m.messageWorkerPool = workerpool.New(func(task workerpool.Task) {
 		m.processPacketMessage(task.Param(0).([]byte), task.Param(1).(*Neighbor))
@@ -121,6 +136,11 @@ func (m *Manager) dropAllNeighbors() {
 	}
 }
 
+// Allowlist returns the Allowlist of the private network or nil if the Manager accepts all peers as neighbors.
+func (m *Manager) Allowlist() *Allowlist {
+	return m.allowlist
+}
+
 // Events returns the events related to the gossip protocol.
 func (m *Manager) Events() Events {
 	return m.events
@@ -225,6 +245,10 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 	if p.ID() == m.local.ID() {
 		return ErrLoopbackNeighbor
 	}
+	if m.allowlist != nil && !m.allowlist.Allowed(p.ID()) {
+		m.neighborsEvents[group].ConnectionFailed.Trigger(p, ErrNotAllowlisted)
+		return ErrNotAllowlisted
+	}
 	m.serverMutex.RLock()
 	defer m.serverMutex.RUnlock()
 	if m.server == nil {
@@ -468,13 +468,37 @@ func TestDropNeighborDifferentGroup(t *testing.T) {
 	}
 }
 
+func TestAllowlist(t *testing.T) {
+	allowlist := NewAllowlist()
+	mgrA, closeA, _ := newTestManager(t, "A", WithAllowlist(allowlist))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B")
+	defer closeB()
+
+	err := mgrA.AddInbound(context.Background(), peerB, NeighborsGroupManual)
+	assert.ErrorIs(t, err, ErrNotAllowlisted)
+	err = mgrA.AddOutbound(context.Background(), peerB, NeighborsGroupManual)
+	assert.ErrorIs(t, err, ErrNotAllowlisted)
+
+	allowlist.Add(peerB.PublicKey())
+	assert.True(t, allowlist.Allowed(peerB.ID()))
+	err = mgrA.AddOutbound(context.Background(), peerB, NeighborsGroupManual)
+	assert.NotErrorIs(t, err, ErrNotAllowlisted)
+
+	allowlist.Remove(peerB.PublicKey())
+	assert.False(t, allowlist.Allowed(peerB.ID()))
+	assert.True(t, NewAllowlist(peerB.PublicKey()).Allowed(peerB.ID()))
+
+	assert.Nil(t, mgrB.Allowlist())
+}
+
 func newTestDB(t require.TestingT) *peer.DB {
 	db, err := peer.NewDB(mapdb.NewMapDB())
 	require.NoError(t, err)
 	return db
 }
 
-func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Peer) {
+func newTestManager(t require.TestingT, name string, opts ...ManagerOption) (*Manager, func(), *peer.Peer) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -492,7 +516,7 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	srv := server.ServeTCP(local, lis, l)
 
 	// start the actual gossipping
-	mgr := NewManager(local, loadTestMessage, l)
+	mgr := NewManager(local, loadTestMessage, l, opts...)
 	mgr.Start(srv)
 
 	detach := func() {
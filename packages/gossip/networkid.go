@@ -0,0 +1,54 @@
+package gossip
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"golang.org/x/crypto/blake2b"
+)
+
+// NetworkIDKey is the key of the service that announces the NetworkID of a peer via autopeering. The services are the
+// only metadata that the autopeering peer record carries, and they are signed and distributed together with it, so
+// that the NetworkID is known before a peer is selected. The network field of the service holds the NetworkID and its
+// port is 0, since the service is not an endpoint. Autopeering never dials services it does not know and the gossip
+// handshake checks the NetworkID again, so a peer that announces a wrong ID still can not connect.
+const NetworkIDKey service.Key = "networkID"
+
+// ErrNoNetworkID is returned when a peer does not announce a NetworkID.
+var ErrNoNetworkID = errors.New("peer does not announce a network ID")
+
+// NetworkID identifies the network a node belongs to. Nodes only gossip with peers of the same network.
+type NetworkID uint64
+
+// NewNetworkID derives the NetworkID from the hash of the genesis snapshot and the marshaled protocol parameters.
+func NewNetworkID(snapshotHash []byte, protocolParameters []byte) NetworkID {
+	hash := blake2b.Sum256(append(append([]byte{}, snapshotHash...), protocolParameters...))
+	return NetworkID(binary.BigEndian.Uint64(hash[:]))
+}
+
+// NetworkIDFromString parses the hex encoded string form of a NetworkID.
+func NetworkIDFromString(s string) (NetworkID, error) {
+	networkID, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, errors.Errorf("failed to parse network ID %s: %w", s, err)
+	}
+	return NetworkID(networkID), nil
+}
+
+// AnnouncedNetworkID returns the NetworkID the given peer announces in its services.
+func AnnouncedNetworkID(p *peer.Peer) (NetworkID, error) {
+	endpoint := p.Services().Get(NetworkIDKey)
+	if endpoint == nil {
+		return 0, ErrNoNetworkID
+	}
+	return NetworkIDFromString(endpoint.Network())
+}
+
+// String returns the hex encoded string form of the NetworkID.
+func (n NetworkID) String() string {
+	return fmt.Sprintf("%016x", uint64(n))
+}
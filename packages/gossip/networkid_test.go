@@ -0,0 +1,39 @@
+package gossip
+
+import (
+	"net"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNetworkID(t *testing.T) {
+	networkID := NewNetworkID([]byte("snapshot"), []byte{0, 32})
+	assert.Equal(t, networkID, NewNetworkID([]byte("snapshot"), []byte{0, 32}))
+	assert.NotEqual(t, networkID, NewNetworkID([]byte("other snapshot"), []byte{0, 32}))
+	assert.NotEqual(t, networkID, NewNetworkID([]byte("snapshot"), []byte{0, 33}))
+
+	parsed, err := NetworkIDFromString(networkID.String())
+	require.NoError(t, err)
+	assert.Equal(t, networkID, parsed)
+}
+
+func TestAnnouncedNetworkID(t *testing.T) {
+	networkID := NewNetworkID([]byte("snapshot"), nil)
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 14626)
+	services.Update(service.GossipKey, "tcp", 14666)
+	_, err := AnnouncedNetworkID(peer.NewPeer(identity.New(ed25519.GenerateKeyPair().PublicKey), net.IPv4zero, services))
+	assert.ErrorIs(t, err, ErrNoNetworkID)
+
+	services.Update(NetworkIDKey, networkID.String(), 0)
+	announced, err := AnnouncedNetworkID(peer.NewPeer(identity.New(ed25519.GenerateKeyPair().PublicKey), net.IPv4zero, services))
+	require.NoError(t, err)
+	assert.Equal(t, networkID, announced)
+}
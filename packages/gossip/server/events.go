@@ -0,0 +1,28 @@
+package server
+
+import (
+	"net"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// Events defines all the events of the TCP server.
+type Events struct {
+	// Fired when the handshake of a peer of another network was rejected.
+	NetworkIDMismatch *events.Event
+}
+
+// NetworkIDMismatchEvent holds data about a rejected handshake of a peer of another network.
+type NetworkIDMismatchEvent struct {
+	// The ID of the rejected peer.
+	ID identity.ID
+	// The address the peer connected from.
+	Address net.Addr
+	// The network ID the peer sent in its handshake.
+	NetworkID uint64
+}
+
+func networkIDMismatchCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*NetworkIDMismatchEvent))(params[0].(*NetworkIDMismatchEvent))
+}
@@ -2,9 +2,12 @@ package server
 
 import (
 	"bytes"
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/server"
+	"github.com/iotaledger/hive.go/identity"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/iotaledger/goshimmer/packages/gossip/server/proto"
@@ -20,11 +23,12 @@ func isExpired(ts int64) bool {
 	return time.Since(time.Unix(ts, 0)) >= handshakeExpiration
 }
 
-func newHandshakeRequest(toAddr string) ([]byte, error) {
+func newHandshakeRequest(toAddr string, networkID uint64) ([]byte, error) {
 	m := &pb.HandshakeRequest{
 		Version:   versionNum,
 		To:        toAddr,
 		Timestamp: time.Now().Unix(),
+		NetworkId: networkID,
 	}
 	return proto.Marshal(m)
 }
@@ -36,28 +40,42 @@ func newHandshakeResponse(reqData []byte) ([]byte, error) {
 	return proto.Marshal(m)
 }
 
-func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
+func (t *TCP) validateHandshakeRequest(fromID identity.ID, fromAddr net.Addr, reqData []byte) error {
 	m := new(pb.HandshakeRequest)
 	if err := proto.Unmarshal(reqData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return ErrInvalidHandshake
 	}
 	if m.GetVersion() != versionNum {
 		t.log.Debugw("invalid handshake",
 			"version", m.GetVersion(),
 			"want", versionNum,
 		)
-		return false
+		return ErrInvalidHandshake
 	}
 	if isExpired(m.GetTimestamp()) {
 		t.log.Debugw("invalid handshake",
 			"timestamp", time.Unix(m.GetTimestamp(), 0),
 		)
 	}
+	if m.GetNetworkId() != t.networkID {
+		t.log.Infow("rejected peer of another network",
+			"id", fromID,
+			"addr", fromAddr,
+			"networkID", fmt.Sprintf("%016x", m.GetNetworkId()),
+			"want", fmt.Sprintf("%016x", t.networkID),
+		)
+		t.Events.NetworkIDMismatch.Trigger(&NetworkIDMismatchEvent{
+			ID:        fromID,
+			Address:   fromAddr,
+			NetworkID: m.GetNetworkId(),
+		})
+		return ErrNetworkIDMismatch
+	}
 
-	return true
+	return nil
 }
 
 func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) bool {
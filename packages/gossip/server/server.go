@@ -17,6 +17,7 @@ import (
 	pb "github.com/iotaledger/hive.go/autopeering/server/proto"
 	"github.com/iotaledger/hive.go/backoff"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/netutil"
 	"go.uber.org/zap"
@@ -34,6 +35,8 @@ var (
 	ErrInvalidHandshake = errors.New("invalid handshake")
 	// ErrNoGossip means that the given peer does not support the gossip service.
 	ErrNoGossip = errors.New("peer does not have a gossip service")
+	// ErrNetworkIDMismatch is returned when the handshake of a peer of another network was rejected.
+	ErrNetworkIDMismatch = errors.New("network ID mismatch")
 )
 
 // connection timeouts
@@ -50,9 +53,13 @@ var dialRetryPolicy = backoff.ConstantBackOff(500 * time.Millisecond).With(backo
 
 // TCP establishes verified incoming and outgoing TCP connections to other peers.
 type TCP struct {
-	local    *peer.Local
-	listener *net.TCPListener
-	log      *zap.SugaredLogger
+	// Events contains the events of the server.
+	Events Events
+
+	local     *peer.Local
+	listener  *net.TCPListener
+	log       *zap.SugaredLogger
+	networkID uint64
 
 	acceptReceivedCh chan accept
 	matchersMap      map[identity.ID]*acceptMatcher
@@ -84,9 +91,23 @@ type accept struct {
 	conn   net.Conn    // the actual network connection
 }
 
+// ServeOption defines an option for ServeTCP.
+type ServeOption func(t *TCP)
+
+// WithNetworkID returns a ServeOption that sets the identifier of the network. Handshakes of peers that belong to
+// another network are rejected.
+func WithNetworkID(networkID uint64) ServeOption {
+	return func(t *TCP) {
+		t.networkID = networkID
+	}
+}
+
 // ServeTCP creates the object and starts listening for incoming connections.
-func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger) *TCP {
+func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger, opts ...ServeOption) *TCP {
 	t := &TCP{
+		Events: Events{
+			NetworkIDMismatch: events.NewEvent(networkIDMismatchCaller),
+		},
 		local:            local,
 		listener:         listener,
 		log:              log,
@@ -94,6 +115,9 @@ func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogg
 		matchersMap:      map[identity.ID]*acceptMatcher{},
 		closing:          make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
 
 	t.log.Debugw("server started",
 		"network", listener.Addr().Network(),
@@ -319,6 +343,10 @@ func (t *TCP) listenLoop() {
 		}
 
 		key, req, err := t.readHandshakeRequest(conn)
+		if errors.Is(err, ErrNetworkIDMismatch) {
+			t.closeConnection(conn)
+			continue
+		}
 		if err != nil {
 			t.log.Warnw("failed handshake", "addr", conn.RemoteAddr(), "err", err)
 			t.closeConnection(conn)
@@ -339,7 +367,7 @@ func (t *TCP) listenLoop() {
 }
 
 func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) error {
-	reqData, err := newHandshakeRequest(remoteAddr)
+	reqData, err := newHandshakeRequest(remoteAddr, t.networkID)
 	if err != nil {
 		return err
 	}
@@ -414,8 +442,8 @@ func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, er
 		return ed25519.PublicKey{}, nil, err
 	}
 
-	if !t.validateHandshakeRequest(pkt.GetData()) {
-		return ed25519.PublicKey{}, nil, ErrInvalidHandshake
+	if err := t.validateHandshakeRequest(identity.NewID(key), conn.RemoteAddr(), pkt.GetData()); err != nil {
+		return ed25519.PublicKey{}, nil, err
 	}
 
 	return key, pkt.GetData(), nil
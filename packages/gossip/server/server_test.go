@@ -9,6 +9,7 @@ import (
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/stretchr/testify/assert"
@@ -176,13 +177,45 @@ func TestWrongConnect(t *testing.T) {
 	wg.Wait()
 }
 
+func TestNetworkIDMismatch(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", WithNetworkID(1))
+	defer closeA()
+	transB, closeB := newTestServer(t, "B", WithNetworkID(2))
+	defer closeB()
+
+	rejected := make(chan *NetworkIDMismatchEvent, 1)
+	transA.Events.NetworkIDMismatch.Attach(events.NewClosure(func(ev *NetworkIDMismatchEvent) {
+		rejected <- ev
+	}))
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		_, err := transA.AcceptPeer(context.Background(), getPeer(transB))
+		assert.Error(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		_, err := transB.DialPeer(context.Background(), getPeer(transA))
+		assert.Error(t, err)
+	}()
+
+	wg.Wait()
+	ev := <-rejected
+	assert.Equal(t, getPeer(transB).ID(), ev.ID)
+	assert.EqualValues(t, 2, ev.NetworkID)
+}
+
 func newTestDB(t require.TestingT) *peer.DB {
 	db, err := peer.NewDB(mapdb.NewMapDB())
 	require.NoError(t, err)
 	return db
 }
 
-func newTestServer(t require.TestingT, name string) (*TCP, func()) {
+func newTestServer(t require.TestingT, name string, opts ...ServeOption) (*TCP, func()) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -197,7 +230,7 @@ func newTestServer(t require.TestingT, name string) (*TCP, func()) {
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := ServeTCP(local, lis, l)
+	srv := ServeTCP(local, lis, l, opts...)
 
 	teardown := func() {
 		srv.Close()
@@ -0,0 +1,190 @@
+package issuerregistry
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+const (
+	// ObjectName defines the name of the issuer registry object (payload).
+	ObjectName  = "issuerRegistry"
+	payloadType = 5
+)
+
+// Type represents the identifier for the issuer registry Payload type.
+var Type = payload.NewType(payloadType, ObjectName, PayloadUnmarshaler)
+
+// Payload is an admin payload that defines the complete set of the authorized issuers from its activation time on.
+// Every Payload carries a version and a message is checked against the Payload with the highest version that was
+// activated at the issuing time of the message. Since the result depends neither on the order in which the Payloads are
+// received nor on the time at which a node checks the message, all nodes agree on the same set of issuers.
+type Payload struct {
+	version        uint64
+	activationTime time.Time
+	issuers        []ed25519.PublicKey
+	admin          ed25519.PublicKey
+	signature      ed25519.Signature
+}
+
+// NewPayload creates a new Payload of the given version that authorizes the given issuers from the given activation
+// time on and that is signed with the given admin key.
+func NewPayload(version uint64, activationTime time.Time, issuers []ed25519.PublicKey, adminKey ed25519.PrivateKey) *Payload {
+	p := &Payload{
+		version:        version,
+		activationTime: activationTime,
+		issuers:        issuers,
+		admin:          adminKey.Public(),
+	}
+	p.signature = adminKey.Sign(p.essenceBytes())
+
+	return p
+}
+
+// FromBytes parses the marshaled version of a Payload into a Payload object.
+func FromBytes(bytes []byte) (result *Payload, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if result, err = FromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse issuer registry Payload from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// FromMarshalUtil unmarshals a Payload using a MarshalUtil (for easier unmarshaling).
+func FromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (result *Payload, err error) {
+	if _, err = marshalUtil.ReadUint32(); err != nil {
+		err = errors.Errorf("failed to parse payload size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	parsedType, err := payload.TypeFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = errors.Errorf("failed to parse Type from MarshalUtil: %w", err)
+		return
+	}
+	if parsedType != payloadType {
+		err = errors.Errorf("invalid payload type %s: %w", parsedType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	result = &Payload{}
+	if result.version, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse version (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.activationTime, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse activation time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	issuersCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		err = errors.Errorf("failed to parse issuers count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	result.issuers = make([]ed25519.PublicKey, issuersCount)
+	for i := range result.issuers {
+		if result.issuers[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse issuer (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+	if result.admin, err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse admin (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.signature, err = ed25519.ParseSignature(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// PayloadUnmarshaler is the UnmarshalerFunc of the Payload.
+func PayloadUnmarshaler(data []byte) (payload.Payload, error) {
+	p, consumedBytes, err := FromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if consumedBytes != len(data) {
+		return nil, errors.New("not all payload bytes were consumed")
+	}
+	return p, nil
+}
+
+// Type returns the type of the Payload.
+func (p *Payload) Type() payload.Type {
+	return Type
+}
+
+// Version returns the version of the registry that is defined by the Payload.
+func (p *Payload) Version() uint64 {
+	return p.version
+}
+
+// ActivationTime returns the time from which on the Payload defines the registry.
+func (p *Payload) ActivationTime() time.Time {
+	return p.activationTime
+}
+
+// Issuers returns the public keys of the authorized issuers.
+func (p *Payload) Issuers() []ed25519.PublicKey {
+	return p.issuers
+}
+
+// Admin returns the public key of the admin that signed the Payload.
+func (p *Payload) Admin() ed25519.PublicKey {
+	return p.admin
+}
+
+// VerifySignature returns true if the Payload is signed by its admin.
+func (p *Payload) VerifySignature() bool {
+	return p.admin.VerifySignature(p.essenceBytes(), p.signature)
+}
+
+// Bytes marshals the Payload into a sequence of bytes.
+func (p *Payload) Bytes() []byte {
+	essenceBytes := p.essenceBytes()
+
+	return marshalutil.New().
+		WriteUint32(payload.TypeLength + uint32(len(essenceBytes)+ed25519.PublicKeySize+ed25519.SignatureSize)).
+		WriteBytes(p.Type().Bytes()).
+		WriteBytes(essenceBytes).
+		WriteBytes(p.admin.Bytes()).
+		WriteBytes(p.signature.Bytes()).
+		Bytes()
+}
+
+// String returns a human readable version of the Payload.
+func (p *Payload) String() string {
+	return stringify.Struct("IssuerRegistryPayload",
+		stringify.StructField("version", p.version),
+		stringify.StructField("activationTime", p.activationTime),
+		stringify.StructField("issuers", p.issuers),
+		stringify.StructField("admin", p.admin),
+	)
+}
+
+// essenceBytes returns the bytes of the Payload that are signed by the admin.
+func (p *Payload) essenceBytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteUint64(p.version).
+		WriteTime(p.activationTime).
+		WriteUint16(uint16(len(p.issuers)))
+	for _, issuer := range p.issuers {
+		marshalUtil.WriteBytes(issuer.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ payload.Payload = &Payload{}
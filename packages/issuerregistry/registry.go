@@ -0,0 +1,246 @@
+package issuerregistry
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/types"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+var (
+	// ErrUnknownAdmin is returned when a Payload is signed by a key that is not an admin of the Registry.
+	ErrUnknownAdmin = errors.New("unknown admin")
+	// ErrInvalidSignature is returned when the signature of a Payload is invalid.
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrRetroactiveActivation is returned when a Payload is activated before the message that carries it was issued.
+	ErrRetroactiveActivation = errors.New("activation time before issuing time")
+)
+
+// region Registry /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Registry keeps track of the issuers that are authorized to issue messages. It keeps all versions of the registry
+// that were defined by admin signed Payloads and checks every message against the version that was in effect at the
+// issuing time of the message: the Payload with the highest version that was activated at that time, or the initial
+// issuers if there is none. A node that receives an update late thereby still reaches the same verdict for every
+// message as the nodes that received it early. If an admin signs conflicting Payloads of the same version, the one with
+// the lowest hash wins on every node, regardless of the order in which they arrive.
+type Registry struct {
+	// Events contains the events of the Registry.
+	Events *Events
+
+	admins   map[identity.ID]types.Empty
+	store    kvstore.KVStore
+	versions map[uint64]*registryVersion
+	mutex    sync.RWMutex
+}
+
+// NewRegistry creates a new Registry with the given admins and initial issuers. It restores the versions of the
+// registry that were persisted in the given store.
+func NewRegistry(store kvstore.KVStore, admins []ed25519.PublicKey, initialIssuers []ed25519.PublicKey) (*Registry, error) {
+	r := &Registry{
+		Events: &Events{
+			Updated: events.NewEvent(updatedEventCaller),
+		},
+		admins:   make(map[identity.ID]types.Empty, len(admins)),
+		store:    store,
+		versions: map[uint64]*registryVersion{0: newInitialRegistryVersion(initialIssuers)},
+	}
+	for _, admin := range admins {
+		r.admins[identity.NewID(admin)] = types.Void
+	}
+
+	var restoreErr error
+	if err := store.Iterate(kvstore.EmptyPrefix, func(_ kvstore.Key, value kvstore.Value) bool {
+		storedPayload, _, parseErr := FromBytes(value)
+		if parseErr != nil {
+			restoreErr = errors.Errorf("failed to parse the stored issuer registry: %w", parseErr)
+			return false
+		}
+		if restoreErr = r.verify(storedPayload); restoreErr != nil {
+			restoreErr = errors.Errorf("failed to restore the stored issuer registry: %w", restoreErr)
+			return false
+		}
+		r.versions[storedPayload.Version()] = newRegistryVersion(storedPayload)
+
+		return true
+	}); err != nil {
+		return nil, errors.Errorf("failed to load the issuer registry: %w", err)
+	}
+	if restoreErr != nil {
+		return nil, restoreErr
+	}
+
+	return r, nil
+}
+
+// IsAuthorized returns true if the given Message was issued by an admin or by an issuer that was authorized at its
+// issuing time, or if it carries a valid Payload of an admin (so that updates of the registry can be issued by any
+// node).
+func (r *Registry) IsAuthorized(message *tangle.Message) bool {
+	issuerID := identity.NewID(message.IssuerPublicKey())
+	if r.isAdmin(issuerID) || r.IsIssuer(issuerID, message.IssuingTime()) {
+		return true
+	}
+
+	registryPayload, ok := message.Payload().(*Payload)
+	return ok && r.verifyMessage(registryPayload, message) == nil
+}
+
+// IsIssuer returns true if the node with the given ID was an authorized issuer at the given time.
+func (r *Registry) IsIssuer(id identity.ID, issuingTime time.Time) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, authorized := r.versionAt(issuingTime).issuers[id]
+	return authorized
+}
+
+// Version returns the version of the registry that is in effect at the given time (0 for the initial issuers).
+func (r *Registry) Version(t time.Time) uint64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.versionAt(t).version
+}
+
+// ApplyMessage adds the Payload of the given Message to the versions of the registry if it is signed by an admin and if
+// it is not activated before the Message was issued. A Payload of a known version only replaces the known one if its
+// hash is lower, so that all nodes end up with the same Payload. It returns true if the registry was updated.
+func (r *Registry) ApplyMessage(message *tangle.Message) (updated bool, err error) {
+	registryPayload, ok := message.Payload().(*Payload)
+	if !ok {
+		return false, nil
+	}
+	if err = r.verifyMessage(registryPayload, message); err != nil {
+		return false, err
+	}
+
+	r.mutex.Lock()
+	newVersion := newRegistryVersion(registryPayload)
+	if existing, exists := r.versions[newVersion.version]; (exists && bytes.Compare(newVersion.hash[:], existing.hash[:]) >= 0) || newVersion.version == 0 {
+		r.mutex.Unlock()
+		return false, nil
+	}
+	if err = r.store.Set(versionKey(newVersion.version), registryPayload.Bytes()); err != nil {
+		r.mutex.Unlock()
+		return false, errors.Errorf("failed to persist the issuer registry: %w", err)
+	}
+	r.versions[newVersion.version] = newVersion
+	r.mutex.Unlock()
+
+	r.Events.Updated.Trigger(registryPayload)
+
+	return true, nil
+}
+
+// versionAt returns the version with the highest number that is activated at the given time. The caller has to hold
+// the mutex.
+func (r *Registry) versionAt(t time.Time) (result *registryVersion) {
+	for _, version := range r.versions {
+		if version.activationTime.After(t) {
+			continue
+		}
+		if result == nil || version.version > result.version {
+			result = version
+		}
+	}
+
+	return result
+}
+
+// verifyMessage checks that the given Payload is signed by an admin of the Registry and that it is not activated
+// before the given Message that carries it was issued, so that a Payload can not change the verdict on messages that
+// the nodes have already processed.
+func (r *Registry) verifyMessage(registryPayload *Payload, message *tangle.Message) error {
+	if err := r.verify(registryPayload); err != nil {
+		return err
+	}
+	if registryPayload.ActivationTime().Before(message.IssuingTime()) {
+		return errors.Errorf("payload activated at %s in message issued at %s: %w", registryPayload.ActivationTime(), message.IssuingTime(), ErrRetroactiveActivation)
+	}
+
+	return nil
+}
+
+// verify checks that the given Payload is signed by an admin of the Registry.
+func (r *Registry) verify(registryPayload *Payload) error {
+	if !r.isAdmin(identity.NewID(registryPayload.Admin())) {
+		return errors.Errorf("payload signed by %s: %w", registryPayload.Admin(), ErrUnknownAdmin)
+	}
+	if !registryPayload.VerifySignature() {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// isAdmin returns true if the node with the given ID is an admin of the Registry.
+func (r *Registry) isAdmin(id identity.ID) bool {
+	_, admin := r.admins[id]
+	return admin
+}
+
+// versionKey returns the key under which the Payload of the given version is persisted.
+func versionKey(version uint64) kvstore.Key {
+	return marshalutil.New(marshalutil.Uint64Size).WriteUint64(version).Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region registryVersion //////////////////////////////////////////////////////////////////////////////////////////////
+
+// registryVersion is a set of authorized issuers that is in effect from its activation time on.
+type registryVersion struct {
+	version        uint64
+	activationTime time.Time
+	issuers        map[identity.ID]types.Empty
+	hash           [blake2b.Size256]byte
+}
+
+// newRegistryVersion creates the registryVersion that is defined by the given Payload.
+func newRegistryVersion(registryPayload *Payload) *registryVersion {
+	r := newInitialRegistryVersion(registryPayload.Issuers())
+	r.version = registryPayload.Version()
+	r.activationTime = registryPayload.ActivationTime()
+	r.hash = blake2b.Sum256(registryPayload.Bytes())
+
+	return r
+}
+
+// newInitialRegistryVersion creates the registryVersion of the initial issuers.
+func newInitialRegistryVersion(issuers []ed25519.PublicKey) *registryVersion {
+	r := &registryVersion{
+		issuers: make(map[identity.ID]types.Empty, len(issuers)),
+	}
+	for _, issuer := range issuers {
+		r.issuers[identity.NewID(issuer)] = types.Void
+	}
+
+	return r
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Events ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Events represents events happening in the Registry.
+type Events struct {
+	// Updated is triggered when a new version of the registry was added by a Payload.
+	Updated *events.Event
+}
+
+func updatedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*Payload))(params[0].(*Payload))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
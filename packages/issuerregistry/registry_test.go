@@ -0,0 +1,158 @@
+package issuerregistry
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestPayload_Bytes(t *testing.T) {
+	admin := ed25519.GenerateKeyPair()
+	issuers := []ed25519.PublicKey{ed25519.GenerateKeyPair().PublicKey, ed25519.GenerateKeyPair().PublicKey}
+	activationTime := time.Now().Add(time.Hour)
+	registryPayload := NewPayload(7, activationTime, issuers, admin.PrivateKey)
+
+	restored, _, err := payload.FromBytes(registryPayload.Bytes())
+	require.NoError(t, err)
+	require.IsType(t, &Payload{}, restored)
+	assert.Equal(t, uint64(7), restored.(*Payload).Version())
+	assert.True(t, activationTime.Equal(restored.(*Payload).ActivationTime()))
+	assert.Equal(t, issuers, restored.(*Payload).Issuers())
+	assert.Equal(t, admin.PublicKey, restored.(*Payload).Admin())
+	assert.True(t, restored.(*Payload).VerifySignature())
+}
+
+func TestRegistry(t *testing.T) {
+	store := mapdb.NewMapDB()
+	admin := ed25519.GenerateKeyPair()
+	issuerA := ed25519.GenerateKeyPair().PublicKey
+	issuerB := ed25519.GenerateKeyPair().PublicKey
+	now := time.Now()
+	activationTime := now.Add(time.Hour)
+
+	registry, err := NewRegistry(store, []ed25519.PublicKey{admin.PublicKey}, []ed25519.PublicKey{issuerA})
+	require.NoError(t, err)
+	assert.True(t, registry.IsIssuer(identity.NewID(issuerA), now))
+	assert.False(t, registry.IsIssuer(identity.NewID(issuerB), now))
+
+	// payloads of unknown admins are rejected
+	_, err = registry.ApplyMessage(newTestMessage(issuerA, now, NewPayload(1, activationTime, []ed25519.PublicKey{issuerB}, ed25519.GenerateKeyPair().PrivateKey)))
+	assert.ErrorIs(t, err, ErrUnknownAdmin)
+
+	// payloads can not change the registry before the message that carries them was issued
+	_, err = registry.ApplyMessage(newTestMessage(issuerA, now, NewPayload(1, now.Add(-time.Minute), []ed25519.PublicKey{issuerB}, admin.PrivateKey)))
+	assert.ErrorIs(t, err, ErrRetroactiveActivation)
+
+	updated, err := registry.ApplyMessage(newTestMessage(issuerA, now, NewPayload(2, activationTime, []ed25519.PublicKey{issuerB}, admin.PrivateKey)))
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	// messages are checked against the version that was in effect at their issuing time
+	assert.Equal(t, uint64(0), registry.Version(now))
+	assert.True(t, registry.IsIssuer(identity.NewID(issuerA), now))
+	assert.False(t, registry.IsIssuer(identity.NewID(issuerB), now))
+	assert.Equal(t, uint64(2), registry.Version(activationTime))
+	assert.False(t, registry.IsIssuer(identity.NewID(issuerA), activationTime))
+	assert.True(t, registry.IsIssuer(identity.NewID(issuerB), activationTime))
+
+	// lower versions do not replace higher versions that are activated at the same time
+	updated, err = registry.ApplyMessage(newTestMessage(issuerA, now, NewPayload(1, activationTime, []ed25519.PublicKey{issuerA}, admin.PrivateKey)))
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.False(t, registry.IsIssuer(identity.NewID(issuerA), activationTime))
+
+	// known payloads are ignored
+	updated, err = registry.ApplyMessage(newTestMessage(issuerA, now, NewPayload(2, activationTime, []ed25519.PublicKey{issuerB}, admin.PrivateKey)))
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	// the registry is restored from the store
+	restored, err := NewRegistry(store, []ed25519.PublicKey{admin.PublicKey}, []ed25519.PublicKey{issuerA})
+	require.NoError(t, err)
+	assert.True(t, restored.IsIssuer(identity.NewID(issuerA), now))
+	assert.Equal(t, uint64(2), restored.Version(activationTime))
+	assert.False(t, restored.IsIssuer(identity.NewID(issuerA), activationTime))
+	assert.True(t, restored.IsIssuer(identity.NewID(issuerB), activationTime))
+}
+
+func TestRegistry_ConflictingPayloads(t *testing.T) {
+	admin := ed25519.GenerateKeyPair()
+	issuerA := ed25519.GenerateKeyPair().PublicKey
+	issuerB := ed25519.GenerateKeyPair().PublicKey
+	now := time.Now()
+	activationTime := now.Add(time.Hour)
+
+	// two payloads of the same version with different issuers
+	payloads := []*Payload{
+		NewPayload(1, activationTime, []ed25519.PublicKey{issuerA}, admin.PrivateKey),
+		NewPayload(1, activationTime, []ed25519.PublicKey{issuerB}, admin.PrivateKey),
+	}
+	winner, loser := payloads[0], payloads[1]
+	if winnerHash, loserHash := blake2b.Sum256(winner.Bytes()), blake2b.Sum256(loser.Bytes()); bytes.Compare(loserHash[:], winnerHash[:]) < 0 {
+		winner, loser = loser, winner
+	}
+
+	for _, order := range [][]*Payload{{winner, loser}, {loser, winner}} {
+		store := mapdb.NewMapDB()
+		registry, err := NewRegistry(store, []ed25519.PublicKey{admin.PublicKey}, nil)
+		require.NoError(t, err)
+
+		updated, err := registry.ApplyMessage(newTestMessage(admin.PublicKey, now, order[0]))
+		require.NoError(t, err)
+		assert.True(t, updated)
+		updated, err = registry.ApplyMessage(newTestMessage(admin.PublicKey, now, order[1]))
+		require.NoError(t, err)
+		assert.Equal(t, order[1] == winner, updated)
+
+		// the payload with the lower hash wins, regardless of the order in which the payloads arrive
+		assert.True(t, registry.IsIssuer(identity.NewID(winner.Issuers()[0]), activationTime))
+		assert.False(t, registry.IsIssuer(identity.NewID(loser.Issuers()[0]), activationTime))
+
+		restored, err := NewRegistry(store, []ed25519.PublicKey{admin.PublicKey}, nil)
+		require.NoError(t, err)
+		assert.True(t, restored.IsIssuer(identity.NewID(winner.Issuers()[0]), activationTime))
+		assert.False(t, restored.IsIssuer(identity.NewID(loser.Issuers()[0]), activationTime))
+	}
+}
+
+func TestRegistry_IsAuthorized(t *testing.T) {
+	admin := ed25519.GenerateKeyPair()
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	other := ed25519.GenerateKeyPair().PublicKey
+	now := time.Now()
+
+	registry, err := NewRegistry(mapdb.NewMapDB(), []ed25519.PublicKey{admin.PublicKey}, []ed25519.PublicKey{issuer})
+	require.NoError(t, err)
+
+	dataPayload := payload.NewGenericDataPayload([]byte("test"))
+	assert.True(t, registry.IsAuthorized(newTestMessage(issuer, now, dataPayload)))
+	assert.True(t, registry.IsAuthorized(newTestMessage(admin.PublicKey, now, dataPayload)))
+	assert.False(t, registry.IsAuthorized(newTestMessage(other, now, dataPayload)))
+
+	// admin payloads are accepted from any issuer
+	assert.True(t, registry.IsAuthorized(newTestMessage(other, now, NewPayload(1, now, nil, admin.PrivateKey))))
+	assert.False(t, registry.IsAuthorized(newTestMessage(other, now, NewPayload(1, now, nil, ed25519.GenerateKeyPair().PrivateKey))))
+	assert.False(t, registry.IsAuthorized(newTestMessage(other, now, NewPayload(1, now.Add(-time.Minute), nil, admin.PrivateKey))))
+
+	// the issuer is authorized until the update is activated
+	updated, err := registry.ApplyMessage(newTestMessage(admin.PublicKey, now, NewPayload(1, now.Add(time.Hour), []ed25519.PublicKey{other}, admin.PrivateKey)))
+	require.NoError(t, err)
+	require.True(t, updated)
+	assert.True(t, registry.IsAuthorized(newTestMessage(issuer, now.Add(time.Minute), dataPayload)))
+	assert.False(t, registry.IsAuthorized(newTestMessage(issuer, now.Add(time.Hour), dataPayload)))
+	assert.True(t, registry.IsAuthorized(newTestMessage(other, now.Add(time.Hour), dataPayload)))
+}
+
+func newTestMessage(issuer ed25519.PublicKey, issuingTime time.Time, messagePayload payload.Payload) *tangle.Message {
+	return tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, nil, issuingTime, issuer, 0, messagePayload, 0, ed25519.Signature{})
+}
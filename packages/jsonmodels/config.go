@@ -0,0 +1,43 @@
+package jsonmodels
+
+// ConfigParameter is the JSON model of a parameter that can be changed at runtime.
+type ConfigParameter struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Value       interface{} `json:"value"`
+}
+
+// GetConfigResponse is the HTTP response containing the parameters that can be changed at runtime.
+type GetConfigResponse struct {
+	Parameters []*ConfigParameter `json:"parameters"`
+}
+
+// SetConfigRequest is the HTTP request to change a parameter at runtime.
+type SetConfigRequest struct {
+	Parameter string      `json:"parameter"`
+	Value     interface{} `json:"value"`
+	WriteBack bool        `json:"writeBack"`
+}
+
+// ConfigChange is the JSON model of a recorded attempt to change a parameter at runtime.
+type ConfigChange struct {
+	Time        int64       `json:"time"`
+	Parameter   string      `json:"parameter"`
+	OldValue    interface{} `json:"oldValue,omitempty"`
+	NewValue    interface{} `json:"newValue"`
+	User        string      `json:"user,omitempty"`
+	WrittenBack bool        `json:"writtenBack"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// SetConfigResponse is the HTTP response of a request to change a parameter at runtime.
+type SetConfigResponse struct {
+	Change *ConfigChange `json:"change,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// GetConfigAuditResponse is the HTTP response containing the latest changes of parameters at runtime.
+type GetConfigAuditResponse struct {
+	Changes []*ConfigChange `json:"changes"`
+}
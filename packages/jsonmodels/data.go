@@ -6,7 +6,35 @@ type DataResponse struct {
 	Error string `json:"error,omitempty"`
 }
 
-// DataRequest contains the data of the message to send.
+// DataRequest contains the data of the message to send. If a tag is given, the data is sent as a tagged data payload
+// that can be queried by its tag.
 type DataRequest struct {
 	Data []byte `json:"data"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+// GetTaggedDataRequest is the request of the /data/tagged endpoint.
+type GetTaggedDataRequest struct {
+	// Tag is the tag of the messages (required).
+	Tag string `query:"tag"`
+	// Cursor is the NextCursor of the previous page (optional).
+	Cursor string `query:"cursor"`
+	// Limit is the maximum number of returned messages (optional).
+	Limit int `query:"limit"`
+}
+
+// GetTaggedDataResponse contains a page of the messages with a tagged data payload of a tag ordered by their issuing
+// time. NextCursor continues the query if more messages exist.
+type GetTaggedDataResponse struct {
+	Messages   []TaggedData `json:"messages,omitempty"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+	Error      string       `json:"error,omitempty"`
+}
+
+// TaggedData represents a message with a tagged data payload.
+type TaggedData struct {
+	ID          string `json:"id"`
+	Tag         string `json:"tag"`
+	Data        []byte `json:"data"`
+	IssuingTime int64  `json:"issuingTime"`
 }
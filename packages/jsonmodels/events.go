@@ -0,0 +1,43 @@
+package jsonmodels
+
+// Topics of the events that are published by the event stream of the web API.
+const (
+	EventTopicMessageBooked        = "messageBooked"
+	EventTopicMessageConfirmed     = "messageConfirmed"
+	EventTopicTransactionConfirmed = "transactionConfirmed"
+	EventTopicTransactionRejected  = "transactionRejected"
+	EventTopicBranchLiked          = "branchLiked"
+	EventTopicBranchFinalized      = "branchFinalized"
+	EventTopicManaPledged          = "manaPledged"
+)
+
+// EventTopics contains all topics of the event stream.
+var EventTopics = []string{
+	EventTopicMessageBooked,
+	EventTopicMessageConfirmed,
+	EventTopicTransactionConfirmed,
+	EventTopicTransactionRejected,
+	EventTopicBranchLiked,
+	EventTopicBranchFinalized,
+	EventTopicManaPledged,
+}
+
+// Event is the JSON model of an event of the event stream. Topic defines which of the fields are set.
+type Event struct {
+	Topic         string       `json:"topic"`
+	Time          int64        `json:"time"`
+	Message       *Message     `json:"message,omitempty"`
+	TransactionID string       `json:"transactionID,omitempty"`
+	Transaction   *Transaction `json:"transaction,omitempty"`
+	Branch        *Branch      `json:"branch,omitempty"`
+	ManaPledge    *ManaPledge  `json:"manaPledge,omitempty"`
+}
+
+// ManaPledge is the JSON model of a mana.PledgedEvent.
+type ManaPledge struct {
+	NodeID        string  `json:"nodeID"`
+	ManaType      string  `json:"manaType"`
+	Amount        float64 `json:"amount"`
+	TransactionID string  `json:"transactionID"`
+	Time          int64   `json:"time"`
+}
@@ -10,6 +10,8 @@ type InfoResponse struct {
 	Version string `json:"version,omitempty"`
 	// Network Version of the autopeering
 	NetworkVersion uint32 `json:"networkVersion,omitempty"`
+	// identifier of the network derived from the genesis snapshot and the protocol parameters
+	NetworkID string `json:"networkID,omitempty"`
 	// TangleTime sync status
 	TangleTime TangleTime `json:"tangleTime,omitempty"`
 	// identity ID of the node encoded in base58
@@ -386,6 +386,7 @@ type ExtendedLockedOutput struct {
 	FallbackAddress  string            `json:"fallbackAddress,omitempty"`
 	FallbackDeadline int64             `json:"fallbackDeadline,omitempty"`
 	TimeLock         int64             `json:"timelock,omitempty"`
+	HashLock         string            `json:"hashLock,omitempty"`
 	Payload          []byte            `json:"payload,omitempty"`
 }
 
@@ -412,6 +413,13 @@ func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (led
 	if e.TimeLock != 0 {
 		res = res.WithTimeLock(time.Unix(e.TimeLock, 0))
 	}
+	if e.HashLock != "" {
+		hashLock, hErr := ledgerstate.HashLockFromBase58EncodedString(e.HashLock)
+		if hErr != nil {
+			return nil, errors.Errorf("wrong hash lock in ExtendedLockedOutput: %w", hErr)
+		}
+		res = res.WithHashLock(hashLock)
+	}
 	if e.Payload != nil {
 		rErr := res.SetPayload(e.Payload)
 		if rErr != nil {
@@ -440,6 +448,9 @@ func ExtendedLockedOutputFromLedgerstate(output ledgerstate.Output) (*ExtendedLo
 	if !castedOutput.TimeLock().Equal(time.Time{}) {
 		res.TimeLock = castedOutput.TimeLock().Unix()
 	}
+	if castedOutput.HashLock() != ledgerstate.EmptyHashLock {
+		res.HashLock = castedOutput.HashLock().Base58()
+	}
 	return res, nil
 }
 
@@ -710,6 +721,7 @@ type UnlockBlock struct {
 	SignatureType   ledgerstate.SignatureType `json:"signatureType,omitempty"`
 	PublicKey       string                    `json:"publicKey,omitempty"`
 	Signature       string                    `json:"signature,omitempty"`
+	Preimage        string                    `json:"preimage,omitempty"`
 }
 
 // NewUnlockBlock returns an UnlockBlock from the given ledgerstate.UnlockBlock.
@@ -735,6 +747,17 @@ func NewUnlockBlock(unlockBlock ledgerstate.UnlockBlock) *UnlockBlock {
 	case ledgerstate.ReferenceUnlockBlockType:
 		referenceUnlockBlock, _, _ := ledgerstate.ReferenceUnlockBlockFromBytes(unlockBlock.Bytes())
 		result.ReferencedIndex = referenceUnlockBlock.ReferencedIndex()
+	case ledgerstate.HashLockUnlockBlockType:
+		hashLockUnlockBlock, _, _ := ledgerstate.HashLockUnlockBlockFromBytes(unlockBlock.Bytes())
+		result.Preimage = base58.Encode(hashLockUnlockBlock.Preimage())
+		result.SignatureType = hashLockUnlockBlock.Signature().Type()
+		switch signature := hashLockUnlockBlock.Signature().(type) {
+		case *ledgerstate.ED25519Signature:
+			result.PublicKey = signature.PublicKey.String()
+			result.Signature = signature.Signature.String()
+		case *ledgerstate.BLSSignature:
+			result.Signature = signature.Signature.String()
+		}
 	}
 
 	return result
@@ -818,6 +841,116 @@ func NewTransactionConsensusMetadata(transactionID ledgerstate.TransactionID, op
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region ColorSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupply represents the JSON model of the ledgerstate.ColorSupply.
+type ColorSupply struct {
+	Color                string `json:"color"`
+	MintingTransactionID string `json:"mintingTransactionID"`
+	MintingTime          int64  `json:"mintingTime"`
+	MintConfirmed        bool   `json:"mintConfirmed"`
+	Minted               uint64 `json:"minted"`
+	Burned               uint64 `json:"burned"`
+	Supply               uint64 `json:"supply"`
+	HolderCount          uint64 `json:"holderCount"`
+}
+
+// NewColorSupply returns the ColorSupply from the given ledgerstate.ColorSupply.
+func NewColorSupply(colorSupply *ledgerstate.ColorSupply) *ColorSupply {
+	return &ColorSupply{
+		Color:                colorSupply.Color().Base58(),
+		MintingTransactionID: colorSupply.MintingTransactionID().Base58(),
+		MintingTime:          colorSupply.MintingTime().Unix(),
+		MintConfirmed:        colorSupply.MintConfirmed(),
+		Minted:               colorSupply.Minted(),
+		Burned:               colorSupply.Burned(),
+		Supply:               colorSupply.Supply(),
+		HolderCount:          colorSupply.HolderCount(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorBurn ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorBurn represents the JSON model of the ledgerstate.ColorBurn.
+type ColorBurn struct {
+	TransactionID string `json:"transactionID"`
+	Amount        uint64 `json:"amount"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+// NewColorBurn returns the ColorBurn from the given ledgerstate.ColorBurn.
+func NewColorBurn(colorBurn *ledgerstate.ColorBurn) *ColorBurn {
+	return &ColorBurn{
+		TransactionID: colorBurn.TransactionID().Base58(),
+		Amount:        colorBurn.Amount(),
+		Timestamp:     colorBurn.Timestamp().Unix(),
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressTransaction ///////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressTransaction represents the JSON model of a ledgerstate.AddressTransactionMapping.
+type AddressTransaction struct {
+	TransactionID  string `json:"transactionID"`
+	Timestamp      int64  `json:"timestamp"`
+	Incoming       bool   `json:"incoming"`
+	Outgoing       bool   `json:"outgoing"`
+	InclusionState string `json:"inclusionState"`
+}
+
+// NewAddressTransaction returns the AddressTransaction from the given ledgerstate.AddressTransactionMapping and the
+// InclusionState of its Transaction.
+func NewAddressTransaction(addressTransactionMapping *ledgerstate.AddressTransactionMapping, inclusionState ledgerstate.InclusionState) *AddressTransaction {
+	return &AddressTransaction{
+		TransactionID:  addressTransactionMapping.TransactionID().Base58(),
+		Timestamp:      addressTransactionMapping.Timestamp().Unix(),
+		Incoming:       addressTransactionMapping.Direction().Incoming(),
+		Outgoing:       addressTransactionMapping.Direction().Outgoing(),
+		InclusionState: InclusionStateName(inclusionState),
+	}
+}
+
+// InclusionStateName returns the name of the given ledgerstate.InclusionState that is used in the API.
+func InclusionStateName(inclusionState ledgerstate.InclusionState) string {
+	switch inclusionState {
+	case ledgerstate.Pending:
+		return "pending"
+	case ledgerstate.Confirmed:
+		return "confirmed"
+	case ledgerstate.Rejected:
+		return "rejected"
+	default:
+		return "unknown"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionViolation /////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionViolation represents the JSON model of a rule that is violated by a transaction. The InputIndex is -1 if
+// the violation is not caused by a specific input.
+type TransactionViolation struct {
+	Type       string `json:"type"`
+	InputIndex int    `json:"inputIndex"`
+	Message    string `json:"message"`
+}
+
+// NewTransactionViolation returns the TransactionViolation from the given ledgerstate.TransactionViolation.
+func NewTransactionViolation(violation *ledgerstate.TransactionViolation) *TransactionViolation {
+	return &TransactionViolation{
+		Type:       violation.Type.String(),
+		InputIndex: violation.InputIndex,
+		Message:    violation.Message,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region utils ////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // getStringBalances translates colored balances to map[string]uint64
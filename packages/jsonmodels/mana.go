@@ -129,3 +129,99 @@ type AllowedPledge struct {
 	IsFilterEnabled bool     `json:"isFilterEnabled"`
 	Allowed         []string `json:"allowed,omitempty"`
 }
+
+// GetManaHistoryRequest is the request object of mana/analytics/history.
+type GetManaHistoryRequest struct {
+	NodeID    string `json:"nodeID"`
+	ManaType  string `json:"manaType"`
+	StartTime int64  `json:"startTime"`
+	EndTime   int64  `json:"endTime"`
+}
+
+// GetManaHistoryResponse holds the pledge and revoke history of a node.
+type GetManaHistoryResponse struct {
+	Error       string             `json:"error,omitempty"`
+	ShortNodeID string             `json:"shortNodeID"`
+	NodeID      string             `json:"nodeID"`
+	ManaType    string             `json:"manaType"`
+	Balance     float64            `json:"balance"`
+	History     []ManaHistoryPoint `json:"history"`
+}
+
+// ManaHistoryPoint is a single pledge or revoke in the history of a node.
+type ManaHistoryPoint struct {
+	Time          int64   `json:"time"`
+	Delta         float64 `json:"delta"`
+	Balance       float64 `json:"balance"`
+	TransactionID string  `json:"txID"`
+}
+
+// GetManaPledgersRequest is the request object of mana/analytics/pledgers.
+type GetManaPledgersRequest struct {
+	NodeID   string `json:"nodeID"`
+	ManaType string `json:"manaType"`
+	Number   uint   `json:"number"`
+}
+
+// GetManaPledgersResponse holds the addresses that pledged the most mana to a node.
+type GetManaPledgersResponse struct {
+	Error       string           `json:"error,omitempty"`
+	ShortNodeID string           `json:"shortNodeID"`
+	NodeID      string           `json:"nodeID"`
+	ManaType    string           `json:"manaType"`
+	Pledgers    []AddressPledged `json:"pledgers"`
+}
+
+// AddressPledged holds the amount of mana an address pledged.
+type AddressPledged struct {
+	Address string  `json:"address"`
+	Mana    float64 `json:"mana"`
+}
+
+// GetManaPledgeesRequest is the request object of mana/analytics/pledgees.
+type GetManaPledgeesRequest struct {
+	Address  string `json:"address"`
+	ManaType string `json:"manaType"`
+	Number   uint   `json:"number"`
+}
+
+// GetManaPledgeesResponse holds the nodes that received the most mana from an address.
+type GetManaPledgeesResponse struct {
+	Error    string         `json:"error,omitempty"`
+	Address  string         `json:"address"`
+	ManaType string         `json:"manaType"`
+	Pledgees []mana.NodeStr `json:"pledgees"`
+}
+
+// GetManaEpochsRequest is the request object of mana/analytics/epochs.
+type GetManaEpochsRequest struct {
+	ManaType string `json:"manaType"`
+	// Epochs contains the requested epoch indices, all kept epochs are returned if it is empty.
+	Epochs []int64 `json:"epochs"`
+}
+
+// GetManaEpochsResponse holds the per epoch distribution of pledged mana.
+type GetManaEpochsResponse struct {
+	Error    string             `json:"error,omitempty"`
+	ManaType string             `json:"manaType"`
+	Epochs   []ManaEpochSummary `json:"epochs"`
+}
+
+// ManaEpochSummary holds the net mana pledged to each node during an epoch.
+type ManaEpochSummary struct {
+	Epoch     int64          `json:"epoch"`
+	StartTime int64          `json:"startTime"`
+	EndTime   int64          `json:"endTime"`
+	Total     float64        `json:"total"`
+	Gini      float64        `json:"gini"`
+	Nodes     []mana.NodeStr `json:"nodes"`
+}
+
+// GetManaGiniResponse holds the Gini coefficients of the access and consensus mana distributions.
+type GetManaGiniResponse struct {
+	Error              string  `json:"error,omitempty"`
+	Access             float64 `json:"access"`
+	AccessTimestamp    int64   `json:"accessTimestamp"`
+	Consensus          float64 `json:"consensus"`
+	ConsensusTimestamp int64   `json:"consensusTimestamp"`
+}
@@ -2,13 +2,48 @@ package jsonmodels
 
 // SpammerResponse is the HTTP response of a spammer request.
 type SpammerResponse struct {
-	Message string `json:"message"`
-	Error   string `json:"error"`
+	Message   string                  `json:"message"`
+	Error     string                  `json:"error"`
+	Scenario  string                  `json:"scenario,omitempty"`
+	Scenarios []*SpammerScenario      `json:"scenarios,omitempty"`
+	Stats     []*SpammerScenarioStats `json:"stats,omitempty"`
 }
 
 // SpammerRequest contains the parameters of a spammer request.
 type SpammerRequest struct {
-	Cmd  string `json:"cmd"`
-	IMIF string `json:"imif"`
-	MPM  int    `json:"mpm"`
+	Cmd      string `json:"cmd"`
+	IMIF     string `json:"imif"`
+	MPM      int    `json:"mpm"`
+	Scenario string `json:"scenario"`
+}
+
+// SpammerScenario is the JSON model of a scenario of the spammer.
+type SpammerScenario struct {
+	Name             string         `json:"name"`
+	MPM              int            `json:"mpm"`
+	IMIF             string         `json:"imif"`
+	Mix              map[string]int `json:"mix"`
+	DoubleSpendRatio float64        `json:"doubleSpendRatio"`
+	PayloadSize      int            `json:"payloadSize"`
+}
+
+// SpammerScenarioStats contains the issuance and confirmation statistics of a scenario of the spammer.
+type SpammerScenarioStats struct {
+	Scenario string                      `json:"scenario"`
+	Running  bool                        `json:"running"`
+	Started  int64                       `json:"started"`
+	Total    SpammerKindStats            `json:"total"`
+	Kinds    map[string]SpammerKindStats `json:"kinds"`
+}
+
+// SpammerKindStats contains the issuance and confirmation statistics of a kind of payload issued by the spammer.
+type SpammerKindStats struct {
+	Issued    uint64 `json:"issued"`
+	Failed    uint64 `json:"failed"`
+	Confirmed uint64 `json:"confirmed"`
+	Rejected  uint64 `json:"rejected"`
+	// Dropped is the amount of messages whose confirmation was no longer tracked (not confirmed in time).
+	Dropped uint64 `json:"dropped"`
+	// AvgConfirmationTime is the average time in milliseconds it took the confirmed messages to be confirmed.
+	AvgConfirmationTime int64 `json:"avgConfirmationTime"`
 }
@@ -73,3 +73,39 @@ func NewMessageConsensusMetadata(metadata *fcob.MessageMetadata, timestampOpinio
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MessageIndex /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMessageIndexRequest contains the query parameters of a query of the message index.
+type GetMessageIndexRequest struct {
+	// PayloadType is the number of the payload type of the messages (required).
+	PayloadType string `query:"payloadType"`
+	// Issuer is the base58 encoded public key of the issuer of the messages (optional).
+	Issuer string `query:"issuer"`
+	// From is the unix timestamp (in seconds) from which on the messages were issued (optional).
+	From int64 `query:"from"`
+	// To is the unix timestamp (in seconds) before which the messages were issued (optional).
+	To int64 `query:"to"`
+	// Cursor is the NextCursor of the previous page (optional).
+	Cursor string `query:"cursor"`
+	// Limit is the maximum number of returned messages (optional).
+	Limit int `query:"limit"`
+}
+
+// GetMessageIndexResponse is the response of a query of the message index.
+type GetMessageIndexResponse struct {
+	Messages   []IndexedMessage `json:"messages"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// IndexedMessage is the JSON model of a message that was found in the message index.
+type IndexedMessage struct {
+	ID              string          `json:"id"`
+	PayloadType     string          `json:"payloadType"`
+	IssuerPublicKey string          `json:"issuerPublicKey"`
+	IssuingTime     int64           `json:"issuingTime"`
+	Metadata        MessageMetadata `json:"metadata"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
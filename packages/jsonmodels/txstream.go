@@ -0,0 +1,195 @@
+package jsonmodels
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/txstream"
+)
+
+// Types of the TxStreamMessage that are sent from the client to the server.
+const (
+	TxStreamMsgSetID                   = "setID"
+	TxStreamMsgResume                  = "resume"
+	TxStreamMsgAck                     = "ack"
+	TxStreamMsgUpdateSubscriptions     = "updateSubscriptions"
+	TxStreamMsgPostTransaction         = "postTransaction"
+	TxStreamMsgGetConfirmedTransaction = "getConfirmedTransaction"
+	TxStreamMsgGetConfirmedOutput      = "getConfirmedOutput"
+	TxStreamMsgGetUnspentAliasOutput   = "getUnspentAliasOutput"
+	TxStreamMsgGetTxInclusionState     = "getTxInclusionState"
+	TxStreamMsgGetBacklog              = "getBacklog"
+)
+
+// Types of the TxStreamMessage that are sent from the server to the client.
+const (
+	TxStreamMsgTransaction        = "transaction"
+	TxStreamMsgTxInclusionState   = "txInclusionState"
+	TxStreamMsgOutput             = "output"
+	TxStreamMsgUnspentAliasOutput = "unspentAliasOutput"
+	TxStreamMsgResumeState        = "resumeState"
+)
+
+// TxStreamMessage is the JSON model of a message of the txstream protocol. Type defines which of the fields are set.
+type TxStreamMessage struct {
+	Type           string                     `json:"type"`
+	ClientID       string                     `json:"clientID,omitempty"`
+	Address        string                     `json:"address,omitempty"`
+	Addresses      []string                   `json:"addresses,omitempty"`
+	TransactionID  string                     `json:"transactionID,omitempty"`
+	OutputID       string                     `json:"outputID,omitempty"`
+	Seq            uint64                     `json:"seq,omitempty"`
+	FirstSeq       uint64                     `json:"firstSeq,omitempty"`
+	LastSeq        uint64                     `json:"lastSeq,omitempty"`
+	Transaction    *Transaction               `json:"transaction,omitempty"`
+	TxBytes        string                     `json:"txBytes,omitempty"`
+	InclusionState *TransactionInclusionState `json:"inclusionState,omitempty"`
+	Output         *Output                    `json:"output,omitempty"`
+	OutputMetadata *OutputMetadata            `json:"outputMetadata,omitempty"`
+	Timestamp      int64                      `json:"timestamp,omitempty"`
+}
+
+// NewTxStreamMessage returns the JSON model of the given txstream message.
+func NewTxStreamMessage(msg txstream.Message) (*TxStreamMessage, error) {
+	switch msg := msg.(type) {
+	case *txstream.MsgSetID:
+		return &TxStreamMessage{Type: TxStreamMsgSetID, ClientID: msg.ClientID}, nil
+	case *txstream.MsgResume:
+		return &TxStreamMessage{Type: TxStreamMsgResume, Seq: msg.Seq}, nil
+	case *txstream.MsgAck:
+		return &TxStreamMessage{Type: TxStreamMsgAck, Seq: msg.Seq}, nil
+	case *txstream.MsgUpdateSubscriptions:
+		addresses := make([]string, len(msg.Addresses))
+		for i, address := range msg.Addresses {
+			addresses[i] = address.Base58()
+		}
+		return &TxStreamMessage{Type: TxStreamMsgUpdateSubscriptions, Addresses: addresses}, nil
+	case *txstream.MsgPostTransaction:
+		return &TxStreamMessage{
+			Type:          TxStreamMsgPostTransaction,
+			TransactionID: msg.Tx.ID().Base58(),
+			TxBytes:       base58.Encode(msg.Tx.Bytes()),
+		}, nil
+	case *txstream.MsgGetConfirmedTransaction:
+		return &TxStreamMessage{Type: TxStreamMsgGetConfirmedTransaction, Address: msg.Address.Base58(), TransactionID: msg.TxID.Base58()}, nil
+	case *txstream.MsgGetConfirmedOutput:
+		return &TxStreamMessage{Type: TxStreamMsgGetConfirmedOutput, Address: msg.Address.Base58(), OutputID: msg.OutputID.Base58()}, nil
+	case *txstream.MsgGetUnspentAliasOutput:
+		return &TxStreamMessage{Type: TxStreamMsgGetUnspentAliasOutput, Address: msg.AliasAddress.Base58()}, nil
+	case *txstream.MsgGetTxInclusionState:
+		return &TxStreamMessage{Type: TxStreamMsgGetTxInclusionState, Address: msg.Address.Base58(), TransactionID: msg.TxID.Base58()}, nil
+	case *txstream.MsgGetBacklog:
+		return &TxStreamMessage{Type: TxStreamMsgGetBacklog, Address: msg.Address.Base58()}, nil
+	case *txstream.MsgTransaction:
+		return &TxStreamMessage{
+			Type:          TxStreamMsgTransaction,
+			Address:       msg.Address.Base58(),
+			TransactionID: msg.Tx.ID().Base58(),
+			Seq:           msg.Seq,
+			Transaction:   NewTransaction(msg.Tx),
+			TxBytes:       base58.Encode(msg.Tx.Bytes()),
+		}, nil
+	case *txstream.MsgTxInclusionState:
+		return &TxStreamMessage{
+			Type:           TxStreamMsgTxInclusionState,
+			Address:        msg.Address.Base58(),
+			TransactionID:  msg.TxID.Base58(),
+			InclusionState: NewTransactionInclusionState(msg.State, msg.TxID, false),
+		}, nil
+	case *txstream.MsgOutput:
+		return &TxStreamMessage{
+			Type:           TxStreamMsgOutput,
+			Address:        msg.Address.Base58(),
+			OutputID:       msg.Output.ID().Base58(),
+			Output:         NewOutput(msg.Output),
+			OutputMetadata: NewOutputMetadata(msg.OutputMetadata),
+		}, nil
+	case *txstream.MsgUnspentAliasOutput:
+		return &TxStreamMessage{
+			Type:           TxStreamMsgUnspentAliasOutput,
+			Address:        msg.AliasAddress.Base58(),
+			OutputID:       msg.AliasOutput.ID().Base58(),
+			Output:         NewOutput(msg.AliasOutput),
+			OutputMetadata: NewOutputMetadata(msg.OutputMetadata),
+			Timestamp:      msg.Timestamp.Unix(),
+		}, nil
+	case *txstream.MsgResumeState:
+		return &TxStreamMessage{Type: TxStreamMsgResumeState, Seq: msg.Seq, FirstSeq: msg.FirstSeq, LastSeq: msg.LastSeq}, nil
+	default:
+		return nil, errors.Errorf("unsupported txstream message type: %T", msg)
+	}
+}
+
+// ToTxStreamMessage converts a TxStreamMessage that was sent by a client into the corresponding txstream message.
+func (m *TxStreamMessage) ToTxStreamMessage() (txstream.Message, error) {
+	switch m.Type {
+	case TxStreamMsgSetID:
+		return &txstream.MsgSetID{ClientID: m.ClientID}, nil
+	case TxStreamMsgResume:
+		return &txstream.MsgResume{Seq: m.Seq}, nil
+	case TxStreamMsgAck:
+		return &txstream.MsgAck{Seq: m.Seq}, nil
+	case TxStreamMsgUpdateSubscriptions:
+		addresses := make([]ledgerstate.Address, len(m.Addresses))
+		for i, addressString := range m.Addresses {
+			address, err := ledgerstate.AddressFromBase58EncodedString(addressString)
+			if err != nil {
+				return nil, errors.Errorf("failed to parse address %s: %w", addressString, err)
+			}
+			addresses[i] = address
+		}
+		return &txstream.MsgUpdateSubscriptions{Addresses: addresses}, nil
+	case TxStreamMsgPostTransaction:
+		txBytes, err := base58.Decode(m.TxBytes)
+		if err != nil {
+			return nil, errors.Errorf("failed to decode transaction bytes: %w", err)
+		}
+		tx, _, err := ledgerstate.TransactionFromBytes(txBytes)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse transaction: %w", err)
+		}
+		return &txstream.MsgPostTransaction{Tx: tx}, nil
+	case TxStreamMsgGetConfirmedTransaction, TxStreamMsgGetTxInclusionState:
+		address, err := ledgerstate.AddressFromBase58EncodedString(m.Address)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse address: %w", err)
+		}
+		txID, err := ledgerstate.TransactionIDFromBase58(m.TransactionID)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse transaction ID: %w", err)
+		}
+		if m.Type == TxStreamMsgGetTxInclusionState {
+			return &txstream.MsgGetTxInclusionState{Address: address, TxID: txID}, nil
+		}
+		return &txstream.MsgGetConfirmedTransaction{Address: address, TxID: txID}, nil
+	case TxStreamMsgGetConfirmedOutput:
+		address, err := ledgerstate.AddressFromBase58EncodedString(m.Address)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse address: %w", err)
+		}
+		outputID, err := ledgerstate.OutputIDFromBase58(m.OutputID)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse output ID: %w", err)
+		}
+		return &txstream.MsgGetConfirmedOutput{Address: address, OutputID: outputID}, nil
+	case TxStreamMsgGetUnspentAliasOutput:
+		address, err := ledgerstate.AddressFromBase58EncodedString(m.Address)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse address: %w", err)
+		}
+		aliasAddress, ok := address.(*ledgerstate.AliasAddress)
+		if !ok {
+			return nil, errors.Errorf("address %s is not an alias address", m.Address)
+		}
+		return &txstream.MsgGetUnspentAliasOutput{AliasAddress: aliasAddress}, nil
+	case TxStreamMsgGetBacklog:
+		address, err := ledgerstate.AddressFromBase58EncodedString(m.Address)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse address: %w", err)
+		}
+		return &txstream.MsgGetBacklog{Address: address}, nil
+	default:
+		return nil, errors.Errorf("unsupported txstream message type: %s", m.Type)
+	}
+}
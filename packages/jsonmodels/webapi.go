@@ -1,6 +1,8 @@
 package jsonmodels
 
 import (
+	"sort"
+
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 )
@@ -127,6 +129,60 @@ func NewGetOutputConsumersResponse(outputID ledgerstate.OutputID, consumers []*l
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetColorBurnsResponse ////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColorBurnsResponse represents the JSON model of a response from the GetColorBurns endpoint.
+type GetColorBurnsResponse struct {
+	Color string       `json:"color"`
+	Burns []*ColorBurn `json:"burns"`
+}
+
+// NewGetColorBurnsResponse returns a GetColorBurnsResponse from the given details.
+func NewGetColorBurnsResponse(color ledgerstate.Color, colorBurns []*ledgerstate.ColorBurn) *GetColorBurnsResponse {
+	burns := make([]*ColorBurn, 0, len(colorBurns))
+	for _, colorBurn := range colorBurns {
+		burns = append(burns, NewColorBurn(colorBurn))
+	}
+	sort.Slice(burns, func(i, j int) bool {
+		return burns[i].Timestamp < burns[j].Timestamp
+	})
+
+	return &GetColorBurnsResponse{
+		Color: color.Base58(),
+		Burns: burns,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAddressTransactionsRequest ////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressTransactionsRequest holds the query parameters of a request to the GetAddressTransactions endpoint.
+type GetAddressTransactionsRequest struct {
+	// Direction limits the result to "incoming" or "outgoing" Transactions (optional).
+	Direction string `query:"direction"`
+	// InclusionState limits the result to "pending", "confirmed" or "rejected" Transactions (optional).
+	InclusionState string `query:"inclusionState"`
+	// Cursor continues the pagination after the last Transaction of a previous page (its NextCursor).
+	Cursor string `query:"cursor"`
+	// Limit is the maximum number of Transactions that are returned.
+	Limit int `query:"limit"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetAddressTransactionsResponse ///////////////////////////////////////////////////////////////////////////////
+
+// GetAddressTransactionsResponse represents the JSON model of a response from the GetAddressTransactions endpoint.
+type GetAddressTransactionsResponse struct {
+	Address      *Address              `json:"address"`
+	Transactions []*AddressTransaction `json:"transactions"`
+	Limit        int                   `json:"limit"`
+	NextCursor   string                `json:"nextCursor,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetTransactionAttachmentsResponse ////////////////////////////////////////////////////////////////////////////
 
 // GetTransactionAttachmentsResponse represents the JSON model of a response from the GetTransactionAttachments endpoint.
@@ -190,6 +246,18 @@ type PostTransactionResponse struct {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region DryRunTransaction Req/Resp ///////////////////////////////////////////////////////////////////////////////////
+
+// DryRunTransactionResponse is the HTTP response from validating a transaction without issuing it.
+type DryRunTransactionResponse struct {
+	TransactionID string                  `json:"transactionID,omitempty"`
+	Valid         bool                    `json:"valid"`
+	Violations    []*TransactionViolation `json:"violations"`
+	Error         string                  `json:"error,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region ErrorResponse ////////////////////////////////////////////////////////////////////////////////////////////////
 
 // ErrorResponse represents the JSON model of an error response from an API endpoint.
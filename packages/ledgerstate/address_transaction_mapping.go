@@ -0,0 +1,173 @@
+package ledgerstate
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region TransactionDirection /////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// IncomingTransaction marks a Transaction that created Outputs on an Address.
+	IncomingTransaction TransactionDirection = 1 << iota
+
+	// OutgoingTransaction marks a Transaction that consumed Outputs of an Address.
+	OutgoingTransaction
+)
+
+// TransactionDirection is a bitmask that encodes if a Transaction sent funds to and/or spent funds from an Address.
+type TransactionDirection uint8
+
+// Incoming returns true if the Transaction created Outputs on the Address.
+func (t TransactionDirection) Incoming() bool {
+	return t&IncomingTransaction != 0
+}
+
+// Outgoing returns true if the Transaction consumed Outputs of the Address.
+func (t TransactionDirection) Outgoing() bool {
+	return t&OutgoingTransaction != 0
+}
+
+// Bytes returns a marshaled version of the TransactionDirection.
+func (t TransactionDirection) Bytes() []byte {
+	return []byte{byte(t)}
+}
+
+// String returns a human readable version of the TransactionDirection.
+func (t TransactionDirection) String() string {
+	directions := make([]string, 0, 2)
+	if t.Incoming() {
+		directions = append(directions, "Incoming")
+	}
+	if t.Outgoing() {
+		directions = append(directions, "Outgoing")
+	}
+
+	return "TransactionDirection(" + strings.Join(directions, "|") + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressTransactionMapping ////////////////////////////////////////////////////////////////////////////////////
+
+// AddressTransactionMappingKeyLength contains the amount of bytes of the key of an AddressTransactionMapping.
+const AddressTransactionMappingKeyLength = AddressLength + marshalutil.Uint64Size + TransactionIDLength
+
+// AddressTransactionMapping is the entry of the address history index of the UTXODAG. It records that a Transaction
+// created Outputs on and/or consumed Outputs of an Address. Since an Address can be referenced by a potentially unbounded
+// amount of Transactions, we store this as a separate k/v pair per Transaction. The entries of an Address are keyed by
+// the inverted timestamp of the Transaction (followed by its TransactionID), so iterating them returns the newest
+// Transaction first.
+type AddressTransactionMapping struct {
+	address       Address
+	transactionID TransactionID
+	timestamp     time.Time
+	direction     TransactionDirection
+}
+
+// NewAddressTransactionMapping returns a new AddressTransactionMapping.
+func NewAddressTransactionMapping(address Address, transactionID TransactionID, timestamp time.Time, direction TransactionDirection) *AddressTransactionMapping {
+	return &AddressTransactionMapping{
+		address:       address,
+		transactionID: transactionID,
+		timestamp:     time.Unix(0, timestamp.UnixNano()),
+		direction:     direction,
+	}
+}
+
+// AddressTransactionMappingFromBytes unmarshals an AddressTransactionMapping from a sequence of bytes.
+func AddressTransactionMappingFromBytes(bytes []byte) (addressTransactionMapping *AddressTransactionMapping, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if addressTransactionMapping, err = AddressTransactionMappingFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AddressTransactionMapping from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressTransactionMappingFromMarshalUtil unmarshals an AddressTransactionMapping using a MarshalUtil (for easier
+// unmarshaling).
+func AddressTransactionMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (addressTransactionMapping *AddressTransactionMapping, err error) {
+	addressTransactionMapping = &AddressTransactionMapping{}
+	if addressTransactionMapping.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	invertedTimestampBytes, err := marshalUtil.ReadBytes(marshalutil.Uint64Size)
+	if err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	addressTransactionMapping.timestamp = time.Unix(0, int64(^binary.BigEndian.Uint64(invertedTimestampBytes)))
+	if addressTransactionMapping.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	directionUint8, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = errors.Errorf("failed to parse TransactionDirection (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	addressTransactionMapping.direction = TransactionDirection(directionUint8)
+
+	return
+}
+
+// Address returns the Address of the AddressTransactionMapping.
+func (a *AddressTransactionMapping) Address() Address {
+	return a.address
+}
+
+// TransactionID returns the identifier of the Transaction that referenced the Address.
+func (a *AddressTransactionMapping) TransactionID() TransactionID {
+	return a.transactionID
+}
+
+// Timestamp returns the timestamp of the Transaction that referenced the Address.
+func (a *AddressTransactionMapping) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// Direction returns the TransactionDirection of the Transaction from the point of view of the Address.
+func (a *AddressTransactionMapping) Direction() TransactionDirection {
+	return a.direction
+}
+
+// Bytes marshals the AddressTransactionMapping into a sequence of bytes.
+func (a *AddressTransactionMapping) Bytes() []byte {
+	return byteutils.ConcatBytes(a.key(), a.direction.Bytes())
+}
+
+// String returns a human readable version of the AddressTransactionMapping.
+func (a *AddressTransactionMapping) String() string {
+	return stringify.Struct("AddressTransactionMapping",
+		stringify.StructField("address", a.Address()),
+		stringify.StructField("transactionID", a.TransactionID()),
+		stringify.StructField("timestamp", a.Timestamp()),
+		stringify.StructField("direction", a.Direction()),
+	)
+}
+
+// key returns the key that is used to store the AddressTransactionMapping in the database. The timestamp is stored in
+// big endian, so the keys are ordered by it.
+func (a *AddressTransactionMapping) key() []byte {
+	invertedTimestampBytes := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(invertedTimestampBytes, ^uint64(a.timestamp.UnixNano()))
+
+	return marshalutil.New(AddressTransactionMappingKeyLength).
+		Write(a.address).
+		WriteBytes(invertedTimestampBytes).
+		Write(a.transactionID).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,105 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressTransactionMapping_MarshalUnmarshal(t *testing.T) {
+	wallets := createWallets(1)
+	mapping := NewAddressTransactionMapping(wallets[0].address, GenesisTransactionID, time.Unix(1000, 5), IncomingTransaction|OutgoingTransaction)
+
+	restored, _, err := AddressTransactionMappingFromBytes(mapping.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, mapping.Address().Bytes(), restored.Address().Bytes())
+	assert.Equal(t, mapping.TransactionID(), restored.TransactionID())
+	assert.True(t, mapping.Timestamp().Equal(restored.Timestamp()))
+	assert.True(t, restored.Direction().Incoming())
+	assert.True(t, restored.Direction().Outgoing())
+}
+
+func TestUTXODAG_AddressTransactionMappings(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	// send part of the funds to the second wallet and the remainder back to the first one
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+		NewSigLockedSingleOutput(60, wallets[1].address),
+		NewSigLockedSingleOutput(40, wallets[0].address),
+	))
+	tx := NewTransaction(essence, wallets[0].unlockBlocks(essence))
+	_, err := utxoDAG.BookTransaction(tx)
+	require.NoError(t, err)
+
+	senderMappings := addressTransactionMappings(t, utxoDAG, wallets[0].address, nil, 0)
+	require.Len(t, senderMappings, 1)
+	assert.Equal(t, tx.ID(), senderMappings[0].TransactionID())
+	assert.True(t, senderMappings[0].Timestamp().Equal(essence.Timestamp()))
+	assert.True(t, senderMappings[0].Direction().Incoming())
+	assert.True(t, senderMappings[0].Direction().Outgoing())
+
+	receiverMappings := addressTransactionMappings(t, utxoDAG, wallets[1].address, nil, 0)
+	require.Len(t, receiverMappings, 1)
+	assert.Equal(t, tx.ID(), receiverMappings[0].TransactionID())
+	assert.True(t, receiverMappings[0].Direction().Incoming())
+	assert.False(t, receiverMappings[0].Direction().Outgoing())
+}
+
+func TestUTXODAG_AddressTransactionMappingsPagination(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	baseTime := time.Now()
+
+	// book transactions with increasing timestamps (two of them sharing the same timestamp)
+	timestamps := []time.Time{baseTime, baseTime.Add(time.Second), baseTime.Add(2 * time.Second), baseTime.Add(2 * time.Second), baseTime.Add(time.Hour)}
+	for i, timestamp := range timestamps {
+		input := generateOutput(utxoDAG, wallets[0].address, uint16(i))
+		essence := NewTransactionEssence(0, timestamp, identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+			NewSigLockedSingleOutput(100, wallets[1].address),
+		))
+		_, err := utxoDAG.BookTransaction(NewTransaction(essence, wallets[0].unlockBlocks(essence)))
+		require.NoError(t, err)
+	}
+
+	allMappings := addressTransactionMappings(t, utxoDAG, wallets[1].address, nil, 0)
+	require.Len(t, allMappings, len(timestamps))
+	for i := 1; i < len(allMappings); i++ {
+		assert.False(t, allMappings[i].Timestamp().After(allMappings[i-1].Timestamp()), "mappings must be ordered newest first")
+	}
+
+	// page through the mappings using the last entry of the previous page as the cursor
+	var pagedMappings []*AddressTransactionMapping
+	var after *AddressTransactionMapping
+	for {
+		page := addressTransactionMappings(t, utxoDAG, wallets[1].address, after, 2)
+		if len(page) == 0 {
+			break
+		}
+		pagedMappings = append(pagedMappings, page...)
+		after = page[len(page)-1]
+	}
+	require.Len(t, pagedMappings, len(allMappings))
+	for i := range allMappings {
+		assert.Equal(t, allMappings[i].TransactionID(), pagedMappings[i].TransactionID())
+	}
+}
+
+func addressTransactionMappings(t *testing.T, utxoDAG *UTXODAG, address Address, after *AddressTransactionMapping, limit int) (mappings []*AddressTransactionMapping) {
+	require.NoError(t, utxoDAG.AddressTransactionMappings(address, after, func(addressTransactionMapping *AddressTransactionMapping) bool {
+		mappings = append(mappings, addressTransactionMapping)
+		return limit == 0 || len(mappings) < limit
+	}))
+
+	return mappings
+}
@@ -0,0 +1,759 @@
+package ledgerstate
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region ColorSupply //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorSupply is the entry of the per-Color supply index of the UTXODAG. It keeps track of the Transaction that minted
+// the Color, the amount of tokens that were minted and burned and the number of Addresses that hold tokens of the Color.
+// The minted amount is recorded as soon as the minting Transaction is booked (and removed again if the Transaction ends
+// up in a rejected Branch) while burned tokens and holders are only counted for confirmed Transactions.
+type ColorSupply struct {
+	color                Color
+	mintingTransactionID TransactionID
+	mintingTime          time.Time
+	minted               uint64
+	mintConfirmed        bool
+	burned               uint64
+	holderCount          uint64
+	mutex                sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorSupply creates a new empty ColorSupply for the given Color.
+func NewColorSupply(color Color) *ColorSupply {
+	return &ColorSupply{
+		color: color,
+	}
+}
+
+// ColorSupplyFromBytes unmarshals a ColorSupply from a sequence of bytes.
+func ColorSupplyFromBytes(bytes []byte) (colorSupply *ColorSupply, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorSupply, err = ColorSupplyFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColorSupply from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorSupplyFromMarshalUtil unmarshals a ColorSupply using a MarshalUtil (for easier unmarshaling).
+func ColorSupplyFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorSupply *ColorSupply, err error) {
+	colorSupply = &ColorSupply{}
+	if colorSupply.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupply.mintingTransactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse minting TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if colorSupply.mintingTime, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse minting time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.minted, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse minted amount (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.mintConfirmed, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse mint confirmed flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.burned, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse burned amount (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorSupply.holderCount, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse holder count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorSupplyFromObjectStorage restores a ColorSupply that was stored in the object storage.
+func ColorSupplyFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = ColorSupplyFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ColorSupply from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color that the ColorSupply belongs to.
+func (c *ColorSupply) Color() Color {
+	return c.color
+}
+
+// MintingTransactionID returns the identifier of the Transaction that minted the Color.
+func (c *ColorSupply) MintingTransactionID() TransactionID {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.mintingTransactionID
+}
+
+// MintingTime returns the timestamp of the Transaction that minted the Color.
+func (c *ColorSupply) MintingTime() time.Time {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.mintingTime
+}
+
+// Minted returns the amount of tokens that were minted.
+func (c *ColorSupply) Minted() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.minted
+}
+
+// MintConfirmed returns true if the minting Transaction has been confirmed.
+func (c *ColorSupply) MintConfirmed() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.mintConfirmed
+}
+
+// Burned returns the amount of tokens that were burned by confirmed Transactions.
+func (c *ColorSupply) Burned() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.burned
+}
+
+// Supply returns the amount of tokens of the Color that currently exist.
+func (c *ColorSupply) Supply() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.burned > c.minted {
+		return 0
+	}
+
+	return c.minted - c.burned
+}
+
+// HolderCount returns the number of Addresses that hold tokens of the Color.
+func (c *ColorSupply) HolderCount() uint64 {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return c.holderCount
+}
+
+// setMint records the Transaction that minted the Color. It returns true if the ColorSupply was modified.
+func (c *ColorSupply) setMint(transactionID TransactionID, mintingTime time.Time, amount uint64, confirmed bool) (modified bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.mintingTransactionID == transactionID && c.minted == amount && c.mintConfirmed == confirmed {
+		return
+	}
+
+	c.mintingTransactionID = transactionID
+	c.mintingTime = mintingTime
+	c.minted = amount
+	c.mintConfirmed = confirmed
+	c.SetModified()
+	modified = true
+
+	return
+}
+
+// addMinted increases the minted amount (used for Colors that exist since the snapshot).
+func (c *ColorSupply) addMinted(amount uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.minted += amount
+	c.SetModified()
+}
+
+// addBurned increases the burned amount.
+func (c *ColorSupply) addBurned(amount uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.burned += amount
+	c.SetModified()
+}
+
+// updateHolderCount adjusts the number of holders by the given delta.
+func (c *ColorSupply) updateHolderCount(delta int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch {
+	case delta > 0:
+		c.holderCount += uint64(delta)
+	case uint64(-delta) > c.holderCount:
+		c.holderCount = 0
+	default:
+		c.holderCount -= uint64(-delta)
+	}
+	c.SetModified()
+}
+
+// Bytes marshals the ColorSupply into a sequence of bytes.
+func (c *ColorSupply) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorSupply.
+func (c *ColorSupply) String() string {
+	return stringify.Struct("ColorSupply",
+		stringify.StructField("color", c.Color()),
+		stringify.StructField("mintingTransactionID", c.MintingTransactionID()),
+		stringify.StructField("mintingTime", c.MintingTime()),
+		stringify.StructField("minted", c.Minted()),
+		stringify.StructField("mintConfirmed", c.MintConfirmed()),
+		stringify.StructField("burned", c.Burned()),
+		stringify.StructField("holderCount", c.HolderCount()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorSupply) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorSupply) ObjectStorageKey() []byte {
+	return c.color.Bytes()
+}
+
+// ObjectStorageValue marshals the ColorSupply into a sequence of bytes. The Color is not serialized here as it is only
+// used as a key in the ObjectStorage.
+func (c *ColorSupply) ObjectStorageValue() []byte {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return marshalutil.New().
+		Write(c.mintingTransactionID).
+		WriteTime(c.mintingTime).
+		WriteUint64(c.minted).
+		WriteBool(c.mintConfirmed).
+		WriteUint64(c.burned).
+		WriteUint64(c.holderCount).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ColorSupply{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorSupply ////////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorSupply is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
+// methods with a type-casted one.
+type CachedColorSupply struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedColorSupply) Retain() *CachedColorSupply {
+	return &CachedColorSupply{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorSupply) Unwrap() *ColorSupply {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorSupply)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedColorSupply) Consume(consumer func(colorSupply *ColorSupply), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorSupply))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedColorSupply.
+func (c *CachedColorSupply) String() string {
+	return stringify.Struct("CachedColorSupply",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorHolder //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorHolder represents the confirmed balance of a Color that is held by an Address. It is used to derive the holder
+// count of the ColorSupply.
+type ColorHolder struct {
+	color        Color
+	address      Address
+	balance      uint64
+	balanceMutex sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorHolder creates a new empty ColorHolder.
+func NewColorHolder(color Color, address Address) *ColorHolder {
+	return &ColorHolder{
+		color:   color,
+		address: address,
+	}
+}
+
+// ColorHolderFromBytes unmarshals a ColorHolder from a sequence of bytes.
+func ColorHolderFromBytes(bytes []byte) (colorHolder *ColorHolder, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorHolder, err = ColorHolderFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColorHolder from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorHolderFromMarshalUtil unmarshals a ColorHolder using a MarshalUtil (for easier unmarshaling).
+func ColorHolderFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorHolder *ColorHolder, err error) {
+	colorHolder = &ColorHolder{}
+	if colorHolder.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorHolder.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	if colorHolder.balance, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse balance (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorHolderFromObjectStorage restores a ColorHolder that was stored in the object storage.
+func ColorHolderFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = ColorHolderFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ColorHolder from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color of the ColorHolder.
+func (c *ColorHolder) Color() Color {
+	return c.color
+}
+
+// Address returns the Address of the ColorHolder.
+func (c *ColorHolder) Address() Address {
+	return c.address
+}
+
+// Balance returns the amount of tokens of the Color that are held by the Address.
+func (c *ColorHolder) Balance() uint64 {
+	c.balanceMutex.RLock()
+	defer c.balanceMutex.RUnlock()
+
+	return c.balance
+}
+
+// updateBalance adds or subtracts the given amount and returns the change of the holder count that results from it.
+func (c *ColorHolder) updateBalance(amount uint64, increase bool) (holderDelta int) {
+	c.balanceMutex.Lock()
+	defer c.balanceMutex.Unlock()
+
+	wasHolder := c.balance > 0
+	switch {
+	case increase:
+		c.balance += amount
+	case amount > c.balance:
+		c.balance = 0
+	default:
+		c.balance -= amount
+	}
+	c.SetModified()
+
+	switch isHolder := c.balance > 0; {
+	case isHolder && !wasHolder:
+		return 1
+	case !isHolder && wasHolder:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Bytes marshals the ColorHolder into a sequence of bytes.
+func (c *ColorHolder) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorHolder.
+func (c *ColorHolder) String() string {
+	return stringify.Struct("ColorHolder",
+		stringify.StructField("color", c.Color()),
+		stringify.StructField("address", c.Address()),
+		stringify.StructField("balance", c.Balance()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorHolder) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorHolder) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(c.color.Bytes(), c.address.Bytes())
+}
+
+// ObjectStorageValue marshals the ColorHolder into a sequence of bytes that are used as the value part in the object
+// storage.
+func (c *ColorHolder) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.Uint64Size).
+		WriteUint64(c.Balance()).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ColorHolder{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorHolder ////////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorHolder is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
+// methods with a type-casted one.
+type CachedColorHolder struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorHolder) Unwrap() *ColorHolder {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorHolder)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedColorHolder) Consume(consumer func(colorHolder *ColorHolder), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorHolder))
+	}, forceRelease...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ColorBurn ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ColorBurn records that a confirmed Transaction burned tokens of a Color (i.e. consumed more tokens of the Color than it
+// created, which happens when tokens are recolored to IOTA).
+type ColorBurn struct {
+	color         Color
+	transactionID TransactionID
+	amount        uint64
+	timestamp     time.Time
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewColorBurn creates a new ColorBurn from the given details.
+func NewColorBurn(color Color, transactionID TransactionID, amount uint64, timestamp time.Time) *ColorBurn {
+	return &ColorBurn{
+		color:         color,
+		transactionID: transactionID,
+		amount:        amount,
+		timestamp:     timestamp,
+	}
+}
+
+// ColorBurnFromBytes unmarshals a ColorBurn from a sequence of bytes.
+func ColorBurnFromBytes(bytes []byte) (colorBurn *ColorBurn, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if colorBurn, err = ColorBurnFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColorBurn from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ColorBurnFromMarshalUtil unmarshals a ColorBurn using a MarshalUtil (for easier unmarshaling).
+func ColorBurnFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (colorBurn *ColorBurn, err error) {
+	colorBurn = &ColorBurn{}
+	if colorBurn.color, err = ColorFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Color from MarshalUtil: %w", err)
+		return
+	}
+	if colorBurn.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+	if colorBurn.amount, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse burned amount (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if colorBurn.timestamp, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ColorBurnFromObjectStorage restores a ColorBurn that was stored in the object storage.
+func ColorBurnFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = ColorBurnFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse ColorBurn from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Color returns the Color whose tokens were burned.
+func (c *ColorBurn) Color() Color {
+	return c.color
+}
+
+// TransactionID returns the identifier of the Transaction that burned the tokens.
+func (c *ColorBurn) TransactionID() TransactionID {
+	return c.transactionID
+}
+
+// Amount returns the amount of burned tokens.
+func (c *ColorBurn) Amount() uint64 {
+	return c.amount
+}
+
+// Timestamp returns the timestamp of the Transaction that burned the tokens.
+func (c *ColorBurn) Timestamp() time.Time {
+	return c.timestamp
+}
+
+// Bytes marshals the ColorBurn into a sequence of bytes.
+func (c *ColorBurn) Bytes() []byte {
+	return byteutils.ConcatBytes(c.ObjectStorageKey(), c.ObjectStorageValue())
+}
+
+// String returns a human readable version of the ColorBurn.
+func (c *ColorBurn) String() string {
+	return stringify.Struct("ColorBurn",
+		stringify.StructField("color", c.Color()),
+		stringify.StructField("transactionID", c.TransactionID()),
+		stringify.StructField("amount", c.Amount()),
+		stringify.StructField("timestamp", c.Timestamp()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (c *ColorBurn) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (c *ColorBurn) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(c.color.Bytes(), c.transactionID.Bytes())
+}
+
+// ObjectStorageValue marshals the ColorBurn into a sequence of bytes that are used as the value part in the object
+// storage.
+func (c *ColorBurn) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.Uint64Size + marshalutil.TimeSize).
+		WriteUint64(c.amount).
+		WriteTime(c.timestamp).
+		Bytes()
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &ColorBurn{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedColorBurn //////////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedColorBurn is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
+// methods with a type-casted one.
+type CachedColorBurn struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedColorBurn) Unwrap() *ColorBurn {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*ColorBurn)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedColorBurn) Consume(consumer func(colorBurn *ColorBurn), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*ColorBurn))
+	}, forceRelease...)
+}
+
+// CachedColorBurns represents a collection of CachedColorBurn objects.
+type CachedColorBurns []*CachedColorBurn
+
+// Unwrap is the type-casted equivalent of Get. It returns a slice of unwrapped objects with the object being nil if it
+// does not exist.
+func (c CachedColorBurns) Unwrap() (unwrappedColorBurns []*ColorBurn) {
+	unwrappedColorBurns = make([]*ColorBurn, 0, len(c))
+	for _, cachedColorBurn := range c {
+		if untypedObject := cachedColorBurn.Unwrap(); untypedObject != nil {
+			unwrappedColorBurns = append(unwrappedColorBurns, untypedObject)
+		}
+	}
+
+	return
+}
+
+// Release is a utility function that allows us to release all CachedObjects in the collection.
+func (c CachedColorBurns) Release(force ...bool) {
+	for _, cachedColorBurn := range c {
+		cachedColorBurn.Release(force...)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PendingMint //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PendingMint marks a booked Transaction that mints Colors but is not confirmed, yet. It is used to find the mints that
+// have to be removed from the supply index again when the Branch of the Transaction gets rejected.
+type PendingMint struct {
+	transactionID TransactionID
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewPendingMint creates a new PendingMint for the given Transaction.
+func NewPendingMint(transactionID TransactionID) *PendingMint {
+	return &PendingMint{
+		transactionID: transactionID,
+	}
+}
+
+// PendingMintFromBytes unmarshals a PendingMint from a sequence of bytes.
+func PendingMintFromBytes(bytes []byte) (pendingMint *PendingMint, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if pendingMint, err = PendingMintFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse PendingMint from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// PendingMintFromMarshalUtil unmarshals a PendingMint using a MarshalUtil (for easier unmarshaling).
+func PendingMintFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (pendingMint *PendingMint, err error) {
+	pendingMint = &PendingMint{}
+	if pendingMint.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// PendingMintFromObjectStorage restores a PendingMint that was stored in the object storage.
+func PendingMintFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = PendingMintFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse PendingMint from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// TransactionID returns the identifier of the minting Transaction.
+func (p *PendingMint) TransactionID() TransactionID {
+	return p.transactionID
+}
+
+// Bytes marshals the PendingMint into a sequence of bytes.
+func (p *PendingMint) Bytes() []byte {
+	return byteutils.ConcatBytes(p.ObjectStorageKey(), p.ObjectStorageValue())
+}
+
+// String returns a human readable version of the PendingMint.
+func (p *PendingMint) String() string {
+	return stringify.Struct("PendingMint",
+		stringify.StructField("transactionID", p.TransactionID()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (p *PendingMint) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (p *PendingMint) ObjectStorageKey() []byte {
+	return p.transactionID.Bytes()
+}
+
+// ObjectStorageValue marshals the PendingMint into a sequence of bytes that are used as the value part in the object
+// storage. It is empty as all information is contained in the key.
+func (p *PendingMint) ObjectStorageValue() []byte {
+	return nil
+}
+
+// code contract (make sure the type implements all required methods)
+var _ objectstorage.StorableObject = &PendingMint{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,145 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestColorSupply_MarshalUnmarshal(t *testing.T) {
+	colorSupply := NewColorSupply(Color{1})
+	colorSupply.setMint(GenesisTransactionID, time.Unix(1000, 0), 100, true)
+	colorSupply.addBurned(10)
+	colorSupply.updateHolderCount(2)
+
+	restored, _, err := ColorSupplyFromBytes(colorSupply.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, colorSupply.Color(), restored.Color())
+	assert.Equal(t, colorSupply.MintingTransactionID(), restored.MintingTransactionID())
+	assert.True(t, colorSupply.MintingTime().Equal(restored.MintingTime()))
+	assert.Equal(t, uint64(90), restored.Supply())
+	assert.True(t, restored.MintConfirmed())
+	assert.Equal(t, uint64(2), restored.HolderCount())
+
+	colorBurn := NewColorBurn(Color{1}, GenesisTransactionID, 10, time.Unix(1000, 0))
+	restoredBurn, _, err := ColorBurnFromBytes(colorBurn.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, colorBurn.Color(), restoredBurn.Color())
+	assert.Equal(t, colorBurn.Amount(), restoredBurn.Amount())
+}
+
+func TestUTXODAG_ColorSupply(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	// mint 60 tokens of a new color
+	mintEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorMint: 60, ColorIOTA: 40}), wallets[1].address),
+	))
+	mintTx := NewTransaction(mintEssence, wallets[0].unlockBlocks(mintEssence))
+	_, err := utxoDAG.BookTransaction(mintTx)
+	require.NoError(t, err)
+
+	mintedOutputID := NewOutputID(mintTx.ID(), 0)
+	color := Color(blake2b.Sum256(mintedOutputID.Bytes()))
+
+	assert.True(t, utxoDAG.CachedColorSupply(color).Consume(func(colorSupply *ColorSupply) {
+		assert.Equal(t, mintTx.ID(), colorSupply.MintingTransactionID())
+		assert.Equal(t, uint64(60), colorSupply.Supply())
+		assert.False(t, colorSupply.MintConfirmed())
+		assert.Equal(t, uint64(0), colorSupply.HolderCount())
+	}))
+
+	require.NoError(t, utxoDAG.SetTransactionConfirmed(mintTx.ID()))
+	assert.True(t, utxoDAG.CachedColorSupply(color).Consume(func(colorSupply *ColorSupply) {
+		assert.True(t, colorSupply.MintConfirmed())
+		assert.Equal(t, uint64(1), colorSupply.HolderCount())
+	}))
+
+	// send 20 tokens back and recolor (burn) the remaining 40
+	burnEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(mintedOutputID)), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{color: 20}), wallets[0].address),
+		NewSigLockedSingleOutput(80, wallets[1].address),
+	))
+	burnTx := NewTransaction(burnEssence, wallets[1].unlockBlocks(burnEssence))
+	_, err = utxoDAG.BookTransaction(burnTx)
+	require.NoError(t, err)
+	require.NoError(t, utxoDAG.SetTransactionConfirmed(burnTx.ID()))
+
+	assert.True(t, utxoDAG.CachedColorSupply(color).Consume(func(colorSupply *ColorSupply) {
+		assert.Equal(t, uint64(60), colorSupply.Minted())
+		assert.Equal(t, uint64(40), colorSupply.Burned())
+		assert.Equal(t, uint64(20), colorSupply.Supply())
+		assert.Equal(t, uint64(1), colorSupply.HolderCount())
+	}))
+
+	cachedColorBurns := utxoDAG.CachedColorBurns(color)
+	defer cachedColorBurns.Release()
+	colorBurns := cachedColorBurns.Unwrap()
+	require.Len(t, colorBurns, 1)
+	assert.Equal(t, burnTx.ID(), colorBurns[0].TransactionID())
+	assert.Equal(t, uint64(40), colorBurns[0].Amount())
+}
+
+func TestUTXODAG_ColorSupplyRevertedMint(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	// two conflicting Transactions that mint a new color each
+	mintTransaction := func(address Address) *Transaction {
+		essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(
+			NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorMint: 60, ColorIOTA: 40}), address),
+		))
+		return NewTransaction(essence, wallets[0].unlockBlocks(essence))
+	}
+	rejectedTx := mintTransaction(wallets[0].address)
+	confirmedTx := mintTransaction(wallets[1].address)
+	_, err := utxoDAG.BookTransaction(rejectedTx)
+	require.NoError(t, err)
+	_, err = utxoDAG.BookTransaction(confirmedTx)
+	require.NoError(t, err)
+
+	// a Transaction that mints on top of the losing Transaction is orphaned together with it
+	orphanedEssence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(NewOutputID(rejectedTx.ID(), 0))), NewOutputs(
+		NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorMint: 40, mintedColor(NewOutputID(rejectedTx.ID(), 0)): 60}), wallets[0].address),
+	))
+	orphanedTx := NewTransaction(orphanedEssence, wallets[0].unlockBlocks(orphanedEssence))
+	_, err = utxoDAG.BookTransaction(orphanedTx)
+	require.NoError(t, err)
+
+	rejectedColor := mintedColor(NewOutputID(rejectedTx.ID(), 0))
+	orphanedColor := mintedColor(NewOutputID(orphanedTx.ID(), 0))
+	confirmedColor := mintedColor(NewOutputID(confirmedTx.ID(), 0))
+	for _, color := range []Color{rejectedColor, orphanedColor, confirmedColor} {
+		assert.True(t, utxoDAG.CachedColorSupply(color).Consume(func(*ColorSupply) {}))
+	}
+
+	require.NoError(t, utxoDAG.SetTransactionConfirmed(confirmedTx.ID()))
+
+	assert.False(t, utxoDAG.CachedColorSupply(rejectedColor).Consume(func(*ColorSupply) {}))
+	assert.False(t, utxoDAG.CachedColorSupply(orphanedColor).Consume(func(*ColorSupply) {}))
+	assert.True(t, utxoDAG.CachedColorSupply(confirmedColor).Consume(func(colorSupply *ColorSupply) {
+		assert.True(t, colorSupply.MintConfirmed())
+		assert.Equal(t, uint64(60), colorSupply.Supply())
+	}))
+
+	// all PendingMints are resolved
+	utxoDAG.pendingMintStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		defer cachedObject.Release()
+		assert.Fail(t, "unexpected PendingMint", "%s", cachedObject.Get())
+		return true
+	})
+}
@@ -0,0 +1,104 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/mr-tron/base58"
+)
+
+// region HashLock /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HashLockLength represents the length of a HashLock (amount of bytes). HashLocks are SHA-256 digests, which is the hash
+// function used by the hash-time-locked contracts of most other chains, so that the same secret can secure both sides
+// of a cross-chain swap.
+const HashLockLength = sha256.Size
+
+// MaxPreimageSize is the maximum size of a preimage that is revealed to unlock a HashLock.
+const MaxPreimageSize = 64
+
+// EmptyHashLock represents the absence of a HashLock.
+var EmptyHashLock = HashLock{}
+
+// HashLock is the hash of a secret preimage. Outputs that are locked by a HashLock can only be unlocked by revealing the
+// preimage.
+type HashLock [HashLockLength]byte
+
+// NewHashLock returns the HashLock that is unlocked by the given preimage.
+func NewHashLock(preimage []byte) HashLock {
+	return sha256.Sum256(preimage)
+}
+
+// HashLockFromBytes unmarshals a HashLock from a sequence of bytes.
+func HashLockFromBytes(hashLockBytes []byte) (hashLock HashLock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(hashLockBytes)
+	if hashLock, err = HashLockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashLock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HashLockFromBase58EncodedString creates a HashLock from a base58 encoded string.
+func HashLockFromBase58EncodedString(base58String string) (hashLock HashLock, err error) {
+	parsedBytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = errors.Errorf("error while decoding base58 encoded HashLock (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+	if len(parsedBytes) != HashLockLength {
+		err = errors.Errorf("HashLock must be %d bytes long, got %d: %w", HashLockLength, len(parsedBytes), cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	if hashLock, _, err = HashLockFromBytes(parsedBytes); err != nil {
+		err = errors.Errorf("failed to parse HashLock from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// HashLockFromMarshalUtil unmarshals a HashLock using a MarshalUtil (for easier unmarshaling).
+func HashLockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (hashLock HashLock, err error) {
+	hashLockBytes, err := marshalUtil.ReadBytes(HashLockLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse HashLock (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(hashLock[:], hashLockBytes)
+
+	return
+}
+
+// Unlocks returns true if the given preimage unlocks the HashLock.
+func (h HashLock) Unlocks(preimage []byte) bool {
+	if len(preimage) == 0 || len(preimage) > MaxPreimageSize {
+		return false
+	}
+	hash := NewHashLock(preimage)
+
+	return bytes.Equal(hash[:], h[:])
+}
+
+// Bytes marshals the HashLock into a sequence of bytes.
+func (h HashLock) Bytes() []byte {
+	return h[:]
+}
+
+// Base58 returns a base58 encoded version of the HashLock.
+func (h HashLock) Base58() string {
+	return base58.Encode(h.Bytes())
+}
+
+// String creates a human readable string of the HashLock.
+func (h HashLock) String() string {
+	return "HashLock(" + h.Base58() + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
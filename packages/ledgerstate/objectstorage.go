@@ -36,6 +36,22 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixColorSupplyStorage defines the storage prefix for the ColorSupply object storage.
+	PrefixColorSupplyStorage
+
+	// PrefixColorHolderStorage defines the storage prefix for the ColorHolder object storage.
+	PrefixColorHolderStorage
+
+	// PrefixColorBurnStorage defines the storage prefix for the ColorBurn object storage.
+	PrefixColorBurnStorage
+
+	// PrefixAddressTransactionMappingStorage defines the storage prefix for the AddressTransactionMappings (they are
+	// stored directly in the KVStore, so they can be iterated in the order of their keys).
+	PrefixAddressTransactionMappingStorage
+
+	// PrefixPendingMintStorage defines the storage prefix for the PendingMint object storage.
+	PrefixPendingMintStorage
 )
 
 // branchStorageOptions contains a list of default settings for the Branch object storage.
@@ -101,3 +117,29 @@ var addressOutputMappingStorageOptions = []objectstorage.Option{
 	objectstorage.PartitionKey(AddressLength, OutputIDLength),
 	objectstorage.LeakDetectionEnabled(false),
 }
+
+// colorSupplyStorageOptions contains a list of default settings for the ColorSupply object storage.
+var colorSupplyStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.LeakDetectionEnabled(false),
+}
+
+// colorHolderStorageOptions contains a list of default settings for the ColorHolder object storage.
+var colorHolderStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.PartitionKey(ColorLength, AddressLength),
+	objectstorage.LeakDetectionEnabled(false),
+}
+
+// colorBurnStorageOptions contains a list of default settings for the ColorBurn object storage.
+var colorBurnStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.PartitionKey(ColorLength, TransactionIDLength),
+	objectstorage.LeakDetectionEnabled(false),
+}
+
+// pendingMintStorageOptions contains a list of default settings for the PendingMint object storage.
+var pendingMintStorageOptions = []objectstorage.Option{
+	objectstorage.CacheTime(10 * time.Second),
+	objectstorage.LeakDetectionEnabled(false),
+}
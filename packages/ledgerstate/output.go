@@ -1845,6 +1845,7 @@ var _ Output = &AliasOutput{}
 // - fallback address and fallback timeout
 // - can be unlocked by AliasUnlockBlock (if address is of AliasAddress type)
 // - can be time locked until deadline
+// - can be hash locked: until the fallback deadline it can only be unlocked by revealing the preimage of the hash lock
 // - data payload for arbitrary metadata (size limits apply)
 type ExtendedLockedOutput struct {
 	id       OutputID
@@ -1860,6 +1861,10 @@ type ExtendedLockedOutput struct {
 	// Deadline since when output can be unlocked
 	timelock time.Time
 
+	// Hash of the preimage that needs to be revealed to unlock the output before the fallback deadline. If empty,
+	// the output is not hash locked
+	hashLock HashLock
+
 	// any attached data (subject to size limits)
 	payload []byte
 
@@ -1870,6 +1875,7 @@ const (
 	flagExtendedLockedOutputFallbackPresent = uint(iota)
 	flagExtendedLockedOutputTimeLockPresent
 	flagExtendedLockedOutputPayloadPresent
+	flagExtendedLockedOutputHashLockPresent
 )
 
 // NewExtendedLockedOutput is the constructor for a ExtendedLockedOutput.
@@ -1897,6 +1903,15 @@ func (o *ExtendedLockedOutput) WithTimeLock(timelock time.Time) *ExtendedLockedO
 	return o
 }
 
+// WithHashLock adds a hash lock to the output and returns the updated version. Combined with the fallback options, it
+// turns the output into a hash-time-locked output: the target address can only unlock it by revealing the preimage
+// before the fallback deadline, the fallback address can unlock it after the deadline. The target address must not be
+// an AliasAddress, as the preimage is revealed together with a signature (such outputs are rejected when parsed).
+func (o *ExtendedLockedOutput) WithHashLock(hashLock HashLock) *ExtendedLockedOutput {
+	o.hashLock = hashLock
+	return o
+}
+
 // SetPayload sets the payload field of the output.
 func (o *ExtendedLockedOutput) SetPayload(data []byte) error {
 	if len(data) > MaxOutputPayloadSize {
@@ -1962,6 +1977,16 @@ func ExtendedOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output
 			return
 		}
 	}
+	if flags.HasBit(flagExtendedLockedOutputHashLockPresent) {
+		if output.hashLock, err = HashLockFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse hashLock (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if output.address.Type() == AliasAddressType {
+			err = errors.Errorf("hash locked output can not target an AliasAddress: %w", cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
 	if flags.HasBit(flagExtendedLockedOutputPayloadPresent) {
 		var size uint16
 		size, err = marshalUtil.ReadUint16()
@@ -1990,6 +2015,9 @@ func (o *ExtendedLockedOutput) compressFlags() bitmask.BitMask {
 	if len(o.payload) > 0 {
 		ret = ret.SetBit(flagExtendedLockedOutputPayloadPresent)
 	}
+	if o.hashLock != EmptyHashLock {
+		ret = ret.SetBit(flagExtendedLockedOutputHashLockPresent)
+	}
 	return ret
 }
 
@@ -2031,6 +2059,24 @@ func (o *ExtendedLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBl
 	}
 	addr := o.UnlockAddressNow(tx.Essence().Timestamp())
 
+	if o.HashLockedNow(tx.Essence().Timestamp()) {
+		// the preimage is revealed together with a signature, which an AliasAddress can not provide
+		if addr.Type() == AliasAddressType {
+			return false, errors.New("ExtendedLockedOutput: hash locked output can not be unlocked by an AliasAddress")
+		}
+		// unlocking by revealing the preimage. The unlock is valid if:
+		// - the preimage matches the hash lock
+		// - the signature is valid for the target address
+		hashLockUnlockBlock, isHashLockUnlock := unlockBlock.(*HashLockUnlockBlock)
+		if !isHashLockUnlock {
+			return false, errors.New("ExtendedLockedOutput: hash locked output can only be unlocked by revealing the preimage")
+		}
+		if !o.hashLock.Unlocks(hashLockUnlockBlock.Preimage()) {
+			return false, errors.New("ExtendedLockedOutput: preimage does not match the hash lock")
+		}
+		return hashLockUnlockBlock.AddressSignatureValid(addr, tx.Essence().Bytes()), nil
+	}
+
 	switch blk := unlockBlock.(type) {
 	case *SignatureUnlockBlock:
 		// unlocking by signature
@@ -2096,6 +2142,7 @@ func (o *ExtendedLockedOutput) Clone() Output {
 	if !o.timelock.IsZero() {
 		ret.timelock = o.timelock
 	}
+	ret.hashLock = o.hashLock
 	if o.payload != nil {
 		ret.payload = make([]byte, len(o.payload))
 		copy(ret.payload, o.payload)
@@ -2113,7 +2160,8 @@ func (o *ExtendedLockedOutput) UpdateMintingColor() Output {
 	}
 	updatedOutput := NewExtendedLockedOutput(coloredBalances, o.Address()).
 		WithFallbackOptions(o.fallbackAddress, o.fallbackDeadline).
-		WithTimeLock(o.timelock)
+		WithTimeLock(o.timelock).
+		WithHashLock(o.hashLock)
 	if err := updatedOutput.SetPayload(o.payload); err != nil {
 		panic(errors.Errorf("UpdateMintingColor: %v", err))
 	}
@@ -2154,6 +2202,9 @@ func (o *ExtendedLockedOutput) ObjectStorageValue() []byte {
 	if flags.HasBit(flagExtendedLockedOutputTimeLockPresent) {
 		ret.WriteTime(o.timelock)
 	}
+	if flags.HasBit(flagExtendedLockedOutputHashLockPresent) {
+		ret.WriteBytes(o.hashLock.Bytes())
+	}
 	if flags.HasBit(flagExtendedLockedOutputPayloadPresent) {
 		ret.WriteUint16(uint16(len(o.payload))).
 			WriteBytes(o.payload)
@@ -2176,6 +2227,7 @@ func (o *ExtendedLockedOutput) String() string {
 		stringify.StructField("fallbackAddress", o.fallbackAddress),
 		stringify.StructField("fallbackDeadline", o.fallbackDeadline),
 		stringify.StructField("timelock", o.timelock),
+		stringify.StructField("hashLock", o.hashLock),
 	)
 }
 
@@ -2194,6 +2246,20 @@ func (o *ExtendedLockedOutput) TimeLockedNow(nowis time.Time) bool {
 	return o.TimeLock().After(nowis)
 }
 
+// HashLock returns the hash of the preimage that unlocks the output. It is empty if the output is not hash locked.
+func (o *ExtendedLockedOutput) HashLock() HashLock {
+	return o.hashLock
+}
+
+// HashLockedNow checks if the preimage of the hash lock needs to be revealed to unlock the output at the specific moment.
+// The hash lock expires together with the fallback deadline.
+func (o *ExtendedLockedOutput) HashLockedNow(nowis time.Time) bool {
+	if o.hashLock == EmptyHashLock {
+		return false
+	}
+	return o.fallbackAddress == nil || !nowis.After(o.fallbackDeadline)
+}
+
 // FallbackOptions returns fallback options of the output. The address is nil if fallback options are not set
 func (o *ExtendedLockedOutput) FallbackOptions() (Address, time.Time) {
 	return o.fallbackAddress, o.fallbackDeadline
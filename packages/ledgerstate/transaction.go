@@ -255,7 +255,7 @@ func TransactionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (transacti
 	maxReferencedUnlockIndex := len(transaction.essence.Inputs()) - 1
 	for i, unlockBlock := range transaction.unlockBlocks {
 		switch unlockBlock.Type() {
-		case SignatureUnlockBlockType:
+		case SignatureUnlockBlockType, HashLockUnlockBlockType:
 			continue
 		case ReferenceUnlockBlockType:
 			if unlockBlock.(*ReferenceUnlockBlock).ReferencedIndex() > uint16(maxReferencedUnlockIndex) {
@@ -0,0 +1,94 @@
+package ledgerstate
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region TransactionViolationType /////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// InputNotFoundViolation is reported if an Output that is referenced by an Input does not exist in the ledger.
+	InputNotFoundViolation TransactionViolationType = iota
+
+	// BalancesInvalidViolation is reported if the consumed and created balances of a Transaction do not match.
+	BalancesInvalidViolation
+
+	// UnlockBlocksInvalidViolation is reported if the UnlockBlocks of a Transaction are semantically invalid as a whole
+	// (i.e. if they contain invalid or cyclic references).
+	UnlockBlocksInvalidViolation
+
+	// InputUnlockInvalidViolation is reported if an Input can not be unlocked by its UnlockBlock (invalid signature, alias
+	// state transition, timelock, ...).
+	InputUnlockInvalidViolation
+
+	// InputSpentByConfirmedTransactionViolation is reported if an Input was already spent by a confirmed Transaction.
+	InputSpentByConfirmedTransactionViolation
+
+	// InputRejectedViolation is reported if an Input is booked into a rejected or invalid Branch.
+	InputRejectedViolation
+
+	// PastConeInvalidViolation is reported if the consumed Outputs reference each other in their past cone.
+	PastConeInvalidViolation
+)
+
+// TransactionViolationType represents the kind of rule that is violated by a Transaction.
+type TransactionViolationType uint8
+
+// String returns a human readable version of the TransactionViolationType.
+func (t TransactionViolationType) String() string {
+	transactionViolationTypeNames := [...]string{
+		"InputNotFound",
+		"BalancesInvalid",
+		"UnlockBlocksInvalid",
+		"InputUnlockInvalid",
+		"InputSpentByConfirmedTransaction",
+		"InputRejected",
+		"PastConeInvalid",
+	}
+
+	if int(t) >= len(transactionViolationTypeNames) {
+		return fmt.Sprintf("TransactionViolationType(%X)", uint8(t))
+	}
+
+	return transactionViolationTypeNames[t]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionViolation /////////////////////////////////////////////////////////////////////////////////////////
+
+// NoInputIndex is used as the InputIndex of TransactionViolations that are not caused by a specific Input.
+const NoInputIndex = -1
+
+// TransactionViolation describes a single rule of the ledger that is violated by a Transaction.
+type TransactionViolation struct {
+	Type       TransactionViolationType
+	InputIndex int
+	Message    string
+}
+
+// NewTransactionViolation creates a new TransactionViolation. The inputIndex should be set to NoInputIndex if the
+// violation is not related to a specific Input.
+func NewTransactionViolation(violationType TransactionViolationType, inputIndex int, message string, args ...interface{}) *TransactionViolation {
+	return &TransactionViolation{
+		Type:       violationType,
+		InputIndex: inputIndex,
+		Message:    fmt.Sprintf(message, args...),
+	}
+}
+
+// String returns a human readable version of the TransactionViolation.
+func (t *TransactionViolation) String() string {
+	return stringify.Struct("TransactionViolation",
+		stringify.StructField("type", t.Type),
+		stringify.StructField("inputIndex", t.InputIndex),
+		stringify.StructField("message", t.Message),
+	)
+}
+
+// TransactionViolations represents a collection of TransactionViolation objects.
+type TransactionViolations []*TransactionViolation
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -22,6 +22,9 @@ const (
 
 	// AliasUnlockBlockType represents the type of a AliasUnlockBlock
 	AliasUnlockBlockType
+
+	// HashLockUnlockBlockType represents the type of a HashLockUnlockBlock.
+	HashLockUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -34,6 +37,7 @@ func (a UnlockBlockType) String() string {
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
 		"AliasUnlockBlockType",
+		"HashLockUnlockBlockType",
 	}[a]
 }
 
@@ -91,6 +95,11 @@ func UnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlo
 			err = errors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
 			return
 		}
+	case HashLockUnlockBlockType:
+		if unlockBlock, err = HashLockUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse HashLockUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
 
 	default:
 		err = errors.Errorf("unsupported UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
@@ -409,3 +418,108 @@ func (r *AliasUnlockBlock) String() string {
 var _ UnlockBlock = &AliasUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HashLockUnlockBlock //////////////////////////////////////////////////////////////////////////////////////////
+
+// HashLockUnlockBlock represents an UnlockBlock that reveals the preimage of the HashLock of an ExtendedLockedOutput
+// and contains a Signature for its target Address.
+type HashLockUnlockBlock struct {
+	preimage  []byte
+	signature Signature
+}
+
+// NewHashLockUnlockBlock is the constructor for HashLockUnlockBlock objects.
+func NewHashLockUnlockBlock(preimage []byte, signature Signature) *HashLockUnlockBlock {
+	return &HashLockUnlockBlock{
+		preimage:  byteutils.ConcatBytes(preimage),
+		signature: signature,
+	}
+}
+
+// HashLockUnlockBlockFromBytes unmarshals a HashLockUnlockBlock from a sequence of bytes.
+func HashLockUnlockBlockFromBytes(bytes []byte) (unlockBlock *HashLockUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = HashLockUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashLockUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HashLockUnlockBlockFromMarshalUtil unmarshals a HashLockUnlockBlock using a MarshalUtil (for easier unmarshaling).
+func HashLockUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *HashLockUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != HashLockUnlockBlockType {
+		err = errors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &HashLockUnlockBlock{}
+	preimageSize, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse preimage size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if preimageSize == 0 || int(preimageSize) > MaxPreimageSize {
+		err = errors.Errorf("preimage size (%d) must be within [1, %d]: %w", preimageSize, MaxPreimageSize, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if unlockBlock.preimage, err = marshalUtil.ReadBytes(int(preimageSize)); err != nil {
+		err = errors.Errorf("failed to parse preimage (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if unlockBlock.signature, err = SignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Signature from MarshalUtil: %w", err)
+		return
+	}
+	return
+}
+
+// Preimage returns the revealed preimage.
+func (h *HashLockUnlockBlock) Preimage() []byte {
+	return h.preimage
+}
+
+// Signature returns the signature itself.
+func (h *HashLockUnlockBlock) Signature() Signature {
+	return h.signature
+}
+
+// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+func (h *HashLockUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	return h.signature.AddressSignatureValid(address, signedData)
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (h *HashLockUnlockBlock) Type() UnlockBlockType {
+	return HashLockUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (h *HashLockUnlockBlock) Bytes() []byte {
+	return marshalutil.New().
+		WriteByte(byte(HashLockUnlockBlockType)).
+		WriteByte(byte(len(h.preimage))).
+		WriteBytes(h.preimage).
+		WriteBytes(h.signature.Bytes()).
+		Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (h *HashLockUnlockBlock) String() string {
+	return stringify.Struct("HashLockUnlockBlock",
+		stringify.StructField("preimage", h.preimage),
+		stringify.StructField("signature", h.signature),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &HashLockUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,10 +1,12 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"container/list"
 	"fmt"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -18,6 +20,7 @@ import (
 	"github.com/iotaledger/hive.go/stringify"
 	"github.com/iotaledger/hive.go/types"
 	"github.com/iotaledger/hive.go/typeutils"
+	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotaledger/goshimmer/packages/database"
 )
@@ -29,14 +32,20 @@ import (
 type UTXODAG struct {
 	Events *UTXODAGEvents
 
-	transactionStorage          *objectstorage.ObjectStorage
-	transactionMetadataStorage  *objectstorage.ObjectStorage
-	outputStorage               *objectstorage.ObjectStorage
-	outputMetadataStorage       *objectstorage.ObjectStorage
-	consumerStorage             *objectstorage.ObjectStorage
-	addressOutputMappingStorage *objectstorage.ObjectStorage
-	branchDAG                   *BranchDAG
-	shutdownOnce                sync.Once
+	transactionStorage             *objectstorage.ObjectStorage
+	transactionMetadataStorage     *objectstorage.ObjectStorage
+	outputStorage                  *objectstorage.ObjectStorage
+	outputMetadataStorage          *objectstorage.ObjectStorage
+	consumerStorage                *objectstorage.ObjectStorage
+	addressOutputMappingStorage    *objectstorage.ObjectStorage
+	colorSupplyStorage             *objectstorage.ObjectStorage
+	colorHolderStorage             *objectstorage.ObjectStorage
+	colorBurnStorage               *objectstorage.ObjectStorage
+	pendingMintStorage             *objectstorage.ObjectStorage
+	addressTransactionMappingStore kvstore.KVStore
+	addressTransactionMappingMutex sync.Mutex
+	branchDAG                      *BranchDAG
+	shutdownOnce                   sync.Once
 }
 
 // NewUTXODAG create a new UTXODAG from the given details.
@@ -47,14 +56,24 @@ func NewUTXODAG(store kvstore.KVStore, branchDAG *BranchDAG) (utxoDAG *UTXODAG)
 			TransactionBranchIDUpdated: events.NewEvent(transactionIDEventHandler),
 			TransactionConfirmed:       events.NewEvent(transactionIDEventHandler),
 		},
-		transactionStorage:          osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, transactionStorageOptions...),
-		transactionMetadataStorage:  osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, transactionMetadataStorageOptions...),
-		outputStorage:               osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, outputStorageOptions...),
-		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, outputMetadataStorageOptions...),
-		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, consumerStorageOptions...),
-		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, addressOutputMappingStorageOptions...),
-		branchDAG:                   branchDAG,
-	}
+		transactionStorage:             osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, transactionStorageOptions...),
+		transactionMetadataStorage:     osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, transactionMetadataStorageOptions...),
+		outputStorage:                  osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, outputStorageOptions...),
+		outputMetadataStorage:          osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, outputMetadataStorageOptions...),
+		consumerStorage:                osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, consumerStorageOptions...),
+		addressOutputMappingStorage:    osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, addressOutputMappingStorageOptions...),
+		colorSupplyStorage:             osFactory.New(PrefixColorSupplyStorage, ColorSupplyFromObjectStorage, colorSupplyStorageOptions...),
+		colorHolderStorage:             osFactory.New(PrefixColorHolderStorage, ColorHolderFromObjectStorage, colorHolderStorageOptions...),
+		colorBurnStorage:               osFactory.New(PrefixColorBurnStorage, ColorBurnFromObjectStorage, colorBurnStorageOptions...),
+		pendingMintStorage:             osFactory.New(PrefixPendingMintStorage, PendingMintFromObjectStorage, pendingMintStorageOptions...),
+		addressTransactionMappingStore: store.WithRealm([]byte{database.PrefixLedgerState, PrefixAddressTransactionMappingStorage}),
+		branchDAG:                      branchDAG,
+	}
+	branchDAG.Events.BranchRejected.Attach(events.NewClosure(func(branchDAGEvent *BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		utxoDAG.revertRejectedMints()
+	}))
+
 	return
 }
 
@@ -67,6 +86,10 @@ func (u *UTXODAG) Shutdown() {
 		u.outputMetadataStorage.Shutdown()
 		u.consumerStorage.Shutdown()
 		u.addressOutputMappingStorage.Shutdown()
+		u.colorSupplyStorage.Shutdown()
+		u.colorHolderStorage.Shutdown()
+		u.colorBurnStorage.Shutdown()
+		u.pendingMintStorage.Shutdown()
 	})
 }
 
@@ -90,6 +113,95 @@ func (u *UTXODAG) CheckTransaction(transaction *Transaction) (err error) {
 	return nil
 }
 
+// ValidateTransaction performs a dry-run of the semantic validation of the given Transaction against the current ledger
+// state. In contrast to CheckTransaction it does not stop at the first error but collects all TransactionViolations. It
+// neither modifies the ledger state nor books the Transaction.
+func (u *UTXODAG) ValidateTransaction(transaction *Transaction) (violations TransactionViolations) {
+	violations = make(TransactionViolations, 0)
+
+	cachedConsumedOutputs := u.ConsumedOutputs(transaction)
+	defer cachedConsumedOutputs.Release()
+	consumedOutputs := cachedConsumedOutputs.Unwrap()
+
+	for i, consumedOutput := range consumedOutputs {
+		if typeutils.IsInterfaceNil(consumedOutput) {
+			violations = append(violations, NewTransactionViolation(InputNotFoundViolation, i, "referenced %s does not exist", transaction.Essence().Inputs()[i].(*UTXOInput).ReferencedOutputID()))
+		}
+	}
+	if len(violations) != 0 {
+		return violations
+	}
+
+	if !TransactionBalancesValid(consumedOutputs, transaction.Essence().Outputs()) {
+		violations = append(violations, NewTransactionViolation(BalancesInvalidViolation, NoInputIndex, "sum of consumed and spent balances is not 0"))
+	}
+	violations = append(violations, u.unlockViolations(consumedOutputs, transaction)...)
+
+	cachedInputsMetadata := u.transactionInputsMetadata(transaction)
+	defer cachedInputsMetadata.Release()
+	inputsMetadata := cachedInputsMetadata.Unwrap()
+
+	inputsMetadataComplete := true
+	for i, inputMetadata := range inputsMetadata {
+		if inputMetadata == nil {
+			inputsMetadataComplete = false
+			continue
+		}
+
+		if inputMetadata.BranchID() == InvalidBranchID {
+			violations = append(violations, NewTransactionViolation(InputRejectedViolation, i, "%s is booked into the InvalidBranch", inputMetadata.ID()))
+		} else if rejected, _ := u.inputsInRejectedBranch(OutputsMetadata{inputMetadata}); rejected {
+			violations = append(violations, NewTransactionViolation(InputRejectedViolation, i, "%s is booked into the rejected %s", inputMetadata.ID(), inputMetadata.BranchID()))
+		}
+
+		spentByConfirmedTransaction, err := u.inputsSpentByConfirmedTransaction(OutputsMetadata{inputMetadata})
+		if err != nil || spentByConfirmedTransaction {
+			violations = append(violations, NewTransactionViolation(InputSpentByConfirmedTransactionViolation, i, "%s was already spent by a confirmed Transaction", inputMetadata.ID()))
+		}
+	}
+
+	if inputsMetadataComplete && !u.consumedOutputsPastConeValid(consumedOutputs, inputsMetadata) {
+		violations = append(violations, NewTransactionViolation(PastConeInvalidViolation, NoInputIndex, "consumed Outputs reference each other in their past cone"))
+	}
+
+	return violations
+}
+
+// unlockViolations is an internal utility function that returns the TransactionViolations caused by UnlockBlocks that
+// do not unlock the referenced Inputs.
+func (u *UTXODAG) unlockViolations(inputs Outputs, transaction *Transaction) (violations TransactionViolations) {
+	unlockBlocks := transaction.UnlockBlocks()
+	cyclePresent, err := checkReferenceCycle(unlockBlocks)
+	if err != nil {
+		return TransactionViolations{NewTransactionViolation(UnlockBlocksInvalidViolation, NoInputIndex, "unlock blocks are semantically invalid: %s", err)}
+	}
+	if cyclePresent {
+		return TransactionViolations{NewTransactionViolation(UnlockBlocksInvalidViolation, NoInputIndex, "unlock blocks contain cyclic dependency, no signature present for an unlock path")}
+	}
+
+	timestamp := transaction.Essence().Timestamp()
+	for i, input := range inputs {
+		currentUnlockBlock := unlockBlocks[i]
+		if currentUnlockBlock.Type() == ReferenceUnlockBlockType {
+			currentUnlockBlock = unlockBlocks[unlockBlocks[i].(*ReferenceUnlockBlock).ReferencedIndex()]
+		}
+
+		unlockValid, unlockErr := input.UnlockValid(transaction, currentUnlockBlock, inputs)
+		switch {
+		case unlockErr != nil:
+			violations = append(violations, NewTransactionViolation(InputUnlockInvalidViolation, i, "%s", unlockErr))
+		case !unlockValid:
+			if extendedLockedOutput, ok := input.(*ExtendedLockedOutput); ok && extendedLockedOutput.TimeLockedNow(timestamp) {
+				violations = append(violations, NewTransactionViolation(InputUnlockInvalidViolation, i, "%s is time locked until %s", input.ID(), extendedLockedOutput.TimeLock()))
+				continue
+			}
+			violations = append(violations, NewTransactionViolation(InputUnlockInvalidViolation, i, "spending of %s is not authorized by its unlock block", input.ID()))
+		}
+	}
+
+	return violations
+}
+
 // BookTransaction books a Transaction into the ledger state.
 func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch BranchID, err error) {
 	cachedConsumedOutputs := u.ConsumedOutputs(transaction)
@@ -282,6 +394,12 @@ func (u *UTXODAG) LoadSnapshot(snapshot *Snapshot) {
 			cachedOutput, stored := u.outputStorage.StoreIfAbsent(output)
 			if stored {
 				cachedOutput.Release()
+
+				// register colored balances of the genesis in the color supply index
+				u.registerSnapshotColors(txID, record.Essence.Timestamp(), output)
+
+				// register the genesis outputs in the address history index
+				u.storeAddressTransactionMappings(output, txID, record.Essence.Timestamp(), IncomingTransaction)
 			}
 
 			// store addressOutputMapping
@@ -312,6 +430,56 @@ func (u *UTXODAG) LoadSnapshot(snapshot *Snapshot) {
 	}
 }
 
+// CachedColorSupply retrieves the ColorSupply of the given Color from the supply index.
+func (u *UTXODAG) CachedColorSupply(color Color) (cachedColorSupply *CachedColorSupply) {
+	return &CachedColorSupply{CachedObject: u.colorSupplyStorage.Load(color.Bytes())}
+}
+
+// CachedColorBurns retrieves the ColorBurns that were recorded for the given Color.
+func (u *UTXODAG) CachedColorBurns(color Color) (cachedColorBurns CachedColorBurns) {
+	u.colorBurnStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedColorBurns = append(cachedColorBurns, &CachedColorBurn{cachedObject})
+		return true
+	}, objectstorage.WithIteratorPrefix(color.Bytes()))
+	return
+}
+
+// AddressTransactionMappings iterates over the AddressTransactionMappings of the Transactions that created Outputs on or
+// consumed Outputs of the given Address, newest Transaction first. If after is given, the iteration continues after it
+// without visiting the newer entries. The iteration stops when the consumer returns false.
+func (u *UTXODAG) AddressTransactionMappings(address Address, after *AddressTransactionMapping, consumer func(addressTransactionMapping *AddressTransactionMapping) bool) (err error) {
+	start := address.Bytes()
+	var afterKey []byte
+	if after != nil {
+		afterKey = NewAddressTransactionMapping(address, after.TransactionID(), after.Timestamp(), 0).key()
+		start = afterKey[:AddressLength+marshalutil.Uint64Size]
+	}
+
+	iterationErr := database.IterateKeysFrom(u.addressTransactionMappingStore, address.Bytes(), start, func(key kvstore.Key) bool {
+		if afterKey != nil && bytes.Compare(key, afterKey) <= 0 {
+			return true
+		}
+
+		value, getErr := u.addressTransactionMappingStore.Get(key)
+		if getErr != nil {
+			err = errors.Errorf("failed to load AddressTransactionMapping: %w", getErr)
+			return false
+		}
+		addressTransactionMapping, _, parseErr := AddressTransactionMappingFromBytes(byteutils.ConcatBytes(key, value))
+		if parseErr != nil {
+			err = errors.Errorf("failed to parse AddressTransactionMapping: %w", parseErr)
+			return false
+		}
+
+		return consumer(addressTransactionMapping)
+	})
+	if iterationErr != nil {
+		return errors.Errorf("failed to iterate AddressTransactionMappings of %s: %w", address, iterationErr)
+	}
+
+	return err
+}
+
 // CachedAddressOutputMapping retrieves the outputs for the given address.
 func (u *UTXODAG) CachedAddressOutputMapping(address Address) (cachedAddressOutputMappings CachedAddressOutputMappings) {
 	u.addressOutputMappingStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
@@ -350,6 +518,7 @@ func (u *UTXODAG) SetTransactionConfirmed(transactionID TransactionID) (err erro
 			continue
 		}
 
+		u.updateColorSupply(currentTransactionID)
 		u.Events.TransactionConfirmed.Trigger(currentTransactionID)
 	}
 
@@ -407,7 +576,7 @@ func (u *UTXODAG) bookInvalidTransaction(transaction *Transaction, transactionMe
 	transactionMetadata.SetSolid(true)
 	transactionMetadata.SetFinalized(true)
 
-	u.bookConsumers(inputsMetadata, transaction.ID(), types.False)
+	u.bookConsumers(inputsMetadata, transaction, types.False)
 	u.bookOutputs(transaction, InvalidBranchID)
 }
 
@@ -418,7 +587,7 @@ func (u *UTXODAG) bookRejectedTransaction(transaction *Transaction, transactionM
 	transactionMetadata.SetSolid(true)
 	transactionMetadata.SetLazyBooked(true)
 
-	u.bookConsumers(inputsMetadata, transaction.ID(), types.Maybe)
+	u.bookConsumers(inputsMetadata, transaction, types.Maybe)
 	u.bookOutputs(transaction, rejectedBranch)
 }
 
@@ -459,8 +628,9 @@ func (u *UTXODAG) bookNonConflictingTransaction(transaction *Transaction, transa
 
 		transactionMetadata.SetBranchID(targetBranch)
 		transactionMetadata.SetSolid(true)
-		u.bookConsumers(inputsMetadata, transaction.ID(), types.True)
+		u.bookConsumers(inputsMetadata, transaction, types.True)
 		u.bookOutputs(transaction, targetBranch)
+		u.registerMintedColors(transaction, false)
 	}) {
 		panic(fmt.Errorf("failed to load AggregatedBranch with %s", cachedAggregatedBranch.ID()))
 	}
@@ -490,8 +660,9 @@ func (u *UTXODAG) bookConflictingTransaction(transaction *Transaction, transacti
 	if !cachedConflictBranch.Consume(func(branch Branch) {
 		transactionMetadata.SetBranchID(targetBranch)
 		transactionMetadata.SetSolid(true)
-		u.bookConsumers(inputsMetadata, transaction.ID(), types.True)
+		u.bookConsumers(inputsMetadata, transaction, types.True)
 		u.bookOutputs(transaction, targetBranch)
+		u.registerMintedColors(transaction, false)
 	}) {
 		panic(fmt.Errorf("failed to load ConflictBranch with %s", cachedConflictBranch.ID()))
 	}
@@ -584,9 +755,14 @@ func (u *UTXODAG) updateBranchOfTransaction(transactionID TransactionID, branchI
 }
 
 // bookConsumers creates the reference between an Output and its spending Transaction. It increases the ConsumerCount if
-// the Transaction is a valid spend.
-func (u *UTXODAG) bookConsumers(inputsMetadata OutputsMetadata, transactionID TransactionID, valid types.TriBool) {
+// the Transaction is a valid spend and records the Transaction as outgoing in the history of the consumed Addresses.
+func (u *UTXODAG) bookConsumers(inputsMetadata OutputsMetadata, transaction *Transaction, valid types.TriBool) {
+	transactionID := transaction.ID()
 	for _, inputMetadata := range inputsMetadata {
+		u.CachedOutput(inputMetadata.ID()).Consume(func(input Output) {
+			u.storeAddressTransactionMappings(input, transactionID, transaction.Essence().Timestamp(), OutgoingTransaction)
+		})
+
 		if valid == types.True {
 			inputMetadata.RegisterConsumer(transactionID)
 		}
@@ -614,6 +790,9 @@ func (u *UTXODAG) bookOutputs(transaction *Transaction, targetBranch BranchID) {
 		// store Output
 		u.outputStorage.Store(updatedOutput).Release()
 
+		// record the Transaction as incoming in the history of the receiving Addresses
+		u.storeAddressTransactionMappings(updatedOutput, transaction.ID(), transaction.Essence().Timestamp(), IncomingTransaction)
+
 		// store OutputMetadata
 		metadata := NewOutputMetadata(updatedOutput.ID())
 		metadata.SetBranchID(targetBranch)
@@ -888,35 +1067,244 @@ func (u *UTXODAG) consumedBranchIDs(transactionID TransactionID) (branchIDs Bran
 
 // ManageStoreAddressOutputMapping mangages how to store the address-output mapping dependent on which type of output it is.
 func (u *UTXODAG) ManageStoreAddressOutputMapping(output Output) {
+	for _, address := range outputAddresses(output) {
+		u.StoreAddressOutputMapping(address, output.ID())
+	}
+}
+
+// StoreAddressOutputMapping stores the address-output mapping.
+func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
+	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
+	if stored {
+		result.Release()
+	}
+}
+
+// storeAddressTransactionMappings records the given Transaction with the given direction in the history of all Addresses
+// that are referenced by the given Output.
+func (u *UTXODAG) storeAddressTransactionMappings(output Output, transactionID TransactionID, timestamp time.Time, direction TransactionDirection) {
+	u.addressTransactionMappingMutex.Lock()
+	defer u.addressTransactionMappingMutex.Unlock()
+
+	for _, address := range outputAddresses(output) {
+		key := NewAddressTransactionMapping(address, transactionID, timestamp, direction).key()
+
+		value, err := u.addressTransactionMappingStore.Get(key)
+		switch {
+		case errors.Is(err, kvstore.ErrKeyNotFound):
+			value = TransactionDirection(0).Bytes()
+		case err != nil:
+			panic(fmt.Errorf("failed to load AddressTransactionMapping of %s: %w", address, err))
+		}
+
+		storedDirection := TransactionDirection(value[0])
+		if storedDirection|direction == storedDirection {
+			continue
+		}
+		if err = u.addressTransactionMappingStore.Set(key, (storedDirection | direction).Bytes()); err != nil {
+			panic(fmt.Errorf("failed to store AddressTransactionMapping of %s: %w", address, err))
+		}
+	}
+}
+
+// outputAddresses returns the Addresses that are able to unlock the given Output (or that are otherwise referenced by
+// it).
+func outputAddresses(output Output) (addresses []Address) {
 	switch output.Type() {
 	case AliasOutputType:
 		castedOutput := output.(*AliasOutput)
 		// if it is an origin alias output, we don't have the aliasaddress from the parsed bytes.
 		// that happens in utxodag output booking, so we calculate the alias address here
-		u.StoreAddressOutputMapping(castedOutput.GetAliasAddress(), output.ID())
-		u.StoreAddressOutputMapping(castedOutput.GetStateAddress(), output.ID())
+		addresses = append(addresses, castedOutput.GetAliasAddress(), castedOutput.GetStateAddress())
 		if !castedOutput.IsSelfGoverned() {
-			u.StoreAddressOutputMapping(castedOutput.GetGoverningAddress(), output.ID())
+			addresses = append(addresses, castedOutput.GetGoverningAddress())
 		}
 	case ExtendedLockedOutputType:
 		castedOutput := output.(*ExtendedLockedOutput)
 		if castedOutput.FallbackAddress() != nil {
-			u.StoreAddressOutputMapping(castedOutput.FallbackAddress(), output.ID())
+			addresses = append(addresses, castedOutput.FallbackAddress())
 		}
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
 	default:
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
 	}
+
+	return
 }
 
-// StoreAddressOutputMapping stores the address-output mapping.
-func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
-	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
-	if stored {
-		result.Release()
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region color supply index ///////////////////////////////////////////////////////////////////////////////////////////
+
+// registerMintedColors records the Colors that are minted by the Outputs of the given Transaction in the supply index.
+// Mints of unconfirmed Transactions are additionally tracked as PendingMints, so they can be reverted if the Transaction
+// gets rejected.
+func (u *UTXODAG) registerMintedColors(transaction *Transaction, confirmed bool) {
+	isMinting := false
+	for _, output := range transaction.Essence().Outputs() {
+		mintedAmount, minting := output.Balances().Get(ColorMint)
+		if !minting {
+			continue
+		}
+		isMinting = true
+
+		u.cachedColorSupplyOrNew(mintedColor(output.ID())).Consume(func(colorSupply *ColorSupply) {
+			colorSupply.setMint(transaction.ID(), transaction.Essence().Timestamp(), mintedAmount, confirmed)
+		})
+	}
+
+	switch {
+	case !isMinting:
+		return
+	case confirmed:
+		u.pendingMintStorage.Delete(transaction.ID().Bytes())
+	default:
+		if cachedPendingMint, stored := u.pendingMintStorage.StoreIfAbsent(NewPendingMint(transaction.ID())); stored {
+			cachedPendingMint.Release()
+		}
+	}
+}
+
+// revertRejectedMints removes the Colors of all PendingMints whose Transaction is booked into a rejected Branch from the
+// supply index. This covers the Transactions that lost a conflict as well as the ones that were orphaned by spending
+// the Outputs of a rejected Transaction.
+func (u *UTXODAG) revertRejectedMints() {
+	rejectedTransactionIDs := make([]TransactionID, 0)
+	u.pendingMintStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			transactionID := object.(*PendingMint).TransactionID()
+			u.CachedTransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+				if u.branchDAG.InclusionState(transactionMetadata.BranchID()) == Rejected {
+					rejectedTransactionIDs = append(rejectedTransactionIDs, transactionID)
+				}
+			})
+		})
+		return true
+	})
+
+	for _, transactionID := range rejectedTransactionIDs {
+		u.CachedTransaction(transactionID).Consume(func(transaction *Transaction) {
+			for _, output := range transaction.Essence().Outputs() {
+				if _, minting := output.Balances().Get(ColorMint); !minting {
+					continue
+				}
+
+				u.CachedColorSupply(mintedColor(output.ID())).Consume(func(colorSupply *ColorSupply) {
+					if colorSupply.MintingTransactionID() == transactionID && !colorSupply.MintConfirmed() {
+						colorSupply.Delete()
+					}
+				})
+			}
+		})
+		u.pendingMintStorage.Delete(transactionID.Bytes())
 	}
 }
 
+// mintedColor returns the Color that is minted by the Output with the given identifier.
+func mintedColor(outputID OutputID) Color {
+	return blake2b.Sum256(outputID.Bytes())
+}
+
+// registerSnapshotColors records the colored balances of an Output of the snapshot in the supply index.
+func (u *UTXODAG) registerSnapshotColors(transactionID TransactionID, timestamp time.Time, output Output) {
+	output.Balances().ForEach(func(color Color, balance uint64) bool {
+		if color == ColorIOTA {
+			return true
+		}
+
+		u.cachedColorSupplyOrNew(color).Consume(func(colorSupply *ColorSupply) {
+			colorSupply.setMint(transactionID, timestamp, colorSupply.Minted(), true)
+			colorSupply.addMinted(balance)
+		})
+		u.updateColorHolder(color, output.Address(), balance, true)
+
+		return true
+	})
+}
+
+// updateColorSupply applies the balance changes of a confirmed Transaction to the supply index.
+func (u *UTXODAG) updateColorSupply(transactionID TransactionID) {
+	u.CachedTransaction(transactionID).Consume(func(transaction *Transaction) {
+		consumedBalances := make(map[Color]uint64)
+		for _, input := range transaction.Essence().Inputs() {
+			u.CachedOutput(input.(*UTXOInput).ReferencedOutputID()).Consume(func(output Output) {
+				output.Balances().ForEach(func(color Color, balance uint64) bool {
+					if color != ColorIOTA {
+						consumedBalances[color] += balance
+						u.updateColorHolder(color, output.Address(), balance, false)
+					}
+					return true
+				})
+			})
+		}
+
+		createdBalances := make(map[Color]uint64)
+		for _, output := range transaction.Essence().Outputs() {
+			// the stored Output carries the minted Color instead of ColorMint
+			u.CachedOutput(output.ID()).Consume(func(storedOutput Output) {
+				storedOutput.Balances().ForEach(func(color Color, balance uint64) bool {
+					if color != ColorIOTA {
+						createdBalances[color] += balance
+						u.updateColorHolder(color, storedOutput.Address(), balance, true)
+					}
+					return true
+				})
+			})
+		}
+		u.registerMintedColors(transaction, true)
+
+		for color, consumedBalance := range consumedBalances {
+			if consumedBalance <= createdBalances[color] {
+				continue
+			}
+
+			burnedAmount := consumedBalance - createdBalances[color]
+			cachedColorBurn, stored := u.colorBurnStorage.StoreIfAbsent(NewColorBurn(color, transactionID, burnedAmount, transaction.Essence().Timestamp()))
+			if !stored {
+				continue
+			}
+			cachedColorBurn.Release()
+
+			u.cachedColorSupplyOrNew(color).Consume(func(colorSupply *ColorSupply) {
+				colorSupply.addBurned(burnedAmount)
+			})
+		}
+	})
+}
+
+// updateColorHolder adds or subtracts the given balance from the holdings of the Address and updates the holder count
+// of the Color accordingly.
+func (u *UTXODAG) updateColorHolder(color Color, address Address, balance uint64, increase bool) {
+	holderDelta := 0
+	(&CachedColorHolder{CachedObject: u.colorHolderStorage.ComputeIfAbsent(byteutils.ConcatBytes(color.Bytes(), address.Bytes()), func(key []byte) objectstorage.StorableObject {
+		colorHolder := NewColorHolder(color, address)
+		colorHolder.Persist()
+		colorHolder.SetModified()
+
+		return colorHolder
+	})}).Consume(func(colorHolder *ColorHolder) {
+		holderDelta = colorHolder.updateBalance(balance, increase)
+	})
+
+	if holderDelta == 0 {
+		return
+	}
+	u.cachedColorSupplyOrNew(color).Consume(func(colorSupply *ColorSupply) {
+		colorSupply.updateHolderCount(holderDelta)
+	})
+}
+
+// cachedColorSupplyOrNew retrieves the ColorSupply of the given Color and creates it if it does not exist, yet.
+func (u *UTXODAG) cachedColorSupplyOrNew(color Color) *CachedColorSupply {
+	return &CachedColorSupply{CachedObject: u.colorSupplyStorage.ComputeIfAbsent(color.Bytes(), func(key []byte) objectstorage.StorableObject {
+		colorSupply := NewColorSupply(color)
+		colorSupply.Persist()
+		colorSupply.SetModified()
+
+		return colorSupply
+	})}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // TODO: IMPLEMENT A GOOD SYNCHRONIZATION MECHANISM FOR THE UTXODAG
@@ -7,11 +7,12 @@ import (
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
-	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/objectstorage"
 	"github.com/iotaledger/hive.go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/database"
 )
 
 var (
@@ -554,7 +555,7 @@ func TestInputsSpentByConfirmedTransaction(t *testing.T) {
 	assert.False(t, spent)
 
 	// testing after booking consumers.
-	utxoDAG.bookConsumers(outputsMetadata, tx.ID(), types.True)
+	utxoDAG.bookConsumers(outputsMetadata, tx, types.True)
 	spent, err = utxoDAG.inputsSpentByConfirmedTransaction(outputsMetadata)
 	assert.NoError(t, err)
 	assert.True(t, spent)
@@ -902,8 +903,45 @@ func TestUTXODAG_CheckTransaction(t *testing.T) {
 	})
 }
 
+func TestUTXODAG_ValidateTransaction(t *testing.T) {
+	branchDAG, utxoDAG := setupDependencies(t)
+	defer branchDAG.Shutdown()
+	defer utxoDAG.Shutdown()
+
+	wallets := createWallets(2)
+	input := generateOutput(utxoDAG, wallets[0].address, 0)
+
+	t.Run("CASE: Valid transaction", func(t *testing.T) {
+		essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(NewSigLockedSingleOutput(100, wallets[1].address)))
+		assert.Empty(t, utxoDAG.ValidateTransaction(NewTransaction(essence, wallets[0].unlockBlocks(essence))))
+	})
+
+	t.Run("CASE: Wrong signature and invalid balances", func(t *testing.T) {
+		essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(NewSigLockedSingleOutput(200, wallets[1].address)))
+		violations := utxoDAG.ValidateTransaction(NewTransaction(essence, wallets[1].unlockBlocks(essence)))
+		require.Len(t, violations, 2)
+		assert.Equal(t, BalancesInvalidViolation, violations[0].Type)
+		assert.Equal(t, NoInputIndex, violations[0].InputIndex)
+		assert.Equal(t, InputUnlockInvalidViolation, violations[1].Type)
+		assert.Equal(t, 0, violations[1].InputIndex)
+	})
+
+	t.Run("CASE: Missing input", func(t *testing.T) {
+		essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(randOutputID())), NewOutputs(NewSigLockedSingleOutput(100, wallets[1].address)))
+		violations := utxoDAG.ValidateTransaction(NewTransaction(essence, wallets[0].unlockBlocks(essence)))
+		require.Len(t, violations, 1)
+		assert.Equal(t, InputNotFoundViolation, violations[0].Type)
+	})
+
+	t.Run("CASE: Dry-run does not book the transaction", func(t *testing.T) {
+		assert.True(t, utxoDAG.CachedOutputMetadata(input.ID()).Consume(func(outputMetadata *OutputMetadata) {
+			assert.Equal(t, 0, outputMetadata.ConsumerCount())
+		}))
+	})
+}
+
 func setupDependencies(t *testing.T) (*BranchDAG, *UTXODAG) {
-	store := mapdb.NewMapDB()
+	store := database.NewSortedMapDB()
 	branchDAG := NewBranchDAG(store)
 	err := branchDAG.Prune()
 	require.NoError(t, err)
@@ -0,0 +1,147 @@
+package utxotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate/utxodb"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate/utxoutil"
+)
+
+var (
+	swapSecret = []byte("the secret of the swap")
+	swapAmount = map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}
+)
+
+func TestHashLockedOutputMarshal(t *testing.T) {
+	u := utxodb.New()
+	_, addr1 := u.NewKeyPairByIndex(1)
+	_, addr2 := u.NewKeyPairByIndex(2)
+
+	out := ledgerstate.NewExtendedLockedOutput(swapAmount, addr1).
+		WithFallbackOptions(addr2, time.Now().Add(time.Hour)).
+		WithHashLock(ledgerstate.NewHashLock(swapSecret))
+	require.NoError(t, out.SetPayload([]byte("swap")))
+
+	outBack, _, err := ledgerstate.OutputFromBytes(out.Bytes())
+	require.NoError(t, err)
+	require.Zero(t, outBack.Compare(out))
+	require.Equal(t, ledgerstate.NewHashLock(swapSecret), outBack.(*ledgerstate.ExtendedLockedOutput).HashLock())
+	require.EqualValues(t, "swap", outBack.(*ledgerstate.ExtendedLockedOutput).GetPayload())
+}
+
+func TestHashLockedOutputAliasTarget(t *testing.T) {
+	u := utxodb.New()
+	_, addr1 := u.NewKeyPairByIndex(1)
+	aliasAddress := ledgerstate.NewAliasAddress([]byte("alias"))
+
+	out := ledgerstate.NewExtendedLockedOutput(swapAmount, aliasAddress).
+		WithFallbackOptions(addr1, time.Now().Add(time.Hour)).
+		WithHashLock(ledgerstate.NewHashLock(swapSecret))
+	_, _, err := ledgerstate.OutputFromBytes(out.Bytes())
+	require.Error(t, err)
+
+	builder := utxoutil.NewBuilder(u.GetAddressOutputs(addr1)...)
+	err = builder.AddHashTimeLockedOutputConsume(aliasAddress, addr1, time.Now().Add(time.Hour), ledgerstate.NewHashLock(swapSecret), swapAmount)
+	require.Error(t, err)
+}
+
+func TestHashLockClaim(t *testing.T) {
+	u, initiator, deadline := initiateSwap(t)
+	recipient, addrRecipient := u.NewKeyPairByIndex(2)
+	outputs := u.GetAddressOutputs(addrRecipient)
+	require.Len(t, outputs, 1)
+
+	// without the preimage the output can't be claimed
+	txb := utxoutil.NewBuilder(outputs...)
+	require.NoError(t, txb.AddSigLockedIOTAOutput(addrRecipient, 100))
+	_, err := txb.Clone().BuildWithED25519(recipient)
+	require.Error(t, err)
+
+	// a signature alone is not enough
+	essence, consumed, err := txb.Clone().BuildEssence()
+	require.NoError(t, err)
+	signature := ledgerstate.NewED25519Signature(recipient.PublicKey, recipient.PrivateKey.Sign(essence.Bytes()))
+	tx := ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{ledgerstate.NewSignatureUnlockBlock(signature)})
+	require.Error(t, u.AddTransaction(tx))
+
+	// a wrong preimage is rejected
+	tx = ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{ledgerstate.NewHashLockUnlockBlock([]byte("wrong"), signature)})
+	require.Error(t, u.AddTransaction(tx))
+
+	// the fallback address can't refund before the deadline
+	_, err = utxoutil.UnlockInputsWithED25519KeyPairs(consumed, essence, initiator)
+	require.Error(t, err)
+
+	tx, err = txb.WithPreimage(swapSecret).BuildWithED25519(recipient)
+	require.NoError(t, err)
+	require.NoError(t, u.AddTransaction(tx))
+	require.EqualValues(t, 100, u.BalanceIOTA(addrRecipient))
+
+	// the preimage is revealed to the initiator
+	claimed, ok := u.GetTransaction(tx.ID())
+	require.True(t, ok)
+	hashLockUnlockBlock, ok := claimed.UnlockBlocks()[0].(*ledgerstate.HashLockUnlockBlock)
+	require.True(t, ok)
+	require.EqualValues(t, swapSecret, hashLockUnlockBlock.Preimage())
+	require.True(t, deadline.After(tx.Essence().Timestamp()))
+}
+
+func TestHashLockRefund(t *testing.T) {
+	u, initiator, deadline := initiateSwap(t)
+	addrInitiator := ledgerstate.NewED25519Address(initiator.PublicKey)
+	recipient, addrRecipient := u.NewKeyPairByIndex(2)
+	outputs := u.GetAddressOutputs(addrRecipient)
+	require.Len(t, outputs, 1)
+
+	// the recipient can't claim after the deadline
+	txb := utxoutil.NewBuilder(outputs...).WithTimestamp(deadline.Add(time.Second)).WithPreimage(swapSecret)
+	require.NoError(t, txb.AddSigLockedIOTAOutput(addrRecipient, 100))
+	_, err := txb.BuildWithED25519(recipient)
+	require.Error(t, err)
+
+	balanceBefore := u.BalanceIOTA(addrInitiator)
+	txb = utxoutil.NewBuilder(outputs...).WithTimestamp(deadline.Add(time.Second))
+	require.NoError(t, txb.AddSigLockedIOTAOutput(addrInitiator, 100))
+	tx, err := txb.BuildWithED25519(initiator)
+	require.NoError(t, err)
+	require.NoError(t, u.AddTransaction(tx))
+	require.EqualValues(t, balanceBefore+100, u.BalanceIOTA(addrInitiator))
+	require.EqualValues(t, 0, u.BalanceIOTA(addrRecipient))
+}
+
+func TestAddHashTimeLockedOutputConsume(t *testing.T) {
+	u := utxodb.New()
+	_, addr1 := u.NewKeyPairByIndex(1)
+	_, err := u.RequestFunds(addr1)
+	require.NoError(t, err)
+	_, addr2 := u.NewKeyPairByIndex(2)
+	hashLock := ledgerstate.NewHashLock(swapSecret)
+
+	txb := utxoutil.NewBuilder(u.GetAddressOutputs(addr1)...)
+	require.Error(t, txb.AddHashTimeLockedOutputConsume(addr2, addr1, time.Now().Add(time.Hour), ledgerstate.EmptyHashLock, swapAmount))
+	require.Error(t, txb.AddHashTimeLockedOutputConsume(addr2, nil, time.Now().Add(time.Hour), hashLock, swapAmount))
+	require.Error(t, txb.AddHashTimeLockedOutputConsume(addr2, addr1, time.Now().Add(-time.Hour), hashLock, swapAmount))
+}
+
+func initiateSwap(t *testing.T) (u *utxodb.UtxoDB, initiator *ed25519.KeyPair, deadline time.Time) {
+	u = utxodb.New()
+	initiator, addrInitiator := u.NewKeyPairByIndex(1)
+	_, err := u.RequestFunds(addrInitiator)
+	require.NoError(t, err)
+	_, addrRecipient := u.NewKeyPairByIndex(2)
+
+	deadline = time.Now().Add(time.Hour)
+	txb := utxoutil.NewBuilder(u.GetAddressOutputs(addrInitiator)...)
+	require.NoError(t, txb.AddHashTimeLockedOutputConsume(addrRecipient, addrInitiator, deadline, ledgerstate.NewHashLock(swapSecret), swapAmount))
+	require.NoError(t, txb.AddRemainderOutputIfNeeded(addrInitiator, nil))
+	tx, err := txb.BuildWithED25519(initiator)
+	require.NoError(t, err)
+	require.NoError(t, u.AddTransaction(tx))
+
+	return u, initiator, deadline
+}
@@ -28,6 +28,8 @@ type Builder struct {
 	outputs     []ledgerstate.Output
 	// buffer of consumed but unspent yet tokens
 	consumedUnspent map[ledgerstate.Color]uint64
+	// preimages revealed to unlock hash locked inputs
+	preimages map[ledgerstate.HashLock][]byte
 }
 
 // NewBuilder creates new builder for outputs
@@ -37,6 +39,7 @@ func NewBuilder(inputs ...ledgerstate.Output) *Builder {
 		consumables:     make([]*ConsumableOutput, len(inputs)),
 		outputs:         make([]ledgerstate.Output, 0),
 		consumedUnspent: make(map[ledgerstate.Color]uint64),
+		preimages:       make(map[ledgerstate.HashLock][]byte),
 	}
 	ret.consumables = NewConsumables(inputs...)
 	return ret
@@ -57,6 +60,10 @@ func (b *Builder) Clone() *Builder {
 	for col, bal := range b.consumedUnspent {
 		ret.consumedUnspent[col] = bal
 	}
+	ret.preimages = make(map[ledgerstate.HashLock][]byte, len(b.preimages))
+	for hashLock, preimage := range b.preimages {
+		ret.preimages[hashLock] = preimage
+	}
 	return &ret
 }
 
@@ -84,6 +91,12 @@ func (b *Builder) WithConsensusPledge(id identity.ID) *Builder {
 	return b
 }
 
+// WithPreimage adds a preimage that is revealed to unlock the inputs locked by the corresponding hash lock
+func (b *Builder) WithPreimage(preimage []byte) *Builder {
+	b.preimages[ledgerstate.NewHashLock(preimage)] = preimage
+	return b
+}
+
 // AddOutputAndSpendUnspent spends the consumed-unspent tokens and adds output
 func (b *Builder) AddOutputAndSpendUnspent(out ledgerstate.Output) error {
 	b.SpendConsumedUnspent()
@@ -273,6 +286,35 @@ func (b *Builder) AddExtendedOutputConsume(targetAddress ledgerstate.Address, da
 	return nil
 }
 
+// AddHashTimeLockedOutputConsume adds a hash-time-locked output. Until the deadline, it can only be unlocked by the
+// target address revealing the preimage of the hash lock, after the deadline by the fallback address.
+// Ensures enough unspent funds by consuming if necessary
+func (b *Builder) AddHashTimeLockedOutputConsume(targetAddress, fallbackAddress ledgerstate.Address, deadline time.Time, hashLock ledgerstate.HashLock, amounts map[ledgerstate.Color]uint64) error {
+	if hashLock == ledgerstate.EmptyHashLock {
+		return xerrors.New("AddHashTimeLockedOutputConsume: hash lock must not be empty")
+	}
+	if targetAddress.Type() == ledgerstate.AliasAddressType {
+		return xerrors.New("AddHashTimeLockedOutputConsume: target address must not be an alias address")
+	}
+	if fallbackAddress == nil {
+		return xerrors.New("AddHashTimeLockedOutputConsume: fallback address is required to refund the output")
+	}
+	if !deadline.After(b.timestamp) {
+		return xerrors.New("AddHashTimeLockedOutputConsume: deadline must be after the transaction timestamp")
+	}
+	balances, err := b.prepareColoredBalancesOutput(amounts)
+	if err != nil {
+		return err
+	}
+	output := ledgerstate.NewExtendedLockedOutput(balances, targetAddress).
+		WithFallbackOptions(fallbackAddress, deadline).
+		WithHashLock(hashLock)
+	if err := b.addOutput(output); err != nil {
+		return err
+	}
+	return nil
+}
+
 // AddRemainderOutputIfNeeded consumes already touched inputs and spends consumed-unspend.
 // Creates reminder output if needed
 func (b *Builder) AddRemainderOutputIfNeeded(remainderAddr ledgerstate.Address, data []byte, compress ...bool) error {
@@ -444,7 +486,7 @@ func (b *Builder) BuildWithED25519(keyPairs ...*ed25519.KeyPair) (*ledgerstate.T
 	if err != nil {
 		return nil, err
 	}
-	unlockBlocks, err2 := UnlockInputsWithED25519KeyPairs(consumedOutputs, essence, keyPairs...)
+	unlockBlocks, err2 := UnlockInputsWithED25519KeyPairsAndPreimages(consumedOutputs, essence, b.preimages, keyPairs...)
 	if err2 != nil {
 		return nil, err2
 	}
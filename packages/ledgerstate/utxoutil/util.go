@@ -1,6 +1,8 @@
 package utxoutil
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"golang.org/x/crypto/blake2b"
 	"golang.org/x/xerrors"
@@ -28,6 +30,12 @@ type signatureUnlockBlockWithIndex struct {
 // - ledgerstate.ReferenceUnlockBlock
 // - ledgerstate.AliasUnlockBlock
 func UnlockInputsWithED25519KeyPairs(inputs []ledgerstate.Output, essence *ledgerstate.TransactionEssence, keyPairs ...*ed25519.KeyPair) ([]ledgerstate.UnlockBlock, error) {
+	return UnlockInputsWithED25519KeyPairsAndPreimages(inputs, essence, nil, keyPairs...)
+}
+
+// UnlockInputsWithED25519KeyPairsAndPreimages works like UnlockInputsWithED25519KeyPairs, but in addition unlocks
+// hash locked ledgerstate.ExtendedLockedOutput by revealing the given preimages in a ledgerstate.HashLockUnlockBlock.
+func UnlockInputsWithED25519KeyPairsAndPreimages(inputs []ledgerstate.Output, essence *ledgerstate.TransactionEssence, preimages map[ledgerstate.HashLock][]byte, keyPairs ...*ed25519.KeyPair) ([]ledgerstate.UnlockBlock, error) {
 	sigs := make(map[[33]byte]*signatureUnlockBlockWithIndex)
 	for _, keyPair := range keyPairs {
 		addr := ledgerstate.NewED25519Address(keyPair.PublicKey)
@@ -41,11 +49,14 @@ func UnlockInputsWithED25519KeyPairs(inputs []ledgerstate.Output, essence *ledge
 			indexUnlocked: -1,
 		}
 	}
-	return unlockInputsWithSignatureBlocks(inputs, sigs)
+	return unlockInputsWithSignatureBlocks(inputs, essence.Timestamp(), preimages, sigs)
 }
 
 // unlockInputsWithSignatureBlocks does the optimized unlocking
-func unlockInputsWithSignatureBlocks(inputs []ledgerstate.Output, sigUnlockBlocks map[[33]byte]*signatureUnlockBlockWithIndex) ([]ledgerstate.UnlockBlock, error) {
+func unlockInputsWithSignatureBlocks(inputs []ledgerstate.Output, timestamp time.Time, preimages map[ledgerstate.HashLock][]byte, sigUnlockBlocks map[[33]byte]*signatureUnlockBlockWithIndex) ([]ledgerstate.UnlockBlock, error) {
+	// hash lock unlock blocks can be referenced by inputs with the same address and hash lock
+	hashLockUnlocked := make(map[hashLockUnlockKey]int)
+
 	// unlock ChainOutputs
 	ret := make([]ledgerstate.UnlockBlock, len(inputs))
 	for index, out := range inputs {
@@ -79,11 +90,27 @@ func unlockInputsWithSignatureBlocks(inputs []ledgerstate.Output, sigUnlockBlock
 			}
 
 		case *ledgerstate.ExtendedLockedOutput:
-			sig, ok := sigUnlockBlocks[ot.Address().Array()]
+			// the fallback address takes over after the deadline
+			unlockAddress := ot.UnlockAddressNow(timestamp)
+			sig, ok := sigUnlockBlocks[unlockAddress.Array()]
 			if !ok {
 				// no corresponding signature, it probably is an alias
 				continue
 			}
+			if ot.HashLockedNow(timestamp) {
+				key := hashLockUnlockKey{address: unlockAddress.Array(), hashLock: ot.HashLock()}
+				if unlockedIndex, unlocked := hashLockUnlocked[key]; unlocked {
+					ret[index] = ledgerstate.NewReferenceUnlockBlock(uint16(unlockedIndex))
+					continue
+				}
+				preimage, ok := preimages[ot.HashLock()]
+				if !ok {
+					return nil, xerrors.Errorf("hash locked input at index %d can't be unlocked without the preimage", index)
+				}
+				ret[index] = ledgerstate.NewHashLockUnlockBlock(preimage, sig.unlockBlock.Signature())
+				hashLockUnlocked[key] = index
+				continue
+			}
 			if sig.indexUnlocked >= 0 {
 				// signature already included
 				ret[index] = ledgerstate.NewReferenceUnlockBlock(uint16(sig.indexUnlocked))
@@ -131,6 +158,12 @@ func unlockInputsWithSignatureBlocks(inputs []ledgerstate.Output, sigUnlockBlock
 	return ret, nil
 }
 
+// hashLockUnlockKey identifies the HashLockUnlockBlock that unlocks the inputs with the same address and hash lock.
+type hashLockUnlockKey struct {
+	address  [33]byte
+	hashLock ledgerstate.HashLock
+}
+
 // collectAliasOutputs scans all outputs and collects ledgerstate.AliasOutput into a map by the Address.Array
 // Returns an error if finds duplicate
 func collectAliasOutputs(tx *ledgerstate.Transaction) (map[[33]byte]*ledgerstate.AliasOutput, error) {
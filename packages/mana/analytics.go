@@ -0,0 +1,343 @@
+package mana
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// minAttributedMana is the amount of mana below which the attribution of a node's mana to an address is removed.
+const minAttributedMana = 1e-6
+
+// Analytics keeps track of how mana was pledged and revoked over time. It is fed by the Pledged and Revoked events of
+// the mana package and keeps per node time series, per epoch distributions and the relationship between the addresses
+// that pledged mana and the nodes that received it. The Analytics are kept in memory only, so they start fresh whenever
+// the node is started.
+type Analytics struct {
+	epochDuration time.Duration
+	maxHistory    int
+	maxEpochs     int
+
+	balances map[Type]map[identity.ID]float64
+	history  map[Type]map[identity.ID][]*AnalyticsPoint
+	pledgers map[Type]map[identity.ID]map[string]*AddressAmount
+	pledgees map[Type]map[string]NodeMap
+	epochs   map[Type]map[int64]NodeMap
+
+	mutex sync.RWMutex
+}
+
+// NewAnalytics returns a new Analytics instance. epochDuration defines the length of the epochs that the pledged mana
+// is aggregated into, maxHistory bounds the amount of samples that are kept per node and maxEpochs bounds the amount
+// of epochs that are kept per mana type.
+func NewAnalytics(epochDuration time.Duration, maxHistory, maxEpochs int) *Analytics {
+	return &Analytics{
+		epochDuration: epochDuration,
+		maxHistory:    maxHistory,
+		maxEpochs:     maxEpochs,
+		balances:      make(map[Type]map[identity.ID]float64),
+		history:       make(map[Type]map[identity.ID][]*AnalyticsPoint),
+		pledgers:      make(map[Type]map[identity.ID]map[string]*AddressAmount),
+		pledgees:      make(map[Type]map[string]NodeMap),
+		epochs:        make(map[Type]map[int64]NodeMap),
+	}
+}
+
+// RecordPledge records a PledgedEvent. The sources are the addresses that funded the pledging transaction together
+// with the amount of tokens each of them contributed, the pledged mana is attributed to them proportionally.
+func (a *Analytics) RecordPledge(event *PledgedEvent, sources []*AddressAmount) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.record(event.ManaType, event.NodeID, event.Time, event.Amount, event.TransactionID)
+	a.attribute(event.ManaType, event.NodeID, event.Amount, sources)
+}
+
+// RecordRevoke records a RevokedEvent. The sources are the addresses that funded the transaction which pledged the
+// revoked mana (the transaction of the spent input), the revoked mana is deducted from them proportionally.
+func (a *Analytics) RecordRevoke(event *RevokedEvent, sources []*AddressAmount) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.record(event.ManaType, event.NodeID, event.Time, -event.Amount, event.TransactionID)
+	a.attribute(event.ManaType, event.NodeID, -event.Amount, sources)
+}
+
+// History returns the recorded samples of the given node that lie within [start, end]. A zero start or end leaves
+// the corresponding side of the interval open.
+func (a *Analytics) History(manaType Type, nodeID identity.ID, start, end time.Time) (points []*AnalyticsPoint) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	points = make([]*AnalyticsPoint, 0)
+	for _, point := range a.history[manaType][nodeID] {
+		if !start.IsZero() && point.Time.Before(start) {
+			continue
+		}
+		if !end.IsZero() && point.Time.After(end) {
+			continue
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// Balance returns the net amount of mana that was pledged to the given node since the Analytics were started.
+func (a *Analytics) Balance(manaType Type, nodeID identity.ID) float64 {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.balances[manaType][nodeID]
+}
+
+// TopPledgers returns the n addresses that pledged the most mana to the given node in descending order. If n is 0,
+// all pledgers are returned.
+func (a *Analytics) TopPledgers(manaType Type, nodeID identity.ID, n uint) (pledgers []*AddressAmount) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	pledgers = make([]*AddressAmount, 0, len(a.pledgers[manaType][nodeID]))
+	for _, pledger := range a.pledgers[manaType][nodeID] {
+		pledgers = append(pledgers, &AddressAmount{Address: pledger.Address, Amount: pledger.Amount})
+	}
+	sort.Slice(pledgers, func(i, j int) bool {
+		if pledgers[i].Amount == pledgers[j].Amount {
+			return pledgers[i].Address.Base58() < pledgers[j].Address.Base58()
+		}
+		return pledgers[i].Amount > pledgers[j].Amount
+	})
+	if n != 0 && int(n) < len(pledgers) {
+		pledgers = pledgers[:n]
+	}
+
+	return pledgers
+}
+
+// TopPledgees returns the n nodes that received the most mana from the given address in descending order. If n is 0,
+// all pledgees are returned.
+func (a *Analytics) TopPledgees(manaType Type, address ledgerstate.Address, n uint) []Node {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	return a.pledgees[manaType][address.Base58()].SortedNodes(n)
+}
+
+// Epoch returns the index of the epoch that contains the given time.
+func (a *Analytics) Epoch(t time.Time) int64 {
+	return t.UnixNano() / int64(a.epochDuration)
+}
+
+// EpochDuration returns the duration of the epochs the Analytics aggregate into.
+func (a *Analytics) EpochDuration() time.Duration {
+	return a.epochDuration
+}
+
+// EpochDistribution returns the net amount of mana that was pledged to each node during the given epoch.
+func (a *Analytics) EpochDistribution(manaType Type, epoch int64) NodeMap {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	distribution := make(NodeMap)
+	for nodeID, amount := range a.epochs[manaType][epoch] {
+		distribution[nodeID] = amount
+	}
+
+	return distribution
+}
+
+// Epochs returns the indices of all the epochs that are currently kept for the given mana type in ascending order.
+func (a *Analytics) Epochs(manaType Type) (epochs []int64) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	epochs = make([]int64, 0, len(a.epochs[manaType]))
+	for epoch := range a.epochs[manaType] {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	return epochs
+}
+
+// record updates the balance, the history and the epoch distribution of a node. It expects the mutex to be held.
+func (a *Analytics) record(manaType Type, nodeID identity.ID, t time.Time, delta float64, transactionID ledgerstate.TransactionID) {
+	if _, exists := a.balances[manaType]; !exists {
+		a.balances[manaType] = make(map[identity.ID]float64)
+		a.history[manaType] = make(map[identity.ID][]*AnalyticsPoint)
+		a.epochs[manaType] = make(map[int64]NodeMap)
+	}
+
+	a.balances[manaType][nodeID] += delta
+
+	history := append(a.history[manaType][nodeID], &AnalyticsPoint{
+		Time:          t,
+		Delta:         delta,
+		Balance:       a.balances[manaType][nodeID],
+		TransactionID: transactionID,
+	})
+	if a.maxHistory > 0 && len(history) > a.maxHistory {
+		history = history[len(history)-a.maxHistory:]
+	}
+	a.history[manaType][nodeID] = history
+
+	epoch := a.Epoch(t)
+	if _, exists := a.epochs[manaType][epoch]; !exists {
+		a.epochs[manaType][epoch] = make(NodeMap)
+		a.pruneEpochs(manaType)
+	}
+	// the epoch might have been pruned right away if it is older than all the kept ones
+	if _, exists := a.epochs[manaType][epoch]; exists {
+		a.epochs[manaType][epoch][nodeID] += delta
+	}
+}
+
+// attribute distributes the given amount of mana (negative if it was revoked) of a node among the given sources
+// proportionally to their amount of tokens. Attributions that are used up are removed. It expects the mutex to be held.
+func (a *Analytics) attribute(manaType Type, nodeID identity.ID, amount float64, sources []*AddressAmount) {
+	var totalSourced float64
+	for _, source := range sources {
+		totalSourced += source.Amount
+	}
+	if totalSourced == 0 {
+		return
+	}
+
+	if _, exists := a.pledgers[manaType]; !exists {
+		a.pledgers[manaType] = make(map[identity.ID]map[string]*AddressAmount)
+	}
+	if _, exists := a.pledgers[manaType][nodeID]; !exists {
+		a.pledgers[manaType][nodeID] = make(map[string]*AddressAmount)
+	}
+	if _, exists := a.pledgees[manaType]; !exists {
+		a.pledgees[manaType] = make(map[string]NodeMap)
+	}
+
+	for _, source := range sources {
+		share := amount * source.Amount / totalSourced
+		addressKey := source.Address.Base58()
+
+		pledger, exists := a.pledgers[manaType][nodeID][addressKey]
+		if !exists {
+			pledger = &AddressAmount{Address: source.Address}
+			a.pledgers[manaType][nodeID][addressKey] = pledger
+		}
+		pledger.Amount += share
+
+		if _, exists := a.pledgees[manaType][addressKey]; !exists {
+			a.pledgees[manaType][addressKey] = make(NodeMap)
+		}
+		a.pledgees[manaType][addressKey][nodeID] += share
+
+		// the shares of a pledge and its revokes are rounded differently, so tiny remainders count as used up
+		if pledger.Amount < minAttributedMana {
+			delete(a.pledgers[manaType][nodeID], addressKey)
+			delete(a.pledgees[manaType][addressKey], nodeID)
+		}
+	}
+	if len(a.pledgers[manaType][nodeID]) == 0 {
+		delete(a.pledgers[manaType], nodeID)
+	}
+	for _, source := range sources {
+		if addressKey := source.Address.Base58(); len(a.pledgees[manaType][addressKey]) == 0 {
+			delete(a.pledgees[manaType], addressKey)
+		}
+	}
+}
+
+// pruneEpochs removes the oldest epochs of the given mana type if more than maxEpochs are kept.
+func (a *Analytics) pruneEpochs(manaType Type) {
+	if a.maxEpochs <= 0 || len(a.epochs[manaType]) <= a.maxEpochs {
+		return
+	}
+
+	epochs := make([]int64, 0, len(a.epochs[manaType]))
+	for epoch := range a.epochs[manaType] {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	for _, epoch := range epochs[:len(epochs)-a.maxEpochs] {
+		delete(a.epochs[manaType], epoch)
+	}
+}
+
+// AnalyticsPoint is a single sample in the pledge history of a node.
+type AnalyticsPoint struct {
+	// Time is the time of the pledge or revoke.
+	Time time.Time
+	// Delta is the amount of mana that was pledged (positive) or revoked (negative).
+	Delta float64
+	// Balance is the net amount of mana pledged to the node after this sample.
+	Balance float64
+	// TransactionID is the transaction that caused the pledge or revoke.
+	TransactionID ledgerstate.TransactionID
+}
+
+// AddressAmount associates an address with an amount of tokens or mana.
+type AddressAmount struct {
+	Address ledgerstate.Address
+	Amount  float64
+}
+
+// Gini returns the Gini coefficient of the mana distribution of the NodeMap. Negative values are treated as 0.
+func (n NodeMap) Gini() float64 {
+	values := make([]float64, 0, len(n))
+	for _, value := range n {
+		values = append(values, value)
+	}
+
+	return Gini(values)
+}
+
+// Gini returns the Gini coefficient of the given values. It is 0 for a perfectly equal distribution and approaches 1
+// if a single entry holds everything. Negative values are treated as 0.
+func Gini(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := make([]float64, len(values))
+	for i, value := range values {
+		if value > 0 {
+			sorted[i] = value
+		}
+	}
+	sort.Float64s(sorted)
+
+	var total, weighted float64
+	for i, value := range sorted {
+		total += value
+		weighted += float64(i+1) * value
+	}
+	if total == 0 {
+		return 0
+	}
+
+	count := float64(len(sorted))
+	return (2*weighted)/(count*total) - (count+1)/count
+}
+
+// SortedNodes returns the n entries of the NodeMap with the highest values in descending order. If n is 0, all
+// entries are returned.
+func (n NodeMap) SortedNodes(limit uint) (nodes []Node) {
+	nodes = make([]Node, 0, len(n))
+	for nodeID, value := range n {
+		nodes = append(nodes, Node{ID: nodeID, Mana: value})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Mana == nodes[j].Mana {
+			return nodes[i].ID.String() < nodes[j].ID.String()
+		}
+		return nodes[i].Mana > nodes[j].Mana
+	})
+	if limit != 0 && int(limit) < len(nodes) {
+		nodes = nodes[:limit]
+	}
+
+	return nodes
+}
@@ -0,0 +1,100 @@
+package mana
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestAnalytics_RecordPledgeAndRevoke(t *testing.T) {
+	analytics := NewAnalytics(time.Minute, 2, 0)
+	nodeID := randomNodeID()
+	address1 := ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	address2 := ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	baseTime := time.Now()
+	sources := []*AddressAmount{{Address: address1, Amount: 3}, {Address: address2, Amount: 1}}
+
+	analytics.RecordPledge(&PledgedEvent{
+		NodeID:        nodeID,
+		Amount:        100,
+		Time:          baseTime,
+		ManaType:      ConsensusMana,
+		TransactionID: randomTxID(),
+	}, sources)
+	// the revoked mana is deducted from the addresses that pledged it
+	analytics.RecordRevoke(&RevokedEvent{
+		NodeID:        nodeID,
+		Amount:        40,
+		Time:          baseTime.Add(time.Second),
+		ManaType:      ConsensusMana,
+		TransactionID: randomTxID(),
+	}, sources)
+
+	assert.Equal(t, 60.0, analytics.Balance(ConsensusMana, nodeID))
+	assert.Equal(t, 0.0, analytics.Balance(AccessMana, nodeID))
+
+	history := analytics.History(ConsensusMana, nodeID, time.Time{}, time.Time{})
+	assert.Len(t, history, 2)
+	assert.Equal(t, 100.0, history[0].Balance)
+	assert.Equal(t, -40.0, history[1].Delta)
+	assert.Equal(t, 60.0, history[1].Balance)
+	assert.Len(t, analytics.History(ConsensusMana, nodeID, baseTime.Add(time.Millisecond), time.Time{}), 1)
+
+	pledgers := analytics.TopPledgers(ConsensusMana, nodeID, 0)
+	assert.Len(t, pledgers, 2)
+	assert.Equal(t, address1.Base58(), pledgers[0].Address.Base58())
+	assert.Equal(t, 45.0, pledgers[0].Amount)
+	assert.Equal(t, 15.0, pledgers[1].Amount)
+	assert.Len(t, analytics.TopPledgers(ConsensusMana, nodeID, 1), 1)
+
+	pledgees := analytics.TopPledgees(ConsensusMana, address2, 0)
+	assert.Len(t, pledgees, 1)
+	assert.Equal(t, nodeID, pledgees[0].ID)
+	assert.Equal(t, 15.0, pledgees[0].Mana)
+
+	// the history is bounded
+	analytics.RecordRevoke(&RevokedEvent{NodeID: nodeID, Amount: 10, Time: baseTime.Add(2 * time.Second), ManaType: ConsensusMana}, nil)
+	assert.Len(t, analytics.History(ConsensusMana, nodeID, time.Time{}, time.Time{}), 2)
+	assert.Len(t, analytics.TopPledgers(ConsensusMana, nodeID, 0), 2)
+
+	// pledgers whose mana was revoked completely are removed
+	analytics.RecordRevoke(&RevokedEvent{NodeID: nodeID, Amount: 60, Time: baseTime.Add(3 * time.Second), ManaType: ConsensusMana}, sources)
+	assert.Empty(t, analytics.TopPledgers(ConsensusMana, nodeID, 0))
+	assert.Empty(t, analytics.TopPledgees(ConsensusMana, address1, 0))
+	assert.Empty(t, analytics.TopPledgees(ConsensusMana, address2, 0))
+}
+
+func TestAnalytics_Epochs(t *testing.T) {
+	analytics := NewAnalytics(time.Minute, 0, 2)
+	nodeID := randomNodeID()
+	baseTime := time.Unix(0, 0)
+
+	for i := 0; i < 3; i++ {
+		analytics.RecordPledge(&PledgedEvent{
+			NodeID:   nodeID,
+			Amount:   float64(i + 1),
+			Time:     baseTime.Add(time.Duration(i) * time.Minute),
+			ManaType: AccessMana,
+		}, nil)
+	}
+
+	assert.Equal(t, []int64{1, 2}, analytics.Epochs(AccessMana))
+	assert.Equal(t, NodeMap{nodeID: 3}, analytics.EpochDistribution(AccessMana, 2))
+	assert.Empty(t, analytics.EpochDistribution(AccessMana, 0))
+
+	// events for pruned epochs are not recorded in the distribution anymore
+	analytics.RecordPledge(&PledgedEvent{NodeID: nodeID, Amount: 1, Time: baseTime, ManaType: AccessMana}, nil)
+	assert.Equal(t, []int64{1, 2}, analytics.Epochs(AccessMana))
+}
+
+func TestGini(t *testing.T) {
+	assert.Equal(t, 0.0, Gini(nil))
+	assert.Equal(t, 0.0, Gini([]float64{0, 0}))
+	assert.InDelta(t, 0.0, Gini([]float64{5, 5, 5, 5}), 1e-9)
+	assert.InDelta(t, 0.75, Gini([]float64{0, 0, 0, 10}), 1e-9)
+	assert.InDelta(t, 0.75, NodeMap{randomNodeID(): 10, randomNodeID(): 0, randomNodeID(): -1, randomNodeID(): 0}.Gini(), 1e-9)
+}
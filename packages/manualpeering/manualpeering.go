@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 )
 
@@ -78,13 +79,25 @@ type Manager struct {
 	reconnectInterval time.Duration
 	knownPeersMutex   sync.RWMutex
 	knownPeers        map[identity.ID]*knownPeer
+	store             kvstore.KVStore
 
 	onGossipNeighborRemovedClosure *events.Closure
 	onGossipNeighborAddedClosure   *events.Closure
 }
 
+// ManagerOption defines an option of the Manager.
+type ManagerOption func(m *Manager)
+
+// WithStore returns a ManagerOption that persists the known peers that are added via AddStoredPeer in the given store.
+// The stored peers are added again when the Manager is started.
+func WithStore(store kvstore.KVStore) ManagerOption {
+	return func(m *Manager) {
+		m.store = store
+	}
+}
+
 // NewManager initializes a new Manager instance.
-func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Manager {
+func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		gm:                gm,
 		local:             local,
@@ -92,16 +105,30 @@ func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Mana
 		reconnectInterval: defaultReconnectInterval,
 		knownPeers:        map[identity.ID]*knownPeer{},
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
 	m.onGossipNeighborRemovedClosure = events.NewClosure(m.onGossipNeighborRemoved)
 	m.onGossipNeighborAddedClosure = events.NewClosure(m.onGossipNeighborAdded)
 	return m
 }
 
-// AddPeer adds multiple peers to the list of known peers.
+// AddPeer adds multiple peers to the list of known peers. The peers are not persisted, e.g. because they are read from
+// the config again whenever the node starts.
 func (m *Manager) AddPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, false)
+}
+
+// AddStoredPeer adds multiple peers to the list of known peers and persists them in the store of the Manager, so that
+// they are added again when the Manager is restarted.
+func (m *Manager) AddStoredPeer(peers ...*KnownPeerToAdd) error {
+	return m.addPeers(peers, true)
+}
+
+func (m *Manager) addPeers(peers []*KnownPeerToAdd, persist bool) error {
 	var resultErr error
 	for _, p := range peers {
-		if err := m.addPeer(p); err != nil {
+		if err := m.addPeer(p, persist); err != nil {
 			resultErr = errors.CombineErrors(resultErr, err)
 		}
 	}
@@ -179,6 +206,7 @@ func (m *Manager) Start() {
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborRemoved.Attach(m.onGossipNeighborRemovedClosure)
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborAdded.Attach(m.onGossipNeighborAddedClosure)
 		m.isStarted.Set()
+		m.addStoredPeers()
 	})
 }
 
@@ -237,7 +265,7 @@ func (kp *knownPeer) setConnStatus(cs ConnectionStatus) {
 	kp.connStatus.Store(cs)
 }
 
-func (m *Manager) addPeer(p *KnownPeerToAdd) error {
+func (m *Manager) addPeer(p *KnownPeerToAdd, persist bool) error {
 	if !m.isStarted.IsSet() {
 		return errors.New("manualpeering manager hasn't been started yet")
 	}
@@ -256,11 +284,19 @@ func (m *Manager) addPeer(p *KnownPeerToAdd) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	if persist {
+		if err := m.storePeer(p); err != nil {
+			m.log.Errorw("Failed to persist the known peer", "peer", p, "err", err)
+		}
+	}
 	if _, exists := m.knownPeers[kp.peer.ID()]; exists {
 		return nil
 	}
 	m.log.Infow("Adding new peer to the list of known peers in manualpeering", "peer", p)
 	m.knownPeers[kp.peer.ID()] = kp
+	if allowlist := m.gm.Allowlist(); allowlist != nil {
+		allowlist.Add(p.PublicKey)
+	}
 	go func() {
 		defer close(kp.doneCh)
 		m.keepPeerConnected(kp)
@@ -274,6 +310,9 @@ func (m *Manager) removePeer(key ed25519.PublicKey) error {
 	m.log.Infow("Removing peer from from the list of known peers in manualpeering",
 		"publicKey", key)
 	peerID := identity.NewID(key)
+	if err := m.deleteStoredPeer(key); err != nil {
+		m.log.Errorw("Failed to delete the persisted known peer", "publicKey", key, "err", err)
+	}
 	err := m.removePeerByID(peerID)
 	return errors.WithStack(err)
 }
@@ -296,6 +335,9 @@ func (m *Manager) removePeerByID(peerID identity.ID) error {
 		return nil
 	}
 	delete(m.knownPeers, peerID)
+	if allowlist := m.gm.Allowlist(); allowlist != nil {
+		allowlist.Remove(kp.peer.PublicKey())
+	}
 	close(kp.removeCh)
 	<-kp.doneCh
 	if err := m.gm.DropNeighbor(peerID, gossip.NeighborsGroupManual); err != nil && !errors.Is(err, gossip.ErrUnknownNeighbor) {
@@ -304,6 +346,55 @@ func (m *Manager) removePeerByID(peerID identity.ID) error {
 	return nil
 }
 
+// storePeer persists the given peer if the Manager has a store.
+func (m *Manager) storePeer(p *KnownPeerToAdd) error {
+	if m.store == nil {
+		return nil
+	}
+	return errors.WithStack(m.store.Set(p.PublicKey.Bytes(), []byte(p.Address)))
+}
+
+// deleteStoredPeer deletes the persisted peer with the given public key if the Manager has a store.
+func (m *Manager) deleteStoredPeer(key ed25519.PublicKey) error {
+	if m.store == nil {
+		return nil
+	}
+	return errors.WithStack(m.store.Delete(key.Bytes()))
+}
+
+// StoredPeers returns the peers that are persisted in the store of the Manager.
+func (m *Manager) StoredPeers() (peers []*KnownPeerToAdd, err error) {
+	if m.store == nil {
+		return nil, nil
+	}
+	err = m.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		publicKey, _, parseErr := ed25519.PublicKeyFromBytes(key)
+		if parseErr != nil {
+			m.log.Warnw("Skipping invalid persisted known peer", "key", key, "err", parseErr)
+			return true
+		}
+		peers = append(peers, &KnownPeerToAdd{PublicKey: publicKey, Address: string(value)})
+		return true
+	})
+	return peers, errors.WithStack(err)
+}
+
+// addStoredPeers adds the peers that were persisted in the store of the Manager.
+func (m *Manager) addStoredPeers() {
+	peers, err := m.StoredPeers()
+	if err != nil {
+		m.log.Errorw("Failed to load the persisted known peers", "err", err)
+		return
+	}
+	if len(peers) == 0 {
+		return
+	}
+	m.log.Infow("Adding the persisted known peers", "peers", peers)
+	if err := m.AddPeer(peers...); err != nil {
+		m.log.Errorw("Failed to add some of the persisted known peers", "err", err)
+	}
+}
+
 func (m *Manager) keepPeerConnected(kp *knownPeer) {
 	ctx, ctxCancel := context.WithCancel(context.Background())
 	cancelContextOnRemove := func() {
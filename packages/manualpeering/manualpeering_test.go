@@ -0,0 +1,60 @@
+package manualpeering
+
+import (
+	"net"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestManager_StoredPeers(t *testing.T) {
+	store := mapdb.NewMapDB()
+	allowlist := gossip.NewAllowlist()
+	local := newTestLocal(t)
+	gm := gossip.NewManager(local, func(tangle.MessageID) ([]byte, error) { return nil, nil }, logger.NewExampleLogger("gossip"), gossip.WithAllowlist(allowlist))
+
+	peerA := &KnownPeerToAdd{PublicKey: ed25519.GenerateKeyPair().PublicKey, Address: "127.0.0.1:14666"}
+	peerB := &KnownPeerToAdd{PublicKey: ed25519.GenerateKeyPair().PublicKey, Address: "127.0.0.1:14667"}
+	configPeer := &KnownPeerToAdd{PublicKey: ed25519.GenerateKeyPair().PublicKey, Address: "127.0.0.1:14668"}
+
+	mgr := NewManager(gm, local, logger.NewExampleLogger("manualpeering"), WithStore(store))
+	mgr.Start()
+	require.NoError(t, mgr.AddPeer(configPeer))
+	require.NoError(t, mgr.AddStoredPeer(peerA, peerB))
+	require.NoError(t, mgr.RemovePeer(peerB.PublicKey))
+	assert.True(t, allowlist.Allowed(identity.NewID(peerA.PublicKey)))
+	assert.False(t, allowlist.Allowed(identity.NewID(peerB.PublicKey)))
+	require.NoError(t, mgr.Stop())
+	assert.False(t, allowlist.Allowed(identity.NewID(peerA.PublicKey)))
+
+	// a new manager with the same store restores the stored peers that were not removed, but not the config peers
+	restarted := NewManager(gm, local, logger.NewExampleLogger("manualpeering"), WithStore(store))
+	restarted.Start()
+	defer func() { require.NoError(t, restarted.Stop()) }()
+
+	peers := restarted.GetPeers()
+	require.Len(t, peers, 1)
+	assert.Equal(t, peerA.PublicKey, peers[0].PublicKey)
+	assert.Equal(t, peerA.Address, peers[0].Address)
+	assert.True(t, allowlist.Allowed(identity.NewID(peerA.PublicKey)))
+}
+
+func newTestLocal(t *testing.T) *peer.Local {
+	db, err := peer.NewDB(mapdb.NewMapDB())
+	require.NoError(t, err)
+	services := service.New()
+	services.Update(service.PeeringKey, "tcp", 0)
+	local, err := peer.NewLocal(net.IPv4zero, services, db)
+	require.NoError(t, err)
+	return local
+}
@@ -30,6 +30,8 @@ type CollectionEvents struct {
 	QueryReplyError *events.Event
 	// AnalysisFPCFinalized defines the global FPC finalization event.
 	AnalysisFPCFinalized *events.Event
+	// NetworkIDMismatch defines the local event of a peer of another network being rejected.
+	NetworkIDMismatch *events.Event
 }
 
 // QueryReceivedEvent is used to pass information through a QueryReceived event.
@@ -46,6 +48,14 @@ type QueryReplyErrorEvent struct {
 	OpinionCount int
 }
 
+// NetworkIDMismatchEvent is used to pass information through a NetworkIDMismatch event.
+type NetworkIDMismatchEvent struct {
+	// ID defines the ID of the rejected peer.
+	ID string
+	// NetworkID defines the network ID of the rejected peer.
+	NetworkID string
+}
+
 // AnalysisFPCFinalizedEvent is triggered by the analysis-server to
 // notify a finalized FPC vote from one node.
 type AnalysisFPCFinalizedEvent struct {
@@ -69,6 +79,10 @@ func queryReplyErrorEventCaller(handler interface{}, params ...interface{}) {
 	handler.(func(ev *QueryReplyErrorEvent))(params[0].(*QueryReplyErrorEvent))
 }
 
+func networkIDMismatchEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(ev *NetworkIDMismatchEvent))(params[0].(*NetworkIDMismatchEvent))
+}
+
 func uint64Caller(handler interface{}, params ...interface{}) {
 	handler.(func(uint64))(params[0].(uint64))
 }
@@ -24,6 +24,7 @@ func newEvents() *CollectionEvents {
 		QueryReceived:         events.NewEvent(queryReceivedEventCaller),
 		QueryReplyError:       events.NewEvent(queryReplyErrorEventCaller),
 		AnalysisFPCFinalized:  events.NewEvent(fpcFinalizedEventCaller),
+		NetworkIDMismatch:     events.NewEvent(networkIDMismatchEventCaller),
 	}
 }
 
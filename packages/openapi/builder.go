@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// ContentTypeJSON is the content type of JSON encoded bodies.
+	ContentTypeJSON = "application/json"
+	// ContentTypeCSV is the content type of CSV encoded bodies.
+	ContentTypeCSV = "text/csv"
+	// ContentTypeText is the content type of plain text bodies.
+	ContentTypeText = "text/plain"
+	// ContentTypeBinary is the content type of binary bodies (e.g. file downloads).
+	ContentTypeBinary = "application/octet-stream"
+	// ContentTypeEventStream is the content type of streams of server-sent events.
+	ContentTypeEventStream = "text/event-stream"
+)
+
+// ErrInvalidRoute is returned if a Route can not be added to the Document.
+var ErrInvalidRoute = errors.New("invalid route")
+
+// Route describes a route of the API together with the Go types of its parameters, its body and its response.
+type Route struct {
+	// Method is the HTTP method of the route.
+	Method string
+	// Path is the path of the route in the notation of the router (path parameters start with a colon).
+	Path string
+	// Summary is a short description of the route.
+	Summary string
+	// Tag groups the route with related routes.
+	Tag string
+	// Query is a struct whose fields are the query parameters of the route (named by their query or json tag).
+	Query interface{}
+	// Body is the value that is expected as the JSON encoded body of the request.
+	Body interface{}
+	// Response is the value that is returned as the body of a successful response (nil for empty responses).
+	Response interface{}
+	// ResponseContentType overrides the content type of the successful response (defaults to ContentTypeJSON).
+	ResponseContentType string
+	// Status overrides the status code of the successful response (defaults to http.StatusOK).
+	Status int
+}
+
+// Builder assembles a Document from Routes.
+type Builder struct {
+	document      *Document
+	generator     *schemaGenerator
+	errorResponse interface{}
+}
+
+// NewBuilder creates a Builder for a Document with the given Info. The errorResponse is the value that is returned by
+// failed requests of all routes.
+func NewBuilder(info Info, errorResponse interface{}) *Builder {
+	return &Builder{
+		document: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   make(map[string]PathItem),
+		},
+		generator:     newSchemaGenerator(),
+		errorResponse: errorResponse,
+	}
+}
+
+// AddSecurityScheme adds a SecurityScheme with the given name. Unless required is true, the authentication is optional.
+func (b *Builder) AddSecurityScheme(name string, scheme *SecurityScheme, required bool) *Builder {
+	if b.document.Components.SecuritySchemes == nil {
+		b.document.Components.SecuritySchemes = make(map[string]*SecurityScheme)
+		if !required {
+			b.document.Security = append(b.document.Security, SecurityRequirement{})
+		}
+	}
+	b.document.Components.SecuritySchemes[name] = scheme
+	b.document.Security = append(b.document.Security, SecurityRequirement{name: {}})
+
+	return b
+}
+
+// AddTag adds a Tag with a description to the Document.
+func (b *Builder) AddTag(name string, description string) *Builder {
+	b.document.Tags = append(b.document.Tags, Tag{Name: name, Description: description})
+
+	return b
+}
+
+// AddRoute adds the Operation of the given Route to the Document.
+func (b *Builder) AddRoute(route Route) (err error) {
+	method := strings.ToLower(route.Method)
+	path, pathParameters := convertPath(route.Path)
+
+	pathItem, exists := b.document.Paths[path]
+	if !exists {
+		pathItem = make(PathItem)
+		b.document.Paths[path] = pathItem
+	}
+	if _, exists = pathItem[method]; exists {
+		return errors.Errorf("%s %s is added twice: %w", route.Method, route.Path, ErrInvalidRoute)
+	}
+
+	operation := &Operation{
+		OperationID: OperationID(route.Method, route.Path),
+		Summary:     route.Summary,
+		Responses:   make(map[string]*Response),
+	}
+	if route.Tag != "" {
+		operation.Tags = []string{route.Tag}
+	}
+
+	for _, name := range pathParameters {
+		operation.Parameters = append(operation.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+
+	if route.Query != nil {
+		queryParameters, queryErr := b.queryParameters(reflect.TypeOf(route.Query))
+		if queryErr != nil {
+			return errors.Errorf("query of %s %s: %w", route.Method, route.Path, queryErr)
+		}
+		operation.Parameters = append(operation.Parameters, queryParameters...)
+	}
+
+	if route.Body != nil {
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{ContentTypeJSON: {Schema: b.generator.Schema(reflect.TypeOf(route.Body))}},
+		}
+	}
+
+	status := route.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := &Response{Description: http.StatusText(status)}
+	if route.Response != nil || route.ResponseContentType != "" {
+		contentType := route.ResponseContentType
+		if contentType == "" {
+			contentType = ContentTypeJSON
+		}
+		mediaType := &MediaType{Schema: &Schema{Type: "string"}}
+		if contentType == ContentTypeBinary {
+			mediaType.Schema.Format = "binary"
+		}
+		if route.Response != nil {
+			mediaType.Schema = b.generator.Schema(reflect.TypeOf(route.Response))
+		}
+		response.Content = map[string]*MediaType{contentType: mediaType}
+	}
+	operation.Responses[strconv.Itoa(status)] = response
+
+	if b.errorResponse != nil {
+		operation.Responses["default"] = &Response{
+			Description: "Error",
+			Content:     map[string]*MediaType{ContentTypeJSON: {Schema: b.generator.Schema(reflect.TypeOf(b.errorResponse))}},
+		}
+	}
+
+	pathItem[method] = operation
+
+	return nil
+}
+
+// Document returns the Document that contains all added Routes.
+func (b *Builder) Document() *Document {
+	b.document.Components.Schemas = b.generator.schemas
+	sort.Slice(b.document.Tags, func(i, j int) bool {
+		return b.document.Tags[i].Name < b.document.Tags[j].Name
+	})
+
+	return b.document
+}
+
+// queryParameters returns the query Parameters that are described by the fields of the given struct type.
+func (b *Builder) queryParameters(t reflect.Type) (parameters []*Parameter, err error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, errors.Errorf("%s is not a struct: %w", t, ErrInvalidRoute)
+	}
+
+	for _, field := range jsonFields(t) {
+		name := field.name
+		if queryName := field.Tag.Get("query"); queryName != "" {
+			name = queryName
+		}
+		parameters = append(parameters, &Parameter{Name: name, In: "query", Schema: b.generator.Schema(field.Type)})
+	}
+
+	return parameters, nil
+}
+
+// OperationID returns the operation ID of a route, e.g. "getLedgerstateAddressesAddressTransactions" for
+// "GET ledgerstate/addresses/:address/transactions".
+func OperationID(method string, path string) string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(method))
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == ':' || r == '.' || r == '-' || r == '_' }) {
+		builder.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+
+	return builder.String()
+}
+
+// convertPath converts the path notation of the router into the OpenAPI notation and returns the names of the path
+// parameters.
+func convertPath(path string) (convertedPath string, parameters []string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			parameters = append(parameters, segment[1:])
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/"), parameters
+}
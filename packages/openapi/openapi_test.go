@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type embedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type node struct {
+	embedded
+	Name     string            `json:"name,omitempty"`
+	Count    uint64            `json:"count,string"`
+	Data     []byte            `json:"data"`
+	Time     time.Time         `json:"time"`
+	Children []*node           `json:"children"`
+	Labels   map[string]string `json:"labels"`
+	Ignored  string            `json:"-"`
+	private  string
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func TestBuilder(t *testing.T) {
+	builder := NewBuilder(Info{Title: "test", Version: "1"}, errorResponse{})
+	require.NoError(t, builder.AddRoute(Route{
+		Method:   http.MethodPost,
+		Path:     "nodes/:nodeID",
+		Summary:  "Updates a node",
+		Body:     node{},
+		Response: &node{},
+	}))
+	require.NoError(t, builder.AddRoute(Route{
+		Method: http.MethodGet,
+		Path:   "nodes",
+		Query: struct {
+			Limit  int    `query:"limit"`
+			Prefix string `json:"prefix"`
+		}{},
+		Response: []node{},
+	}))
+	assert.ErrorIs(t, builder.AddRoute(Route{Method: http.MethodGet, Path: "/nodes"}), ErrInvalidRoute)
+	document := builder.Document()
+	nodeRef := componentsPrefix + componentName(reflect.TypeOf(node{}))
+
+	update := document.Paths["/nodes/{nodeID}"]["post"]
+	assert.Equal(t, "postNodesNodeID", update.OperationID)
+	assert.Equal(t, []*Parameter{{Name: "nodeID", In: "path", Required: true, Schema: &Schema{Type: "string"}}}, update.Parameters)
+	assert.Equal(t, nodeRef, update.RequestBody.Content[ContentTypeJSON].Schema.Ref)
+	assert.Equal(t, nodeRef, update.Responses["200"].Content[ContentTypeJSON].Schema.Ref)
+	assert.Equal(t, componentsPrefix+componentName(reflect.TypeOf(errorResponse{})), update.Responses["default"].Content[ContentTypeJSON].Schema.Ref)
+
+	list := document.Paths["/nodes"]["get"]
+	assert.Equal(t, []string{"limit", "prefix"}, []string{list.Parameters[0].Name, list.Parameters[1].Name})
+	assert.Equal(t, "array", list.Responses["200"].Content[ContentTypeJSON].Schema.Type)
+
+	nodeSchema := document.Components.Schemas[componentName(reflect.TypeOf(node{}))]
+	assert.Len(t, nodeSchema.Properties, 7)
+	assert.Equal(t, &Schema{Type: "string"}, nodeSchema.Properties["embedded"])
+	assert.Equal(t, &Schema{Type: "string"}, nodeSchema.Properties["count"])
+	assert.Equal(t, &Schema{Type: "string", Format: "byte"}, nodeSchema.Properties["data"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, nodeSchema.Properties["time"])
+	assert.Equal(t, nodeRef, nodeSchema.Properties["children"].Items.Ref)
+	assert.Equal(t, &Schema{Type: "string"}, nodeSchema.Properties["labels"].AdditionalProperties)
+}
+
+func TestOperationID(t *testing.T) {
+	assert.Equal(t, "get", OperationID(http.MethodGet, "/"))
+	assert.Equal(t, "getOpenapiJson", OperationID(http.MethodGet, "/openapi.json"))
+	assert.Equal(t, "deleteManualpeeringPeers", OperationID(http.MethodDelete, "manualpeering/peers"))
+}
@@ -0,0 +1,195 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// componentsPrefix is the prefix of references to the Schemas in the Components of a Document.
+const componentsPrefix = "#/components/schemas/"
+
+// modelsPackage is the package whose types keep their plain name in the Components. Types of other packages are
+// prefixed with their package name to avoid collisions.
+const modelsPackage = "jsonmodels"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator derives Schemas from Go types following the rules of encoding/json.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// newSchemaGenerator creates a new schemaGenerator.
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// Schema returns the Schema of the given type. Named struct types are added to the Components and referenced.
+func (g *schemaGenerator) Schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface && implementsMarshaler(t):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return g.Schema(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		if t == durationType {
+			return &Schema{Type: "integer", Format: "int64", Description: "duration in nanoseconds"}
+		}
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Minimum: new(float64)}
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Format: "int64", Minimum: new(float64)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 && !implementsMarshaler(t.Elem()) {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.Schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Schema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		// interfaces can contain any value
+		return &Schema{}
+	}
+}
+
+// structSchema returns the Schema of a struct type and stores named structs in the Components.
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t)
+	}
+
+	if name, exists := g.names[t]; exists {
+		return &Schema{Ref: componentsPrefix + name}
+	}
+
+	name := componentName(t)
+	g.names[t] = name
+	// reserve the name before descending into the fields to support recursive types
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.objectSchema(t)
+
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+// objectSchema returns the inline Schema of the fields of a struct type.
+func (g *schemaGenerator) objectSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range jsonFields(t) {
+		fieldSchema := g.Schema(field.Type)
+		if field.stringEncoded {
+			fieldSchema = &Schema{Type: "string"}
+		}
+		schema.Properties[field.name] = fieldSchema
+	}
+
+	return schema
+}
+
+// jsonField is a field of a struct as seen by encoding/json.
+type jsonField struct {
+	reflect.StructField
+	name          string
+	stringEncoded bool
+}
+
+// jsonFields returns the fields of a struct that are encoded by encoding/json (including promoted fields of embedded
+// structs).
+func jsonFields(t reflect.Type) (fields []jsonField) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := parseTag(tag)
+
+		if field.Anonymous && name == "" {
+			embeddedType := field.Type
+			if embeddedType.Kind() == reflect.Ptr {
+				embeddedType = embeddedType.Elem()
+			}
+			if embeddedType.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(embeddedType)...)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fields = append(fields, jsonField{
+			StructField:   field,
+			name:          name,
+			stringEncoded: strings.Contains(options, "string"),
+		})
+	}
+
+	return fields
+}
+
+// parseTag splits a json struct tag into its name and its options.
+func parseTag(tag string) (name string, options string) {
+	if index := strings.Index(tag, ","); index != -1 {
+		return tag[:index], tag[index+1:]
+	}
+
+	return tag, ""
+}
+
+// implementsMarshaler returns true if the type (or a pointer to it) defines a custom JSON or text encoding.
+func implementsMarshaler(t reflect.Type) bool {
+	pointerType := reflect.PtrTo(t)
+
+	return t.Implements(jsonMarshalerType) || pointerType.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || pointerType.Implements(textMarshalerType)
+}
+
+// componentName returns the name of a named type in the Components.
+func componentName(t reflect.Type) string {
+	packagePath := t.PkgPath()
+	packageName := packagePath[strings.LastIndex(packagePath, "/")+1:]
+	if packageName == modelsPackage || packageName == "" {
+		return t.Name()
+	}
+
+	return strings.ToUpper(packageName[:1]) + packageName[1:] + t.Name()
+}
@@ -0,0 +1,158 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// magic identifies a recording file.
+var magic = []byte("GSREC")
+
+// formatVersion is the version of the recording format.
+const formatVersion byte = 1
+
+// maxRecordSize is the upper bound of the size of a single serialized Record. Larger records are treated as corruption.
+const maxRecordSize = 1 << 20
+
+// region Record ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Record is a single entry of a recording: the raw bytes that were passed to the Parser together with their arrival time
+// and the neighbor they were received from.
+type Record struct {
+	// Time is the arrival time of the bytes.
+	Time time.Time
+
+	// HasPeer defines whether the bytes were received from a peer.
+	HasPeer bool
+
+	// PeerPublicKey is the public key of the peer that sent the bytes. It is only valid if HasPeer is set.
+	PeerPublicKey ed25519.PublicKey
+
+	// MessageBytes contains the raw message bytes.
+	MessageBytes []byte
+}
+
+// RecordFromBytes unmarshals a Record from a sequence of bytes.
+func RecordFromBytes(bytes []byte) (record *Record, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if record, err = RecordFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Record from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// RecordFromMarshalUtil unmarshals a Record using a MarshalUtil (for easier unmarshaling).
+func RecordFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (record *Record, err error) {
+	record = &Record{}
+	if record.Time, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if record.HasPeer, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Errorf("failed to parse peer flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if record.HasPeer {
+		if record.PeerPublicKey, err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse peer public key (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+	}
+	bytesCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse bytes count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if record.MessageBytes, err = marshalUtil.ReadBytes(int(bytesCount)); err != nil {
+		return nil, errors.Errorf("failed to parse message bytes (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	return record, nil
+}
+
+// Bytes returns a marshaled version of the Record.
+func (r *Record) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteTime(r.Time).
+		WriteBool(r.HasPeer)
+	if r.HasPeer {
+		marshalUtil.Write(r.PeerPublicKey)
+	}
+
+	return marshalUtil.
+		WriteUint32(uint32(len(r.MessageBytes))).
+		WriteBytes(r.MessageBytes).
+		Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Reader ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Reader reads the Records of a recording.
+type Reader struct {
+	reader *bufio.Reader
+}
+
+// NewReader creates a Reader that reads a recording from the given io.Reader. It fails if the recording does not start
+// with a valid header.
+func NewReader(reader io.Reader) (*Reader, error) {
+	r := &Reader{reader: bufio.NewReader(reader)}
+
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(r.reader, header); err != nil {
+		return nil, errors.Errorf("failed to read recording header: %w", err)
+	}
+	if string(header[:len(magic)]) != string(magic) {
+		return nil, errors.New("not a recording: invalid header")
+	}
+	if header[len(magic)] != formatVersion {
+		return nil, errors.Errorf("unsupported recording format version %d", header[len(magic)])
+	}
+
+	return r, nil
+}
+
+// Next returns the next Record of the recording. It returns io.EOF if there are no more Records.
+func (r *Reader) Next() (*Record, error) {
+	var size uint32
+	if err := binary.Read(r.reader, binary.LittleEndian, &size); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.Errorf("failed to read record size: %w", err)
+	}
+	if size > maxRecordSize {
+		return nil, errors.Errorf("record size %d exceeds the maximum of %d", size, maxRecordSize)
+	}
+
+	recordBytes := make([]byte, size)
+	if _, err := io.ReadFull(r.reader, recordBytes); err != nil {
+		return nil, errors.Errorf("failed to read record: %w", err)
+	}
+	record, _, err := RecordFromBytes(recordBytes)
+
+	return record, err
+}
+
+// ReadAll reads all remaining Records of the recording.
+func (r *Reader) ReadAll() (records []*Record, err error) {
+	for {
+		record, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
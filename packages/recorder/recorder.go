@@ -0,0 +1,145 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// Recorder writes everything that enters the Parser of a Tangle to a recording, so that it can be replayed later.
+type Recorder struct {
+	writer       *bufio.Writer
+	closer       io.Closer
+	count        uint64
+	err          error
+	closed       bool
+	mutex        sync.Mutex
+	parser       *tangle.Parser
+	bytesClosure *events.Closure
+}
+
+// NewRecorder creates a Recorder that writes the recording to the given io.Writer. If the writer is an io.Closer, it
+// is closed when the Recorder is closed.
+func NewRecorder(writer io.Writer) (recorder *Recorder, err error) {
+	recorder = &Recorder{
+		writer: bufio.NewWriter(writer),
+	}
+	if closer, ok := writer.(io.Closer); ok {
+		recorder.closer = closer
+	}
+	recorder.bytesClosure = events.NewClosure(func(event *tangle.BytesReceivedEvent) {
+		recorder.Record(time.Now(), event.Bytes, event.Peer)
+	})
+
+	if _, err = recorder.writer.Write(append(append([]byte{}, magic...), formatVersion)); err != nil {
+		return nil, errors.Errorf("failed to write recording header: %w", err)
+	}
+
+	return recorder, nil
+}
+
+// Attach starts recording the bytes that are passed to the given Parser.
+func (r *Recorder) Attach(parser *tangle.Parser) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.parser != nil {
+		r.parser.Events.BytesReceived.Detach(r.bytesClosure)
+	}
+	r.parser = parser
+	r.parser.Events.BytesReceived.Attach(r.bytesClosure)
+}
+
+// Detach stops recording the bytes of the attached Parser.
+func (r *Recorder) Detach() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.detach()
+}
+
+// Record adds an entry to the recording. The peer is nil if the bytes were not received from a neighbor.
+func (r *Recorder) Record(arrivalTime time.Time, bytes []byte, peer *peer.Peer) {
+	record := &Record{
+		Time:         arrivalTime,
+		MessageBytes: bytes,
+	}
+	if peer != nil {
+		record.HasPeer = true
+		record.PeerPublicKey = peer.PublicKey()
+	}
+	recordBytes := record.Bytes()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// stop recording after the first error, a recording with gaps can not be replayed deterministically
+	if r.closed || r.err != nil {
+		return
+	}
+	if err := binary.Write(r.writer, binary.LittleEndian, uint32(len(recordBytes))); err != nil {
+		r.err = errors.Errorf("failed to write record size: %w", err)
+		return
+	}
+	if _, err := r.writer.Write(recordBytes); err != nil {
+		r.err = errors.Errorf("failed to write record: %w", err)
+		return
+	}
+	r.count++
+}
+
+// Count returns the number of records that were written.
+func (r *Recorder) Count() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.count
+}
+
+// Err returns the first error that occurred while writing the recording.
+func (r *Recorder) Err() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.err
+}
+
+// Close detaches the Recorder, flushes the recording and closes the underlying writer. It returns the first error that
+// occurred while recording.
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.closed {
+		return r.err
+	}
+	r.closed = true
+	r.detach()
+
+	if err := r.writer.Flush(); err != nil && r.err == nil {
+		r.err = errors.Errorf("failed to flush recording: %w", err)
+	}
+	if r.closer != nil {
+		if err := r.closer.Close(); err != nil && r.err == nil {
+			r.err = errors.Errorf("failed to close recording: %w", err)
+		}
+	}
+
+	return r.err
+}
+
+func (r *Recorder) detach() {
+	if r.parser == nil {
+		return
+	}
+	r.parser.Events.BytesReceived.Detach(r.bytesClosure)
+	r.parser = nil
+}
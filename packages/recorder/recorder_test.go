@@ -0,0 +1,167 @@
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+const messageCount = 50
+
+func TestRecord_Bytes(t *testing.T) {
+	record := &Record{
+		Time:          time.Unix(1622548800, 42),
+		HasPeer:       true,
+		PeerPublicKey: identity.GenerateIdentity().PublicKey(),
+		MessageBytes:  []byte("message"),
+	}
+
+	restored, consumedBytes, err := RecordFromBytes(record.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(record.Bytes()), consumedBytes)
+	assert.True(t, record.Time.Equal(restored.Time))
+	assert.Equal(t, record.PeerPublicKey, restored.PeerPublicKey)
+	assert.Equal(t, record.MessageBytes, restored.MessageBytes)
+
+	local := &Record{Time: time.Unix(1622548800, 0), MessageBytes: []byte("local")}
+	restored, _, err = RecordFromBytes(local.Bytes())
+	require.NoError(t, err)
+	assert.False(t, restored.HasPeer)
+	assert.Equal(t, local.MessageBytes, restored.MessageBytes)
+}
+
+func TestNewReader_InvalidHeader(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("NOTAREC")))
+	assert.Error(t, err)
+
+	_, err = NewReader(bytes.NewReader(nil))
+	assert.Error(t, err)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	source := newTangle()
+	defer source.Shutdown()
+
+	buffer := &bytes.Buffer{}
+	recorder, err := NewRecorder(buffer)
+	require.NoError(t, err)
+	recorder.Attach(source.Parser)
+
+	source.MessageFactory.Events.MessageConstructed.Attach(events.NewClosure(func(message *tangle.Message) {
+		source.ProcessGossipMessage(message.Bytes(), nil)
+	}))
+
+	issued := make(map[tangle.MessageID]bool)
+	for i := 0; i < messageCount; i++ {
+		message, issueErr := source.IssuePayload(payload.NewGenericDataPayload([]byte("recorded")))
+		require.NoError(t, issueErr)
+		issued[message.ID()] = true
+	}
+	require.NoError(t, recorder.Close())
+	assert.EqualValues(t, messageCount, recorder.Count())
+
+	reader, err := NewReader(buffer)
+	require.NoError(t, err)
+	records, err := reader.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, messageCount)
+
+	for _, order := range []Order{OrderRecorded, OrderShuffled, OrderReversed} {
+		t.Run(string(order), func(t *testing.T) {
+			target := newTangle()
+			defer target.Shutdown()
+
+			booked := make(chan tangle.MessageID, messageCount)
+			target.Booker.Events.MessageBooked.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+				booked <- messageID
+			}))
+
+			replayer := NewReplayer(target, Ordering(order), Seed(42))
+			defer replayer.Detach()
+			require.NoError(t, replayer.Replay(records))
+
+			for i := 0; i < messageCount; i++ {
+				select {
+				case messageID := <-booked:
+					assert.True(t, issued[messageID])
+				case <-time.After(5 * time.Second):
+					t.Fatalf("only %d of %d messages were booked", i, messageCount)
+				}
+			}
+
+			stats := replayer.Stats()
+			assert.EqualValues(t, messageCount, stats.Replayed)
+			assert.EqualValues(t, messageCount, stats.Parsed)
+			assert.Zero(t, stats.Invalid)
+		})
+	}
+}
+
+func TestReplayer_Speed(t *testing.T) {
+	start := time.Now()
+	records := []*Record{
+		{Time: start, MessageBytes: []byte("a")},
+		{Time: start.Add(100 * time.Millisecond), MessageBytes: []byte("b")},
+	}
+
+	target := newTangle()
+	defer target.Shutdown()
+
+	replayer := NewReplayer(target, Speed(2))
+	defer replayer.Detach()
+
+	replayStart := time.Now()
+	require.NoError(t, replayer.Replay(records))
+	assert.GreaterOrEqual(t, int64(time.Since(replayStart)), int64(50*time.Millisecond))
+	assert.True(t, replayer.WaitIdle(50*time.Millisecond, time.Second))
+
+	// the bytes are no valid messages
+	stats := replayer.Stats()
+	assert.EqualValues(t, 2, stats.Replayed)
+	assert.EqualValues(t, 2, stats.BytesRejected)
+
+	unordered := NewReplayer(target, Ordering("sideways"))
+	defer unordered.Detach()
+	assert.Error(t, unordered.Replay(records))
+}
+
+func TestReader_Truncated(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	recorder, err := NewRecorder(buffer)
+	require.NoError(t, err)
+	recorder.Record(time.Now(), []byte("message"), nil)
+	require.NoError(t, recorder.Close())
+
+	data := buffer.Bytes()
+	reader, err := NewReader(bytes.NewReader(data[:len(data)-1]))
+	require.NoError(t, err)
+	_, err = reader.Next()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+}
+
+func newTangle() *tangle.Tangle {
+	t := tangle.New(
+		tangle.Store(mapdb.NewMapDB()),
+		tangle.StartSynced(true),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			MaxBufferSize:               1024 * 1024,
+			Rate:                        time.Second / 5000,
+			AccessManaRetrieveFunc:      func(identity.ID) float64 { return 1 },
+			TotalAccessManaRetrieveFunc: func() float64 { return 1000 },
+		}),
+	)
+	t.Setup()
+
+	return t
+}
@@ -0,0 +1,263 @@
+package recorder
+
+import (
+	"math/rand"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// idlePollInterval is the interval in which WaitIdle checks the progress of the Tangle.
+const idlePollInterval = 10 * time.Millisecond
+
+// region Order ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Order defines the order in which the Records of a recording are replayed.
+type Order string
+
+const (
+	// OrderRecorded replays the Records in the order they were recorded.
+	OrderRecorded Order = "recorded"
+
+	// OrderShuffled replays the Records in a random order that is derived from the seed of the Replayer.
+	OrderShuffled Order = "shuffled"
+
+	// OrderReversed replays the Records in the reverse order they were recorded.
+	OrderReversed Order = "reversed"
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Option is a function that configures the Replayer.
+type Option func(*Replayer)
+
+// Speed sets the factor by which the replay is faster than the recording: 1 replays in real time, 2 twice as fast. A
+// speed of 0 (default) replays the Records as fast as possible.
+func Speed(speed float64) Option {
+	return func(r *Replayer) {
+		r.speed = speed
+	}
+}
+
+// Ordering sets the order in which the Records are replayed (default OrderRecorded).
+func Ordering(order Order) Option {
+	return func(r *Replayer) {
+		r.order = order
+	}
+}
+
+// Seed sets the seed that is used to shuffle the Records, so that a shuffled replay can be reproduced.
+func Seed(seed int64) Option {
+	return func(r *Replayer) {
+		r.seed = seed
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Stats ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Stats contains the number of messages that reached the different stages of the Tangle during a replay.
+type Stats struct {
+	Replayed        uint64
+	Parsed          uint64
+	BytesRejected   uint64
+	MessageRejected uint64
+	Stored          uint64
+	Solid           uint64
+	Booked          uint64
+	Invalid         uint64
+}
+
+func (s Stats) progress() uint64 {
+	return s.Replayed + s.Parsed + s.BytesRejected + s.MessageRejected + s.Stored + s.Solid + s.Booked + s.Invalid
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Replayer /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Replayer feeds the Records of a recording into a Tangle and keeps track of how far the messages get.
+type Replayer struct {
+	tangle *tangle.Tangle
+	speed  float64
+	order  Order
+	seed   int64
+	peers  map[ed25519.PublicKey]*peer.Peer
+	stats  Stats
+
+	stopOnce       sync.Once
+	shutdownSignal chan struct{}
+	detachOnce     sync.Once
+	detachFuncs    []func()
+}
+
+// NewReplayer creates a Replayer that feeds the Records into the given Tangle. The Tangle should be fresh (e.g. using a
+// mapdb store) for the replay to be deterministic.
+func NewReplayer(t *tangle.Tangle, options ...Option) *Replayer {
+	r := &Replayer{
+		tangle:         t,
+		order:          OrderRecorded,
+		peers:          make(map[ed25519.PublicKey]*peer.Peer),
+		shutdownSignal: make(chan struct{}),
+	}
+	for _, option := range options {
+		option(r)
+	}
+
+	r.attachCounter(t.Parser.Events.MessageParsed, events.NewClosure(func(*tangle.MessageParsedEvent) { atomic.AddUint64(&r.stats.Parsed, 1) }))
+	r.attachCounter(t.Parser.Events.BytesRejected, events.NewClosure(func(*tangle.BytesRejectedEvent, error) { atomic.AddUint64(&r.stats.BytesRejected, 1) }))
+	r.attachCounter(t.Parser.Events.MessageRejected, events.NewClosure(func(*tangle.MessageRejectedEvent, error) { atomic.AddUint64(&r.stats.MessageRejected, 1) }))
+	r.attachCounter(t.Storage.Events.MessageStored, events.NewClosure(func(tangle.MessageID) { atomic.AddUint64(&r.stats.Stored, 1) }))
+	r.attachCounter(t.Solidifier.Events.MessageSolid, events.NewClosure(func(tangle.MessageID) { atomic.AddUint64(&r.stats.Solid, 1) }))
+	r.attachCounter(t.Booker.Events.MessageBooked, events.NewClosure(func(tangle.MessageID) { atomic.AddUint64(&r.stats.Booked, 1) }))
+	r.attachCounter(t.Events.MessageInvalid, events.NewClosure(func(tangle.MessageID) { atomic.AddUint64(&r.stats.Invalid, 1) }))
+
+	return r
+}
+
+// Replay feeds the given Records into the Tangle using the configured order and speed. The pacing follows the
+// inter-arrival times of the recording, independently of the order, so that a reordered replay puts the Tangle under the
+// same load as the recorded one. It blocks until all Records were replayed or the Replayer was stopped.
+func (r *Replayer) Replay(records []*Record) error {
+	ordered, err := r.ordered(records)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	for i, record := range ordered {
+		if r.speed > 0 {
+			offset := time.Duration(float64(records[i].Time.Sub(records[0].Time)) / r.speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-r.shutdownSignal:
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-r.shutdownSignal:
+			return nil
+		default:
+		}
+
+		r.tangle.ProcessGossipMessage(record.MessageBytes, r.peer(record))
+		atomic.AddUint64(&r.stats.Replayed, 1)
+	}
+
+	return nil
+}
+
+// WaitIdle blocks until the Tangle made no progress for the given quiet duration or the timeout is reached. It returns
+// false if the timeout was reached.
+func (r *Replayer) WaitIdle(quiet, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	lastProgress := r.Stats().progress()
+	lastChange := time.Now()
+
+	for time.Now().Before(deadline) {
+		time.Sleep(idlePollInterval)
+
+		if progress := r.Stats().progress(); progress != lastProgress {
+			lastProgress = progress
+			lastChange = time.Now()
+			continue
+		}
+		if time.Since(lastChange) >= quiet {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Stats returns a snapshot of the statistics of the replay.
+func (r *Replayer) Stats() Stats {
+	return Stats{
+		Replayed:        atomic.LoadUint64(&r.stats.Replayed),
+		Parsed:          atomic.LoadUint64(&r.stats.Parsed),
+		BytesRejected:   atomic.LoadUint64(&r.stats.BytesRejected),
+		MessageRejected: atomic.LoadUint64(&r.stats.MessageRejected),
+		Stored:          atomic.LoadUint64(&r.stats.Stored),
+		Solid:           atomic.LoadUint64(&r.stats.Solid),
+		Booked:          atomic.LoadUint64(&r.stats.Booked),
+		Invalid:         atomic.LoadUint64(&r.stats.Invalid),
+	}
+}
+
+// Stop aborts a running replay.
+func (r *Replayer) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.shutdownSignal)
+	})
+}
+
+// Detach stops the Replayer and detaches it from the events of the Tangle.
+func (r *Replayer) Detach() {
+	r.Stop()
+	r.detachOnce.Do(func() {
+		for _, detach := range r.detachFuncs {
+			detach()
+		}
+	})
+}
+
+func (r *Replayer) ordered(records []*Record) (ordered []*Record, err error) {
+	ordered = make([]*Record, len(records))
+	copy(ordered, records)
+
+	switch r.order {
+	case OrderRecorded:
+	case OrderShuffled:
+		random := rand.New(rand.NewSource(r.seed))
+		random.Shuffle(len(ordered), func(i, j int) { ordered[i], ordered[j] = ordered[j], ordered[i] })
+	case OrderReversed:
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	default:
+		return nil, errors.Errorf("unknown replay order %s", r.order)
+	}
+
+	return ordered, nil
+}
+
+// peer returns the peer that sent the Record. Peers are reconstructed from their public key only, the replay does not
+// depend on their network address.
+func (r *Replayer) peer(record *Record) *peer.Peer {
+	if !record.HasPeer {
+		return nil
+	}
+	if p, exists := r.peers[record.PeerPublicKey]; exists {
+		return p
+	}
+
+	services := service.New()
+	services.Update(service.PeeringKey, "tcp", 0)
+	p := peer.NewPeer(identity.New(record.PeerPublicKey), net.IPv4zero, services)
+	r.peers[record.PeerPublicKey] = p
+
+	return p
+}
+
+func (r *Replayer) attachCounter(event *events.Event, closure *events.Closure) {
+	event.Attach(closure)
+	r.detachFuncs = append(r.detachFuncs, func() { event.Detach(closure) })
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,171 @@
+package schedulersim
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// region issuer ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// issuer creates the messages of a single simulated node and issues them either through a rate setter or directly.
+type issuer struct {
+	name     string
+	group    *IssuerGroup
+	identity *identity.Identity
+	mana     float64
+
+	nextArrival    time.Duration
+	nextBurst      time.Duration
+	sequenceNumber uint64
+
+	// state of the rate setter
+	rate         float64
+	pauseUpdates uint
+	lastIssue    time.Duration
+	issued       bool
+	queue        []*pendingMessage
+	queueSize    int
+
+	stats issuerStats
+}
+
+// pendingMessage is a message that was created but not yet scheduled or discarded.
+type pendingMessage struct {
+	issuer    *issuer
+	message   *tangle.Message
+	created   time.Duration
+	submitted time.Duration
+}
+
+// issuerStats contains the statistics of an issuer.
+type issuerStats struct {
+	created           int
+	submitted         int
+	scheduled         int
+	scheduledBytes    int
+	discarded         int
+	dropped           int
+	blacklisted       int
+	latencies         []time.Duration
+	schedulingDelays  []time.Duration
+	firstBlacklisting time.Duration
+}
+
+func newIssuer(name string, group *IssuerGroup, keyPair *ed25519.KeyPair, mana float64, random *rand.Rand) *issuer {
+	i := &issuer{
+		name:      name,
+		group:     group,
+		identity:  identity.New(keyPair.PublicKey),
+		mana:      mana,
+		rate:      group.InitialRate,
+		nextBurst: time.Duration(group.BurstInterval),
+	}
+	if i.rate == 0 {
+		i.rate = tangle.Initial
+	}
+	i.nextArrival = i.interarrivalTime(random)
+
+	return i
+}
+
+// createMessages returns the messages the issuer created up to the given (virtual) time.
+func (i *issuer) createMessages(now time.Duration, epoch time.Time, random *rand.Rand) (created []*pendingMessage) {
+	if i.group.Rate > 0 {
+		for ; i.nextArrival <= now; i.nextArrival += i.interarrivalTime(random) {
+			created = append(created, i.newMessage(now, epoch))
+		}
+	}
+	if i.group.Behavior == Bursty {
+		for ; i.nextBurst <= now; i.nextBurst += time.Duration(i.group.BurstInterval) {
+			for n := 0; n < i.group.BurstSize; n++ {
+				created = append(created, i.newMessage(now, epoch))
+			}
+		}
+	}
+	i.stats.created += len(created)
+
+	return created
+}
+
+// enqueue adds the message to the issuing queue of the rate setter and returns false if the queue is full.
+func (i *issuer) enqueue(pending *pendingMessage) bool {
+	if i.queueSize+pending.message.Size() > tangle.MaxLocalQueueSize {
+		i.stats.dropped++
+		return false
+	}
+	i.queue = append(i.queue, pending)
+	i.queueSize += pending.message.Size()
+	return true
+}
+
+// dequeue removes the next message from the issuing queue of the rate setter, if the rate allows to issue it at the
+// given (virtual) time.
+func (i *issuer) dequeue(now time.Duration) *pendingMessage {
+	if len(i.queue) == 0 {
+		return nil
+	}
+	pending := i.queue[0]
+	if i.issued && now < i.lastIssue+tangle.IssueInterval(pending.message.Size(), i.rate) {
+		return nil
+	}
+
+	i.queue[0] = nil
+	i.queue = i.queue[1:]
+	i.queueSize -= pending.message.Size()
+	i.lastIssue = now
+	i.issued = true
+
+	return pending
+}
+
+// updateRate is called for every scheduled message and updates the rate like the RateSetter of a node.
+func (i *issuer) updateRate(queueSize int, totalMana float64) {
+	if i.pauseUpdates > 0 {
+		i.pauseUpdates--
+		return
+	}
+	if len(i.queue) == 0 {
+		return
+	}
+
+	var backoff bool
+	if i.rate, backoff = tangle.NextRate(i.rate, queueSize, i.mana, totalMana); backoff {
+		i.pauseUpdates = tangle.RateSettingPause
+	}
+}
+
+func (i *issuer) newMessage(now time.Duration, epoch time.Time) *pendingMessage {
+	i.sequenceNumber++
+	message := tangle.NewMessage(
+		[]tangle.MessageID{tangle.EmptyMessageID},
+		[]tangle.MessageID{},
+		epoch.Add(now),
+		i.identity.PublicKey(),
+		i.sequenceNumber,
+		payload.NewGenericDataPayload(make([]byte, i.group.PayloadSize)),
+		0,
+		ed25519.Signature{},
+	)
+
+	return &pendingMessage{
+		issuer:  i,
+		message: message,
+		created: now,
+	}
+}
+
+// interarrivalTime returns the exponentially distributed time until the next message is created.
+func (i *issuer) interarrivalTime(random *rand.Rand) time.Duration {
+	if i.group.Rate <= 0 {
+		return 0
+	}
+	return time.Duration(random.ExpFloat64() / i.group.Rate * float64(time.Second))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
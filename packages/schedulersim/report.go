@@ -0,0 +1,244 @@
+package schedulersim
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// region Report ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Report contains the results of a simulation.
+type Report struct {
+	// Scenario is the simulated Scenario.
+	Scenario *Scenario `json:"scenario"`
+	// Nodes contains the results per issuer.
+	Nodes []*NodeReport `json:"nodes"`
+	// Fairness contains the fairness indices of the throughput.
+	Fairness *Fairness `json:"fairness"`
+	// Blacklistings contains all blacklisting events in the order they happened.
+	Blacklistings []*BlacklistEvent `json:"blacklistings"`
+}
+
+// NodeReport contains the results of a single issuer.
+type NodeReport struct {
+	Issuer    string   `json:"issuer"`
+	NodeID    string   `json:"nodeID"`
+	Behavior  Behavior `json:"behavior"`
+	ManaShare float64  `json:"manaShare"`
+
+	Created   int `json:"created"`
+	Submitted int `json:"submitted"`
+	Scheduled int `json:"scheduled"`
+	// Discarded is the number of messages that were discarded by the scheduler.
+	Discarded int `json:"discarded"`
+	// Dropped is the number of messages that were dropped because the issuing queue of the rate setter was full.
+	Dropped int `json:"dropped"`
+
+	// Throughput is the number of scheduled messages per second.
+	Throughput float64 `json:"throughput"`
+	// ThroughputBytes is the number of scheduled bytes per second.
+	ThroughputBytes float64 `json:"throughputBytes"`
+	// ThroughputShare is the share of the issuer in all scheduled bytes.
+	ThroughputShare float64 `json:"throughputShare"`
+
+	// LatencyP50, LatencyP90, LatencyP99 and LatencyMax are the percentiles of the time between the creation and the
+	// scheduling of the messages (including the time in the issuing queue of the rate setter) [ms].
+	LatencyP50 float64 `json:"latencyP50"`
+	LatencyP90 float64 `json:"latencyP90"`
+	LatencyP99 float64 `json:"latencyP99"`
+	LatencyMax float64 `json:"latencyMax"`
+	// SchedulingDelayP50 and SchedulingDelayP99 are the percentiles of the time the messages spent in the scheduler
+	// buffer [ms].
+	SchedulingDelayP50 float64 `json:"schedulingDelayP50"`
+	SchedulingDelayP99 float64 `json:"schedulingDelayP99"`
+
+	// Blacklisted is the number of times the issuer was blacklisted.
+	Blacklisted int `json:"blacklisted"`
+	// FirstBlacklisting is the time the issuer was blacklisted for the first time (if it was blacklisted).
+	FirstBlacklisting *Duration `json:"firstBlacklisting,omitempty"`
+	// FinalRate is the rate of the rate setter at the end of the simulation [bytes/s] (0 for malicious issuers).
+	FinalRate float64 `json:"finalRate"`
+}
+
+// Fairness contains Jain's fairness indices of the throughput shares normalized by the mana shares. An index of 1 means
+// that all issuers got a share of the throughput that is proportional to their access mana, while 1/n means that a
+// single issuer got all the throughput. Issuers that did not need their fair share lower the index, so it is most
+// meaningful for issuers that were congested.
+type Fairness struct {
+	// Index is the fairness index of all issuers that are not malicious.
+	Index float64 `json:"index"`
+	// IndexAll is the fairness index of all issuers.
+	IndexAll float64 `json:"indexAll"`
+}
+
+// BlacklistEvent is the event of an issuer being blacklisted by the scheduler.
+type BlacklistEvent struct {
+	Time      Duration `json:"time"`
+	Issuer    string   `json:"issuer"`
+	NodeID    string   `json:"nodeID"`
+	Behavior  Behavior `json:"behavior"`
+	QueueSize int      `json:"queueSize"`
+}
+
+// WriteJSON writes the Report as JSON.
+func (r *Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteNodesCSV writes the results per issuer as CSV.
+func (r *Report) WriteNodesCSV(writer io.Writer) error {
+	records := [][]string{{
+		"issuer", "nodeID", "behavior", "manaShare", "created", "submitted", "scheduled", "discarded", "dropped",
+		"throughput", "throughputBytes", "throughputShare", "latencyP50", "latencyP90", "latencyP99", "latencyMax",
+		"schedulingDelayP50", "schedulingDelayP99", "blacklisted", "firstBlacklisting", "finalRate",
+	}}
+	for _, n := range r.Nodes {
+		firstBlacklisting := ""
+		if n.FirstBlacklisting != nil {
+			firstBlacklisting = formatMilliseconds(time.Duration(*n.FirstBlacklisting))
+		}
+		records = append(records, []string{
+			n.Issuer, n.NodeID, string(n.Behavior), formatFloat(n.ManaShare), strconv.Itoa(n.Created),
+			strconv.Itoa(n.Submitted), strconv.Itoa(n.Scheduled), strconv.Itoa(n.Discarded), strconv.Itoa(n.Dropped),
+			formatFloat(n.Throughput), formatFloat(n.ThroughputBytes), formatFloat(n.ThroughputShare),
+			formatFloat(n.LatencyP50), formatFloat(n.LatencyP90), formatFloat(n.LatencyP99), formatFloat(n.LatencyMax),
+			formatFloat(n.SchedulingDelayP50), formatFloat(n.SchedulingDelayP99), strconv.Itoa(n.Blacklisted),
+			firstBlacklisting, formatFloat(n.FinalRate),
+		})
+	}
+	return writeCSV(writer, records)
+}
+
+// WriteBlacklistingsCSV writes the blacklisting events as CSV.
+func (r *Report) WriteBlacklistingsCSV(writer io.Writer) error {
+	records := [][]string{{"time", "issuer", "nodeID", "behavior", "queueSize"}}
+	for _, event := range r.Blacklistings {
+		records = append(records, []string{
+			formatMilliseconds(time.Duration(event.Time)), event.Issuer, event.NodeID, string(event.Behavior),
+			strconv.Itoa(event.QueueSize),
+		})
+	}
+	return writeCSV(writer, records)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// report creates the Report of the simulation.
+func (s *simulation) report() *Report {
+	report := &Report{
+		Scenario:      s.scenario,
+		Blacklistings: s.blacklistings,
+	}
+
+	var totalScheduledBytes int
+	for _, i := range s.issuers {
+		totalScheduledBytes += i.stats.scheduledBytes
+	}
+
+	seconds := time.Duration(s.scenario.Duration).Seconds()
+	var normalized, normalizedHonest []float64
+	for _, i := range s.issuers {
+		n := &NodeReport{
+			Issuer:             i.name,
+			NodeID:             i.identity.ID().String(),
+			Behavior:           i.group.Behavior,
+			ManaShare:          i.mana / s.totalMana,
+			Created:            i.stats.created,
+			Submitted:          i.stats.submitted,
+			Scheduled:          i.stats.scheduled,
+			Discarded:          i.stats.discarded,
+			Dropped:            i.stats.dropped,
+			Throughput:         float64(i.stats.scheduled) / seconds,
+			ThroughputBytes:    float64(i.stats.scheduledBytes) / seconds,
+			LatencyP50:         milliseconds(percentile(i.stats.latencies, 50)),
+			LatencyP90:         milliseconds(percentile(i.stats.latencies, 90)),
+			LatencyP99:         milliseconds(percentile(i.stats.latencies, 99)),
+			LatencyMax:         milliseconds(percentile(i.stats.latencies, 100)),
+			SchedulingDelayP50: milliseconds(percentile(i.stats.schedulingDelays, 50)),
+			SchedulingDelayP99: milliseconds(percentile(i.stats.schedulingDelays, 99)),
+			Blacklisted:        i.stats.blacklisted,
+		}
+		if totalScheduledBytes > 0 {
+			n.ThroughputShare = float64(i.stats.scheduledBytes) / float64(totalScheduledBytes)
+		}
+		if i.stats.blacklisted > 0 {
+			firstBlacklisting := Duration(i.stats.firstBlacklisting)
+			n.FirstBlacklisting = &firstBlacklisting
+		}
+		if i.group.Behavior.usesRateSetter() {
+			n.FinalRate = i.rate
+			normalizedHonest = append(normalizedHonest, n.ThroughputShare/n.ManaShare)
+		}
+		normalized = append(normalized, n.ThroughputShare/n.ManaShare)
+		report.Nodes = append(report.Nodes, n)
+	}
+	report.Fairness = &Fairness{
+		Index:    jainIndex(normalizedHonest),
+		IndexAll: jainIndex(normalized),
+	}
+
+	return report
+}
+
+// percentile returns the p-th percentile (nearest rank) of the given durations.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// jainIndex returns Jain's fairness index of the given values (0 if there are no values or all values are 0).
+func jainIndex(values []float64) float64 {
+	var sum, sumOfSquares float64
+	for _, value := range values {
+		sum += value
+		sumOfSquares += value * value
+	}
+	if sumOfSquares == 0 {
+		return 0
+	}
+	return sum * sum / (float64(len(values)) * sumOfSquares)
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+func formatMilliseconds(duration time.Duration) string {
+	return formatFloat(milliseconds(duration))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+func writeCSV(writer io.Writer, records [][]string) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.WriteAll(records); err != nil {
+		return err
+	}
+	return csvWriter.Error()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,176 @@
+package schedulersim
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// ErrInvalidScenario is returned when a Scenario cannot be simulated.
+var ErrInvalidScenario = errors.New("invalid scenario")
+
+// region Behavior /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// Honest issuers issue their messages through the rate setter.
+	Honest Behavior = "honest"
+	// Bursty issuers issue their messages through the rate setter, but create additional bursts of messages.
+	Bursty Behavior = "bursty"
+	// Malicious issuers ignore the rate setter and submit their messages to the scheduler right away.
+	Malicious Behavior = "malicious"
+)
+
+// Behavior defines how an issuer creates and issues its messages.
+type Behavior string
+
+// usesRateSetter returns true if the issuer issues its messages through the rate setter.
+func (b Behavior) usesRateSetter() bool {
+	return b != Malicious
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Duration /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Duration is a time.Duration that is encoded as a duration string (e.g. "1m30s") in JSON.
+type Duration time.Duration
+
+// MarshalJSON encodes the Duration as a duration string.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON decodes the Duration from a duration string.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Scenario /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Scenario defines the setup of a simulation.
+type Scenario struct {
+	// Duration is the simulated (virtual) time.
+	Duration Duration `json:"duration"`
+	// Rate is the interval in which the scheduler schedules a message.
+	Rate Duration `json:"rate"`
+	// MaxBufferSize is the maximum size of the scheduler buffer (in bytes).
+	MaxBufferSize int `json:"maxBufferSize"`
+	// Seed is the seed of the random arrival times of the messages.
+	Seed int64 `json:"seed"`
+	// Issuers contains the groups of issuers.
+	Issuers []*IssuerGroup `json:"issuers"`
+}
+
+// IssuerGroup defines a number of issuers with the same access mana and behavior.
+type IssuerGroup struct {
+	// Name is the name of the group. The issuers are called <Name>-<index>.
+	Name string `json:"name"`
+	// Count is the number of issuers in the group.
+	Count int `json:"count"`
+	// Behavior is the behavior of the issuers.
+	Behavior Behavior `json:"behavior"`
+	// Mana is the access mana of each issuer as a fraction of the total supply.
+	Mana float64 `json:"mana"`
+	// Rate is the average number of messages each issuer creates per second.
+	Rate float64 `json:"rate"`
+	// PayloadSize is the size of the data payload of the messages (in bytes).
+	PayloadSize int `json:"payloadSize"`
+	// BurstSize is the number of additional messages a bursty issuer creates at once.
+	BurstSize int `json:"burstSize,omitempty"`
+	// BurstInterval is the interval of the bursts of a bursty issuer.
+	BurstInterval Duration `json:"burstInterval,omitempty"`
+	// InitialRate is the initial rate of the rate setter (in bytes per second, 0 uses the default of the node).
+	InitialRate float64 `json:"initialRate,omitempty"`
+}
+
+// DefaultScenario returns a Scenario with honest issuers of different mana, bursty issuers and a malicious issuer that
+// exceeds its share of the throughput.
+func DefaultScenario() *Scenario {
+	return &Scenario{
+		Duration:      Duration(time.Minute),
+		Rate:          Duration(5 * time.Millisecond),
+		MaxBufferSize: 1024 * 1024,
+		Seed:          1,
+		Issuers: []*IssuerGroup{
+			{Name: "honest-high", Count: 5, Behavior: Honest, Mana: 0.1, Rate: 20, PayloadSize: 100},
+			{Name: "honest-low", Count: 20, Behavior: Honest, Mana: 0.01, Rate: 2, PayloadSize: 100},
+			{Name: "bursty", Count: 2, Behavior: Bursty, Mana: 0.05, Rate: 5, PayloadSize: 100, BurstSize: 200, BurstInterval: Duration(10 * time.Second)},
+			{Name: "malicious", Count: 1, Behavior: Malicious, Mana: 0.05, Rate: 100, PayloadSize: 100},
+		},
+	}
+}
+
+// ReadScenario reads a JSON encoded Scenario.
+func ReadScenario(reader io.Reader) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := json.NewDecoder(reader).Decode(scenario); err != nil {
+		return nil, errors.Errorf("failed to decode scenario: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// WriteJSON writes the Scenario as JSON.
+func (s *Scenario) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Validate checks that the Scenario can be simulated.
+func (s *Scenario) Validate() error {
+	if s.Duration <= 0 || s.Rate <= 0 {
+		return errors.Errorf("duration and rate must be positive: %w", ErrInvalidScenario)
+	}
+	if s.MaxBufferSize <= 0 {
+		return errors.Errorf("maxBufferSize must be positive: %w", ErrInvalidScenario)
+	}
+	if len(s.Issuers) == 0 {
+		return errors.Errorf("no issuers: %w", ErrInvalidScenario)
+	}
+
+	names := make(map[string]bool, len(s.Issuers))
+	for _, group := range s.Issuers {
+		if group.Name == "" || names[group.Name] {
+			return errors.Errorf("issuer groups need a unique name (%q): %w", group.Name, ErrInvalidScenario)
+		}
+		names[group.Name] = true
+
+		switch group.Behavior {
+		case Honest, Malicious:
+		case Bursty:
+			if group.BurstSize <= 0 || group.BurstInterval <= 0 {
+				return errors.Errorf("bursty group %s needs a positive burstSize and burstInterval: %w", group.Name, ErrInvalidScenario)
+			}
+		default:
+			return errors.Errorf("unknown behavior %q of group %s: %w", group.Behavior, group.Name, ErrInvalidScenario)
+		}
+		if group.Count <= 0 || group.Mana <= 0 || group.Rate < 0 || group.InitialRate < 0 {
+			return errors.Errorf("group %s needs a positive count and mana, and a non-negative rate and initialRate: %w", group.Name, ErrInvalidScenario)
+		}
+		// the data payload adds a header of 8 bytes
+		if group.PayloadSize < 0 || group.PayloadSize > payload.MaxSize-8 {
+			return errors.Errorf("payloadSize of group %s must be between 0 and %d: %w", group.Name, payload.MaxSize-8, ErrInvalidScenario)
+		}
+	}
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
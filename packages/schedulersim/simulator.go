@@ -0,0 +1,222 @@
+package schedulersim
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// totalSupply is the total supply of the simulated ledger. The access mana of the issuers is given as a fraction of it.
+const totalSupply = 1000000000000000
+
+// epoch is the real time that corresponds to the start of the virtual time. It lies in the past, so that the messages
+// of the simulation are never considered to be issued in the future by the scheduler.
+var epoch = time.Unix(tangle.DefaultGenesisTime, 0)
+
+// region Run //////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Run simulates the given Scenario and returns the Report of the simulation. The messages are scheduled by the
+// Scheduler of a Tangle, which is driven by a virtual clock that advances by the scheduler rate in every step.
+func Run(scenario *Scenario) (*Report, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	s, err := newSimulation(scenario)
+	if err != nil {
+		return nil, err
+	}
+	defer s.tangle.Shutdown()
+
+	for s.now = 0; s.now < time.Duration(scenario.Duration); s.now += time.Duration(scenario.Rate) {
+		s.step()
+	}
+
+	return s.report(), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region simulation ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// simulation contains the state of a running simulation.
+type simulation struct {
+	scenario      *Scenario
+	tangle        *tangle.Tangle
+	random        *rand.Rand
+	issuers       []*issuer
+	issuersByID   map[identity.ID]*issuer
+	totalMana     float64
+	pending       map[tangle.MessageID]*pendingMessage
+	blacklistings []*BlacklistEvent
+	now           time.Duration
+}
+
+func newSimulation(scenario *Scenario) (*simulation, error) {
+	s := &simulation{
+		scenario:    scenario,
+		random:      rand.New(rand.NewSource(scenario.Seed)),
+		issuersByID: make(map[identity.ID]*issuer),
+		pending:     make(map[tangle.MessageID]*pendingMessage),
+	}
+
+	seedBytes := make([]byte, ed25519.SeedSize)
+	binary.LittleEndian.PutUint64(seedBytes, uint64(scenario.Seed))
+	seed := ed25519.NewSeed(seedBytes)
+	for _, group := range scenario.Issuers {
+		for n := 0; n < group.Count; n++ {
+			i := newIssuer(fmt.Sprintf("%s-%d", group.Name, n), group, seed.KeyPair(uint64(len(s.issuers))), group.Mana*totalSupply, s.random)
+			s.issuers = append(s.issuers, i)
+			s.issuersByID[i.identity.ID()] = i
+			s.totalMana += i.mana
+		}
+	}
+
+	s.tangle = tangle.New(
+		tangle.Store(mapdb.NewMapDB()),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			MaxBufferSize:               scenario.MaxBufferSize,
+			Rate:                        time.Duration(scenario.Rate),
+			AccessManaRetrieveFunc:      s.accessMana,
+			TotalAccessManaRetrieveFunc: func() float64 { return s.totalMana },
+		}),
+	)
+	// the maximum inbox length of the scheduler depends on the total supply, so it is created after the snapshot
+	s.tangle.Scheduler.Shutdown()
+	if err := s.tangle.LedgerState.LoadSnapshot(snapshot()); err != nil {
+		s.tangle.Shutdown()
+		return nil, errors.Errorf("failed to load the snapshot: %w", err)
+	}
+	s.tangle.Scheduler = tangle.NewScheduler(s.tangle)
+
+	s.tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(s.onMessageScheduled))
+	s.tangle.Scheduler.Events.NodeBlacklisted.Attach(events.NewClosure(s.onNodeBlacklisted))
+
+	return s, nil
+}
+
+// step advances the simulation by one tick of the scheduler.
+func (s *simulation) step() {
+	for _, i := range s.issuers {
+		for _, pending := range i.createMessages(s.now, epoch, s.random) {
+			if !i.group.Behavior.usesRateSetter() {
+				s.submit(pending)
+				continue
+			}
+			i.enqueue(pending)
+		}
+		if i.group.Behavior.usesRateSetter() {
+			if pending := i.dequeue(s.now); pending != nil {
+				s.submit(pending)
+			}
+		}
+	}
+
+	s.tangle.Scheduler.ScheduleNext()
+}
+
+// submit submits the message to the scheduler.
+func (s *simulation) submit(pending *pendingMessage) {
+	pending.submitted = s.now
+	pending.issuer.stats.submitted++
+
+	messageID := pending.message.ID()
+	s.tangle.Storage.StoreMessage(pending.message)
+	s.pending[messageID] = pending
+	blacklistings := len(s.blacklistings)
+	if err := s.tangle.Scheduler.SubmitAndReady(messageID); err != nil {
+		delete(s.pending, messageID)
+		pending.issuer.stats.discarded++
+	}
+
+	// the NodeBlacklisted event is triggered while the scheduler is locked, so the queue size is added afterwards
+	for _, event := range s.blacklistings[blacklistings:] {
+		event.QueueSize = s.tangle.Scheduler.NodeQueueSize(pending.issuer.identity.ID())
+	}
+}
+
+func (s *simulation) onMessageScheduled(messageID tangle.MessageID) {
+	pending, exists := s.pending[messageID]
+	if !exists {
+		return
+	}
+	delete(s.pending, messageID)
+
+	stats := &pending.issuer.stats
+	stats.scheduled++
+	stats.scheduledBytes += pending.message.Size()
+	stats.latencies = append(stats.latencies, s.now-pending.created)
+	stats.schedulingDelays = append(stats.schedulingDelays, s.now-pending.submitted)
+
+	for _, i := range s.issuers {
+		if i.group.Behavior.usesRateSetter() {
+			i.updateRate(s.tangle.Scheduler.NodeQueueSize(i.identity.ID()), s.totalMana)
+		}
+	}
+}
+
+func (s *simulation) onNodeBlacklisted(nodeID identity.ID) {
+	i, exists := s.issuersByID[nodeID]
+	if !exists {
+		return
+	}
+
+	if i.stats.blacklisted == 0 {
+		i.stats.firstBlacklisting = s.now
+	}
+	i.stats.blacklisted++
+	s.blacklistings = append(s.blacklistings, &BlacklistEvent{
+		Time:     Duration(s.now),
+		Issuer:   i.name,
+		NodeID:   nodeID.String(),
+		Behavior: i.group.Behavior,
+	})
+}
+
+func (s *simulation) accessMana(nodeID identity.ID) float64 {
+	if i, exists := s.issuersByID[nodeID]; exists {
+		return i.mana
+	}
+	return 0
+}
+
+// snapshot returns a snapshot with a single output holding the totalSupply.
+func snapshot() *ledgerstate.Snapshot {
+	output := ledgerstate.NewSigLockedColoredOutput(
+		ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{
+			ledgerstate.ColorIOTA: totalSupply,
+		}),
+		ledgerstate.NewED25519Address(ed25519.PublicKey{}),
+	)
+	tx := ledgerstate.NewTransaction(ledgerstate.NewTransactionEssence(
+		0,
+		epoch,
+		identity.ID{},
+		identity.ID{},
+		ledgerstate.NewInputs(ledgerstate.NewUTXOInput(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))),
+		ledgerstate.NewOutputs(output),
+	), ledgerstate.UnlockBlocks{ledgerstate.NewReferenceUnlockBlock(0)})
+
+	return &ledgerstate.Snapshot{
+		Transactions: map[ledgerstate.TransactionID]ledgerstate.Record{
+			tx.ID(): {
+				Essence:        tx.Essence(),
+				UnlockBlocks:   tx.UnlockBlocks(),
+				UnspentOutputs: []bool{true},
+			},
+		},
+		AccessManaByNode: map[identity.ID]ledgerstate.AccessMana{},
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,96 @@
+package schedulersim
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	scenario := &Scenario{
+		Duration:      Duration(10 * time.Second),
+		Rate:          Duration(5 * time.Millisecond),
+		MaxBufferSize: 100 * 1024,
+		Seed:          1,
+		Issuers: []*IssuerGroup{
+			{Name: "honest", Count: 4, Behavior: Honest, Mana: 0.2, Rate: 10, PayloadSize: 100},
+			{Name: "bursty", Count: 1, Behavior: Bursty, Mana: 0.1, Rate: 1, PayloadSize: 100, BurstSize: 50, BurstInterval: Duration(2 * time.Second)},
+			{Name: "malicious", Count: 1, Behavior: Malicious, Mana: 0.1, Rate: 500, PayloadSize: 100},
+		},
+	}
+
+	report, err := Run(scenario)
+	require.NoError(t, err)
+	require.Len(t, report.Nodes, 6)
+
+	for _, n := range report.Nodes {
+		assert.Greater(t, n.Scheduled, 0, n.Issuer)
+		assert.LessOrEqual(t, n.LatencyP50, n.LatencyP99, n.Issuer)
+		assert.LessOrEqual(t, n.LatencyP99, n.LatencyMax, n.Issuer)
+
+		switch n.Behavior {
+		case Honest:
+			// honest issuers keep their messages in the queue of the rate setter and are never blacklisted
+			assert.Zero(t, n.Dropped, n.Issuer)
+			assert.Zero(t, n.Blacklisted, n.Issuer)
+			assert.Zero(t, n.Discarded, n.Issuer)
+		case Malicious:
+			// the malicious issuer floods the scheduler and gets blacklisted
+			assert.Greater(t, n.Blacklisted, 0, n.Issuer)
+			assert.Greater(t, n.Discarded, 0, n.Issuer)
+			assert.NotNil(t, n.FirstBlacklisting, n.Issuer)
+		}
+	}
+	assert.NotEmpty(t, report.Blacklistings)
+	assert.InDelta(t, 1, report.Fairness.Index, 0.5)
+
+	var buffer bytes.Buffer
+	require.NoError(t, report.WriteNodesCSV(&buffer))
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 1+len(report.Nodes))
+
+	buffer.Reset()
+	require.NoError(t, report.WriteBlacklistingsCSV(&buffer))
+	records, err = csv.NewReader(&buffer).ReadAll()
+	require.NoError(t, err)
+	assert.Len(t, records, 1+len(report.Blacklistings))
+
+	buffer.Reset()
+	require.NoError(t, report.WriteJSON(&buffer))
+	assert.True(t, strings.Contains(buffer.String(), `"blacklistings"`))
+}
+
+func TestReadScenario(t *testing.T) {
+	scenario, err := ReadScenario(strings.NewReader(`{
+		"duration": "30s", "rate": "5ms", "maxBufferSize": 1024, "seed": 2,
+		"issuers": [{"name": "a", "count": 2, "behavior": "bursty", "mana": 0.1, "rate": 1, "burstSize": 10, "burstInterval": "5s"}]
+	}`))
+	require.NoError(t, err)
+	assert.Equal(t, Duration(30*time.Second), scenario.Duration)
+	assert.Equal(t, Duration(5*time.Second), scenario.Issuers[0].BurstInterval)
+
+	_, err = ReadScenario(strings.NewReader(`{"duration": "30s", "rate": "5ms", "maxBufferSize": 1024, "issuers": [{"name": "a", "count": 1, "behavior": "lazy", "mana": 0.1}]}`))
+	assert.ErrorIs(t, err, ErrInvalidScenario)
+
+	assert.NoError(t, DefaultScenario().Validate())
+}
+
+func TestPercentile(t *testing.T) {
+	durations := []time.Duration{5, 1, 4, 2, 3}
+	assert.Equal(t, time.Duration(1), percentile(durations, 0))
+	assert.Equal(t, time.Duration(3), percentile(durations, 50))
+	assert.Equal(t, time.Duration(5), percentile(durations, 99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 50))
+}
+
+func TestJainIndex(t *testing.T) {
+	assert.Equal(t, 1.0, jainIndex([]float64{2, 2, 2}))
+	assert.InDelta(t, 1.0/3, jainIndex([]float64{3, 0, 0}), 1e-9)
+	assert.Equal(t, 0.0, jainIndex(nil))
+}
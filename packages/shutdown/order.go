@@ -11,6 +11,8 @@ const (
 	PriorityFPC
 	// PriorityFaucet defines the shutdown priority for the faucet.
 	PriorityFaucet
+	// PriorityRemoteLogConn defines the shutdown priority for the connection to the remote log server.
+	PriorityRemoteLogConn
 	// PriorityRemoteLog defines the shutdown priority for remote log.
 	PriorityRemoteLog
 	// PriorityAnalysis defines the shutdown priority for analysis server.
@@ -27,6 +29,8 @@ const (
 	PriorityManualpeering
 	// PriorityWebAPI defines the shutdown priority for webapi.
 	PriorityWebAPI
+	// PriorityWebAPIEventStream defines the shutdown priority for the event stream of the webapi.
+	PriorityWebAPIEventStream
 	// PriorityDashboard defines the shutdown priority for dashboard.
 	PriorityDashboard
 	// PrioritySynchronization defines the shutdown priority for synchronization.
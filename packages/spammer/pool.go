@@ -0,0 +1,168 @@
+package spammer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// ErrNoOutputs is returned if the OutputPool has no output left to spend.
+var ErrNoOutputs = errors.New("no spendable outputs in the pool")
+
+// spentRetention is the time the pool remembers the outputs it spent, so that they are not picked up again by a
+// refresh before the spending transaction is booked.
+const spentRetention = time.Minute
+
+// UnspentOutputsFunc returns the unspent outputs of an address.
+type UnspentOutputsFunc = func(address ledgerstate.Address) ledgerstate.Outputs
+
+// OutputPool manages the outputs that the spammer uses to fund its transactions. The outputs belong to the first
+// addresses of a seed, new outputs are created on these addresses again, so that the pool keeps itself funded.
+type OutputPool struct {
+	seed               *ed25519.Seed
+	addresses          []ledgerstate.Address
+	indices            map[[ledgerstate.AddressLength]byte]uint64
+	unspentOutputsFunc UnspentOutputsFunc
+
+	available []ledgerstate.Output
+	// queued contains the IDs of the available outputs.
+	queued map[ledgerstate.OutputID]bool
+	// spent contains the outputs that were taken from the pool but might not be booked as spent in the ledger yet.
+	spent       map[ledgerstate.OutputID]time.Time
+	nextAddress int
+	mutex       sync.Mutex
+}
+
+// NewOutputPool creates an OutputPool that uses the first addressCount addresses of the given seed. The unspent outputs
+// of these addresses are looked up with the given function whenever the pool runs empty.
+func NewOutputPool(seed *ed25519.Seed, addressCount int, unspentOutputsFunc UnspentOutputsFunc) *OutputPool {
+	// double spends need two different target addresses
+	if addressCount < 2 {
+		addressCount = 2
+	}
+
+	pool := &OutputPool{
+		seed:               seed,
+		addresses:          make([]ledgerstate.Address, addressCount),
+		indices:            make(map[[ledgerstate.AddressLength]byte]uint64, addressCount),
+		unspentOutputsFunc: unspentOutputsFunc,
+		queued:             make(map[ledgerstate.OutputID]bool),
+		spent:              make(map[ledgerstate.OutputID]time.Time),
+	}
+	for i := range pool.addresses {
+		pool.addresses[i] = ledgerstate.NewED25519Address(seed.KeyPair(uint64(i)).PublicKey)
+		pool.indices[pool.addresses[i].Array()] = uint64(i)
+	}
+
+	return pool
+}
+
+// Addresses returns the addresses of the pool.
+func (p *OutputPool) Addresses() []ledgerstate.Address {
+	return p.addresses
+}
+
+// NextAddress returns the address of the pool that receives the next output (round robin).
+func (p *OutputPool) NextAddress() ledgerstate.Address {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	address := p.addresses[p.nextAddress]
+	p.nextAddress = (p.nextAddress + 1) % len(p.addresses)
+
+	return address
+}
+
+// Take removes an output from the pool and returns it together with the key pair that unlocks it. If the pool is
+// empty, it is refreshed from the ledger first.
+func (p *OutputPool) Take() (output ledgerstate.Output, keyPair *ed25519.KeyPair, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.available) == 0 {
+		p.refresh()
+	}
+	if len(p.available) == 0 {
+		return nil, nil, ErrNoOutputs
+	}
+
+	output = p.available[0]
+	p.available = p.available[1:]
+	delete(p.queued, output.ID())
+	p.spent[output.ID()] = time.Now()
+
+	return output, p.seed.KeyPair(p.indices[output.Address().Array()]), nil
+}
+
+// Add adds the outputs that belong to one of the addresses of the pool and can be unlocked by a signature.
+func (p *OutputPool) Add(outputs ...ledgerstate.Output) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, output := range outputs {
+		p.add(output)
+	}
+}
+
+// Return puts an output that was taken from the pool but not spent back into the pool.
+func (p *OutputPool) Return(output ledgerstate.Output) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.spent, output.ID())
+	p.add(output)
+}
+
+// Size returns the amount of outputs that are available in the pool.
+func (p *OutputPool) Size() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return len(p.available)
+}
+
+// Refresh adds the unspent outputs of the addresses of the pool that are not known to the pool yet.
+func (p *OutputPool) Refresh() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.refresh()
+}
+
+func (p *OutputPool) refresh() {
+	unspent := make(map[ledgerstate.OutputID]bool)
+	for _, address := range p.addresses {
+		for _, output := range p.unspentOutputsFunc(address) {
+			unspent[output.ID()] = true
+			p.add(output)
+		}
+	}
+
+	// outputs that are no longer unspent in the ledger don't need to be remembered anymore
+	for outputID, spentTime := range p.spent {
+		if !unspent[outputID] && time.Since(spentTime) > spentRetention {
+			delete(p.spent, outputID)
+		}
+	}
+}
+
+func (p *OutputPool) add(output ledgerstate.Output) {
+	switch output.Type() {
+	case ledgerstate.SigLockedSingleOutputType, ledgerstate.SigLockedColoredOutputType:
+	default:
+		return
+	}
+	if _, owned := p.indices[output.Address().Array()]; !owned {
+		return
+	}
+	if _, spent := p.spent[output.ID()]; spent || p.queued[output.ID()] {
+		return
+	}
+
+	p.queued[output.ID()] = true
+	p.available = append(p.available, output.UpdateMintingColor())
+}
@@ -0,0 +1,164 @@
+package spammer
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// DefaultScenarioName is the name of the scenario that only issues data payloads. It is used by Spammer.Start.
+const DefaultScenarioName = "data"
+
+// MaxPayloadSize is the largest Scenario.PayloadSize that still fits into a data payload (the payload size and type are
+// marshaled in front of the data).
+const MaxPayloadSize = payload.MaxSize - marshalutil.Uint32Size - payload.TypeLength
+
+// Kind is the kind of a payload issued by the spammer.
+type Kind string
+
+const (
+	// KindData is a small data payload.
+	KindData Kind = "data"
+	// KindValue is a value transaction that moves the funds of an output of the pool to another address of the pool.
+	KindValue Kind = "value"
+	// KindDoubleSpend is a pair of value transactions that spend the same output.
+	KindDoubleSpend Kind = "doubleSpend"
+	// KindAlias is a transaction that mints a new alias.
+	KindAlias Kind = "alias"
+	// KindNFT is a transaction that mints a new alias with immutable data.
+	KindNFT Kind = "nft"
+	// KindLargePayload is a data payload of Scenario.PayloadSize bytes.
+	KindLargePayload Kind = "largePayload"
+)
+
+// Kinds contains all the kinds of payloads the spammer can issue.
+var Kinds = []Kind{KindData, KindValue, KindDoubleSpend, KindAlias, KindNFT, KindLargePayload}
+
+// Scenario describes the load a spammer generates.
+type Scenario struct {
+	// Name identifies the scenario.
+	Name string `json:"name"`
+	// MPM is the amount of messages issued per minute.
+	MPM int `json:"mpm"`
+	// IMIF is the inter message issuing function, either "uniform" or "poisson".
+	IMIF string `json:"imif"`
+	// Mix defines the relative weights of the kinds of payloads that are issued.
+	Mix map[Kind]int `json:"mix"`
+	// DoubleSpendRatio is the fraction of value transactions that are issued as double spends. It is applied on top of
+	// the KindDoubleSpend weight of the Mix.
+	DoubleSpendRatio float64 `json:"doubleSpendRatio"`
+	// PayloadSize is the size in bytes of the payloads of kind KindLargePayload.
+	PayloadSize int `json:"payloadSize"`
+}
+
+// NewDataScenario returns a Scenario that only issues data payloads.
+func NewDataScenario(mpm int, imif string) *Scenario {
+	return &Scenario{
+		Name: DefaultScenarioName,
+		MPM:  mpm,
+		IMIF: imif,
+		Mix:  map[Kind]int{KindData: 1},
+	}
+}
+
+// Validate checks that the Scenario is well formed.
+func (s *Scenario) Validate() error {
+	if s.Name == "" {
+		return errors.New("scenario has no name")
+	}
+	if s.MPM <= 0 {
+		return errors.Errorf("scenario %s: mpm must be positive", s.Name)
+	}
+	if s.DoubleSpendRatio < 0 || s.DoubleSpendRatio > 1 {
+		return errors.Errorf("scenario %s: doubleSpendRatio must be within [0, 1]", s.Name)
+	}
+
+	for kind, weight := range s.Mix {
+		if !kind.valid() {
+			return errors.Errorf("scenario %s: unknown payload kind %s", s.Name, kind)
+		}
+		if weight < 0 {
+			return errors.Errorf("scenario %s: weight of %s must not be negative", s.Name, kind)
+		}
+	}
+	if s.Total() == 0 {
+		return errors.Errorf("scenario %s: mix is empty", s.Name)
+	}
+	if s.Mix[KindLargePayload] > 0 && s.PayloadSize <= 0 {
+		return errors.Errorf("scenario %s: payloadSize must be positive for large payloads", s.Name)
+	}
+	if s.PayloadSize > MaxPayloadSize {
+		return errors.Errorf("scenario %s: payloadSize must not exceed %d bytes", s.Name, MaxPayloadSize)
+	}
+
+	return nil
+}
+
+// Total returns the sum of the weights of the Mix.
+func (s *Scenario) Total() (total int) {
+	for _, kind := range Kinds {
+		total += s.Mix[kind]
+	}
+	return total
+}
+
+// NextKind randomly picks the kind of the next payload according to the Mix and the DoubleSpendRatio.
+func (s *Scenario) NextKind() Kind {
+	kind := KindData
+	r := rand.Intn(s.Total())
+	for _, candidate := range Kinds {
+		if r < s.Mix[candidate] {
+			kind = candidate
+			break
+		}
+		r -= s.Mix[candidate]
+	}
+
+	if kind == KindValue && s.DoubleSpendRatio > 0 && rand.Float64() < s.DoubleSpendRatio {
+		return KindDoubleSpend
+	}
+	return kind
+}
+
+func (k Kind) valid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadScenarios reads a JSON encoded list of scenarios from the given file and validates them.
+func LoadScenarios(path string) (scenarios map[string]*Scenario, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read scenario file %s: %w", path, err)
+	}
+
+	var list []*Scenario
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Errorf("failed to parse scenario file %s: %w", path, err)
+	}
+
+	scenarios = make(map[string]*Scenario, len(list))
+	for i, scenario := range list {
+		if scenario == nil {
+			return nil, errors.Errorf("failed to parse scenario file %s: scenario %d is null", path, i)
+		}
+		if err = scenario.Validate(); err != nil {
+			return nil, err
+		}
+		if _, exists := scenarios[scenario.Name]; exists {
+			return nil, errors.Errorf("scenario %s is defined more than once", scenario.Name)
+		}
+		scenarios[scenario.Name] = scenario
+	}
+
+	return scenarios, nil
+}
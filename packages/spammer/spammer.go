@@ -1,14 +1,19 @@
 package spammer
 
 import (
-	"math/rand"
+	"crypto/rand"
+	mathrand "math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/typeutils"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 )
@@ -16,30 +21,68 @@ import (
 // IssuePayloadFunc is a function which issues a payload.
 type IssuePayloadFunc = func(payload payload.Payload, parentsCount ...int) (*tangle.Message, error)
 
-// Spammer spams messages with a static data payload.
+// Spammer spams messages according to a Scenario.
 type Spammer struct {
 	issuePayloadFunc IssuePayloadFunc
 	log              *logger.Logger
+	outputPool       *OutputPool
+	pledgeID         identity.ID
+	stats            *statsTracker
+	scenario         string
+	scenarioMutex    sync.RWMutex
 	running          typeutils.AtomicBool
 	wg               sync.WaitGroup
 }
 
+// Option is a function that configures the Spammer.
+type Option func(*Spammer)
+
+// WithOutputPool sets the OutputPool that funds the transactions issued by the spammer. Without it, only data
+// payloads can be issued.
+func WithOutputPool(outputPool *OutputPool) Option {
+	return func(s *Spammer) {
+		s.outputPool = outputPool
+	}
+}
+
+// WithPledgeID sets the node the issued transactions pledge their access and consensus mana to.
+func WithPledgeID(pledgeID identity.ID) Option {
+	return func(s *Spammer) {
+		s.pledgeID = pledgeID
+	}
+}
+
 // New creates a new spammer.
-func New(issuePayloadFunc IssuePayloadFunc, log *logger.Logger) *Spammer {
-	return &Spammer{
+func New(issuePayloadFunc IssuePayloadFunc, log *logger.Logger, options ...Option) *Spammer {
+	s := &Spammer{
 		issuePayloadFunc: issuePayloadFunc,
 		log:              log,
+		stats:            newStatsTracker(),
 	}
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
 }
 
-// Start starts the spammer to spam with the given messages per time unit,
+// Start starts the spammer to spam data payloads with the given messages per time unit,
 // according to a inter message issuing function (IMIF)
 func (s *Spammer) Start(rate int, timeUnit time.Duration, imif string) {
-	// only start if not yet running
-	if s.running.SetToIf(false, true) {
-		s.wg.Add(1)
-		go s.run(rate, timeUnit, imif)
+	s.start(NewDataScenario(rate, imif), rate, timeUnit)
+}
+
+// StartScenario starts the spammer to issue messages according to the given Scenario.
+func (s *Spammer) StartScenario(scenario *Scenario) error {
+	if err := scenario.Validate(); err != nil {
+		return err
+	}
+	if s.outputPool == nil && scenario.Mix[KindData]+scenario.Mix[KindLargePayload] != scenario.Total() {
+		return errors.Errorf("scenario %s issues transactions, but the spammer has no output pool", scenario.Name)
 	}
+
+	s.start(scenario, scenario.MPM, time.Minute)
+	return nil
 }
 
 // Shutdown shuts down the spammer.
@@ -48,7 +91,50 @@ func (s *Spammer) Shutdown() {
 	s.wg.Wait()
 }
 
-func (s *Spammer) run(rate int, timeUnit time.Duration, imif string) {
+// Scenario returns the name of the running scenario or an empty string if the spammer is not running.
+func (s *Spammer) Scenario() string {
+	if !s.running.IsSet() {
+		return ""
+	}
+
+	s.scenarioMutex.RLock()
+	defer s.scenarioMutex.RUnlock()
+
+	return s.scenario
+}
+
+// MessageConfirmed marks the given message as confirmed in the statistics if it was issued by the spammer.
+func (s *Spammer) MessageConfirmed(messageID tangle.MessageID) {
+	s.stats.confirmed(messageID)
+}
+
+// MessageRejected marks the given message as rejected in the statistics if it was issued by the spammer.
+func (s *Spammer) MessageRejected(messageID tangle.MessageID) {
+	s.stats.rejected(messageID)
+}
+
+// Stats returns the statistics of all the scenarios that were run, sorted by name.
+func (s *Spammer) Stats() (stats []*ScenarioStats) {
+	stats = s.stats.stats()
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Scenario < stats[j].Scenario })
+
+	return stats
+}
+
+func (s *Spammer) start(scenario *Scenario, rate int, timeUnit time.Duration) {
+	// only start if not yet running
+	if s.running.SetToIf(false, true) {
+		s.scenarioMutex.Lock()
+		s.scenario = scenario.Name
+		s.scenarioMutex.Unlock()
+
+		s.stats.started(scenario.Name)
+		s.wg.Add(1)
+		go s.run(scenario, rate, timeUnit)
+	}
+}
+
+func (s *Spammer) run(scenario *Scenario, rate int, timeUnit time.Duration) {
 	defer s.wg.Done()
 
 	// emit messages every msgInterval interval, when IMIF is other than exponential
@@ -61,22 +147,23 @@ func (s *Spammer) run(rate int, timeUnit time.Duration, imif string) {
 			return
 		}
 
-		// we don't care about errors or the actual issued message
-		_, err := s.issuePayloadFunc(payload.NewGenericDataPayload([]byte("SPAM")))
+		kind := scenario.NextKind()
+		err := s.issue(scenario, kind)
 		if errors.Is(err, tangle.ErrNotSynced) {
 			s.log.Info("Stopped spamming messages because node lost sync")
 			s.running.SetTo(false)
 			return
 		}
 		if err != nil {
+			s.stats.failed(scenario.Name, kind)
 			s.log.Warnf("could not issue spam payload: %s", err)
 		}
 
 		currentInterval := time.Since(start)
 
-		if imif == "poisson" {
+		if scenario.IMIF == "poisson" {
 			// emit messages modeled with Poisson point process, whose time intervals are exponential variables with mean 1/rate
-			msgInterval = time.Duration(float64(timeUnit.Nanoseconds()) * rand.ExpFloat64() / float64(rate))
+			msgInterval = time.Duration(float64(timeUnit.Nanoseconds()) * mathrand.ExpFloat64() / float64(rate))
 		}
 
 		if currentInterval < msgInterval {
@@ -86,3 +173,106 @@ func (s *Spammer) run(rate int, timeUnit time.Duration, imif string) {
 		// when currentInterval > msgInterval, the node can't issue msgs as fast as requested, will do as fast as it can
 	}
 }
+
+// issue creates and issues the payloads of the given kind.
+func (s *Spammer) issue(scenario *Scenario, kind Kind) (err error) {
+	var payloads []payload.Payload
+	switch kind {
+	case KindData:
+		payloads = []payload.Payload{payload.NewGenericDataPayload([]byte("SPAM"))}
+	case KindLargePayload:
+		data := make([]byte, scenario.PayloadSize)
+		if _, err = rand.Read(data); err != nil {
+			return err
+		}
+		payloads = []payload.Payload{payload.NewGenericDataPayload(data)}
+	case KindValue:
+		payloads, err = s.valueTransactions(1)
+	case KindDoubleSpend:
+		payloads, err = s.valueTransactions(2)
+	case KindAlias:
+		payloads, err = s.aliasTransaction(nil)
+	case KindNFT:
+		immutableData := make([]byte, 32)
+		if _, err = rand.Read(immutableData); err != nil {
+			return err
+		}
+		payloads, err = s.aliasTransaction(immutableData)
+	default:
+		return errors.Errorf("unknown payload kind %s", kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, p := range payloads {
+		msg, issueErr := s.issuePayloadFunc(p)
+		if issueErr != nil {
+			return issueErr
+		}
+		s.stats.issued(scenario.Name, kind, msg.ID())
+
+		// the outputs of transactions that are not part of a double spend are used for the next transactions
+		if tx, ok := p.(*ledgerstate.Transaction); ok && len(payloads) == 1 {
+			s.outputPool.Add(tx.Essence().Outputs()...)
+		}
+	}
+
+	return nil
+}
+
+// valueTransactions creates count transactions that move the funds of the same output of the pool to different
+// addresses of the pool. If count is greater than 1, the transactions are conflicting.
+func (s *Spammer) valueTransactions(count int) (payloads []payload.Payload, err error) {
+	input, keyPair, err := s.outputPool.Take()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < count; i++ {
+		output := ledgerstate.NewSigLockedColoredOutput(input.Balances().Clone(), s.outputPool.NextAddress())
+		payloads = append(payloads, s.transaction(input, keyPair, output))
+	}
+
+	return payloads, nil
+}
+
+// aliasTransaction creates a transaction that mints a new alias, which is controlled by the address of the spent
+// output. The alias is an NFT if immutableData is given.
+func (s *Spammer) aliasTransaction(immutableData []byte) (payloads []payload.Payload, err error) {
+	input, keyPair, err := s.outputPool.Take()
+	if err != nil {
+		return nil, err
+	}
+
+	balances := input.Balances().Map()
+	if balances[ledgerstate.ColorIOTA] < ledgerstate.DustThresholdAliasOutputIOTA {
+		s.outputPool.Return(input)
+		return nil, errors.Errorf("output %s holds not enough funds to mint an alias", input.ID().Base58())
+	}
+
+	aliasOutput, err := ledgerstate.NewAliasOutputMint(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: ledgerstate.DustThresholdAliasOutputIOTA}, input.Address(), immutableData)
+	if err != nil {
+		s.outputPool.Return(input)
+		return nil, err
+	}
+	outputs := []ledgerstate.Output{aliasOutput}
+
+	balances[ledgerstate.ColorIOTA] -= ledgerstate.DustThresholdAliasOutputIOTA
+	if balances[ledgerstate.ColorIOTA] == 0 {
+		delete(balances, ledgerstate.ColorIOTA)
+	}
+	if len(balances) != 0 {
+		outputs = append(outputs, ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(balances), input.Address()))
+	}
+
+	return []payload.Payload{s.transaction(input, keyPair, outputs...)}, nil
+}
+
+// transaction creates a transaction that spends the given input.
+func (s *Spammer) transaction(input ledgerstate.Output, keyPair *ed25519.KeyPair, outputs ...ledgerstate.Output) *ledgerstate.Transaction {
+	essence := ledgerstate.NewTransactionEssence(0, time.Now(), s.pledgeID, s.pledgeID, ledgerstate.NewInputs(ledgerstate.NewUTXOInput(input.ID())), ledgerstate.NewOutputs(outputs...))
+	signature := ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes()))
+
+	return ledgerstate.NewTransaction(essence, ledgerstate.UnlockBlocks{ledgerstate.NewSignatureUnlockBlock(signature)})
+}
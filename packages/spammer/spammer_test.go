@@ -0,0 +1,179 @@
+package spammer
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestScenario_Validate(t *testing.T) {
+	assert.NoError(t, NewDataScenario(10, "uniform").Validate())
+	assert.Error(t, (&Scenario{Name: "empty", MPM: 10}).Validate())
+	assert.Error(t, (&Scenario{Name: "unknown", MPM: 10, Mix: map[Kind]int{"foo": 1}}).Validate())
+	assert.Error(t, (&Scenario{Name: "size", MPM: 10, Mix: map[Kind]int{KindLargePayload: 1}}).Validate())
+	assert.NoError(t, (&Scenario{Name: "max", MPM: 10, Mix: map[Kind]int{KindLargePayload: 1}, PayloadSize: MaxPayloadSize}).Validate())
+	assert.Error(t, (&Scenario{Name: "max", MPM: 10, Mix: map[Kind]int{KindLargePayload: 1}, PayloadSize: MaxPayloadSize + 1}).Validate())
+	assert.Error(t, (&Scenario{Name: "ratio", MPM: 10, Mix: map[Kind]int{KindValue: 1}, DoubleSpendRatio: 2}).Validate())
+}
+
+func TestScenario_NextKind(t *testing.T) {
+	scenario := &Scenario{Name: "value", MPM: 10, Mix: map[Kind]int{KindValue: 1}, DoubleSpendRatio: 1}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, KindDoubleSpend, scenario.NextKind())
+	}
+
+	scenario = &Scenario{Name: "mixed", MPM: 10, Mix: map[Kind]int{KindData: 1, KindNFT: 0}}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, KindData, scenario.NextKind())
+	}
+}
+
+func TestLoadScenarios(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenarios.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "mixed", "mpm": 600, "mix": {"data": 2, "value": 5, "nft": 1, "largePayload": 1}, "doubleSpendRatio": 0.1, "payloadSize": 4096}
+	]`), 0o600))
+
+	scenarios, err := LoadScenarios(path)
+	require.NoError(t, err)
+	require.Contains(t, scenarios, "mixed")
+	assert.Equal(t, 600, scenarios["mixed"].MPM)
+	assert.Equal(t, 5, scenarios["mixed"].Mix[KindValue])
+	assert.Equal(t, 0.1, scenarios["mixed"].DoubleSpendRatio)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "a", "mpm": 1, "mix": {"data": 1}}, {"name": "a", "mpm": 1, "mix": {"data": 1}}]`), 0o600))
+	_, err = LoadScenarios(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "a", "mpm": 1, "mix": {"data": 1}}, null]`), 0o600))
+	_, err = LoadScenarios(path)
+	assert.Error(t, err)
+}
+
+func TestStatsTracker(t *testing.T) {
+	s := newStatsTracker()
+	messageIDs := make([]tangle.MessageID, 4)
+	for i := range messageIDs {
+		messageIDs[i][0] = byte(i)
+		s.issued("test", KindData, messageIDs[i])
+	}
+
+	s.confirmed(messageIDs[0])
+	s.rejected(messageIDs[1])
+	// messages that are no longer tracked are not counted again
+	s.rejected(messageIDs[0])
+
+	// messages that are not confirmed within the TTL are dropped
+	s.tracked[messageIDs[2]].issued = time.Now().Add(-trackingTTL)
+	stats := s.stats()[0].Kinds[KindData]
+	assert.EqualValues(t, 4, stats.Issued)
+	assert.EqualValues(t, 1, stats.Confirmed)
+	assert.EqualValues(t, 1, stats.Rejected)
+	assert.EqualValues(t, 1, stats.Dropped)
+	assert.Len(t, s.tracked, 1)
+	assert.Equal(t, 1, s.issuance.Len())
+}
+
+func TestSpammer_Issue(t *testing.T) {
+	seed := ed25519.NewSeed()
+	genesis := ledgerstate.NewSigLockedSingleOutput(1000, ledgerstate.NewED25519Address(seed.KeyPair(0).PublicKey))
+	genesis.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
+	pool := NewOutputPool(seed, 2, func(address ledgerstate.Address) ledgerstate.Outputs {
+		if address.Equals(genesis.Address()) {
+			return ledgerstate.Outputs{genesis}
+		}
+		return nil
+	})
+
+	issuer := &testIssuer{}
+	s := New(issuer.issuePayload, newTestLogger(t), WithOutputPool(pool), WithPledgeID(identity.GenerateIdentity().ID()))
+	scenario := &Scenario{Name: "test", MPM: 1, Mix: map[Kind]int{KindValue: 1}, PayloadSize: 1000}
+
+	// value transactions keep the pool funded
+	for i := 0; i < 3; i++ {
+		require.NoError(t, s.issue(scenario, KindValue))
+		assert.Equal(t, 1, pool.Size())
+	}
+
+	// the alias takes the dust threshold from the output, the remainder is put back into the pool
+	require.NoError(t, s.issue(scenario, KindNFT))
+	assert.Equal(t, 1, pool.Size())
+	nftTx := issuer.payloads[len(issuer.payloads)-1].(*ledgerstate.Transaction)
+	assert.Len(t, nftTx.Essence().Outputs(), 2)
+
+	// double spends consume the output without putting anything back
+	require.NoError(t, s.issue(scenario, KindDoubleSpend))
+	assert.Equal(t, 0, pool.Size())
+	doubleSpends := issuer.payloads[len(issuer.payloads)-2:]
+	assert.Equal(t,
+		doubleSpends[0].(*ledgerstate.Transaction).Essence().Inputs()[0],
+		doubleSpends[1].(*ledgerstate.Transaction).Essence().Inputs()[0],
+	)
+	assert.ErrorIs(t, s.issue(scenario, KindValue), ErrNoOutputs)
+
+	require.NoError(t, s.issue(scenario, KindLargePayload))
+	assert.Len(t, issuer.payloads[len(issuer.payloads)-1].(*payload.GenericDataPayload).Blob(), 1000)
+
+	s.MessageConfirmed(issuer.messages[0].ID())
+	stats := s.Stats()
+	require.Len(t, stats, 1)
+	assert.EqualValues(t, 3, stats[0].Kinds[KindValue].Issued)
+	assert.EqualValues(t, 1, stats[0].Kinds[KindValue].Confirmed)
+	assert.EqualValues(t, 2, stats[0].Kinds[KindDoubleSpend].Issued)
+	assert.EqualValues(t, 7, stats[0].Total().Issued)
+}
+
+func TestSpammer_StartScenario(t *testing.T) {
+	issuer := &testIssuer{}
+	s := New(issuer.issuePayload, newTestLogger(t))
+
+	assert.Error(t, s.StartScenario(&Scenario{Name: "value", MPM: 60, Mix: map[Kind]int{KindValue: 1}}))
+
+	require.NoError(t, s.StartScenario(&Scenario{Name: "data", MPM: 6000, Mix: map[Kind]int{KindData: 1}}))
+	assert.Equal(t, "data", s.Scenario())
+	assert.Eventually(t, func() bool { return issuer.count() >= 2 }, 5*time.Second, 10*time.Millisecond)
+	s.Shutdown()
+	assert.Equal(t, "", s.Scenario())
+}
+
+type testIssuer struct {
+	payloads []payload.Payload
+	messages []*tangle.Message
+	mutex    sync.Mutex
+}
+
+func (i *testIssuer) issuePayload(p payload.Payload, _ ...int) (*tangle.Message, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	msg := tangle.NewMessage([]tangle.MessageID{tangle.EmptyMessageID}, nil, time.Now(), ed25519.PublicKey{}, uint64(len(i.messages)), p, 0, ed25519.Signature{})
+	i.payloads = append(i.payloads, p)
+	i.messages = append(i.messages, msg)
+
+	return msg, nil
+}
+
+func (i *testIssuer) count() int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return len(i.messages)
+}
+
+func newTestLogger(t *testing.T) *zap.SugaredLogger {
+	log, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	return log.Sugar()
+}
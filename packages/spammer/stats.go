@@ -0,0 +1,221 @@
+package spammer
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// maxTrackedMessages bounds the amount of issued messages whose confirmation is tracked. The oldest message is
+	// dropped when a new one is issued while the limit is reached.
+	maxTrackedMessages = 100000
+
+	// trackingTTL is the time after which an issued message that was not confirmed is no longer tracked.
+	trackingTTL = 10 * time.Minute
+)
+
+// KindStats contains the issuance and confirmation counters of a kind of payload.
+type KindStats struct {
+	// Issued is the amount of messages that were issued.
+	Issued uint64
+	// Failed is the amount of messages that could not be created or issued.
+	Failed uint64
+	// Confirmed is the amount of issued messages that were confirmed.
+	Confirmed uint64
+	// Rejected is the amount of issued messages that were found to be invalid.
+	Rejected uint64
+	// Dropped is the amount of issued messages whose confirmation was no longer tracked, because they were not
+	// confirmed within the trackingTTL or too many messages were tracked.
+	Dropped uint64
+	// ConfirmationTime is the sum of the times it took the confirmed messages to be confirmed after issuance.
+	ConfirmationTime time.Duration
+}
+
+// AvgConfirmationTime returns the average time it took the confirmed messages to be confirmed.
+func (k *KindStats) AvgConfirmationTime() time.Duration {
+	if k.Confirmed == 0 {
+		return 0
+	}
+	return k.ConfirmationTime / time.Duration(k.Confirmed)
+}
+
+// ScenarioStats contains the statistics of a scenario.
+type ScenarioStats struct {
+	// Scenario is the name of the scenario.
+	Scenario string
+	// Started is the time the scenario was last started.
+	Started time.Time
+	// Kinds contains the counters per kind of payload.
+	Kinds map[Kind]*KindStats
+}
+
+// Total returns the counters summed up over all kinds of payloads.
+func (s *ScenarioStats) Total() (total *KindStats) {
+	total = &KindStats{}
+	for _, kindStats := range s.Kinds {
+		total.Issued += kindStats.Issued
+		total.Failed += kindStats.Failed
+		total.Confirmed += kindStats.Confirmed
+		total.Rejected += kindStats.Rejected
+		total.Dropped += kindStats.Dropped
+		total.ConfirmationTime += kindStats.ConfirmationTime
+	}
+	return total
+}
+
+func (s *ScenarioStats) clone() *ScenarioStats {
+	clone := &ScenarioStats{
+		Scenario: s.Scenario,
+		Started:  s.Started,
+		Kinds:    make(map[Kind]*KindStats, len(s.Kinds)),
+	}
+	for kind, kindStats := range s.Kinds {
+		kindStatsCopy := *kindStats
+		clone.Kinds[kind] = &kindStatsCopy
+	}
+	return clone
+}
+
+// trackedMessage is a message issued by the spammer that was not confirmed yet.
+type trackedMessage struct {
+	scenario string
+	kind     Kind
+	issued   time.Time
+}
+
+// statsTracker keeps the statistics of all the scenarios that ran.
+type statsTracker struct {
+	scenarios map[string]*ScenarioStats
+	tracked   map[tangle.MessageID]*trackedMessage
+	// issuance contains the IDs of the tracked messages in the order they were issued (IDs of messages that are no
+	// longer tracked are skipped when they reach the front).
+	issuance *list.List
+	mutex    sync.Mutex
+}
+
+func newStatsTracker() *statsTracker {
+	return &statsTracker{
+		scenarios: make(map[string]*ScenarioStats),
+		tracked:   make(map[tangle.MessageID]*trackedMessage),
+		issuance:  list.New(),
+	}
+}
+
+func (s *statsTracker) started(scenario string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.scenarioStats(scenario).Started = time.Now()
+}
+
+func (s *statsTracker) issued(scenario string, kind Kind, messageID tangle.MessageID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	s.dropExpired(now)
+	for len(s.tracked) >= maxTrackedMessages {
+		s.dropOldest()
+	}
+
+	s.kindStats(scenario, kind).Issued++
+	s.tracked[messageID] = &trackedMessage{scenario: scenario, kind: kind, issued: now}
+	s.issuance.PushBack(messageID)
+}
+
+func (s *statsTracker) failed(scenario string, kind Kind) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.kindStats(scenario, kind).Failed++
+}
+
+func (s *statsTracker) confirmed(messageID tangle.MessageID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	message, tracked := s.tracked[messageID]
+	if !tracked {
+		return
+	}
+	delete(s.tracked, messageID)
+
+	kindStats := s.kindStats(message.scenario, message.kind)
+	kindStats.Confirmed++
+	kindStats.ConfirmationTime += time.Since(message.issued)
+}
+
+func (s *statsTracker) rejected(messageID tangle.MessageID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	message, tracked := s.tracked[messageID]
+	if !tracked {
+		return
+	}
+	delete(s.tracked, messageID)
+
+	s.kindStats(message.scenario, message.kind).Rejected++
+}
+
+func (s *statsTracker) stats() (stats []*ScenarioStats) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.dropExpired(time.Now())
+
+	stats = make([]*ScenarioStats, 0, len(s.scenarios))
+	for _, scenarioStats := range s.scenarios {
+		stats = append(stats, scenarioStats.clone())
+	}
+	return stats
+}
+
+// dropExpired stops tracking the messages that were issued more than trackingTTL before the given time.
+func (s *statsTracker) dropExpired(now time.Time) {
+	for front := s.issuance.Front(); front != nil; front = s.issuance.Front() {
+		messageID := front.Value.(tangle.MessageID)
+		if message, tracked := s.tracked[messageID]; tracked {
+			if now.Sub(message.issued) < trackingTTL {
+				return
+			}
+			delete(s.tracked, messageID)
+			s.kindStats(message.scenario, message.kind).Dropped++
+		}
+		s.issuance.Remove(front)
+	}
+}
+
+// dropOldest stops tracking the message that was issued first.
+func (s *statsTracker) dropOldest() {
+	for front := s.issuance.Front(); front != nil; front = s.issuance.Front() {
+		messageID := s.issuance.Remove(front).(tangle.MessageID)
+		if message, tracked := s.tracked[messageID]; tracked {
+			delete(s.tracked, messageID)
+			s.kindStats(message.scenario, message.kind).Dropped++
+			return
+		}
+	}
+}
+
+func (s *statsTracker) scenarioStats(scenario string) *ScenarioStats {
+	scenarioStats, exists := s.scenarios[scenario]
+	if !exists {
+		scenarioStats = &ScenarioStats{Scenario: scenario, Kinds: make(map[Kind]*KindStats)}
+		s.scenarios[scenario] = scenarioStats
+	}
+	return scenarioStats
+}
+
+func (s *statsTracker) kindStats(scenario string, kind Kind) *KindStats {
+	scenarioStats := s.scenarioStats(scenario)
+	kindStats, exists := scenarioStats.Kinds[kind]
+	if !exists {
+		kindStats = &KindStats{}
+		scenarioStats.Kinds[kind] = kindStats
+	}
+	return kindStats
+}
@@ -276,6 +276,12 @@ func (l *LedgerState) CheckTransaction(transaction *ledgerstate.Transaction) (er
 	return l.UTXODAG.CheckTransaction(transaction)
 }
 
+// ValidateTransaction performs a dry-run validation of the given Transaction against the current ledger state and
+// returns all TransactionViolations without booking the Transaction.
+func (l *LedgerState) ValidateTransaction(transaction *ledgerstate.Transaction) (violations ledgerstate.TransactionViolations) {
+	return l.UTXODAG.ValidateTransaction(transaction)
+}
+
 // ConsumedOutputs returns the consumed (cached)Outputs of the given Transaction.
 func (l *LedgerState) ConsumedOutputs(transaction *ledgerstate.Transaction) (cachedInputs ledgerstate.CachedOutputs) {
 	return l.UTXODAG.ConsumedOutputs(transaction)
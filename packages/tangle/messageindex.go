@@ -0,0 +1,299 @@
+package tangle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// region MessageIndexEntry ////////////////////////////////////////////////////////////////////////////////////////////
+
+// MessageIndexEntryLength holds the length of a marshaled MessageIndexEntry in bytes.
+const MessageIndexEntryLength = marshalutil.Uint32Size + marshalutil.Uint64Size + ed25519.PublicKeySize + MessageIDLength
+
+const (
+	// messageIndexByTime marks the keys of the message index that are ordered by payload type and issuing time.
+	messageIndexByTime byte = iota
+	// messageIndexByIssuer marks the keys of the message index that are ordered by payload type, issuer and issuing
+	// time.
+	messageIndexByIssuer
+)
+
+// MessageIndexEntry is an entry of the secondary index of the Messages that is keyed by payload type and issuing time.
+// Every entry is stored twice: once ordered by payload type and issuing time and once ordered by payload type, issuer
+// and issuing time, so that the queries with and without an issuer can start at the requested time and stop after the
+// requested page. The keys are encoded in big endian, so that the database orders them by the issuing time.
+type MessageIndexEntry struct {
+	payloadType payload.Type
+	issuingTime time.Time
+	issuer      ed25519.PublicKey
+	messageID   MessageID
+}
+
+// NewMessageIndexEntry creates the MessageIndexEntry of the given Message.
+func NewMessageIndexEntry(message *Message) *MessageIndexEntry {
+	return &MessageIndexEntry{
+		payloadType: message.Payload().Type(),
+		issuingTime: message.IssuingTime(),
+		issuer:      message.IssuerPublicKey(),
+		messageID:   message.ID(),
+	}
+}
+
+// MessageIndexEntryFromBytes unmarshals a MessageIndexEntry from a sequence of bytes.
+func MessageIndexEntryFromBytes(bytes []byte) (entry *MessageIndexEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if entry, err = MessageIndexEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MessageIndexEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MessageIndexEntryFromMarshalUtil unmarshals a MessageIndexEntry using a MarshalUtil (for easier unmarshaling).
+func MessageIndexEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (entry *MessageIndexEntry, err error) {
+	entryBytes, err := marshalUtil.ReadBytes(MessageIndexEntryLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse MessageIndexEntry (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	entry = &MessageIndexEntry{}
+	entry.payloadType = payload.Type(binary.BigEndian.Uint32(entryBytes))
+	offset := marshalutil.Uint32Size
+	entry.issuingTime = time.Unix(0, int64(binary.BigEndian.Uint64(entryBytes[offset:])))
+	offset += marshalutil.Uint64Size
+	copy(entry.issuer[:], entryBytes[offset:offset+ed25519.PublicKeySize])
+	offset += ed25519.PublicKeySize
+	copy(entry.messageID[:], entryBytes[offset:])
+
+	return
+}
+
+// messageIndexEntryFromKey restores the MessageIndexEntry from one of its keys in the message index.
+func messageIndexEntryFromKey(key []byte) (entry *MessageIndexEntry, err error) {
+	if len(key) != 1+MessageIndexEntryLength {
+		return nil, errors.Errorf("message index key has the wrong length (%d): %w", len(key), cerrors.ErrParseBytesFailed)
+	}
+
+	switch key[0] {
+	case messageIndexByTime:
+		entry, _, err = MessageIndexEntryFromBytes(key[1:])
+	case messageIndexByIssuer:
+		// move the issuer behind the issuing time to restore the layout of Bytes
+		issuerOffset := 1 + marshalutil.Uint32Size
+		timeOffset := issuerOffset + ed25519.PublicKeySize
+		entry, _, err = MessageIndexEntryFromBytes(byteutils.ConcatBytes(
+			key[1:issuerOffset],
+			key[timeOffset:timeOffset+marshalutil.Uint64Size],
+			key[issuerOffset:timeOffset],
+			key[timeOffset+marshalutil.Uint64Size:],
+		))
+	default:
+		err = errors.Errorf("unknown message index key type %d: %w", key[0], cerrors.ErrParseBytesFailed)
+	}
+
+	return
+}
+
+// PayloadType returns the payload type of the indexed Message.
+func (m *MessageIndexEntry) PayloadType() payload.Type {
+	return m.payloadType
+}
+
+// Issuer returns the public key of the issuer of the indexed Message.
+func (m *MessageIndexEntry) Issuer() ed25519.PublicKey {
+	return m.issuer
+}
+
+// IssuingTime returns the issuing time of the indexed Message.
+func (m *MessageIndexEntry) IssuingTime() time.Time {
+	return m.issuingTime
+}
+
+// MessageID returns the MessageID of the indexed Message.
+func (m *MessageIndexEntry) MessageID() MessageID {
+	return m.messageID
+}
+
+// Bytes returns a marshaled version of the MessageIndexEntry.
+func (m *MessageIndexEntry) Bytes() []byte {
+	return byteutils.ConcatBytes(messageIndexPrefix(m.payloadType, nil), messageIndexTime(m.issuingTime), m.issuer[:], m.messageID.Bytes())
+}
+
+// String returns a human readable version of the MessageIndexEntry.
+func (m *MessageIndexEntry) String() string {
+	return stringify.Struct("MessageIndexEntry",
+		stringify.StructField("payloadType", m.payloadType),
+		stringify.StructField("issuingTime", m.issuingTime),
+		stringify.StructField("issuer", m.issuer),
+		stringify.StructField("messageID", m.messageID),
+	)
+}
+
+// timeKey returns the key of the entry in the part of the message index that is ordered by payload type and issuing
+// time.
+func (m *MessageIndexEntry) timeKey() []byte {
+	return byteutils.ConcatBytes([]byte{messageIndexByTime}, m.Bytes())
+}
+
+// issuerKey returns the key of the entry in the part of the message index that is ordered by payload type, issuer and
+// issuing time.
+func (m *MessageIndexEntry) issuerKey() []byte {
+	return byteutils.ConcatBytes(messageIndexPrefix(m.payloadType, &m.issuer), messageIndexTime(m.issuingTime), m.messageID.Bytes())
+}
+
+// messageIndexPrefix returns the encoded payload type that starts the keys of the message index. If an issuer is given,
+// it returns the prefix of the part of the index that is ordered by issuer.
+func messageIndexPrefix(payloadType payload.Type, issuer *ed25519.PublicKey) []byte {
+	prefix := make([]byte, marshalutil.Uint32Size, 1+marshalutil.Uint32Size+ed25519.PublicKeySize)
+	binary.BigEndian.PutUint32(prefix, uint32(payloadType))
+	if issuer != nil {
+		prefix = append(append([]byte{messageIndexByIssuer}, prefix...), issuer[:]...)
+	}
+
+	return prefix
+}
+
+// messageIndexTime returns the big endian encoding of the issuing time that is used in the key.
+func messageIndexTime(issuingTime time.Time) []byte {
+	timeBytes := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(timeBytes, uint64(issuingTime.UnixNano()))
+
+	return timeBytes
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MessageIndexQuery ////////////////////////////////////////////////////////////////////////////////////////////
+
+// MessageIndexQuery describes a page of the entries of the message index.
+type MessageIndexQuery struct {
+	// PayloadType is the payload type of the returned Messages.
+	PayloadType payload.Type
+	// Issuer optionally restricts the result to the Messages of a single issuer.
+	Issuer *ed25519.PublicKey
+	// From optionally restricts the result to the Messages that were issued at or after the given time.
+	From time.Time
+	// To optionally restricts the result to the Messages that were issued before the given time.
+	To time.Time
+	// After optionally continues a previous query after the given entry (the last entry of the previous page).
+	After *MessageIndexEntry
+	// Limit is the maximum number of returned entries (0 means no limit).
+	Limit int
+}
+
+// prefix returns the prefix of the keys of the message index that are returned by the query.
+func (m *MessageIndexQuery) prefix() []byte {
+	if m.Issuer != nil {
+		return messageIndexPrefix(m.PayloadType, m.Issuer)
+	}
+
+	return byteutils.ConcatBytes([]byte{messageIndexByTime}, messageIndexPrefix(m.PayloadType, nil))
+}
+
+// key returns the key of the given entry in the part of the message index that is used by the query.
+func (m *MessageIndexQuery) key(entry *MessageIndexEntry) []byte {
+	if m.Issuer != nil {
+		return byteutils.ConcatBytes(m.prefix(), messageIndexTime(entry.issuingTime), entry.messageID.Bytes())
+	}
+
+	return byteutils.ConcatBytes(m.prefix(), messageIndexTime(entry.issuingTime), entry.issuer[:], entry.messageID.Bytes())
+}
+
+// start returns the key that the iteration of the query starts at: the issuing time of its cursor or the start of its
+// time range.
+func (m *MessageIndexQuery) start() []byte {
+	switch {
+	case m.After != nil && !m.After.issuingTime.Before(m.From):
+		return byteutils.ConcatBytes(m.prefix(), messageIndexTime(m.After.issuingTime))
+	case !m.From.IsZero():
+		return byteutils.ConcatBytes(m.prefix(), messageIndexTime(m.From))
+	default:
+		return m.prefix()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Storage methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// indexMessage adds the Message with the given MessageID to the message index.
+func (s *Storage) indexMessage(messageID MessageID) {
+	s.Message(messageID).Consume(func(message *Message) {
+		entry := NewMessageIndexEntry(message)
+		for _, key := range [][]byte{entry.timeKey(), entry.issuerKey()} {
+			if err := s.messageIndexStore.Set(key, nil); err != nil {
+				panic(fmt.Errorf("failed to store %s: %w", entry, err))
+			}
+		}
+	})
+}
+
+// unindexMessage removes the given Message from the message index.
+func (s *Storage) unindexMessage(message *Message) {
+	entry := NewMessageIndexEntry(message)
+	for _, key := range [][]byte{entry.timeKey(), entry.issuerKey()} {
+		if err := s.messageIndexStore.Delete(key); err != nil {
+			panic(fmt.Errorf("failed to delete %s: %w", entry, err))
+		}
+	}
+}
+
+// QueryMessageIndex returns the entries of the message index that match the given query ordered by their issuing
+// time. The index is iterated from the cursor (or the start of the time range) of the query and the iteration stops
+// as soon as the page is complete or the end of the time range is reached. The returned flag indicates whether more
+// entries exist after the returned page. It returns nothing if the message index is disabled.
+func (s *Storage) QueryMessageIndex(query MessageIndexQuery) (entries []*MessageIndexEntry, more bool, err error) {
+	if !s.tangle.Options.MessageIndex {
+		return nil, false, nil
+	}
+
+	var afterKey []byte
+	if query.After != nil {
+		afterKey = query.key(query.After)
+	}
+
+	iterationErr := database.IterateKeysFrom(s.messageIndexStore, query.prefix(), query.start(), func(key kvstore.Key) bool {
+		if afterKey != nil && bytes.Compare(key, afterKey) <= 0 {
+			return true
+		}
+
+		entry, parseErr := messageIndexEntryFromKey(key)
+		if parseErr != nil {
+			err = errors.Errorf("failed to parse message index entry: %w", parseErr)
+			return false
+		}
+		if !query.To.IsZero() && !entry.issuingTime.Before(query.To) {
+			return false
+		}
+		if query.Limit > 0 && len(entries) == query.Limit {
+			more = true
+			return false
+		}
+		entries = append(entries, entry)
+
+		return true
+	})
+	if iterationErr != nil {
+		return nil, false, errors.Errorf("failed to iterate the message index: %w", iterationErr)
+	}
+
+	return entries, more, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
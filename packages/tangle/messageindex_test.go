@@ -0,0 +1,111 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestStorage_QueryMessageIndex(t *testing.T) {
+	tangle := newTestTangle(MessageIndex(true))
+	defer tangle.Shutdown()
+	tangle.Storage.Setup()
+
+	issuerA := ed25519.GenerateKeyPair().PublicKey
+	issuerB := ed25519.GenerateKeyPair().PublicKey
+	start := time.Now().Add(-time.Hour)
+
+	messageIDs := make(map[ed25519.PublicKey][]MessageID)
+	for i := 0; i < 5; i++ {
+		for _, issuer := range []ed25519.PublicKey{issuerA, issuerB} {
+			message := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, start.Add(time.Duration(i)*time.Minute), issuer, nextSequenceNumber(), payload.NewGenericDataPayload([]byte("test")), 0, ed25519.Signature{})
+			tangle.Storage.StoreMessage(message)
+			messageIDs[issuer] = append(messageIDs[issuer], message.ID())
+		}
+	}
+
+	entries, more, err := tangle.Storage.QueryMessageIndex(MessageIndexQuery{PayloadType: payload.GenericDataPayloadType})
+	require.NoError(t, err)
+	assert.False(t, more)
+	assert.Len(t, entries, 10)
+	for i := 1; i < len(entries); i++ {
+		assert.False(t, entries[i].IssuingTime().Before(entries[i-1].IssuingTime()))
+	}
+
+	// pages of the messages of all issuers return every message exactly once
+	pagedEntries := make([]*MessageIndexEntry, 0)
+	pageQuery := MessageIndexQuery{PayloadType: payload.GenericDataPayloadType, Limit: 3}
+	for {
+		page, pageMore, pageErr := tangle.Storage.QueryMessageIndex(pageQuery)
+		require.NoError(t, pageErr)
+		require.LessOrEqual(t, len(page), 3)
+		pagedEntries = append(pagedEntries, page...)
+		if !pageMore {
+			break
+		}
+		pageQuery.After = page[len(page)-1]
+	}
+	assert.Equal(t, entryMessageIDs(entries), entryMessageIDs(pagedEntries))
+
+	// pages of the messages of a single issuer in a time range
+	query := MessageIndexQuery{
+		PayloadType: payload.GenericDataPayloadType,
+		Issuer:      &issuerA,
+		From:        start.Add(time.Minute),
+		To:          start.Add(4 * time.Minute),
+		Limit:       2,
+	}
+	entries, more, err = tangle.Storage.QueryMessageIndex(query)
+	require.NoError(t, err)
+	assert.True(t, more)
+	assert.Equal(t, messageIDs[issuerA][1:3], entryMessageIDs(entries))
+
+	query.After, _, _ = MessageIndexEntryFromBytes(entries[1].Bytes())
+	entries, more, err = tangle.Storage.QueryMessageIndex(query)
+	require.NoError(t, err)
+	assert.False(t, more)
+	assert.Equal(t, messageIDs[issuerA][3:4], entryMessageIDs(entries))
+
+	// other payload types are not returned
+	entries, _, err = tangle.Storage.QueryMessageIndex(MessageIndexQuery{PayloadType: payload.Type(4711)})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// deleted messages are removed from the index
+	tangle.Storage.DeleteMessage(messageIDs[issuerB][0])
+	entries, _, err = tangle.Storage.QueryMessageIndex(MessageIndexQuery{PayloadType: payload.GenericDataPayloadType, Issuer: &issuerB})
+	require.NoError(t, err)
+	assert.Equal(t, messageIDs[issuerB][1:], entryMessageIDs(entries))
+}
+
+func TestMessageIndexEntry_Bytes(t *testing.T) {
+	message := newTestDataMessagePublicKey("test", ed25519.GenerateKeyPair().PublicKey)
+	entry := NewMessageIndexEntry(message)
+
+	restored, consumedBytes, err := MessageIndexEntryFromBytes(entry.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, MessageIndexEntryLength, consumedBytes)
+	assert.Equal(t, entry.PayloadType(), restored.PayloadType())
+	assert.Equal(t, entry.Issuer(), restored.Issuer())
+	assert.True(t, entry.IssuingTime().Equal(restored.IssuingTime()))
+	assert.Equal(t, entry.MessageID(), restored.MessageID())
+
+	for _, key := range [][]byte{entry.timeKey(), entry.issuerKey()} {
+		restored, err = messageIndexEntryFromKey(key)
+		require.NoError(t, err)
+		assert.Equal(t, entry.Bytes(), restored.Bytes())
+	}
+}
+
+func entryMessageIDs(entries []*MessageIndexEntry) (messageIDs []MessageID) {
+	for _, entry := range entries {
+		messageIDs = append(messageIDs, entry.MessageID())
+	}
+
+	return messageIDs
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/typeutils"
+	"go.uber.org/atomic"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/pow"
@@ -41,6 +42,7 @@ func NewParser() (result *Parser) {
 		bytesFilters:   make([]BytesFilter, 0),
 		messageFilters: make([]MessageFilter, 0),
 		Events: &ParserEvents{
+			BytesReceived:   events.NewEvent(bytesReceivedEventHandler),
 			MessageParsed:   events.NewEvent(messageParsedEventHandler),
 			BytesRejected:   events.NewEvent(bytesRejectedEventHandler),
 			MessageRejected: events.NewEvent(messageRejectedEventHandler),
@@ -62,6 +64,10 @@ func (p *Parser) Setup() {
 
 // Parse parses the given message bytes.
 func (p *Parser) Parse(messageBytes []byte, peer *peer.Peer) {
+	p.Events.BytesReceived.Trigger(&BytesReceivedEvent{
+		Bytes: messageBytes,
+		Peer:  peer,
+	})
 	p.bytesFilters[0].Filter(messageBytes, peer)
 }
 
@@ -160,6 +166,9 @@ func (p *Parser) parseMessage(bytes []byte, peer *peer.Peer) {
 
 // ParserEvents represents events happening in the Parser.
 type ParserEvents struct {
+	// Fired when bytes are submitted to the parser, before any filter is applied.
+	BytesReceived *events.Event
+
 	// Fired when a message was parsed.
 	MessageParsed *events.Event
 
@@ -172,6 +181,24 @@ type ParserEvents struct {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region BytesReceivedEvent ///////////////////////////////////////////////////////////////////////////////////////////
+
+// BytesReceivedEvent holds the information provided by the BytesReceived event that gets triggered when bytes are
+// submitted to the Parser.
+type BytesReceivedEvent struct {
+	// Bytes contains the raw bytes of the message.
+	Bytes []byte
+
+	// Peer contains the node that sent the bytes to the node or nil if the bytes were issued locally.
+	Peer *peer.Peer
+}
+
+func bytesReceivedEventHandler(handler interface{}, params ...interface{}) {
+	handler.(func(*BytesReceivedEvent))(params[0].(*BytesReceivedEvent))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region BytesRejectedEvent ///////////////////////////////////////////////////////////////////////////////////////////
 
 // BytesRejectedEvent holds the information provided by the BytesRejected event that gets triggered when the bytes of a
@@ -306,12 +333,73 @@ func (f *MessageSignatureFilter) getRejectCallback() (result func(msg *Message,
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region IssuerFilter /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IssuerFilter filters messages based on whether their issuer is authorized to issue messages.
+type IssuerFilter struct {
+	isAuthorized func(msg *Message) bool
+
+	onAcceptCallback func(msg *Message, peer *peer.Peer)
+	onRejectCallback func(msg *Message, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewIssuerFilter creates a new issuer filter that uses the given function to decide whether a message was issued by an
+// authorized issuer.
+func NewIssuerFilter(isAuthorized func(msg *Message) bool) *IssuerFilter {
+	return &IssuerFilter{
+		isAuthorized: isAuthorized,
+	}
+}
+
+// Filter filters up on the given message and peer and calls the acceptance callback
+// if the input passes or the rejection callback if the input is rejected.
+func (f *IssuerFilter) Filter(msg *Message, peer *peer.Peer) {
+	if f.isAuthorized(msg) {
+		f.getAcceptCallback()(msg, peer)
+		return
+	}
+	f.getRejectCallback()(msg, ErrUnauthorizedIssuer, peer)
+}
+
+// OnAccept registers the given callback as the acceptance function of the filter.
+func (f *IssuerFilter) OnAccept(callback func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.Lock()
+	f.onAcceptCallback = callback
+	f.onAcceptCallbackMutex.Unlock()
+}
+
+// OnReject registers the given callback as the rejection function of the filter.
+func (f *IssuerFilter) OnReject(callback func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.Lock()
+	f.onRejectCallback = callback
+	f.onRejectCallbackMutex.Unlock()
+}
+
+func (f *IssuerFilter) getAcceptCallback() (result func(msg *Message, peer *peer.Peer)) {
+	f.onAcceptCallbackMutex.RLock()
+	result = f.onAcceptCallback
+	f.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (f *IssuerFilter) getRejectCallback() (result func(msg *Message, err error, peer *peer.Peer)) {
+	f.onRejectCallbackMutex.RLock()
+	result = f.onRejectCallback
+	f.onRejectCallbackMutex.RUnlock()
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PowFilter ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // PowFilter is a message bytes filter validating the PoW nonce.
 type PowFilter struct {
 	worker     *pow.Worker
-	difficulty int
+	difficulty atomic.Int64
 
 	mu             sync.RWMutex
 	acceptCallback func([]byte, *peer.Peer)
@@ -320,10 +408,23 @@ type PowFilter struct {
 
 // NewPowFilter creates a new PoW bytes filter.
 func NewPowFilter(worker *pow.Worker, difficulty int) *PowFilter {
-	return &PowFilter{
-		worker:     worker,
-		difficulty: difficulty,
+	filter := &PowFilter{
+		worker: worker,
 	}
+	filter.difficulty.Store(int64(difficulty))
+
+	return filter
+}
+
+// Difficulty returns the PoW difficulty that messages need to fulfill.
+func (f *PowFilter) Difficulty() int {
+	return int(f.difficulty.Load())
+}
+
+// SetDifficulty changes the PoW difficulty that messages need to fulfill. It only affects messages that are filtered
+// afterwards.
+func (f *PowFilter) SetDifficulty(difficulty int) {
+	f.difficulty.Store(int64(difficulty))
 }
 
 // Filter checks whether the given bytes pass the PoW validation and calls the corresponding callback.
@@ -372,8 +473,8 @@ func (f *PowFilter) validate(msgBytes []byte) error {
 	if err != nil {
 		return err
 	}
-	if zeros < f.difficulty {
-		return fmt.Errorf("%w: leading zeros %d for difficulty %d", ErrInvalidPOWDifficultly, zeros, f.difficulty)
+	if difficulty := f.Difficulty(); zeros < difficulty {
+		return fmt.Errorf("%w: leading zeros %d for difficulty %d", ErrInvalidPOWDifficultly, zeros, difficulty)
 	}
 	return nil
 }
@@ -528,6 +629,9 @@ var (
 	// ErrInvalidSignature is returned when a message contains an invalid signature.
 	ErrInvalidSignature = fmt.Errorf("invalid signature")
 
+	// ErrUnauthorizedIssuer is returned when a message is issued by an issuer that is not authorized to issue messages.
+	ErrUnauthorizedIssuer = fmt.Errorf("unauthorized issuer")
+
 	// ErrReceivedDuplicateBytes is returned when duplicated bytes are rejected.
 	ErrReceivedDuplicateBytes = fmt.Errorf("received duplicate bytes")
 
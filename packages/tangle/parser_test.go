@@ -89,6 +89,24 @@ func TestTransactionFilter_Filter(t *testing.T) {
 	})
 }
 
+func TestIssuerFilter_Filter(t *testing.T) {
+	authorized := newTestDataMessage("authorized")
+	unauthorized := newTestDataMessage("unauthorized")
+	filter := NewIssuerFilter(func(msg *Message) bool { return msg == authorized })
+	// set callbacks
+	m := &messageCallbackMock{}
+	filter.OnAccept(m.Accept)
+	filter.OnReject(m.Reject)
+
+	m.On("Accept", authorized, testPeer)
+	filter.Filter(authorized, testPeer)
+
+	m.On("Reject", unauthorized, ErrUnauthorizedIssuer, testPeer)
+	filter.Filter(unauthorized, testPeer)
+
+	m.AssertExpectations(t)
+}
+
 func Test_isMessageAndTransactionTimestampsValid(t *testing.T) {
 	msg := &Message{}
 	t.Run("older tx timestamp within limit", func(t *testing.T) {
@@ -149,6 +167,14 @@ func TestPowFilter_Filter(t *testing.T) {
 		filter.Filter(msgPOWBytes, testPeer)
 	})
 
+	t.Run("accept invalid nonce after lowering the difficulty", func(t *testing.T) {
+		filter.SetDifficulty(0)
+		assert.Equal(t, 0, filter.Difficulty())
+
+		m.On("Accept", msgBytes, testPeer)
+		filter.Filter(msgBytes, testPeer)
+	})
+
 	m.AssertExpectations(t)
 }
 
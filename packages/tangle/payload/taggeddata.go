@@ -0,0 +1,146 @@
+package payload
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+const (
+	// MaxTagLength defines the maximum length of the tag of a TaggedDataPayload in bytes.
+	MaxTagLength = 64
+
+	taggedDataPayloadType = 4
+)
+
+// TaggedDataPayloadType is the Type of a TaggedDataPayload.
+var TaggedDataPayloadType = NewType(taggedDataPayloadType, "TaggedDataPayloadType", TaggedDataPayloadUnmarshaler)
+
+// TaggedDataPayloadUnmarshaler is the UnmarshalerFunc of the TaggedDataPayload.
+func TaggedDataPayloadUnmarshaler(data []byte) (Payload, error) {
+	payload, consumedBytes, err := TaggedDataPayloadFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if consumedBytes != len(data) {
+		return nil, errors.New("not all payload bytes were consumed")
+	}
+	return payload, nil
+}
+
+// TaggedDataPayload represents a payload which contains a blob of data that is indexed by a tag.
+type TaggedDataPayload struct {
+	tag  []byte
+	data []byte
+}
+
+// NewTaggedDataPayload creates a new TaggedDataPayload. It returns an error if the tag is empty or longer than
+// MaxTagLength.
+func NewTaggedDataPayload(tag []byte, data []byte) (*TaggedDataPayload, error) {
+	if len(tag) == 0 || len(tag) > MaxTagLength {
+		return nil, errors.Errorf("tag length %d is not within 1 and %d bytes", len(tag), MaxTagLength)
+	}
+
+	return &TaggedDataPayload{
+		tag:  tag,
+		data: data,
+	}, nil
+}
+
+// TaggedDataPayloadFromBytes unmarshals a TaggedDataPayload from a sequence of bytes.
+func TaggedDataPayloadFromBytes(bytes []byte) (taggedDataPayload *TaggedDataPayload, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if taggedDataPayload, err = TaggedDataPayloadFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TaggedDataPayload from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// TaggedDataPayloadFromMarshalUtil unmarshals a TaggedDataPayload using a MarshalUtil (for easier unmarshaling).
+func TaggedDataPayloadFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (taggedDataPayload *TaggedDataPayload, err error) {
+	payloadSize, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = errors.Errorf("failed to parse payload size (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	payloadType, err := TypeFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = errors.Errorf("failed to parse Type from MarshalUtil: %w", err)
+		return
+	}
+	if payloadType != taggedDataPayloadType {
+		err = errors.Errorf("invalid payload type %s: %w", payloadType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	tagLength, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = errors.Errorf("failed to parse tag length (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if tagLength == 0 || tagLength > MaxTagLength {
+		err = errors.Errorf("tag length %d is not within 1 and %d bytes: %w", tagLength, MaxTagLength, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	dataLength := int(payloadSize) - TypeLength - marshalutil.Uint8Size - int(tagLength)
+	if dataLength < 0 {
+		err = errors.Errorf("payload size %d is too small for a tag of %d bytes: %w", payloadSize, tagLength, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	taggedDataPayload = &TaggedDataPayload{}
+	if taggedDataPayload.tag, err = marshalUtil.ReadBytes(int(tagLength)); err != nil {
+		err = errors.Errorf("failed to parse tag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if taggedDataPayload.data, err = marshalUtil.ReadBytes(dataLength); err != nil {
+		err = errors.Errorf("failed to parse data (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Type returns the Type of the Payload.
+func (t *TaggedDataPayload) Type() Type {
+	return TaggedDataPayloadType
+}
+
+// Tag returns the tag that indexes the TaggedDataPayload.
+func (t *TaggedDataPayload) Tag() []byte {
+	return t.tag
+}
+
+// Data returns the contained data of the TaggedDataPayload.
+func (t *TaggedDataPayload) Data() []byte {
+	return t.data
+}
+
+// Bytes returns a marshaled version of the Payload.
+func (t *TaggedDataPayload) Bytes() []byte {
+	return marshalutil.New().
+		WriteUint32(TypeLength + marshalutil.Uint8Size + uint32(len(t.tag)) + uint32(len(t.data))).
+		WriteBytes(t.Type().Bytes()).
+		WriteUint8(uint8(len(t.tag))).
+		WriteBytes(t.tag).
+		WriteBytes(t.data).
+		Bytes()
+}
+
+// String returns a human readable version of the Payload.
+func (t *TaggedDataPayload) String() string {
+	return stringify.Struct("TaggedDataPayload",
+		stringify.StructField("type", t.Type()),
+		stringify.StructField("tag", string(t.tag)),
+		stringify.StructField("data", t.data),
+	)
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ Payload = &TaggedDataPayload{}
@@ -8,9 +8,14 @@ import (
 	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
 )
 
 const (
@@ -24,6 +29,8 @@ const (
 	RateSettingDecrease = 1.5
 	// RateSettingPause is the time to wait before next rate's update after a backoff
 	RateSettingPause = 2
+
+	rateSetterStateKey = "RateSetterState"
 )
 
 var (
@@ -58,9 +65,13 @@ type RateSetter struct {
 	pauseUpdates   uint
 	shutdownSignal chan struct{}
 	shutdownOnce   sync.Once
+	loopStopped    chan struct{}
 }
 
-// NewRateSetter returns a new RateSetter.
+// NewRateSetter returns a new RateSetter, whose issuing queue and rate are restored from the state that was persisted at
+// the last shutdown. The node does not create a RateSetter (its own messages are submitted to the Scheduler like any
+// other message), so a running node only persists the state of the Scheduler: the issuing queue is only persisted
+// where a RateSetter is created explicitly.
 func NewRateSetter(tangle *Tangle) *RateSetter {
 	rateSetter := &RateSetter{
 		tangle: tangle,
@@ -68,16 +79,18 @@ func NewRateSetter(tangle *Tangle) *RateSetter {
 			MessageDiscarded: events.NewEvent(MessageIDCaller),
 		},
 		self:           tangle.Options.Identity.ID(),
-		issuingQueue:   schedulerutils.NewNodeQueue(tangle.Options.Identity.ID()),
+		issuingQueue:   schedulerutils.NewNodeQueue(tangle.Options.Identity.ID(), tangle.Scheduler.priority),
 		issueChan:      make(chan *Message),
 		ownRate:        atomic.NewFloat64(Initial),
 		pauseUpdates:   0,
 		shutdownSignal: make(chan struct{}),
 		shutdownOnce:   sync.Once{},
+		loopStopped:    make(chan struct{}),
 	}
 	if tangle.Options.RateSetterParams.Initial != nil {
 		Initial = *tangle.Options.RateSetterParams.Initial
 	}
+	rateSetter.restoreState()
 
 	go rateSetter.issuerLoop()
 	return rateSetter
@@ -111,11 +124,13 @@ func (r *RateSetter) Issue(message *Message) error {
 	}
 }
 
-// Shutdown shuts down the RateSetter.
+// Shutdown shuts down the RateSetter and persists its issuing queue and rate.
+// Shutdown blocks until the issuer loop has been stopped, so it must be called before the Tangle is shut down.
 func (r *RateSetter) Shutdown() {
 	r.shutdownOnce.Do(func() {
 		close(r.shutdownSignal)
 	})
+	<-r.loopStopped
 }
 
 // Rate returns the rate of the rate setter.
@@ -133,12 +148,9 @@ func (r *RateSetter) rateSetting() {
 	ownMana := r.tangle.Options.SchedulerParams.AccessManaRetrieveFunc(r.self)
 	totalMana := r.tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc()
 
-	ownRate := r.ownRate.Load()
-	if float64(r.tangle.Scheduler.NodeQueueSize(r.self))/ownMana > Backoff {
-		ownRate /= RateSettingDecrease
+	ownRate, backoff := NextRate(r.ownRate.Load(), r.tangle.Scheduler.NodeQueueSize(r.self), ownMana, totalMana)
+	if backoff {
 		r.pauseUpdates = RateSettingPause
-	} else {
-		ownRate += RateSettingIncrease * ownMana / totalMana
 	}
 	r.ownRate.Store(ownRate)
 }
@@ -150,6 +162,7 @@ func (r *RateSetter) issuerLoop() {
 		lastIssueTime = time.Now()
 	)
 	defer issueTimer.Stop()
+	defer close(r.loopStopped)
 
 loop:
 	for {
@@ -197,15 +210,165 @@ loop:
 		}
 	}
 
-	// discard all remaining messages at shutdown
+	// discard all remaining messages at shutdown, unless they are restored at the next start
+	if r.tangle.Options.SchedulerParams.maxRestoreAge() >= 0 && r.persistState() {
+		return
+	}
 	for _, id := range r.issuingQueue.IDs() {
 		r.Events.MessageDiscarded.Trigger(id)
 	}
 }
 
+// persistState stores the issuing queue and the rate, so that they can be restored at the next start.
+func (r *RateSetter) persistState() bool {
+	state := &rateSetterState{
+		PersistedAt: clock.SyncedTime(),
+		Rate:        r.ownRate.Load(),
+	}
+	for _, id := range r.issuingQueue.ReadyIDs() {
+		state.MessageIDs = append(state.MessageIDs, MessageID(id))
+	}
+
+	if err := r.tangle.Options.Store.Set(kvstore.Key(rateSetterStateKey), state.Bytes()); err != nil {
+		r.tangle.Events.Error.Trigger(errors.Errorf("failed to persists RateSetterState (%v): %w", err, cerrors.ErrFatal))
+		return false
+	}
+	return true
+}
+
+// restoreState restores the issuing queue and the rate that were persisted at the last shutdown. The state is dropped if
+// it is older than the MaxRestoreAge, and so are messages that are no longer in the storage, have already been
+// scheduled, are invalid, have been issued before the MaxRestoreAge or no longer fit into the queue.
+func (r *RateSetter) restoreState() {
+	maxAge := r.tangle.Options.SchedulerParams.maxRestoreAge()
+	if maxAge < 0 {
+		return
+	}
+
+	marshaledState, err := r.tangle.Options.Store.Get(kvstore.Key(rateSetterStateKey))
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrKeyNotFound) {
+			r.tangle.Events.Error.Trigger(errors.Errorf("failed to load RateSetterState: %w", err))
+		}
+		return
+	}
+	// the state is only restored once
+	if err = r.tangle.Options.Store.Delete(kvstore.Key(rateSetterStateKey)); err != nil {
+		r.tangle.Events.Error.Trigger(errors.Errorf("failed to delete RateSetterState: %w", err))
+	}
+
+	state, _, err := rateSetterStateFromBytes(marshaledState)
+	if err != nil {
+		r.tangle.Events.Error.Trigger(errors.Errorf("failed to restore RateSetterState: %w", err))
+		return
+	}
+
+	now := clock.SyncedTime()
+	if now.Sub(state.PersistedAt) > maxAge {
+		return
+	}
+	if state.Rate > 0 {
+		r.ownRate.Store(state.Rate)
+	}
+
+	oldestIssuingTime := now.Add(-maxAge)
+	for _, messageID := range state.MessageIDs {
+		pending := false
+		r.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			pending = !messageMetadata.Scheduled() && !messageMetadata.IsInvalid()
+		})
+		if !pending {
+			continue
+		}
+
+		r.tangle.Storage.Message(messageID).Consume(func(message *Message) {
+			if identity.NewID(message.IssuerPublicKey()) != r.self || message.IssuingTime().Before(oldestIssuingTime) ||
+				r.issuingQueue.Size()+message.Size() > MaxLocalQueueSize {
+				return
+			}
+			r.issuingQueue.Submit(message)
+			r.issuingQueue.Ready(message)
+		})
+	}
+}
+
 func (r *RateSetter) issueInterval(msg *Message) time.Duration {
-	wait := time.Duration(math.Ceil(float64(len(msg.Bytes())) / r.ownRate.Load() * float64(time.Second)))
-	return wait
+	return IssueInterval(len(msg.Bytes()), r.ownRate.Load())
+}
+
+// IssueInterval returns the time to wait after issuing a message of the given size (in bytes) at the given rate.
+func IssueInterval(size int, rate float64) time.Duration {
+	return time.Duration(math.Ceil(float64(size) / rate * float64(time.Second)))
+}
+
+// NextRate returns the rate that follows ownRate given the size of the node's queue in the scheduler and its access
+// mana. The rate is increased additively, unless the queue is too long in which case the rate is decreased
+// multiplicatively and backoff is true (i.e. the next RateSettingPause updates are skipped).
+func NextRate(ownRate float64, queueSize int, ownMana, totalMana float64) (rate float64, backoff bool) {
+	if float64(queueSize)/ownMana > Backoff {
+		return ownRate / RateSettingDecrease, true
+	}
+	return ownRate + RateSettingIncrease*ownMana/totalMana, false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region rateSetterState //////////////////////////////////////////////////////////////////////////////////////////////
+
+// rateSetterState is the state of the RateSetter that is persisted at shutdown.
+type rateSetterState struct {
+	PersistedAt time.Time
+	Rate        float64
+	MessageIDs  []MessageID
+}
+
+// rateSetterStateFromBytes unmarshals a rateSetterState from a sequence of bytes.
+func rateSetterStateFromBytes(bytes []byte) (state *rateSetterState, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if state, err = rateSetterStateFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse RateSetterState from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// rateSetterStateFromMarshalUtil unmarshals a rateSetterState using a MarshalUtil (for easier unmarshaling).
+func rateSetterStateFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (state *rateSetterState, err error) {
+	state = &rateSetterState{}
+	if state.PersistedAt, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse persisted time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if state.Rate, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, errors.Errorf("failed to parse rate (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	messagesCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse messages count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	state.MessageIDs = make([]MessageID, messagesCount)
+	for i := range state.MessageIDs {
+		if state.MessageIDs[i], err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse MessageID: %w", err)
+		}
+	}
+
+	return state, nil
+}
+
+// Bytes returns a marshaled version of the rateSetterState.
+func (s *rateSetterState) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteTime(s.PersistedAt).
+		WriteFloat64(s.Rate).
+		WriteUint32(uint32(len(s.MessageIDs)))
+	for _, messageID := range s.MessageIDs {
+		marshalUtil.Write(messageID)
+	}
+
+	return marshalUtil.Bytes()
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
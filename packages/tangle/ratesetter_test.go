@@ -9,6 +9,8 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -75,3 +77,47 @@ func TestRateSetter_ErrorHandling(t *testing.T) {
 		}
 	}, 1*time.Second, 10*time.Millisecond)
 }
+
+func TestRateSetter_Restore(t *testing.T) {
+	localID := identity.GenerateLocalIdentity()
+	localNode := identity.New(localID.PublicKey())
+	db := mapdb.NewMapDB()
+
+	tangle := newTestTangle(Identity(localID), Store(db), RateSetterConfig(testRateSetterParams))
+	rateSetter := NewRateSetter(tangle)
+	// make sure that the queued messages are not issued before the shutdown
+	rateSetter.ownRate.Store(1)
+
+	for i := 0; i < 3; i++ {
+		msg := NewMessage(
+			[]MessageID{EmptyMessageID},
+			[]MessageID{},
+			time.Now(),
+			localNode.PublicKey(),
+			uint64(i),
+			payload.NewGenericDataPayload(make([]byte, MaxMessageSize/2)),
+			0,
+			ed25519.Signature{},
+		)
+		tangle.Storage.StoreMessage(msg)
+		assert.NoError(t, rateSetter.Issue(msg))
+	}
+	// the first message might have been issued right away
+	time.Sleep(100 * time.Millisecond)
+	queued := rateSetter.Size()
+	assert.Greater(t, queued, 0)
+	rateSetter.Shutdown()
+	tangle.Shutdown()
+
+	tangle = newTestTangle(Identity(localID), Store(db), RateSetterConfig(testRateSetterParams))
+	defer tangle.Shutdown()
+	rateSetter = NewRateSetter(tangle)
+	defer rateSetter.Shutdown()
+
+	assert.Equal(t, queued, rateSetter.Size())
+	assert.Equal(t, 1.0, rateSetter.Rate())
+
+	// the state is only restored once
+	_, err := db.Get(kvstore.Key(rateSetterStateKey))
+	assert.ErrorIs(t, err, kvstore.ErrKeyNotFound)
+}
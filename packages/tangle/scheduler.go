@@ -6,12 +6,16 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/typeutils"
 	"go.uber.org/atomic"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
 )
 
@@ -22,6 +26,11 @@ const (
 	// MinMana is the minimum amount of Mana needed to issue messages.
 	// MaxMessageSize / MinMana is also the upper bound of iterations inside one schedule call, as such it should not be too small.
 	MinMana float64 = 1.0
+	// DefaultMaxRestoreAge is the default maximum age of the persisted scheduler (and RateSetter) state and of the
+	// messages it contains, for them to be restored at startup.
+	DefaultMaxRestoreAge = 5 * time.Minute
+
+	schedulerStateKey = "SchedulerState"
 )
 
 // ErrNotRunning is returned when a message is submitted when the scheduler has been stopped
@@ -33,16 +42,32 @@ type SchedulerParams struct {
 	Rate                        time.Duration
 	AccessManaRetrieveFunc      func(identity.ID) float64
 	TotalAccessManaRetrieveFunc func() float64
+	// PayloadPriorities maps payload types to the priority class of their messages within the queue of a node.
+	// Messages with other payload types have the schedulerutils.PriorityNormal class.
+	PayloadPriorities map[payload.Type]schedulerutils.Priority
+	// MaxRestoreAge is the maximum age of the state persisted at shutdown and of the messages it contains, for them to
+	// be restored at startup (DefaultMaxRestoreAge if 0). A negative value disables the persistence, i.e. the buffered
+	// messages are discarded at shutdown.
+	MaxRestoreAge time.Duration
+}
+
+// maxRestoreAge returns the maximum age of the persisted state and its messages for them to be restored.
+func (p SchedulerParams) maxRestoreAge() time.Duration {
+	if p.MaxRestoreAge == 0 {
+		return DefaultMaxRestoreAge
+	}
+	return p.MaxRestoreAge
 }
 
 // Scheduler is a Tangle component that takes care of scheduling the messages that shall be booked.
 type Scheduler struct {
 	Events *SchedulerEvents
 
-	tangle  *Tangle
-	ticker  *time.Ticker
-	started typeutils.AtomicBool
-	stopped typeutils.AtomicBool
+	tangle    *Tangle
+	ticker    *time.Ticker
+	started   typeutils.AtomicBool
+	stopped   typeutils.AtomicBool
+	persisted typeutils.AtomicBool
 
 	mu       sync.Mutex
 	buffer   *schedulerutils.BufferQueue
@@ -64,7 +89,7 @@ func NewScheduler(tangle *Tangle) *Scheduler {
 	// maximum access mana-scaled inbox length
 	maxQueue := float64(maxBuffer) / float64(tangle.LedgerState.TotalSupply())
 
-	return &Scheduler{
+	scheduler := &Scheduler{
 		Events: &SchedulerEvents{
 			MessageScheduled: events.NewEvent(MessageIDCaller),
 			MessageDiscarded: events.NewEvent(MessageIDCaller),
@@ -73,15 +98,18 @@ func NewScheduler(tangle *Tangle) *Scheduler {
 		tangle:         tangle,
 		rate:           atomic.NewDuration(tangle.Options.SchedulerParams.Rate),
 		ticker:         time.NewTicker(tangle.Options.SchedulerParams.Rate),
-		buffer:         schedulerutils.NewBufferQueue(maxBuffer, maxQueue),
 		deficits:       make(map[identity.ID]float64),
 		shutdownSignal: make(chan struct{}),
 	}
+	scheduler.buffer = schedulerutils.NewBufferQueue(maxBuffer, maxQueue, scheduler.priority)
+
+	return scheduler
 }
 
-// Start starts the scheduler.
+// Start starts the scheduler after restoring the state that was persisted at the last shutdown.
 func (s *Scheduler) Start() {
 	s.started.Set()
+	s.restoreState()
 	// start the main loop
 	go s.mainLoop()
 }
@@ -91,7 +119,7 @@ func (s *Scheduler) Running() bool {
 	return s.started.IsSet()
 }
 
-// Shutdown shuts down the Scheduler.
+// Shutdown shuts down the Scheduler and persists the buffered messages and the deficits, if it has been started.
 // Shutdown blocks until the scheduler has been shutdown successfully.
 func (s *Scheduler) Shutdown() {
 	s.shutdownOnce.Do(func() {
@@ -99,6 +127,10 @@ func (s *Scheduler) Shutdown() {
 		s.mu.Lock()
 		defer s.mu.Unlock()
 		s.stopped.Set()
+		// a scheduler that was never started did not restore the persisted state, so it must not overwrite it
+		if s.started.IsSet() && s.tangle.Options.SchedulerParams.maxRestoreAge() >= 0 {
+			s.persistState()
+		}
 		close(s.shutdownSignal)
 	})
 }
@@ -145,6 +177,26 @@ func (s *Scheduler) NodeQueueSizes() map[identity.ID]int {
 	return nodeQueueSizes
 }
 
+// PrioritySizes returns the total size of the messages in the buffer per priority class.
+func (s *Scheduler) PrioritySizes() [schedulerutils.NumPriorities]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buffer.PrioritySizes()
+}
+
+// Priority returns the priority class of the given message within the queue of its issuer.
+func (s *Scheduler) Priority(message *Message) schedulerutils.Priority {
+	priority, exists := s.tangle.Options.SchedulerParams.PayloadPriorities[message.Payload().Type()]
+	if !exists {
+		return schedulerutils.PriorityNormal
+	}
+	if int(priority) >= schedulerutils.NumPriorities {
+		return schedulerutils.PriorityCritical
+	}
+	return priority
+}
+
 // Submit submits a message to be considered by the scheduler.
 // This transactions will be included in all the control metrics, but it will never be
 // scheduled until Ready(messageID) has been called.
@@ -321,6 +373,23 @@ func (s *Scheduler) schedule() *Message {
 	return msg.(*Message)
 }
 
+// ScheduleNext schedules the next ready message (if there is one) and triggers the MessageScheduled event. It is called
+// by the main loop for every tick of the rate, and can be used to drive a Scheduler that was not started by an external
+// (e.g. virtual) clock.
+func (s *Scheduler) ScheduleNext() (scheduled bool) {
+	msg := s.schedule()
+	if msg == nil {
+		return false
+	}
+
+	s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
+		if messageMetadata.SetScheduled(true) {
+			s.Events.MessageScheduled.Trigger(msg.ID())
+		}
+	})
+	return true
+}
+
 // mainLoop periodically triggers the scheduling of ready messages.
 func (s *Scheduler) mainLoop() {
 	defer s.ticker.Stop()
@@ -331,13 +400,7 @@ loop:
 		// every rate time units
 		case <-s.ticker.C:
 			// TODO: pause the ticker, if there are no ready messages
-			if msg := s.schedule(); msg != nil {
-				s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
-					if messageMetadata.SetScheduled(true) {
-						s.Events.MessageScheduled.Trigger(msg.ID())
-					}
-				})
-			}
+			s.ScheduleNext()
 
 		// on close, exit the loop
 		case <-s.shutdownSignal:
@@ -345,8 +408,103 @@ loop:
 		}
 	}
 
-	// remove all unscheduled messages
-	s.Clear()
+	// remove all unscheduled messages, unless they are restored at the next start
+	if !s.persisted.IsSet() {
+		s.Clear()
+	}
+}
+
+// persistState stores the buffered messages and the deficits, so that they can be restored at the next start.
+func (s *Scheduler) persistState() {
+	state := &schedulerState{
+		PersistedAt: clock.SyncedTime(),
+		Deficits:    s.deficits,
+	}
+	for _, nodeID := range s.buffer.NodeIDs() {
+		nodeQueue := s.buffer.NodeQueue(nodeID)
+		for _, id := range nodeQueue.SubmittedIDs() {
+			state.Messages = append(state.Messages, schedulerStateMessage{MessageID: MessageID(id)})
+		}
+		for _, id := range nodeQueue.ReadyIDs() {
+			state.Messages = append(state.Messages, schedulerStateMessage{MessageID: MessageID(id), Ready: true})
+		}
+	}
+
+	if err := s.tangle.Options.Store.Set(kvstore.Key(schedulerStateKey), state.Bytes()); err != nil {
+		s.tangle.Events.Error.Trigger(errors.Errorf("failed to persists SchedulerState (%v): %w", err, cerrors.ErrFatal))
+		return
+	}
+	s.persisted.Set()
+}
+
+// restoreState restores the buffered messages and the deficits that were persisted at the last shutdown. The state is
+// dropped if it is older than the MaxRestoreAge, and so are messages that are no longer in the storage, have already
+// been scheduled, are invalid or have been issued before the MaxRestoreAge.
+func (s *Scheduler) restoreState() {
+	maxAge := s.tangle.Options.SchedulerParams.maxRestoreAge()
+	if maxAge < 0 {
+		return
+	}
+
+	marshaledState, err := s.tangle.Options.Store.Get(kvstore.Key(schedulerStateKey))
+	if err != nil {
+		if !errors.Is(err, kvstore.ErrKeyNotFound) {
+			s.tangle.Events.Error.Trigger(errors.Errorf("failed to load SchedulerState: %w", err))
+		}
+		return
+	}
+	// the state is only restored once
+	if err = s.tangle.Options.Store.Delete(kvstore.Key(schedulerStateKey)); err != nil {
+		s.tangle.Events.Error.Trigger(errors.Errorf("failed to delete SchedulerState: %w", err))
+	}
+
+	state, _, err := schedulerStateFromBytes(marshaledState)
+	if err != nil {
+		s.tangle.Events.Error.Trigger(errors.Errorf("failed to restore SchedulerState: %w", err))
+		return
+	}
+
+	now := clock.SyncedTime()
+	if now.Sub(state.PersistedAt) > maxAge {
+		return
+	}
+
+	s.mu.Lock()
+	for nodeID, deficit := range state.Deficits {
+		if deficit > 0 {
+			s.deficits[nodeID] = math.Min(deficit, MaxDeficit)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, stateMessage := range state.Messages {
+		s.restoreMessage(stateMessage, now.Add(-maxAge))
+	}
+}
+
+// restoreMessage submits a restored message again, if it is still valid and was not issued before oldestIssuingTime.
+func (s *Scheduler) restoreMessage(stateMessage schedulerStateMessage, oldestIssuingTime time.Time) {
+	pending := false
+	s.tangle.Storage.MessageMetadata(stateMessage.MessageID).Consume(func(messageMetadata *MessageMetadata) {
+		pending = !messageMetadata.Scheduled() && !messageMetadata.IsInvalid()
+	})
+	if !pending {
+		return
+	}
+
+	s.tangle.Storage.Message(stateMessage.MessageID).Consume(func(message *Message) {
+		if message.IssuingTime().Before(oldestIssuingTime) {
+			s.Events.MessageDiscarded.Trigger(message.ID())
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if s.submit(message) == nil && stateMessage.Ready {
+			s.ready(message)
+		}
+	})
 }
 
 func (s *Scheduler) getDeficit(nodeID identity.ID) float64 {
@@ -362,6 +520,11 @@ func (s *Scheduler) updateDeficit(nodeID identity.ID, d float64) {
 	s.deficits[nodeID] = math.Min(deficit, MaxDeficit)
 }
 
+// priority is the schedulerutils.PriorityFunc of the buffer.
+func (s *Scheduler) priority(element schedulerutils.Element) schedulerutils.Priority {
+	return s.Priority(element.(*Message))
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region SchedulerEvents /////////////////////////////////////////////////////////////////////////////////////////////
@@ -380,3 +543,87 @@ func NodeIDCaller(handler interface{}, params ...interface{}) {
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region schedulerState ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// schedulerState is the state of the Scheduler that is persisted at shutdown.
+type schedulerState struct {
+	PersistedAt time.Time
+	Deficits    map[identity.ID]float64
+	Messages    []schedulerStateMessage
+}
+
+// schedulerStateMessage is a buffered message of the persisted schedulerState.
+type schedulerStateMessage struct {
+	MessageID MessageID
+	Ready     bool
+}
+
+// schedulerStateFromBytes unmarshals a schedulerState from a sequence of bytes.
+func schedulerStateFromBytes(bytes []byte) (state *schedulerState, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if state, err = schedulerStateFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse SchedulerState from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// schedulerStateFromMarshalUtil unmarshals a schedulerState using a MarshalUtil (for easier unmarshaling).
+func schedulerStateFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (state *schedulerState, err error) {
+	state = &schedulerState{}
+	if state.PersistedAt, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse persisted time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+
+	deficitsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse deficits count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	state.Deficits = make(map[identity.ID]float64, deficitsCount)
+	for i := uint32(0); i < deficitsCount; i++ {
+		nodeID, nodeIDErr := identity.IDFromMarshalUtil(marshalUtil)
+		if nodeIDErr != nil {
+			return nil, errors.Errorf("failed to parse node ID (%v): %w", nodeIDErr, cerrors.ErrParseBytesFailed)
+		}
+		if state.Deficits[nodeID], err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, errors.Errorf("failed to parse deficit (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+	}
+
+	messagesCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse messages count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	state.Messages = make([]schedulerStateMessage, messagesCount)
+	for i := range state.Messages {
+		if state.Messages[i].MessageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse MessageID: %w", err)
+		}
+		if state.Messages[i].Ready, err = marshalUtil.ReadBool(); err != nil {
+			return nil, errors.Errorf("failed to parse ready flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+	}
+
+	return state, nil
+}
+
+// Bytes returns a marshaled version of the schedulerState.
+func (s *schedulerState) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteTime(s.PersistedAt).
+		WriteUint32(uint32(len(s.Deficits)))
+	for nodeID, deficit := range s.Deficits {
+		marshalUtil.WriteBytes(nodeID.Bytes()).WriteFloat64(deficit)
+	}
+	marshalUtil.WriteUint32(uint32(len(s.Messages)))
+	for _, stateMessage := range s.Messages {
+		marshalUtil.Write(stateMessage.MessageID).WriteBool(stateMessage.Ready)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -8,6 +8,8 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
@@ -71,7 +73,10 @@ func TestScheduler_Discarded(t *testing.T) {
 }
 
 func TestScheduler_DiscardedAtShutdown(t *testing.T) {
-	tangle := New(Identity(selfLocalIdentity), SchedulerConfig(testSchedulerParams))
+	params := testSchedulerParams
+	// disable the persistence of the buffered messages
+	params.MaxRestoreAge = -1
+	tangle := New(Identity(selfLocalIdentity), SchedulerConfig(params))
 	defer tangle.Shutdown()
 
 	messageDiscarded := make(chan MessageID, 1)
@@ -96,6 +101,52 @@ func TestScheduler_DiscardedAtShutdown(t *testing.T) {
 	}, 1*time.Second, 10*time.Millisecond)
 }
 
+func TestScheduler_Restore(t *testing.T) {
+	db := mapdb.NewMapDB()
+	params := testSchedulerParams
+	// make sure that nothing is scheduled before the shutdown
+	params.Rate = time.Hour
+
+	tangle := New(Identity(selfLocalIdentity), Store(db), SchedulerConfig(params))
+	tangle.Scheduler.Start()
+
+	readyMessage := newMessage(selfNode.PublicKey())
+	submittedMessage := newMessage(peerNode.PublicKey())
+	oldMessage := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, time.Now().Add(-2*DefaultMaxRestoreAge), selfNode.PublicKey(), 1, payload.NewGenericDataPayload([]byte("")), 0, ed25519.Signature{})
+	for _, msg := range []*Message{readyMessage, submittedMessage, oldMessage} {
+		tangle.Storage.StoreMessage(msg)
+	}
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(readyMessage.ID()))
+	assert.NoError(t, tangle.Scheduler.Submit(submittedMessage.ID()))
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(oldMessage.ID()))
+	tangle.Scheduler.mu.Lock()
+	tangle.Scheduler.deficits[peerNode.ID()] = 42
+	tangle.Scheduler.mu.Unlock()
+	tangle.Shutdown()
+
+	tangle = New(Identity(selfLocalIdentity), Store(db), SchedulerConfig(params))
+	defer tangle.Shutdown()
+
+	messageDiscarded := make(chan MessageID, 1)
+	tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(id MessageID) { messageDiscarded <- id }))
+	tangle.Scheduler.Start()
+
+	// the old message is discarded, while the others are restored with their ready state
+	assert.Equal(t, oldMessage.ID(), <-messageDiscarded)
+	assert.Equal(t, readyMessage.Size(), tangle.Scheduler.NodeQueueSize(selfNode.ID()))
+	assert.Equal(t, submittedMessage.Size(), tangle.Scheduler.NodeQueueSize(peerNode.ID()))
+
+	tangle.Scheduler.mu.Lock()
+	assert.Len(t, tangle.Scheduler.buffer.NodeQueue(selfNode.ID()).ReadyIDs(), 1)
+	assert.Equal(t, []schedulerutils.ElementID{schedulerutils.ElementID(submittedMessage.ID())}, tangle.Scheduler.buffer.NodeQueue(peerNode.ID()).SubmittedIDs())
+	assert.Equal(t, 42.0, tangle.Scheduler.deficits[peerNode.ID()])
+	tangle.Scheduler.mu.Unlock()
+
+	// the state is only restored once
+	_, err := db.Get(kvstore.Key(schedulerStateKey))
+	assert.True(t, errors.Is(err, kvstore.ErrKeyNotFound))
+}
+
 func TestScheduler_SetRateBeforeStart(t *testing.T) {
 	tangle := New(Identity(selfLocalIdentity), SchedulerConfig(testSchedulerParams))
 	defer tangle.Shutdown()
@@ -241,6 +292,67 @@ func TestScheduler_Issue(t *testing.T) {
 	assert.ElementsMatch(t, ids, scheduledIDs)
 }
 
+func TestScheduler_Priority(t *testing.T) {
+	params := testSchedulerParams
+	params.PayloadPriorities = map[payload.Type]schedulerutils.Priority{
+		payload.GenericDataPayloadType: schedulerutils.PriorityCritical,
+	}
+	tangle := New(Identity(selfLocalIdentity), SchedulerConfig(params))
+	defer tangle.Shutdown()
+
+	dataMessage := newMessage(selfNode.PublicKey())
+	tangle.Storage.StoreMessage(dataMessage)
+	taggedPayload, err := payload.NewTaggedDataPayload([]byte("tag"), []byte(""))
+	assert.NoError(t, err)
+	taggedMessage := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, time.Now(), selfNode.PublicKey(), 0, taggedPayload, 0, ed25519.Signature{})
+	tangle.Storage.StoreMessage(taggedMessage)
+
+	assert.Equal(t, schedulerutils.PriorityCritical, tangle.Scheduler.Priority(dataMessage))
+	assert.Equal(t, schedulerutils.PriorityNormal, tangle.Scheduler.Priority(taggedMessage))
+
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(dataMessage.ID()))
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(taggedMessage.ID()))
+	sizes := tangle.Scheduler.PrioritySizes()
+	assert.Equal(t, dataMessage.Size(), sizes[schedulerutils.PriorityCritical])
+	assert.Equal(t, taggedMessage.Size(), sizes[schedulerutils.PriorityNormal])
+}
+
+func TestScheduler_FuturePriority(t *testing.T) {
+	params := testSchedulerParams
+	params.PayloadPriorities = map[payload.Type]schedulerutils.Priority{
+		payload.GenericDataPayloadType: schedulerutils.PriorityCritical,
+	}
+	tangle := New(Identity(selfLocalIdentity), SchedulerConfig(params))
+	defer tangle.Shutdown()
+
+	messageScheduled := make(chan MessageID, 2)
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(id MessageID) { messageScheduled <- id }))
+
+	tangle.Scheduler.Start()
+
+	// a critical message that is issued in the future must not block the due normal message of the same node
+	futureMessage := newMessage(peerNode.PublicKey())
+	futureMessage.issuingTime = time.Now().Add(time.Hour)
+	tangle.Storage.StoreMessage(futureMessage)
+	taggedPayload, err := payload.NewTaggedDataPayload([]byte("tag"), []byte(""))
+	assert.NoError(t, err)
+	dueMessage := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, time.Now(), peerNode.PublicKey(), 0, taggedPayload, 0, ed25519.Signature{})
+	tangle.Storage.StoreMessage(dueMessage)
+
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(futureMessage.ID()))
+	assert.NoError(t, tangle.Scheduler.SubmitAndReady(dueMessage.ID()))
+
+	assert.Eventually(t, func() bool {
+		select {
+		case id := <-messageScheduled:
+			return assert.Equal(t, dueMessage.ID(), id)
+		default:
+			return false
+		}
+	}, 1*time.Second, 10*time.Millisecond)
+	assert.Equal(t, futureMessage.Size(), tangle.Scheduler.PrioritySizes()[schedulerutils.PriorityCritical])
+}
+
 func TestSchedulerFlow(t *testing.T) {
 	// create Scheduler dependencies
 	// create the tangle
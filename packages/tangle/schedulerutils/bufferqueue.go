@@ -20,21 +20,24 @@ var (
 
 // BufferQueue represents a buffer of NodeQueue
 type BufferQueue struct {
-	maxBuffer int
-	maxQueue  float64
+	maxBuffer    int
+	maxQueue     float64
+	priorityFunc PriorityFunc
 
 	activeNode map[identity.ID]*ring.Ring
 	ring       *ring.Ring
 	size       int
 }
 
-// NewBufferQueue returns a new BufferQueue.
-func NewBufferQueue(maxBuffer int, maxQueue float64) *BufferQueue {
+// NewBufferQueue returns a new BufferQueue. The messages within the queue of a node are ordered by the priority classes
+// returned by the given PriorityFunc (nil means that all messages have the same priority).
+func NewBufferQueue(maxBuffer int, maxQueue float64, priorityFunc PriorityFunc) *BufferQueue {
 	return &BufferQueue{
-		maxBuffer:  maxBuffer,
-		maxQueue:   maxQueue,
-		activeNode: make(map[identity.ID]*ring.Ring),
-		ring:       nil,
+		maxBuffer:    maxBuffer,
+		maxQueue:     maxQueue,
+		priorityFunc: priorityFunc,
+		activeNode:   make(map[identity.ID]*ring.Ring),
+		ring:         nil,
 	}
 }
 
@@ -48,6 +51,17 @@ func (b *BufferQueue) Size() int {
 	return b.size
 }
 
+// PrioritySizes returns the total size (in bytes) of all messages in b per priority class.
+func (b *BufferQueue) PrioritySizes() (sizes [NumPriorities]int) {
+	for _, element := range b.activeNode {
+		nodeQueue := element.Value.(*NodeQueue)
+		for priority := range sizes {
+			sizes[priority] += nodeQueue.PrioritySize(Priority(priority))
+		}
+	}
+	return sizes
+}
+
 // NodeQueue returns the queue for the corresponding node.
 func (b *BufferQueue) NodeQueue(nodeID identity.ID) *NodeQueue {
 	element, ok := b.activeNode[nodeID]
@@ -70,7 +84,7 @@ func (b *BufferQueue) Submit(msg Element, rep float64) error {
 	if nodeActive {
 		nodeQueue = element.Value.(*NodeQueue)
 	} else {
-		nodeQueue = NewNodeQueue(nodeID)
+		nodeQueue = NewNodeQueue(nodeID, b.priorityFunc)
 	}
 
 	if float64(nodeQueue.Size()+size)/rep > b.maxQueue {
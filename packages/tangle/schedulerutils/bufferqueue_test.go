@@ -27,7 +27,7 @@ var (
 )
 
 func TestBufferQueue_Submit(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	var size int
 	for i := 0; i < numMessages; i++ {
@@ -41,7 +41,7 @@ func TestBufferQueue_Submit(t *testing.T) {
 }
 
 func TestBufferQueue_Unsubmit(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	messages := make([]*testMessage, numMessages)
 	for i := range messages {
@@ -59,7 +59,7 @@ func TestBufferQueue_Unsubmit(t *testing.T) {
 }
 
 func TestBufferQueue_Ready(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	messages := make([]*testMessage, numMessages)
 	for i := range messages {
@@ -75,7 +75,7 @@ func TestBufferQueue_Ready(t *testing.T) {
 }
 
 func TestBufferQueue_Time(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	future := newTestMessage(selfNode.PublicKey())
 	future.issuingTime = time.Now().Add(time.Second)
@@ -93,7 +93,7 @@ func TestBufferQueue_Time(t *testing.T) {
 }
 
 func TestBufferQueue_Ring(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	messages := make([]*testMessage, numMessages)
 	for i := range messages {
@@ -109,7 +109,7 @@ func TestBufferQueue_Ring(t *testing.T) {
 }
 
 func TestBufferQueue_IDs(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	assert.Empty(t, b.IDs())
 
@@ -126,7 +126,7 @@ func TestBufferQueue_IDs(t *testing.T) {
 }
 
 func TestBufferQueue_RemoveNode(t *testing.T) {
-	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue)
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, nil)
 
 	assert.NoError(t, b.Submit(newTestMessage(selfNode.PublicKey()), 1))
 
@@ -140,6 +140,81 @@ func TestBufferQueue_RemoveNode(t *testing.T) {
 	assert.Nil(t, b.Current())
 }
 
+func TestBufferQueue_Priorities(t *testing.T) {
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, testPriority)
+
+	otherNode := identity.GenerateIdentity()
+	normal := newTestMessage(selfNode.PublicKey())
+	normal.issuingTime = normal.issuingTime.Add(-3 * time.Second)
+	// messages of a higher priority class are issued later, so that they would be served last without priorities
+	high := newTestMessage(selfNode.PublicKey())
+	high.issuingTime = normal.issuingTime.Add(time.Second)
+	high.priority = schedulerutils.PriorityHigh
+	critical := newTestMessage(selfNode.PublicKey())
+	critical.issuingTime = normal.issuingTime.Add(2 * time.Second)
+	critical.priority = schedulerutils.PriorityCritical
+	other := newTestMessage(otherNode.PublicKey())
+	for _, msg := range []*testMessage{normal, high, critical, other} {
+		assert.NoError(t, b.Submit(msg, 10))
+		assert.True(t, b.Ready(msg))
+	}
+
+	sizes := b.PrioritySizes()
+	assert.Equal(t, normal.Size()+other.Size(), sizes[schedulerutils.PriorityNormal])
+	assert.Equal(t, high.Size(), sizes[schedulerutils.PriorityHigh])
+	assert.Equal(t, critical.Size(), sizes[schedulerutils.PriorityCritical])
+
+	// the messages of a node are served by priority class, while the nodes are still served in round robin order
+	assert.Equal(t, selfNode.ID(), b.Current().NodeID())
+	assert.Equal(t, critical, b.PopFront())
+	assert.Equal(t, otherNode.ID(), b.Next().NodeID())
+	assert.Equal(t, other, b.PopFront())
+	assert.Equal(t, selfNode.ID(), b.Current().NodeID())
+	assert.Equal(t, high, b.PopFront())
+	assert.Equal(t, normal, b.PopFront())
+
+	assert.Equal(t, [schedulerutils.NumPriorities]int{}, b.PrioritySizes())
+	assert.Nil(t, b.Current())
+}
+
+func TestBufferQueue_FuturePriority(t *testing.T) {
+	b := schedulerutils.NewBufferQueue(maxBuffer, maxQueue, testPriority)
+
+	// a critical message that is issued in the future must not block the due messages of the lower classes
+	critical := newTestMessage(selfNode.PublicKey())
+	critical.issuingTime = time.Now().Add(time.Hour)
+	critical.priority = schedulerutils.PriorityCritical
+	normal := newTestMessage(selfNode.PublicKey())
+	for _, msg := range []*testMessage{critical, normal} {
+		assert.NoError(t, b.Submit(msg, 10))
+		assert.True(t, b.Ready(msg))
+	}
+
+	assert.Equal(t, normal, b.Current().Front())
+	assert.Equal(t, normal, b.PopFront())
+	assert.Equal(t, critical, b.Current().Front())
+	assert.Equal(t, critical, b.PopFront())
+}
+
+func TestPriorityFromString(t *testing.T) {
+	for _, priority := range []schedulerutils.Priority{schedulerutils.PriorityNormal, schedulerutils.PriorityHigh, schedulerutils.PriorityCritical} {
+		parsed, err := schedulerutils.PriorityFromString(priority.String())
+		assert.NoError(t, err)
+		assert.Equal(t, priority, parsed)
+	}
+
+	parsed, err := schedulerutils.PriorityFromString("2")
+	assert.NoError(t, err)
+	assert.Equal(t, schedulerutils.PriorityCritical, parsed)
+
+	_, err = schedulerutils.PriorityFromString("urgent")
+	assert.Error(t, err)
+}
+
+func testPriority(element schedulerutils.Element) schedulerutils.Priority {
+	return element.(*testMessage).priority
+}
+
 func ringLen(b *schedulerutils.BufferQueue) int {
 	n := 0
 	if q := b.Current(); q != nil {
@@ -156,6 +231,7 @@ func ringLen(b *schedulerutils.BufferQueue) int {
 type testMessage struct {
 	pubKey      ed25519.PublicKey
 	issuingTime time.Time
+	priority    schedulerutils.Priority
 	bytes       []byte
 }
 
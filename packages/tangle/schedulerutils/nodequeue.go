@@ -3,11 +3,14 @@ package schedulerutils
 import (
 	"container/heap"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
 )
 
 // ElementIDLength defines the length of an ElementID.
@@ -42,23 +45,77 @@ type Element interface {
 	IssuingTime() time.Time
 }
 
+// region Priority /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// PriorityNormal is the priority class of all Elements that are not prioritized.
+	PriorityNormal Priority = iota
+	// PriorityHigh is the priority class of Elements that are scheduled before the normal ones.
+	PriorityHigh
+	// PriorityCritical is the priority class of Elements that are scheduled before all others.
+	PriorityCritical
+
+	// NumPriorities is the number of priority classes.
+	NumPriorities = int(PriorityCritical) + 1
+)
+
+// Priority is the priority class of an Element within the queue of its node. Ready Elements of a higher class are
+// scheduled before the ones of a lower class, while the order in which the nodes are served is not affected.
+type Priority uint8
+
+// PriorityFunc returns the priority class of an Element.
+type PriorityFunc func(element Element) Priority
+
+// PriorityFromString parses the name or the number of a priority class.
+func PriorityFromString(s string) (Priority, error) {
+	for p := PriorityNormal; int(p) < NumPriorities; p++ {
+		if s == p.String() || s == strconv.Itoa(int(p)) {
+			return p, nil
+		}
+	}
+	return PriorityNormal, fmt.Errorf("unknown priority class: %s", s)
+}
+
+// String returns the name of the priority class.
+func (p Priority) String() string {
+	switch p {
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "Priority(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region NodeQueue /////////////////////////////////////////////////////////////////////////////////////////////
 
-// NodeQueue keeps the submitted messages of a node
+// NodeQueue keeps the submitted messages of a node. The ready messages are kept in one inbox per priority class.
 type NodeQueue struct {
-	nodeID    identity.ID
-	submitted map[ElementID]*Element
-	inbox     *ElementHeap
-	size      atomic.Int64
+	nodeID        identity.ID
+	priorityFunc  PriorityFunc
+	submitted     map[ElementID]*Element
+	inboxes       [NumPriorities]*ElementHeap
+	size          atomic.Int64
+	prioritySizes [NumPriorities]atomic.Int64
 }
 
-// NewNodeQueue returns a new NodeQueue
-func NewNodeQueue(nodeID identity.ID) *NodeQueue {
-	return &NodeQueue{
-		nodeID:    nodeID,
-		submitted: make(map[ElementID]*Element),
-		inbox:     new(ElementHeap),
+// NewNodeQueue returns a new NodeQueue that classifies its messages with the given PriorityFunc. If the PriorityFunc is
+// nil, all messages have the PriorityNormal class.
+func NewNodeQueue(nodeID identity.ID, priorityFunc PriorityFunc) *NodeQueue {
+	q := &NodeQueue{
+		nodeID:       nodeID,
+		priorityFunc: priorityFunc,
+		submitted:    make(map[ElementID]*Element),
+	}
+	for i := range q.inboxes {
+		q.inboxes[i] = new(ElementHeap)
 	}
+	return q
 }
 
 // Size returns the total size of the messages in the queue.
@@ -70,6 +127,26 @@ func (q *NodeQueue) Size() int {
 	return int(q.size.Load())
 }
 
+// PrioritySize returns the total size of the messages of the given priority class in the queue.
+// This function is thread-safe.
+func (q *NodeQueue) PrioritySize(priority Priority) int {
+	if q == nil || int(priority) >= NumPriorities {
+		return 0
+	}
+	return int(q.prioritySizes[priority].Load())
+}
+
+// Priority returns the priority class of the given message.
+func (q *NodeQueue) Priority(element Element) Priority {
+	if q.priorityFunc == nil {
+		return PriorityNormal
+	}
+	if priority := q.priorityFunc(element); int(priority) < NumPriorities {
+		return priority
+	}
+	return PriorityCritical
+}
+
 // NodeID returns the ID of the node belonging to the queue.
 func (q *NodeQueue) NodeID() identity.ID {
 	return q.nodeID
@@ -89,6 +166,7 @@ func (q *NodeQueue) Submit(element Element) bool {
 
 	q.submitted[id] = &element
 	q.size.Add(int64(element.Size()))
+	q.prioritySizes[q.Priority(element)].Add(int64(element.Size()))
 	return true
 }
 
@@ -101,6 +179,7 @@ func (q *NodeQueue) Unsubmit(element Element) bool {
 
 	delete(q.submitted, id)
 	q.size.Sub(int64(element.Size()))
+	q.prioritySizes[q.Priority(element)].Sub(int64(element.Size()))
 	return true
 }
 
@@ -112,36 +191,72 @@ func (q *NodeQueue) Ready(element Element) bool {
 	}
 
 	delete(q.submitted, id)
-	heap.Push(q.inbox, element)
+	heap.Push(q.inboxes[q.Priority(element)], element)
 	return true
 }
 
 // IDs returns the IDs of all submitted messages (ready or not).
 func (q *NodeQueue) IDs() (ids []ElementID) {
+	return append(q.SubmittedIDs(), q.ReadyIDs()...)
+}
+
+// SubmittedIDs returns the IDs of all submitted messages that are not ready yet.
+func (q *NodeQueue) SubmittedIDs() (ids []ElementID) {
 	for id := range q.submitted {
 		ids = append(ids, id)
 	}
-	for _, element := range *q.inbox {
-		ids = append(ids, ElementIDFromBytes(element.IDBytes()))
+	return ids
+}
+
+// ReadyIDs returns the IDs of all ready messages.
+func (q *NodeQueue) ReadyIDs() (ids []ElementID) {
+	for _, inbox := range q.inboxes {
+		for _, element := range *inbox {
+			ids = append(ids, ElementIDFromBytes(element.IDBytes()))
+		}
 	}
 	return ids
 }
 
-// Front returns the first ready message in the queue.
+// Front returns the first ready message of the highest priority class whose issuing time is not in the future. If no
+// ready message is due yet, it returns the one with the earliest issuing time.
 func (q *NodeQueue) Front() Element {
-	if q == nil || q.inbox.Len() == 0 {
+	if q == nil {
 		return nil
 	}
-	return (*q.inbox)[0]
+	if inbox := q.frontInbox(clock.SyncedTime()); inbox != nil {
+		return (*inbox)[0]
+	}
+	return nil
 }
 
-// PopFront removes the first ready message from the queue.
+// PopFront removes the message that is returned by Front from the queue.
 func (q *NodeQueue) PopFront() Element {
-	msg := heap.Pop(q.inbox).(Element)
+	msg := heap.Pop(q.frontInbox(clock.SyncedTime())).(Element)
 	q.size.Sub(int64(msg.Size()))
+	q.prioritySizes[q.Priority(msg)].Sub(int64(msg.Size()))
 	return msg
 }
 
+// frontInbox returns the inbox of the highest priority class whose first message is due at the given time. A message
+// that is issued in the future must not hold back the due messages of the lower classes, so if no first message is due
+// yet, the inbox with the earliest first message is returned (or nil if there are no ready messages).
+func (q *NodeQueue) frontInbox(now time.Time) (earliest *ElementHeap) {
+	for priority := NumPriorities - 1; priority >= 0; priority-- {
+		inbox := q.inboxes[priority]
+		if inbox.Len() == 0 {
+			continue
+		}
+		if !now.Before((*inbox)[0].IssuingTime()) {
+			return inbox
+		}
+		if earliest == nil || (*inbox)[0].IssuingTime().Before((*earliest)[0].IssuingTime()) {
+			earliest = inbox
+		}
+	}
+	return earliest
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region ElementHeap /////////////////////////////////////////////////////////////////////////////////////////////
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/objectstorage"
 	"github.com/iotaledger/hive.go/stringify"
@@ -56,6 +57,12 @@ const (
 	// PrefixMarkerMessageMapping defines the storage prefix for the MarkerMessageMapping.
 	PrefixMarkerMessageMapping
 
+	// PrefixMessageIndex defines the storage prefix for the MessageIndexEntry.
+	PrefixMessageIndex
+
+	// PrefixTagIndex defines the storage prefix for the TagIndexEntry.
+	PrefixTagIndex
+
 	// DBSequenceNumber defines the db sequence number.
 	DBSequenceNumber = "seq"
 )
@@ -80,6 +87,8 @@ type Storage struct {
 	statementStorage                  *objectstorage.ObjectStorage
 	branchWeightStorage               *objectstorage.ObjectStorage
 	markerMessageMappingStorage       *objectstorage.ObjectStorage
+	messageIndexStore                 kvstore.KVStore
+	tagIndexStore                     kvstore.KVStore
 
 	Events   *StorageEvents
 	shutdown chan struct{}
@@ -104,6 +113,8 @@ func NewStorage(tangle *Tangle) (storage *Storage) {
 		statementStorage:                  osFactory.New(PrefixStatement, StatementFromObjectStorage, objectstorage.CacheTime(CacheTime), objectstorage.LeakDetectionEnabled(false)),
 		branchWeightStorage:               osFactory.New(PrefixBranchWeight, BranchWeightFromObjectStorage, objectstorage.CacheTime(CacheTime), objectstorage.LeakDetectionEnabled(false)),
 		markerMessageMappingStorage:       osFactory.New(PrefixMarkerMessageMapping, MarkerMessageMappingFromObjectStorage, objectstorage.CacheTime(CacheTime), MarkerMessageMappingPartitionKeys),
+		messageIndexStore:                 tangle.Options.Store.WithRealm([]byte{database.PrefixTangle, PrefixMessageIndex}),
+		tagIndexStore:                     tangle.Options.Store.WithRealm([]byte{database.PrefixTangle, PrefixTagIndex}),
 
 		Events: &StorageEvents{
 			MessageStored:        events.NewEvent(MessageIDCaller),
@@ -125,6 +136,11 @@ func (s *Storage) Setup() {
 	s.tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
 		s.DeleteMessage(messageID)
 	}))
+
+	s.Events.MessageStored.Attach(events.NewClosure(s.indexTag))
+	if s.tangle.Options.MessageIndex {
+		s.Events.MessageStored.Attach(events.NewClosure(s.indexMessage))
+	}
 }
 
 // StoreMessage stores a new message to the message store.
@@ -262,6 +278,11 @@ func (s *Storage) DeleteMessage(messageID MessageID) {
 			s.deleteWeakApprover(parentMessageID, messageID)
 		})
 
+		s.unindexTag(currentMsg)
+		if s.tangle.Options.MessageIndex {
+			s.unindexMessage(currentMsg)
+		}
+
 		s.messageMetadataStorage.Delete(messageID[:])
 		s.messageStorage.Delete(messageID[:])
 
@@ -453,6 +474,12 @@ func (s *Storage) Prune() error {
 			return err
 		}
 	}
+	if err := s.messageIndexStore.Clear(); err != nil {
+		return fmt.Errorf("failed to prune message index: %w", err)
+	}
+	if err := s.tagIndexStore.Clear(); err != nil {
+		return fmt.Errorf("failed to prune tag index: %w", err)
+	}
 
 	s.storeGenesis()
 
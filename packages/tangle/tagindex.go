@@ -0,0 +1,170 @@
+package tangle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// region TagIndexEntry ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// TagHashLength contains the amount of bytes of the hash of a tag.
+const TagHashLength = blake2b.Size256
+
+// TagIndexEntryLength holds the length of a marshaled TagIndexEntry in bytes.
+const TagIndexEntryLength = TagHashLength + marshalutil.Uint64Size + MessageIDLength
+
+// TagIndexEntry is an entry of the index of the Messages with a TaggedDataPayload. Since tags have a variable length,
+// the entries are keyed by the hash of the tag, followed by the issuing time and the MessageID of the Message. The
+// issuing time is encoded in big endian, so that the database orders the entries of a tag by their issuing time.
+type TagIndexEntry struct {
+	tagHash     [TagHashLength]byte
+	issuingTime time.Time
+	messageID   MessageID
+}
+
+// NewTagIndexEntry creates a TagIndexEntry for the Message with the given tag.
+func NewTagIndexEntry(tag []byte, message *Message) *TagIndexEntry {
+	return &TagIndexEntry{
+		tagHash:     blake2b.Sum256(tag),
+		issuingTime: message.IssuingTime(),
+		messageID:   message.ID(),
+	}
+}
+
+// TagIndexEntryFromBytes unmarshals a TagIndexEntry from a sequence of bytes.
+func TagIndexEntryFromBytes(bytes []byte) (entry *TagIndexEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if entry, err = TagIndexEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TagIndexEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// TagIndexEntryFromMarshalUtil unmarshals a TagIndexEntry using a MarshalUtil (for easier unmarshaling).
+func TagIndexEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (entry *TagIndexEntry, err error) {
+	entryBytes, err := marshalUtil.ReadBytes(TagIndexEntryLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse TagIndexEntry (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	entry = &TagIndexEntry{}
+	copy(entry.tagHash[:], entryBytes)
+	entry.issuingTime = time.Unix(0, int64(binary.BigEndian.Uint64(entryBytes[TagHashLength:])))
+	copy(entry.messageID[:], entryBytes[TagHashLength+marshalutil.Uint64Size:])
+
+	return
+}
+
+// IssuingTime returns the issuing time of the indexed Message.
+func (t *TagIndexEntry) IssuingTime() time.Time {
+	return t.issuingTime
+}
+
+// MessageID returns the MessageID of the indexed Message.
+func (t *TagIndexEntry) MessageID() MessageID {
+	return t.messageID
+}
+
+// Bytes returns a marshaled version of the TagIndexEntry.
+func (t *TagIndexEntry) Bytes() []byte {
+	return marshalutil.New(TagIndexEntryLength).
+		WriteBytes(t.tagHash[:]).
+		WriteBytes(messageIndexTime(t.issuingTime)).
+		WriteBytes(t.messageID.Bytes()).
+		Bytes()
+}
+
+// String returns a human readable version of the TagIndexEntry.
+func (t *TagIndexEntry) String() string {
+	return stringify.Struct("TagIndexEntry",
+		stringify.StructField("tagHash", t.tagHash[:]),
+		stringify.StructField("issuingTime", t.issuingTime),
+		stringify.StructField("messageID", t.messageID),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Storage methods //////////////////////////////////////////////////////////////////////////////////////////////
+
+// indexTag adds the Message with the given MessageID to the tag index if it contains a TaggedDataPayload.
+func (s *Storage) indexTag(messageID MessageID) {
+	s.Message(messageID).Consume(func(message *Message) {
+		taggedDataPayload, ok := message.Payload().(*payload.TaggedDataPayload)
+		if !ok {
+			return
+		}
+
+		entry := NewTagIndexEntry(taggedDataPayload.Tag(), message)
+		if err := s.tagIndexStore.Set(entry.Bytes(), nil); err != nil {
+			panic(fmt.Errorf("failed to store %s: %w", entry, err))
+		}
+	})
+}
+
+// unindexTag removes the given Message from the tag index.
+func (s *Storage) unindexTag(message *Message) {
+	if taggedDataPayload, ok := message.Payload().(*payload.TaggedDataPayload); ok {
+		entry := NewTagIndexEntry(taggedDataPayload.Tag(), message)
+		if err := s.tagIndexStore.Delete(entry.Bytes()); err != nil {
+			panic(fmt.Errorf("failed to delete %s: %w", entry, err))
+		}
+	}
+}
+
+// TaggedMessages returns the entries of the tag index of the given tag ordered by their issuing time. It returns at
+// most limit entries (0 means no limit) that follow the optional after entry (the last entry of a previous page). The
+// index is iterated from the issuing time of the after entry and the iteration stops as soon as the page is complete.
+// The returned flag indicates whether more entries exist after the returned page.
+func (s *Storage) TaggedMessages(tag []byte, after *TagIndexEntry, limit int) (entries []*TagIndexEntry, more bool, err error) {
+	tagHash := blake2b.Sum256(tag)
+	start := tagHash[:]
+	var afterKey []byte
+	if after != nil {
+		start = byteutils.ConcatBytes(tagHash[:], messageIndexTime(after.issuingTime))
+		afterKey = byteutils.ConcatBytes(tagHash[:], messageIndexTime(after.issuingTime), after.messageID.Bytes())
+	}
+
+	iterationErr := database.IterateKeysFrom(s.tagIndexStore, tagHash[:], start, func(key kvstore.Key) bool {
+		if afterKey != nil && bytes.Compare(key, afterKey) <= 0 {
+			return true
+		}
+
+		entry, _, parseErr := TagIndexEntryFromBytes(key)
+		if parseErr != nil {
+			err = errors.Errorf("failed to parse tag index entry: %w", parseErr)
+			return false
+		}
+		if limit > 0 && len(entries) == limit {
+			more = true
+			return false
+		}
+		entries = append(entries, entry)
+
+		return true
+	})
+	if iterationErr != nil {
+		return nil, false, errors.Errorf("failed to iterate the tag index: %w", iterationErr)
+	}
+
+	return entries, more, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,89 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestStorage_TaggedMessages(t *testing.T) {
+	tangle := newTestTangle()
+	defer tangle.Shutdown()
+	tangle.Storage.Setup()
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	start := time.Now().Add(-time.Hour)
+	storeMessage := func(issuingTime time.Time, messagePayload payload.Payload) MessageID {
+		message := NewMessage([]MessageID{EmptyMessageID}, []MessageID{}, issuingTime, issuer, nextSequenceNumber(), messagePayload, 0, ed25519.Signature{})
+		tangle.Storage.StoreMessage(message)
+		return message.ID()
+	}
+
+	var taggedMessageIDs []MessageID
+	for i := 0; i < 5; i++ {
+		taggedDataPayload, err := payload.NewTaggedDataPayload([]byte("tag"), []byte("data"))
+		require.NoError(t, err)
+		taggedMessageIDs = append(taggedMessageIDs, storeMessage(start.Add(time.Duration(i)*time.Minute), taggedDataPayload))
+
+		otherPayload, err := payload.NewTaggedDataPayload([]byte("other"), []byte("data"))
+		require.NoError(t, err)
+		storeMessage(start.Add(time.Duration(i)*time.Minute), otherPayload)
+		storeMessage(start.Add(time.Duration(i)*time.Minute), payload.NewGenericDataPayload([]byte("tag")))
+	}
+
+	entries, more, err := tangle.Storage.TaggedMessages([]byte("tag"), nil, 0)
+	require.NoError(t, err)
+	assert.False(t, more)
+	assert.Equal(t, taggedMessageIDs, tagEntryMessageIDs(entries))
+
+	entries, more, err = tangle.Storage.TaggedMessages([]byte("tag"), nil, 3)
+	require.NoError(t, err)
+	assert.True(t, more)
+	assert.Equal(t, taggedMessageIDs[:3], tagEntryMessageIDs(entries))
+
+	after, _, err := TagIndexEntryFromBytes(entries[2].Bytes())
+	require.NoError(t, err)
+	entries, more, err = tangle.Storage.TaggedMessages([]byte("tag"), after, 3)
+	require.NoError(t, err)
+	assert.False(t, more)
+	assert.Equal(t, taggedMessageIDs[3:], tagEntryMessageIDs(entries))
+
+	entries, _, err = tangle.Storage.TaggedMessages([]byte("unknown"), nil, 0)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	// deleted messages are removed from the index
+	tangle.Storage.DeleteMessage(taggedMessageIDs[0])
+	entries, _, err = tangle.Storage.TaggedMessages([]byte("tag"), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, taggedMessageIDs[1:], tagEntryMessageIDs(entries))
+}
+
+func TestTaggedDataPayload(t *testing.T) {
+	taggedDataPayload, err := payload.NewTaggedDataPayload([]byte("tag"), []byte("data"))
+	require.NoError(t, err)
+
+	restored, _, err := payload.FromBytes(taggedDataPayload.Bytes())
+	require.NoError(t, err)
+	require.IsType(t, &payload.TaggedDataPayload{}, restored)
+	assert.Equal(t, []byte("tag"), restored.(*payload.TaggedDataPayload).Tag())
+	assert.Equal(t, []byte("data"), restored.(*payload.TaggedDataPayload).Data())
+
+	_, err = payload.NewTaggedDataPayload(nil, []byte("data"))
+	assert.Error(t, err)
+	_, err = payload.NewTaggedDataPayload(make([]byte, payload.MaxTagLength+1), []byte("data"))
+	assert.Error(t, err)
+}
+
+func tagEntryMessageIDs(entries []*TagIndexEntry) (messageIDs []MessageID) {
+	for _, entry := range entries {
+		messageIDs = append(messageIDs, entry.MessageID())
+	}
+
+	return messageIDs
+}
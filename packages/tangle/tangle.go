@@ -11,11 +11,11 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore"
-	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/timeutil"
 	"github.com/iotaledger/hive.go/typeutils"
 	"github.com/mr-tron/base58"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/markers"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
@@ -92,7 +92,7 @@ func New(options ...Option) (tangle *Tangle) {
 func (t *Tangle) Configure(options ...Option) {
 	if t.Options == nil {
 		t.Options = &Options{
-			Store:                        mapdb.NewMapDB(),
+			Store:                        database.NewSortedMapDB(),
 			Identity:                     identity.GenerateLocalIdentity(),
 			IncreaseMarkersIndexCallback: increaseMarkersIndexCallbackStrategy,
 		}
@@ -292,6 +292,7 @@ type Options struct {
 	WeightProvider               WeightProvider
 	SyncTimeWindow               time.Duration
 	StartSynced                  bool
+	MessageIndex                 bool
 }
 
 // Store is an Option for the Tangle that allows to specify which storage layer is supposed to be used to persist data.
@@ -381,6 +382,14 @@ func StartSynced(startSynced bool) Option {
 	}
 }
 
+// MessageIndex is an Option for the Tangle that allows to maintain a secondary index of the Messages by payload type,
+// issuer and issuing time.
+func MessageIndex(enabled bool) Option {
+	return func(options *Options) {
+		options.MessageIndex = enabled
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region WeightProvider //////////////////////////////////////////////////////////////////////////////////////////////////////
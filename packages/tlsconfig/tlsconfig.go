@@ -0,0 +1,180 @@
+// Package tlsconfig provides the TLS configuration that is shared by the servers of the node (web API, dashboard and
+// txstream). Certificates are reloaded automatically whenever their files change, so they can be renewed without
+// restarting the node.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// reloadInterval defines how often the files of the certificates are checked for changes.
+const reloadInterval = time.Second
+
+// ErrInvalidConfig is returned if the TLS configuration is incomplete or contradictory.
+var ErrInvalidConfig = errors.New("invalid TLS configuration")
+
+// Config contains the file based settings of the TLS configuration.
+type Config struct {
+	// CertFile is the path of the PEM encoded certificate (chain) of the server.
+	CertFile string
+	// KeyFile is the path of the PEM encoded private key of the server.
+	KeyFile string
+	// ClientCAFile is the path of the PEM encoded CA certificates that are used to verify client certificates.
+	ClientCAFile string
+	// RequireClientCert enables mutual TLS - clients have to present a certificate that is signed by a client CA.
+	RequireClientCert bool
+}
+
+// Reloader holds the certificates of a Config and reloads them whenever their files change.
+type Reloader struct {
+	config Config
+
+	certificate   *tls.Certificate
+	clientCAs     *x509.CertPool
+	certModTime   time.Time
+	keyModTime    time.Time
+	clientModTime time.Time
+	lastCheck     time.Time
+	mutex         sync.Mutex
+
+	// ReloadErrorHandler is called if the changed certificates can not be loaded (the last valid ones remain in use).
+	ReloadErrorHandler func(err error)
+	// ReloadHandler is called after the certificates were reloaded successfully.
+	ReloadHandler func()
+}
+
+// NewReloader creates a Reloader for the given Config and loads the certificates for the first time.
+func NewReloader(config Config) (reloader *Reloader, err error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.Errorf("certificate and key file have to be set: %w", ErrInvalidConfig)
+	}
+	if config.RequireClientCert && config.ClientCAFile == "" {
+		return nil, errors.Errorf("client certificates can not be verified without a client CA file: %w", ErrInvalidConfig)
+	}
+
+	reloader = &Reloader{config: config}
+	if _, err = reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// ServerConfig returns a tls.Config for servers that always uses the latest certificates of the Reloader.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			certificate, clientCAs := r.current()
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*certificate},
+				ClientCAs:    clientCAs,
+				ClientAuth:   tls.NoClientCert,
+			}
+			if clientCAs != nil {
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+			if r.config.RequireClientCert {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+
+			return config, nil
+		},
+	}
+}
+
+// current returns the current certificates and reloads them first if their files changed.
+func (r *Reloader) current() (certificate *tls.Certificate, clientCAs *x509.CertPool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if time.Since(r.lastCheck) >= reloadInterval {
+		reloaded, err := r.reload()
+		switch {
+		case err != nil && r.ReloadErrorHandler != nil:
+			r.ReloadErrorHandler(err)
+		case reloaded && r.ReloadHandler != nil:
+			r.ReloadHandler()
+		}
+	}
+
+	return r.certificate, r.clientCAs
+}
+
+// reload loads the certificates if their files changed since they were loaded the last time.
+func (r *Reloader) reload() (reloaded bool, err error) {
+	r.lastCheck = time.Now()
+
+	certModTime, err := modTime(r.config.CertFile)
+	if err != nil {
+		return false, err
+	}
+	keyModTime, err := modTime(r.config.KeyFile)
+	if err != nil {
+		return false, err
+	}
+	var clientModTime time.Time
+	if r.config.ClientCAFile != "" {
+		if clientModTime, err = modTime(r.config.ClientCAFile); err != nil {
+			return false, err
+		}
+	}
+
+	if r.certificate != nil && certModTime.Equal(r.certModTime) && keyModTime.Equal(r.keyModTime) && clientModTime.Equal(r.clientModTime) {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return false, errors.Errorf("failed to load key pair %s, %s: %w", r.config.CertFile, r.config.KeyFile, err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		if clientCAs, err = LoadCertPool(r.config.ClientCAFile); err != nil {
+			return false, err
+		}
+	}
+
+	r.certificate = &certificate
+	r.clientCAs = clientCAs
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	r.clientModTime = clientModTime
+
+	return true, nil
+}
+
+// LoadCertPool reads the PEM encoded certificates from the given file into a new CertPool.
+func LoadCertPool(path string) (certPool *x509.CertPool, err error) {
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read CA file %s: %w", path, err)
+	}
+
+	certPool = x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(pemBytes) {
+		return nil, errors.Errorf("CA file %s does not contain any PEM encoded certificates: %w", path, ErrInvalidConfig)
+	}
+
+	return certPool, nil
+}
+
+// modTime returns the modification time of the file with the given path.
+func modTime(path string) (time.Time, error) {
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, errors.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	return fileInfo.ModTime(), nil
+}
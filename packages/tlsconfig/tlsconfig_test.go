@@ -0,0 +1,157 @@
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	writeCertificate(t, certFile, keyFile, "first", nil)
+
+	reloader, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+	reloaded := make(chan struct{}, 1)
+	reloader.ReloadHandler = func() { reloaded <- struct{}{} }
+
+	listener := listen(t, reloader.ServerConfig())
+	assert.Equal(t, "first", serverCommonName(t, listener.Addr().String()))
+
+	writeCertificate(t, certFile, keyFile, "second", nil)
+	require.NoError(t, os.Chtimes(certFile, time.Now(), time.Now().Add(time.Minute)))
+	require.NoError(t, os.Chtimes(keyFile, time.Now(), time.Now().Add(time.Minute)))
+	time.Sleep(reloadInterval)
+
+	assert.Equal(t, "second", serverCommonName(t, listener.Addr().String()))
+	assert.Len(t, reloaded, 1)
+
+	_, err = NewReloader(Config{CertFile: certFile})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestReloader_ClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	caFile, caKeyFile := filepath.Join(dir, "ca.crt"), filepath.Join(dir, "ca.key")
+	writeCertificate(t, certFile, keyFile, "server", nil)
+	ca := writeCertificate(t, caFile, caKeyFile, "ca", nil)
+
+	reloader, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true})
+	require.NoError(t, err)
+	listener := listen(t, reloader.ServerConfig())
+
+	// a client without a certificate is rejected
+	assert.Error(t, handshake(listener.Addr().String(), nil))
+
+	// a client with a certificate signed by the client CA is accepted
+	clientCertFile, clientKeyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	writeCertificate(t, clientCertFile, clientKeyFile, "client", ca)
+	clientCertificate, err := tls.LoadX509KeyPair(clientCertFile, clientKeyFile)
+	require.NoError(t, err)
+	assert.NoError(t, handshake(listener.Addr().String(), &clientCertificate))
+}
+
+// listen starts a TLS listener that completes the handshake of every accepted connection.
+func listen(t *testing.T, config *tls.Config) net.Listener {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, acceptErr := listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if conn.(*tls.Conn).Handshake() == nil {
+					_, _ = conn.Write([]byte{1})
+				}
+			}()
+		}
+	}()
+
+	return listener
+}
+
+// handshake connects to the given address and returns an error if the server rejected the connection.
+func handshake(address string, clientCertificate *tls.Certificate) error {
+	config := &tls.Config{InsecureSkipVerify: true} // #nosec G402
+	if clientCertificate != nil {
+		config.Certificates = []tls.Certificate{*clientCertificate}
+	}
+	conn, err := tls.Dial("tcp", address, config)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// TLS 1.3 reports a rejected client certificate on the first read
+	_, err = conn.Read(make([]byte, 1))
+
+	return err
+}
+
+// serverCommonName returns the common name of the certificate that is presented by the server.
+func serverCommonName(t *testing.T, address string) string {
+	conn, err := tls.Dial("tcp", address, &tls.Config{InsecureSkipVerify: true}) // #nosec G402
+	require.NoError(t, err)
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+}
+
+type certificateAuthority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// writeCertificate creates a certificate with the given common name that is signed by the given CA (self-signed if
+// nil) and writes it and its key to the given files.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string, ca *certificateAuthority) *certificateAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  ca == nil,
+	}
+	parent, signer := template, key
+	if ca != nil {
+		parent, signer = ca.certificate, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0o600))
+
+	return &certificateAuthority{certificate: certificate, key: key}
+}
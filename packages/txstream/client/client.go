@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
+	"go.uber.org/atomic"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/txstream"
@@ -17,8 +18,12 @@ type Client struct {
 	chSend        chan txstream.Message
 	chSubscribe   chan ledgerstate.Address
 	chUnsubscribe chan ledgerstate.Address
-	shutdown      chan bool
-	Events        Events
+	// chResubscribe signals the subscriptions loop to send all subscriptions after a reconnect
+	chResubscribe chan struct{}
+	// lastSeq is the highest sequence number received from the server, i.e. the resume token
+	lastSeq  atomic.Uint64
+	shutdown chan bool
+	Events   Events
 }
 
 // Events contains all events emitted by the Client
@@ -31,6 +36,8 @@ type Events struct {
 	OutputReceived *events.Event
 	// UnspentAliasOutputReceived is triggered whenever an unspent AliasOutput is received
 	UnspentAliasOutputReceived *events.Event
+	// ResumeStateReceived is triggered when the server replies to the resume token sent after connecting
+	ResumeStateReceived *events.Event
 	// Connected is triggered when the client connects successfully to the server
 	Connected *events.Event
 }
@@ -54,27 +61,38 @@ func handleInclusionStateReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*txstream.MsgTxInclusionState))(params[0].(*txstream.MsgTxInclusionState))
 }
 
+func handleResumeStateReceived(handler interface{}, params ...interface{}) {
+	handler.(func(*txstream.MsgResumeState))(params[0].(*txstream.MsgResumeState))
+}
+
 func handleConnected(handler interface{}, params ...interface{}) {
 	handler.(func())()
 }
 
-// New creates a new client
-func New(clientID string, log *logger.Logger, dial DialFunc) *Client {
+// New creates a new client. The optional resumeToken is a value previously
+// returned by ResumeToken, it lets the server replay the confirmed transactions
+// that were missed while the client was not running.
+func New(clientID string, log *logger.Logger, dial DialFunc, resumeToken ...uint64) *Client {
 	n := &Client{
 		clientID:      clientID,
 		log:           log,
 		chSend:        make(chan txstream.Message),
 		chSubscribe:   make(chan ledgerstate.Address),
 		chUnsubscribe: make(chan ledgerstate.Address),
+		chResubscribe: make(chan struct{}, 1),
 		shutdown:      make(chan bool),
 		Events: Events{
 			TransactionReceived:        events.NewEvent(handleTransactionReceived),
 			InclusionStateReceived:     events.NewEvent(handleInclusionStateReceived),
 			OutputReceived:             events.NewEvent(handleOutputReceived),
 			UnspentAliasOutputReceived: events.NewEvent(handleUnspentAliasOutputReceived),
+			ResumeStateReceived:        events.NewEvent(handleResumeStateReceived),
 			Connected:                  events.NewEvent(handleConnected),
 		},
 	}
+	if len(resumeToken) > 0 {
+		n.lastSeq.Store(resumeToken[0])
+	}
 
 	go n.subscriptionsLoop()
 	go n.connectLoop(dial)
@@ -82,6 +100,13 @@ func New(clientID string, log *logger.Logger, dial DialFunc) *Client {
 	return n
 }
 
+// ResumeToken returns the sequence number of the last confirmed transaction
+// received from the server. It can be persisted and passed to New to resume
+// the stream after a restart.
+func (n *Client) ResumeToken() uint64 {
+	return n.lastSeq.Load()
+}
+
 // Close shuts down the client
 func (n *Client) Close() {
 	close(n.shutdown)
@@ -89,5 +114,6 @@ func (n *Client) Close() {
 	n.Events.InclusionStateReceived.DetachAll()
 	n.Events.OutputReceived.DetachAll()
 	n.Events.UnspentAliasOutputReceived.DetachAll()
+	n.Events.ResumeStateReceived.DetachAll()
 	n.Events.Connected.DetachAll()
 }
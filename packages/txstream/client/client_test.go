@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -38,19 +39,37 @@ func start(t *testing.T) (*utxodbledger.UtxoDBLedger, *Client) {
 	ledger := utxodbledger.New(log)
 	t.Cleanup(ledger.Detach)
 
+	txLog := newTxLog(t, ledger)
+
+	n := connect(t, ledger, txLog, "test")
+	t.Cleanup(n.Close)
+
+	return ledger, n
+}
+
+func newTxLog(t *testing.T, ledger *utxodbledger.UtxoDBLedger) *txstream.TxLog {
+	t.Helper()
+
+	txLog, err := txstream.NewTxLog(ledger, mapdb.NewMapDB(), 100)
+	require.NoError(t, err)
+	t.Cleanup(txLog.Detach)
+
+	return txLog
+}
+
+func connect(t *testing.T, ledger *utxodbledger.UtxoDBLedger, txLog *txstream.TxLog, clientID string, resumeToken ...uint64) *Client {
+	t.Helper()
+
 	done := make(chan struct{})
 	t.Cleanup(func() { close(done) })
 
 	dial := DialFunc(func() (string, net.Conn, error) {
 		conn1, conn2 := net.Pipe()
-		go server.Run(conn2, log.Named("txstream/server"), ledger, done)
+		go server.Run(conn2, log.Named("txstream/server"), ledger, txLog, done)
 		return "pipe", conn1, nil
 	})
 
-	n := New("test", log.Named("txstream/client"), dial)
-	t.Cleanup(n.Close)
-
-	return ledger, n
+	return New(clientID, log.Named("txstream/client"), dial, resumeToken...)
 }
 
 func send(t *testing.T, n *Client, sendMsg func(), callback func(msg txstream.Message) bool) {
@@ -304,3 +323,70 @@ func TestSubscribe(t *testing.T) {
 	)
 	require.EqualValues(t, txMsg.Tx.ID(), reqTx.ID())
 }
+
+func TestResume(t *testing.T) {
+	ledger := utxodbledger.New(log)
+	t.Cleanup(ledger.Detach)
+	txLog := newTxLog(t, ledger)
+
+	n := connect(t, ledger, txLog, "test")
+	createTx, chainAddress := createAliasChain(t, ledger, creatorIndex, stateControlIndex, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100})
+
+	// the backlog is sent once the subscription is processed by the server
+	send(t, n,
+		func() {
+			n.Subscribe(chainAddress)
+		},
+		func(msg txstream.Message) bool {
+			if msg, ok := msg.(*txstream.MsgTransaction); ok {
+				return msg.Tx.ID() == createTx.ID()
+			}
+			return false
+		},
+	)
+
+	// receive a sequenced notification to obtain a resume token
+	var reqTx *ledgerstate.Transaction
+	send(t, n,
+		func() {
+			reqTx = postRequest(t, ledger, 2, chainAddress)
+		},
+		func(msg txstream.Message) bool {
+			if msg, ok := msg.(*txstream.MsgTransaction); ok {
+				return msg.Tx.ID() == reqTx.ID() && msg.Seq != 0
+			}
+			return false
+		},
+	)
+	resumeToken := n.ResumeToken()
+	require.Equal(t, txLog.LastSeq(), resumeToken)
+	n.Close()
+
+	// confirm a transaction while the client is gone
+	missedTx := postRequest(t, ledger, 2, chainAddress)
+
+	// a new client resuming from the token gets the missed transaction replayed
+	resumed := connect(t, ledger, txLog, "test", resumeToken)
+	t.Cleanup(resumed.Close)
+	var replayed *txstream.MsgTransaction
+	send(t, resumed,
+		func() {
+			resumed.Subscribe(chainAddress)
+		},
+		func(msg txstream.Message) bool {
+			if msg, ok := msg.(*txstream.MsgTransaction); ok && msg.Tx.ID() == missedTx.ID() && msg.Seq != 0 {
+				replayed = msg
+				return true
+			}
+			return false
+		},
+	)
+	require.Equal(t, resumeToken+1, replayed.Seq)
+	require.True(t, chainAddress.Equals(replayed.Address))
+
+	// acknowledgements are persisted for the client ID
+	require.Eventually(t, func() bool {
+		acked, err := txLog.Acked("test")
+		return err == nil && acked == replayed.Seq
+	}, 10*time.Second, 100*time.Millisecond)
+}
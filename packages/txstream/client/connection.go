@@ -20,6 +20,7 @@ const (
 	dialRetries  = 10
 	backoffDelay = 500 * time.Millisecond
 	retryAfter   = 8 * time.Second
+	ackInterval  = time.Second
 )
 
 // retry net.Dial once, on fail after 0.5s
@@ -102,10 +103,28 @@ func (n *Client) connect(dial DialFunc, msgChopper *chopper.Chopper) bool {
 	if err := n.send(&txstream.MsgSetID{ClientID: n.clientID}, bconn, msgChopper); err != nil {
 		n.log.Errorf("sending client ID to server: %v", err)
 	}
+	// send resume token, the server replays what we missed once we subscribe
+	lastAcked := n.lastSeq.Load()
+	if err := n.send(&txstream.MsgResume{Seq: lastAcked}, bconn, msgChopper); err != nil {
+		n.log.Errorf("sending resume token to server: %v", err)
+	}
+	n.resubscribe()
+
+	ackTicker := time.NewTicker(ackInterval)
+	defer ackTicker.Stop()
 
 	// r/w loop
 	for {
 		select {
+		case <-ackTicker.C:
+			// acknowledge everything that was processed by the event handlers
+			if seq := n.lastSeq.Load(); seq > lastAcked {
+				if err := n.send(&txstream.MsgAck{Seq: seq}, bconn, msgChopper); err != nil {
+					n.log.Errorf("sending ack to server: %v", err)
+					continue
+				}
+				lastAcked = seq
+			}
 		case msg := <-n.chSend:
 			if err := n.send(msg, bconn, msgChopper); err != nil {
 				n.log.Errorf("sending message to server (%T): %v", msg, err)
@@ -140,6 +159,16 @@ func (n *Client) decodeReceivedMessage(data []byte, msgChopper *chopper.Chopper)
 	case *txstream.MsgTransaction:
 		n.log.Debugf("received message from server: %T", msg)
 		n.Events.TransactionReceived.Trigger(msg)
+		if msg.Seq > n.lastSeq.Load() {
+			n.lastSeq.Store(msg.Seq)
+		}
+
+	case *txstream.MsgResumeState:
+		n.log.Debugf("received message from server: %T", msg)
+		if msg.Gap() {
+			n.log.Warnf("server no longer has the transactions between seq %d and %d, backlog should be requested", msg.Seq, msg.FirstSeq)
+		}
+		n.Events.ResumeStateReceived.Trigger(msg)
 
 	case *txstream.MsgTxInclusionState:
 		n.log.Debugf("received message from server: %T", msg)
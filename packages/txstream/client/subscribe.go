@@ -37,6 +37,9 @@ func (n *Client) subscriptionsLoop() {
 			}
 		case addr := <-n.chUnsubscribe:
 			delete(subscriptions, addr.Array())
+		case <-n.chResubscribe:
+			// the connection was re-established
+			n.sendSubscriptions(subscriptions)
 		case <-ticker1m.C:
 			// send subscriptions once every minute
 			n.sendSubscriptions(subscriptions)
@@ -60,3 +63,11 @@ func (n *Client) sendSubscriptions(subscriptions map[[ledgerstate.AddressLength]
 
 	n.sendMessage(&txstream.MsgUpdateSubscriptions{Addresses: addrs})
 }
+
+// resubscribe asks the subscriptions loop to send all subscriptions again without blocking the caller
+func (n *Client) resubscribe() {
+	select {
+	case n.chResubscribe <- struct{}{}:
+	default:
+	}
+}
@@ -33,6 +33,11 @@ const (
 	msgTypeTxInclusionState
 	msgTypeOutput
 	msgTypeUnspentAliasOutput
+
+	msgTypeAck = MessageType(FlagClientToServer + iota)
+	msgTypeResume
+
+	msgTypeResumeState = MessageType(FlagServerToClient + iota)
 )
 
 // Message is the common interface of all messages in the txstream protocol
@@ -106,6 +111,23 @@ type MsgSetID struct {
 	ClientID string
 }
 
+// MsgAck is a message from the client acknowledging all sequenced messages
+// up to and including Seq. The server persists the acknowledged sequence
+// number for the client ID.
+type MsgAck struct {
+	Seq uint64
+}
+
+// MsgResume is sent by the client right after MsgSetID. Seq is the resume
+// token, i.e. the last sequence number the client has processed. For every
+// address subscribed afterwards in the same connection, the server replays
+// all confirmed transactions with a greater sequence number that are still
+// kept in its log. If Seq is 0, the server resumes from the sequence number
+// last acknowledged by the client ID. Server replies with MsgResumeState.
+type MsgResume struct {
+	Seq uint64
+}
+
 // endregion
 
 // region server --> client
@@ -116,6 +138,10 @@ type MsgTransaction struct {
 	Address ledgerstate.Address
 	// Tx is the transaction being sent
 	Tx *ledgerstate.Transaction
+	// Seq is the sequence number of the transaction in the server's log.
+	// It is 0 if the message is a reply to a request and not a notification
+	// for a subscribed address.
+	Seq uint64
 }
 
 // MsgTxInclusionState informs the client with the inclusion state of a given
@@ -141,6 +167,23 @@ type MsgUnspentAliasOutput struct {
 	Timestamp      time.Time
 }
 
+// MsgResumeState is the response for MsgResume.
+type MsgResumeState struct {
+	// Seq is the sequence number the server resumes from.
+	Seq uint64
+	// FirstSeq is the oldest sequence number still kept in the server's log.
+	FirstSeq uint64
+	// LastSeq is the most recent sequence number in the server's log.
+	LastSeq uint64
+}
+
+// Gap returns true if the server no longer has all the transactions the
+// client missed. In this case the client should request the backlog of its
+// addresses.
+func (msg *MsgResumeState) Gap() bool {
+	return msg.Seq+1 < msg.FirstSeq && msg.Seq < msg.LastSeq
+}
+
 // endregion
 
 // EncodeMsg encodes the given Message as a byte slice
@@ -203,6 +246,15 @@ func DecodeMsg(data []byte, expectedFlags uint8) (interface{}, error) {
 	case msgTypeUnspentAliasOutput:
 		ret = &MsgUnspentAliasOutput{}
 
+	case msgTypeAck:
+		ret = &MsgAck{}
+
+	case msgTypeResume:
+		ret = &MsgResume{}
+
+	case msgTypeResumeState:
+		ret = &MsgResumeState{}
+
 	default:
 		return nil, fmt.Errorf("unknown message type %d", msgType)
 	}
@@ -338,6 +390,7 @@ func (msg *MsgSetID) Type() MessageType {
 func (msg *MsgTransaction) Write(w *marshalutil.MarshalUtil) {
 	w.Write(msg.Address)
 	w.Write(msg.Tx)
+	w.WriteUint64(msg.Seq)
 }
 
 func (msg *MsgTransaction) Read(m *marshalutil.MarshalUtil) error {
@@ -348,6 +401,9 @@ func (msg *MsgTransaction) Read(m *marshalutil.MarshalUtil) error {
 	if msg.Tx, err = ledgerstate.TransactionFromMarshalUtil(m); err != nil {
 		return err
 	}
+	if msg.Seq, err = m.ReadUint64(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -502,3 +558,56 @@ func (msg *MsgChunk) Read(m *marshalutil.MarshalUtil) error {
 func (msg *MsgChunk) Type() MessageType {
 	return msgTypeChunk
 }
+
+func (msg *MsgAck) Write(w *marshalutil.MarshalUtil) {
+	w.WriteUint64(msg.Seq)
+}
+
+func (msg *MsgAck) Read(m *marshalutil.MarshalUtil) error {
+	var err error
+	msg.Seq, err = m.ReadUint64()
+	return err
+}
+
+// Type returns the Message type
+func (msg *MsgAck) Type() MessageType {
+	return msgTypeAck
+}
+
+func (msg *MsgResume) Write(w *marshalutil.MarshalUtil) {
+	w.WriteUint64(msg.Seq)
+}
+
+func (msg *MsgResume) Read(m *marshalutil.MarshalUtil) error {
+	var err error
+	msg.Seq, err = m.ReadUint64()
+	return err
+}
+
+// Type returns the Message type
+func (msg *MsgResume) Type() MessageType {
+	return msgTypeResume
+}
+
+func (msg *MsgResumeState) Write(w *marshalutil.MarshalUtil) {
+	w.WriteUint64(msg.Seq)
+	w.WriteUint64(msg.FirstSeq)
+	w.WriteUint64(msg.LastSeq)
+}
+
+func (msg *MsgResumeState) Read(m *marshalutil.MarshalUtil) error {
+	var err error
+	if msg.Seq, err = m.ReadUint64(); err != nil {
+		return err
+	}
+	if msg.FirstSeq, err = m.ReadUint64(); err != nil {
+		return err
+	}
+	msg.LastSeq, err = m.ReadUint64()
+	return err
+}
+
+// Type returns the Message type
+func (msg *MsgResumeState) Type() MessageType {
+	return msgTypeResumeState
+}
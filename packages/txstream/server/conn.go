@@ -0,0 +1,162 @@
+package server
+
+import (
+	"io"
+	"net"
+	"sync"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/netutil/buffconn"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/txstream"
+	"github.com/iotaledger/goshimmer/packages/txstream/chopper"
+)
+
+// ErrInvalidMessage is returned by MessageConn.ReadMsg if a message could not be decoded. The connection stays usable.
+var ErrInvalidMessage = xerrors.New("invalid message")
+
+// MessageConn is the transport between the server and a single client. It carries txstream messages, independently of
+// how they are encoded on the wire.
+type MessageConn interface {
+	// ReadMsg blocks until the next message from the client was received.
+	ReadMsg() (txstream.Message, error)
+	// WriteMsg sends a message to the client.
+	WriteMsg(msg txstream.Message) error
+	// RemoteAddr returns the address of the client.
+	RemoteAddr() string
+	// Close closes the connection.
+	Close() error
+}
+
+// binaryConn is a MessageConn that uses the binary encoding of the txstream protocol over a stream connection.
+type binaryConn struct {
+	bconn    *buffconn.BufferedConnection
+	chopper  *chopper.Chopper
+	received chan []byte
+	// closed is closed when the read loop terminated.
+	closed chan struct{}
+	// done is closed when the connection was closed locally.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewBinaryConn returns a MessageConn that speaks the binary txstream protocol over the given connection.
+func NewBinaryConn(conn net.Conn) MessageConn {
+	b := &binaryConn{
+		bconn:    buffconn.NewBufferedConnection(conn, tangle.MaxMessageSize),
+		chopper:  chopper.NewChopper(),
+		received: make(chan []byte, rcvBufferSize),
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(b.closed)
+
+		cl := events.NewClosure(func(data []byte) {
+			// data slice is from internal buffconn buffer
+			d := make([]byte, len(data))
+			copy(d, data)
+			select {
+			case b.received <- d:
+			case <-b.done:
+			}
+		})
+		b.bconn.Events.ReceiveMessage.Attach(cl)
+		defer b.bconn.Events.ReceiveMessage.Detach(cl)
+
+		_ = b.bconn.Read()
+	}()
+
+	return b
+}
+
+// ReadMsg blocks until the next message from the client was received. Chunked messages are reassembled.
+func (b *binaryConn) ReadMsg() (txstream.Message, error) {
+	for {
+		var data []byte
+		select {
+		case data = <-b.received:
+		case <-b.closed:
+			// deliver what was received before the connection was lost
+			select {
+			case data = <-b.received:
+			default:
+				return nil, io.EOF
+			}
+		case <-b.done:
+			return nil, io.EOF
+		}
+
+		msg, err := b.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		if msg != nil {
+			return msg, nil
+		}
+	}
+}
+
+// decode decodes a message from the given data. It returns nil if the data was a chunk of an incomplete message.
+func (b *binaryConn) decode(data []byte) (txstream.Message, error) {
+	msg, err := txstream.DecodeMsg(data, txstream.FlagClientToServer)
+	if err != nil {
+		return nil, xerrors.Errorf("DecodeMsg: %v: %w", err, ErrInvalidMessage)
+	}
+
+	chunk, ok := msg.(*txstream.MsgChunk)
+	if !ok {
+		return msg.(txstream.Message), nil
+	}
+	finalMsg, err := b.chopper.IncomingChunk(chunk.Data, tangle.MaxMessageSize, txstream.ChunkMessageHeaderSize)
+	if err != nil {
+		return nil, xerrors.Errorf("IncomingChunk: %v: %w", err, ErrInvalidMessage)
+	}
+	if finalMsg == nil {
+		return nil, nil
+	}
+	return b.decode(finalMsg)
+}
+
+// WriteMsg sends a message to the client, chopping it into chunks if it is too big.
+func (b *binaryConn) WriteMsg(msg txstream.Message) error {
+	data := txstream.EncodeMsg(msg)
+	choppedData, chopped, err := b.chopper.ChopData(data, tangle.MaxMessageSize, txstream.ChunkMessageHeaderSize)
+	if err != nil {
+		return err
+	}
+	if !chopped {
+		_, err = b.bconn.Write(data)
+		return err
+	}
+
+	// sending piece by piece wrapped in MsgChunk
+	for _, piece := range choppedData {
+		dataToSend := txstream.EncodeMsg(&txstream.MsgChunk{Data: piece})
+		if len(dataToSend) > tangle.MaxMessageSize {
+			return xerrors.Errorf("internal inconsistency: size too big: %d", len(dataToSend))
+		}
+		if _, err = b.bconn.Write(dataToSend); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoteAddr returns the address of the client.
+func (b *binaryConn) RemoteAddr() string {
+	return b.bconn.RemoteAddr().String()
+}
+
+// Close closes the connection.
+func (b *binaryConn) Close() (err error) {
+	b.closeOnce.Do(func() {
+		close(b.done)
+		b.chopper.Close()
+		err = b.bconn.Close()
+	})
+	return err
+}
@@ -4,19 +4,13 @@ package server
 // SPDX-License-Identifier: Apache-2.0
 
 import (
-	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/txstream"
 
 	"golang.org/x/xerrors"
 )
 
 // process first message from client
-func (c *Connection) receiveClientID(data []byte) (string, error) {
-	msg, err := txstream.DecodeMsg(data, txstream.FlagClientToServer)
-	if err != nil {
-		return "", xerrors.Errorf("DecodeMsg: %v", err)
-	}
-
+func (c *Connection) receiveClientID(msg txstream.Message) (string, error) {
 	if msg, ok := msg.(*txstream.MsgSetID); ok {
 		return msg.ClientID, nil
 	}
@@ -24,22 +18,8 @@ func (c *Connection) receiveClientID(data []byte) (string, error) {
 }
 
 // process messages received from the clien
-func (c *Connection) processMessageFromClient(data []byte) error {
-	msg, err := txstream.DecodeMsg(data, txstream.FlagClientToServer)
-	if err != nil {
-		return xerrors.Errorf("DecodeMsg: %v", err)
-	}
-
+func (c *Connection) processMessageFromClient(msg txstream.Message) error {
 	switch msg := msg.(type) {
-	case *txstream.MsgChunk:
-		finalMsg, err := c.chopper.IncomingChunk(msg.Data, tangle.MaxMessageSize, txstream.ChunkMessageHeaderSize)
-		if err != nil {
-			return xerrors.Errorf("IncomingChunk: %v", err)
-		}
-		if finalMsg != nil {
-			return c.processMessageFromClient(finalMsg)
-		}
-
 	case *txstream.MsgPostTransaction:
 		c.postTransaction(msg.Tx)
 
@@ -49,6 +29,14 @@ func (c *Connection) processMessageFromClient(data []byte) error {
 		for _, addr := range newAddrs {
 			c.getBacklog(addr)
 		}
+		// replay what the newly subscribed addresses missed since the client's resume token
+		c.replay(newAddrs)
+
+	case *txstream.MsgResume:
+		c.resume(msg.Seq)
+
+	case *txstream.MsgAck:
+		c.ack(msg.Seq)
 
 	case *txstream.MsgGetConfirmedTransaction:
 		c.pushTransaction(msg.TxID, msg.Address)
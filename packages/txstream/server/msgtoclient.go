@@ -7,38 +7,12 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
-	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/txstream"
 )
 
 func (c *Connection) sendMsgToClient(msg txstream.Message) {
-	var err error
-	defer func() {
-		if err != nil {
-			c.log.Errorf("sending message to client (%T): %v", msg, err)
-		}
-	}()
-
-	data := txstream.EncodeMsg(msg)
-	choppedData, chopped, err := c.chopper.ChopData(data, tangle.MaxMessageSize, txstream.ChunkMessageHeaderSize)
-	if err != nil {
-		return
-	}
-	if !chopped {
-		_, err = c.bconn.Write(data)
-		return
-	}
-
-	// sending piece by piece wrapped in MsgChunk
-	for _, piece := range choppedData {
-		dataToSend := txstream.EncodeMsg(&txstream.MsgChunk{Data: piece})
-		if len(dataToSend) > tangle.MaxMessageSize {
-			c.log.Panicf("sendMsgToClient: internal inconsistency: size too big: %d", len(dataToSend))
-		}
-		_, err = c.bconn.Write(dataToSend)
-		if err != nil {
-			return
-		}
+	if err := c.conn.WriteMsg(msg); err != nil {
+		c.log.Errorf("sending message to client (%T): %v", msg, err)
 	}
 }
 
@@ -51,10 +25,15 @@ func (c *Connection) sendTxInclusionState(txid ledgerstate.TransactionID, addr l
 }
 
 func (c *Connection) pushTransaction(txid ledgerstate.TransactionID, addr ledgerstate.Address) {
+	c.pushSequencedTransaction(txid, addr, 0)
+}
+
+func (c *Connection) pushSequencedTransaction(txid ledgerstate.TransactionID, addr ledgerstate.Address, seq uint64) {
 	found := c.ledger.GetConfirmedTransaction(txid, func(tx *ledgerstate.Transaction) {
 		c.sendMsgToClient(&txstream.MsgTransaction{
 			Address: addr,
 			Tx:      tx,
+			Seq:     seq,
 		})
 	})
 	if !found {
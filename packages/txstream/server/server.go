@@ -1,6 +1,7 @@
 package server
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -9,36 +10,48 @@ import (
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
-	"github.com/iotaledger/hive.go/netutil/buffconn"
+	"golang.org/x/xerrors"
 
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
-	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/txstream"
-	"github.com/iotaledger/goshimmer/packages/txstream/chopper"
 )
 
 // Connection handles the server-side part of the txstream protocol
 type Connection struct {
-	bconn         *buffconn.BufferedConnection
-	chopper       *chopper.Chopper
+	conn          MessageConn
 	subscriptions map[[ledgerstate.AddressLength]byte]bool
 	ledger        txstream.Ledger
-	log           *logger.Logger
+	txLog         *txstream.TxLog
+	clientID      string
+	// resumeSeq is the sequence number that newly subscribed addresses are replayed from.
+	resumeSeq uint64
+	// replayedSeqs holds the highest sequence number that was sent during a replay for each address.
+	replayedSeqs map[[ledgerstate.AddressLength]byte]uint64
+	log          *logger.Logger
 }
 
 type (
-	wrapConfirmedTx *ledgerstate.Transaction
+	wrapConfirmedTx *txstream.TxLogEntry
 	wrapBookedTx    *ledgerstate.Transaction
 )
 
-const rcvClientIDTimeout = 5 * time.Second
+const (
+	rcvClientIDTimeout = 5 * time.Second
+	// rcvBufferSize is the amount of client messages that are buffered while the connection is busy sending, so that
+	// client acknowledgements can't block a server that is writing to a client that is writing to the server.
+	rcvBufferSize = 64
+)
 
-// Listen starts a TCP listener and starts a Connection for each accepted connection
-func Listen(ledger txstream.Ledger, bindAddress string, log *logger.Logger, shutdownSignal <-chan struct{}) error {
+// Listen starts a TCP listener and starts a Connection for each accepted connection. If a tls.Config is given, the
+// connections are served over TLS.
+func Listen(ledger txstream.Ledger, txLog *txstream.TxLog, bindAddress string, tlsConfig *tls.Config, log *logger.Logger, shutdownSignal <-chan struct{}) error {
 	listener, err := net.Listen("tcp", bindAddress)
 	if err != nil {
 		return fmt.Errorf("failed to start TXStream daemon: %w", err)
 	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
 
 	go func() {
 		for {
@@ -47,7 +60,7 @@ func Listen(ledger txstream.Ledger, bindAddress string, log *logger.Logger, shut
 				return
 			}
 			log.Debugf("accepted connection from %s", conn.RemoteAddr().String())
-			go Run(conn, log, ledger, shutdownSignal)
+			go Run(conn, log, ledger, txLog, shutdownSignal)
 		}
 	}()
 
@@ -64,34 +77,44 @@ func Listen(ledger txstream.Ledger, bindAddress string, log *logger.Logger, shut
 }
 
 // Run starts the server-side handling code for an already accepted connection from a client
-func Run(conn net.Conn, log *logger.Logger, ledger txstream.Ledger, shutdownSignal <-chan struct{}) {
+func Run(conn net.Conn, log *logger.Logger, ledger txstream.Ledger, txLog *txstream.TxLog, shutdownSignal <-chan struct{}) {
+	Serve(NewBinaryConn(conn), log, ledger, txLog, shutdownSignal)
+}
+
+// Serve runs the server-side part of the txstream protocol on the given MessageConn until the connection is lost or
+// the shutdown signal is received.
+func Serve(conn MessageConn, log *logger.Logger, ledger txstream.Ledger, txLog *txstream.TxLog, shutdownSignal <-chan struct{}) {
 	c := &Connection{
-		bconn:         buffconn.NewBufferedConnection(conn, tangle.MaxMessageSize),
-		chopper:       chopper.NewChopper(),
+		conn:          conn,
 		subscriptions: make(map[[ledgerstate.AddressLength]byte]bool),
 		ledger:        ledger,
+		txLog:         txLog,
+		resumeSeq:     txLog.LastSeq(),
+		replayedSeqs:  make(map[[ledgerstate.AddressLength]byte]uint64),
 		log:           log,
 	}
 
-	defer c.bconn.Close()
-	defer c.chopper.Close()
+	done := make(chan struct{})
+	defer close(done)
+	defer c.conn.Close()
 
-	bconnDataReceived, bconnClosed := c.bconnReadLoop()
+	msgReceived, connClosed := c.readLoop(done)
 
 	// expect first message received from client == MsgSetID
 	select {
-	case data := <-bconnDataReceived:
-		id, err := c.receiveClientID(data)
+	case msg := <-msgReceived:
+		id, err := c.receiveClientID(msg)
 		if err != nil {
 			c.log.Errorf("first message from client: %v", err)
 			return
 		}
+		c.clientID = id
 		c.log = c.log.Named(id)
-		c.log.Infof("client connection id has been set to '%s' for '%s'", id, c.bconn.RemoteAddr().String())
+		c.log.Infof("client connection id has been set to '%s' for '%s'", id, c.conn.RemoteAddr())
 	case <-shutdownSignal:
 		c.log.Infof("shutdown signal received")
 		return
-	case <-bconnClosed:
+	case <-connClosed:
 		c.log.Errorf("connection lost")
 		return
 	case <-time.After(rcvClientIDTimeout):
@@ -102,12 +125,12 @@ func Run(conn net.Conn, log *logger.Logger, ledger txstream.Ledger, shutdownSign
 	txFromLedgerQueue := make(chan interface{})
 
 	{
-		cl := events.NewClosure(func(tx *ledgerstate.Transaction) {
-			c.log.Debugf("on transaction confirmed: %s", tx.ID().Base58())
-			txFromLedgerQueue <- wrapConfirmedTx(tx)
+		cl := events.NewClosure(func(entry *txstream.TxLogEntry) {
+			c.log.Debugf("on transaction confirmed: %s", entry.TransactionID.Base58())
+			txFromLedgerQueue <- wrapConfirmedTx(entry)
 		})
-		c.ledger.EventTransactionConfirmed().Attach(cl)
-		defer c.ledger.EventTransactionConfirmed().Detach(cl)
+		c.txLog.Events.EntryAppended.Attach(cl)
+		defer c.txLog.Events.EntryAppended.Detach(cl)
 	}
 
 	{
@@ -134,51 +157,48 @@ func Run(conn net.Conn, log *logger.Logger, ledger txstream.Ledger, shutdownSign
 			default:
 				c.log.Panicf("wrong type")
 			}
-		case data := <-bconnDataReceived:
-			if err := c.processMessageFromClient(data); err != nil {
+		case msg := <-msgReceived:
+			if err := c.processMessageFromClient(msg); err != nil {
 				c.log.Errorf("processMessageFromClient: %v", err)
 			}
 		case <-shutdownSignal:
 			c.log.Infof("shutdown signal received")
 			return
-		case <-bconnClosed:
+		case <-connClosed:
 			c.log.Errorf("connection lost")
 			return
 		}
 	}
 }
 
-func (c *Connection) bconnReadLoop() (chan []byte, chan bool) {
-	bconnDataReceived := make(chan []byte)
-	bconnClosed := make(chan bool)
+func (c *Connection) readLoop(done <-chan struct{}) (chan txstream.Message, chan bool) {
+	msgReceived := make(chan txstream.Message, rcvBufferSize)
+	connClosed := make(chan bool)
 
-	// bconn read loop
 	go func() {
-		{
-			cl := events.NewClosure(func() { close(bconnClosed) })
-			c.bconn.Events.Close.Attach(cl)
-			defer c.bconn.Events.Close.Detach(cl)
-		}
-
-		{
-			cl := events.NewClosure(func(data []byte) {
-				// data slice is from internal buffconn buffer
-				d := make([]byte, len(data))
-				copy(d, data)
-				bconnDataReceived <- d
-			})
-			c.bconn.Events.ReceiveMessage.Attach(cl)
-			defer c.bconn.Events.ReceiveMessage.Detach(cl)
-		}
+		defer close(connClosed)
 
-		if err := c.bconn.Read(); err != nil {
-			if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
-				c.log.Warnw("bconn read error", "err", err)
+		for {
+			msg, err := c.conn.ReadMsg()
+			if err != nil {
+				if xerrors.Is(err, ErrInvalidMessage) {
+					c.log.Errorf("reading message from client: %v", err)
+					continue
+				}
+				if err != io.EOF && !strings.Contains(err.Error(), "use of closed network connection") {
+					c.log.Warnw("read error", "err", err)
+				}
+				return
+			}
+			select {
+			case msgReceived <- msg:
+			case <-done:
+				return
 			}
 		}
 	}()
 
-	return bconnDataReceived, bconnClosed
+	return msgReceived, connClosed
 }
 
 func (c *Connection) setSubscriptions(addrs []ledgerstate.Address) (newAddrs []ledgerstate.Address) {
@@ -211,10 +231,14 @@ func (c *Connection) txSubscribedAddresses(tx *ledgerstate.Transaction) map[[led
 
 // processConfirmedTransaction receives only confirmed transactions
 // it parses SC transaction incoming from the ledger. Forwards it to the client if subscribed
-func (c *Connection) processConfirmedTransaction(tx *ledgerstate.Transaction) {
-	for _, addr := range c.txSubscribedAddresses(tx) {
-		c.log.Debugf("confirmed tx -> client -- addr: %s txid: %s", addr.Base58(), tx.ID().String())
-		c.pushTransaction(tx.ID(), addr)
+func (c *Connection) processConfirmedTransaction(entry *txstream.TxLogEntry) {
+	for _, addr := range entry.Addresses {
+		if !c.isSubscribed(addr) || entry.Seq <= c.replayedSeqs[addr.Array()] {
+			// not subscribed or already sent during a replay
+			continue
+		}
+		c.log.Debugf("confirmed tx -> client -- addr: %s txid: %s seq: %d", addr.Base58(), entry.TransactionID.String(), entry.Seq)
+		c.pushSequencedTransaction(entry.TransactionID, addr, entry.Seq)
 	}
 }
 
@@ -234,6 +258,55 @@ func (c *Connection) getTxInclusionState(txid ledgerstate.TransactionID, addr le
 	c.sendTxInclusionState(txid, addr, state)
 }
 
+// resume sets the sequence number that newly subscribed addresses are replayed from.
+func (c *Connection) resume(seq uint64) {
+	if seq == 0 {
+		acked, err := c.txLog.Acked(c.clientID)
+		if err != nil {
+			c.log.Warnf("resume: %v", err)
+		}
+		seq = acked
+	}
+	if seq == 0 || seq > c.txLog.LastSeq() {
+		// nothing to resume from
+		seq = c.txLog.LastSeq()
+	}
+	c.resumeSeq = seq
+	c.log.Debugf("resuming from seq %d", seq)
+
+	c.sendMsgToClient(&txstream.MsgResumeState{
+		Seq:      seq,
+		FirstSeq: c.txLog.FirstSeq(),
+		LastSeq:  c.txLog.LastSeq(),
+	})
+}
+
+// replay sends all logged transactions since the resume sequence number that belong to the given addresses.
+func (c *Connection) replay(addrs []ledgerstate.Address) {
+	if len(addrs) == 0 {
+		return
+	}
+	err := c.txLog.ForEachSince(c.resumeSeq, func(entry *txstream.TxLogEntry) bool {
+		for _, addr := range addrs {
+			if entry.Contains(addr) {
+				c.log.Debugf("replayed tx -> client -- addr: %s txid: %s seq: %d", addr.Base58(), entry.TransactionID.String(), entry.Seq)
+				c.pushSequencedTransaction(entry.TransactionID, addr, entry.Seq)
+			}
+			c.replayedSeqs[addr.Array()] = entry.Seq
+		}
+		return true
+	})
+	if err != nil {
+		c.log.Warnf("replay: %v", err)
+	}
+}
+
+func (c *Connection) ack(seq uint64) {
+	if err := c.txLog.SetAcked(c.clientID, seq); err != nil {
+		c.log.Warnf("ack: %v", err)
+	}
+}
+
 func (c *Connection) getBacklog(addr ledgerstate.Address) {
 	txs := make(map[ledgerstate.TransactionID]bool)
 	c.ledger.GetUnspentOutputs(addr, func(out ledgerstate.Output) {
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/txstream"
+)
+
+const wsWriteTimeout = 5 * time.Second
+
+// webSocketConn is a MessageConn that exchanges the JSON model of the txstream messages over a WebSocket.
+type webSocketConn struct {
+	ws         *websocket.Conn
+	writeMutex sync.Mutex
+}
+
+// NewWebSocketConn returns a MessageConn that speaks the JSON encoded txstream protocol over the given WebSocket.
+func NewWebSocketConn(ws *websocket.Conn) MessageConn {
+	return &webSocketConn{ws: ws}
+}
+
+// ReadMsg blocks until the next message from the client was received.
+func (w *webSocketConn) ReadMsg() (txstream.Message, error) {
+	messageType, data, err := w.ws.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+	if messageType != websocket.TextMessage {
+		return nil, xerrors.Errorf("unexpected websocket message type %d: %w", messageType, ErrInvalidMessage)
+	}
+
+	jsonMsg := &jsonmodels.TxStreamMessage{}
+	if err = json.Unmarshal(data, jsonMsg); err != nil {
+		return nil, xerrors.Errorf("%v: %w", err, ErrInvalidMessage)
+	}
+	msg, err := jsonMsg.ToTxStreamMessage()
+	if err != nil {
+		return nil, xerrors.Errorf("%v: %w", err, ErrInvalidMessage)
+	}
+	return msg, nil
+}
+
+// WriteMsg sends a message to the client.
+func (w *webSocketConn) WriteMsg(msg txstream.Message) error {
+	jsonMsg, err := jsonmodels.NewTxStreamMessage(msg)
+	if err != nil {
+		return err
+	}
+
+	w.writeMutex.Lock()
+	defer w.writeMutex.Unlock()
+
+	if err = w.ws.SetWriteDeadline(time.Now().Add(wsWriteTimeout)); err != nil {
+		return err
+	}
+	return w.ws.WriteJSON(jsonMsg)
+}
+
+// RemoteAddr returns the address of the client.
+func (w *webSocketConn) RemoteAddr() string {
+	return w.ws.RemoteAddr().String()
+}
+
+// Close closes the connection.
+func (w *webSocketConn) Close() error {
+	return w.ws.Close()
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/mr-tron/base58"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/txstream"
+	"github.com/iotaledger/goshimmer/packages/txstream/utxodbledger"
+)
+
+func TestWebSocketConn(t *testing.T) {
+	zapLog, err := zap.NewDevelopment()
+	require.NoError(t, err)
+	log := zapLog.Sugar()
+
+	ledger := utxodbledger.New(log)
+	txLog, err := txstream.NewTxLog(ledger, mapdb.NewMapDB(), 100)
+	require.NoError(t, err)
+	defer txLog.Detach()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	upgrader := websocket.Upgrader{}
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		Serve(NewWebSocketConn(ws), log.Named("txstream/server"), ledger, txLog, done)
+	}))
+	defer httpServer.Close()
+
+	ws, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	_, address := ledger.NewKeyPairByIndex(2)
+	require.NoError(t, ledger.RequestFunds(address))
+
+	require.NoError(t, ws.WriteJSON(&jsonmodels.TxStreamMessage{Type: jsonmodels.TxStreamMsgSetID, ClientID: "test"}))
+	require.NoError(t, ws.WriteJSON(&jsonmodels.TxStreamMessage{Type: jsonmodels.TxStreamMsgResume}))
+	// invalid messages are reported, but do not terminate the connection
+	require.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("{")))
+	require.NoError(t, ws.WriteJSON(&jsonmodels.TxStreamMessage{Type: "unknown"}))
+	require.NoError(t, ws.WriteJSON(&jsonmodels.TxStreamMessage{
+		Type:      jsonmodels.TxStreamMsgUpdateSubscriptions,
+		Addresses: []string{address.Base58()},
+	}))
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(10*time.Second)))
+
+	resumeState := &jsonmodels.TxStreamMessage{}
+	require.NoError(t, ws.ReadJSON(resumeState))
+	require.Equal(t, jsonmodels.TxStreamMsgResumeState, resumeState.Type)
+
+	// the backlog of the subscribed address contains the funding transaction
+	txMsg := &jsonmodels.TxStreamMessage{}
+	require.NoError(t, ws.ReadJSON(txMsg))
+	require.Equal(t, jsonmodels.TxStreamMsgTransaction, txMsg.Type)
+	require.Equal(t, address.Base58(), txMsg.Address)
+	require.NotNil(t, txMsg.Transaction)
+
+	txBytes, err := base58.Decode(txMsg.TxBytes)
+	require.NoError(t, err)
+	tx, _, err := ledgerstate.TransactionFromBytes(txBytes)
+	require.NoError(t, err)
+	require.Equal(t, txMsg.TransactionID, tx.ID().Base58())
+
+	require.NoError(t, ws.WriteJSON(&jsonmodels.TxStreamMessage{
+		Type:          jsonmodels.TxStreamMsgGetTxInclusionState,
+		Address:       address.Base58(),
+		TransactionID: tx.ID().Base58(),
+	}))
+	stateMsg := &jsonmodels.TxStreamMessage{}
+	require.NoError(t, ws.ReadJSON(stateMsg))
+	require.Equal(t, jsonmodels.TxStreamMsgTxInclusionState, stateMsg.Type)
+	require.True(t, stateMsg.InclusionState.Confirmed)
+}
@@ -0,0 +1,272 @@
+package txstream
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+const (
+	txLogPrefixEntry byte = iota
+	txLogPrefixAck
+	txLogPrefixBounds
+)
+
+// TxLog is a bounded, persistent log of the transactions confirmed in the ledger. Each entry gets a sequence number
+// that is strictly increasing, so that clients can acknowledge what they received and resume from there after a
+// reconnect. Only the most recent maxEntries entries are kept.
+type TxLog struct {
+	Events *TxLogEvents
+
+	ledger     Ledger
+	store      kvstore.KVStore
+	maxEntries uint64
+	firstSeq   uint64
+	lastSeq    uint64
+	closure    *events.Closure
+	mutex      sync.RWMutex
+}
+
+// TxLogEvents contains all events emitted by the TxLog.
+type TxLogEvents struct {
+	// EntryAppended is triggered when a confirmed transaction was appended to the log.
+	EntryAppended *events.Event
+}
+
+// NewTxLog creates a TxLog that is backed by the given store and appends every transaction confirmed in the ledger.
+func NewTxLog(ledger Ledger, store kvstore.KVStore, maxEntries uint64) (txLog *TxLog, err error) {
+	txLog = &TxLog{
+		Events: &TxLogEvents{
+			EntryAppended: events.NewEvent(txLogEntryCaller),
+		},
+		ledger:     ledger,
+		store:      store,
+		maxEntries: maxEntries,
+		firstSeq:   1,
+	}
+
+	bounds, err := store.Get(kvstore.Key{txLogPrefixBounds})
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return nil, errors.Errorf("failed to read txlog bounds: %w", err)
+	}
+	if bounds != nil {
+		marshalUtil := marshalutil.New(bounds)
+		if txLog.firstSeq, err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse first sequence number of txlog: %w", err)
+		}
+		if txLog.lastSeq, err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse last sequence number of txlog: %w", err)
+		}
+	}
+
+	txLog.closure = events.NewClosure(func(tx *ledgerstate.Transaction) {
+		if _, err := txLog.Append(tx); err != nil {
+			panic(err)
+		}
+	})
+	ledger.EventTransactionConfirmed().Attach(txLog.closure)
+
+	return txLog, nil
+}
+
+// Append appends the given transaction to the log and returns the entry that was created.
+func (l *TxLog) Append(tx *ledgerstate.Transaction) (entry *TxLogEntry, err error) {
+	if entry, err = l.append(tx); err != nil {
+		return nil, err
+	}
+	l.Events.EntryAppended.Trigger(entry)
+
+	return entry, nil
+}
+
+// FirstSeq returns the sequence number of the oldest entry that is still kept in the log.
+func (l *TxLog) FirstSeq() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.firstSeq
+}
+
+// LastSeq returns the sequence number of the most recent entry in the log or 0 if the log is empty.
+func (l *TxLog) LastSeq() uint64 {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	return l.lastSeq
+}
+
+// ForEachSince calls the consumer for every entry with a sequence number greater than seq in ascending order. The
+// iteration is aborted if the consumer returns false.
+func (l *TxLog) ForEachSince(seq uint64, consumer func(entry *TxLogEntry) bool) error {
+	l.mutex.RLock()
+	first, last := l.firstSeq, l.lastSeq
+	l.mutex.RUnlock()
+
+	if seq+1 > first {
+		first = seq + 1
+	}
+	for current := first; current <= last; current++ {
+		value, err := l.store.Get(txLogEntryKey(current))
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the entry was pruned in the meantime
+				continue
+			}
+			return errors.Errorf("failed to read txlog entry %d: %w", current, err)
+		}
+		entry, err := TxLogEntryFromBytes(value)
+		if err != nil {
+			return errors.Errorf("failed to parse txlog entry %d: %w", current, err)
+		}
+		if !consumer(entry) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// SetAcked persists the sequence number that was last acknowledged by the given client.
+func (l *TxLog) SetAcked(clientID string, seq uint64) error {
+	return l.store.Set(txLogAckKey(clientID), marshalutil.New(marshalutil.Uint64Size).WriteUint64(seq).Bytes())
+}
+
+// Acked returns the sequence number that was last acknowledged by the given client or 0 if it is unknown.
+func (l *TxLog) Acked(clientID string) (seq uint64, err error) {
+	value, err := l.store.Get(txLogAckKey(clientID))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return marshalutil.New(value).ReadUint64()
+}
+
+// Detach detaches the TxLog from the ledger.
+func (l *TxLog) Detach() {
+	l.ledger.EventTransactionConfirmed().Detach(l.closure)
+}
+
+func (l *TxLog) append(tx *ledgerstate.Transaction) (entry *TxLogEntry, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entry = &TxLogEntry{
+		Seq:           l.lastSeq + 1,
+		TransactionID: tx.ID(),
+	}
+	seen := make(map[[ledgerstate.AddressLength]byte]bool)
+	for _, output := range tx.Essence().Outputs() {
+		if !seen[output.Address().Array()] {
+			seen[output.Address().Array()] = true
+			entry.Addresses = append(entry.Addresses, output.Address())
+		}
+	}
+
+	firstSeq := l.firstSeq
+	batch := l.store.Batched()
+	if err = batch.Set(txLogEntryKey(entry.Seq), entry.Bytes()); err != nil {
+		batch.Cancel()
+		return nil, err
+	}
+	for ; l.maxEntries != 0 && entry.Seq-firstSeq >= l.maxEntries; firstSeq++ {
+		if err = batch.Delete(txLogEntryKey(firstSeq)); err != nil {
+			batch.Cancel()
+			return nil, err
+		}
+	}
+	if err = batch.Set(kvstore.Key{txLogPrefixBounds}, marshalutil.New(2*marshalutil.Uint64Size).WriteUint64(firstSeq).WriteUint64(entry.Seq).Bytes()); err != nil {
+		batch.Cancel()
+		return nil, err
+	}
+	if err = batch.Commit(); err != nil {
+		return nil, err
+	}
+
+	l.firstSeq = firstSeq
+	l.lastSeq = entry.Seq
+
+	return entry, nil
+}
+
+func txLogEntryKey(seq uint64) kvstore.Key {
+	return byteutils.ConcatBytes([]byte{txLogPrefixEntry}, marshalutil.New(marshalutil.Uint64Size).WriteUint64(seq).Bytes())
+}
+
+func txLogAckKey(clientID string) kvstore.Key {
+	return byteutils.ConcatBytes([]byte{txLogPrefixAck}, []byte(clientID))
+}
+
+func txLogEntryCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*TxLogEntry))(params[0].(*TxLogEntry))
+}
+
+// TxLogEntry is a single confirmed transaction in the TxLog.
+type TxLogEntry struct {
+	// Seq is the sequence number of the entry.
+	Seq uint64
+	// TransactionID is the ID of the confirmed transaction.
+	TransactionID ledgerstate.TransactionID
+	// Addresses contains the addresses of the outputs of the transaction.
+	Addresses []ledgerstate.Address
+}
+
+// TxLogEntryFromBytes unmarshals a TxLogEntry from a sequence of bytes.
+func TxLogEntryFromBytes(data []byte) (entry *TxLogEntry, err error) {
+	marshalUtil := marshalutil.New(data)
+	entry = &TxLogEntry{}
+	if entry.Seq, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, err
+	}
+	if entry.TransactionID, err = ledgerstate.TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, err
+	}
+	addressCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+	entry.Addresses = make([]ledgerstate.Address, addressCount)
+	for i := range entry.Addresses {
+		if entry.Addresses[i], err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
+// Contains returns true if one of the outputs of the transaction belongs to the given address.
+func (e *TxLogEntry) Contains(address ledgerstate.Address) bool {
+	for _, entryAddress := range e.Addresses {
+		if entryAddress.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Bytes returns a marshaled version of the TxLogEntry.
+func (e *TxLogEntry) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteUint64(e.Seq)
+	marshalUtil.Write(e.TransactionID)
+	marshalUtil.WriteUint16(uint16(len(e.Addresses)))
+	for _, address := range e.Addresses {
+		marshalUtil.Write(address)
+	}
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the TxLogEntry.
+func (e *TxLogEntry) String() string {
+	return fmt.Sprintf("TxLogEntry(%d, %s)", e.Seq, e.TransactionID.Base58())
+}
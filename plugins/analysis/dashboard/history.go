@@ -0,0 +1,306 @@
+package dashboard
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	analysisserver "github.com/iotaledger/goshimmer/plugins/analysis/server"
+)
+
+const (
+	// defaultHistoryRange is the time range of the history queries without a start time.
+	defaultHistoryRange = time.Hour
+	// defaultHistoryLimit is the number of records returned by the history queries without a limit.
+	defaultHistoryLimit = 1000
+	// maxHistoryLimit is the maximum number of records returned by the history queries.
+	maxHistoryLimit = 10000
+)
+
+// ErrHistoryDisabled is returned when the history of the analysis server is queried while it is disabled.
+var ErrHistoryDisabled = errors.New("the heartbeat history of the analysis server is disabled")
+
+// region API routes ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+func setupHistoryRoutes(e *echo.Echo) {
+	e.GET("/api/history/graph", historyGraphHandler)
+	e.GET("/api/history/heartbeats", historyHeartbeatsHandler)
+	e.GET("/api/history/metrics", historyMetricsHandler)
+}
+
+// historyGraphHandler returns the autopeering graph of a network at the given time (now by default) as JSON, DOT or
+// GraphML.
+func historyGraphHandler(c echo.Context) error {
+	history := analysisserver.HeartbeatHistory()
+	if history == nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(ErrHistoryDisabled))
+	}
+
+	networkID := c.QueryParam("network")
+	if networkID == "" {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("missing network parameter: %w", ErrInvalidParameter)))
+	}
+	at, err := parseHistoryTime(c.QueryParam("time"), time.Now())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	var window time.Duration
+	if windowString := c.QueryParam("window"); windowString != "" {
+		if window, err = time.ParseDuration(windowString); err != nil || window <= 0 {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("invalid window '%s': %w", windowString, ErrInvalidParameter)))
+		}
+	}
+
+	networkMap, err := history.NetworkMap(networkID, at, window)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	switch format := c.QueryParam("format"); format {
+	case "", "json":
+		return c.JSON(http.StatusOK, newHistoryGraphResponse(networkMap, at))
+	case "dot":
+		var buffer bytes.Buffer
+		if err := networkMap.WriteDOT(&buffer); err != nil {
+			return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+		}
+		return c.Blob(http.StatusOK, "text/vnd.graphviz", buffer.Bytes())
+	case "graphml":
+		var buffer bytes.Buffer
+		if err := networkMap.WriteGraphML(&buffer); err != nil {
+			return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+		}
+		return c.Blob(http.StatusOK, "application/graphml+xml", buffer.Bytes())
+	default:
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("unknown format '%s': %w", format, ErrInvalidParameter)))
+	}
+}
+
+// historyHeartbeatsHandler returns the autopeering heartbeats received in the given time range (the last hour by
+// default), optionally of a single node or network.
+func historyHeartbeatsHandler(c echo.Context) error {
+	history := analysisserver.HeartbeatHistory()
+	if history == nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(ErrHistoryDisabled))
+	}
+
+	query, err := parseHistoryQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	networkID := c.QueryParam("network")
+
+	response := &HistoryHeartbeatsResponse{Heartbeats: make([]*HistoryHeartbeat, 0)}
+	if err := history.Heartbeats(query.from, query.to, func(record *analysisserver.HeartbeatRecord) bool {
+		if networkID != "" && string(record.Heartbeat.NetworkID) != networkID {
+			return true
+		}
+		if query.nodeID != "" && analysisserver.ShortNodeIDString(record.Heartbeat.OwnID) != query.nodeID {
+			return true
+		}
+		if len(response.Heartbeats) == query.limit {
+			response.Truncated = true
+			return false
+		}
+		response.Heartbeats = append(response.Heartbeats, newHistoryHeartbeat(record))
+		return true
+	}); err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// historyMetricsHandler returns the metric heartbeats received in the given time range (the last hour by default),
+// optionally of a single node.
+func historyMetricsHandler(c echo.Context) error {
+	history := analysisserver.HeartbeatHistory()
+	if history == nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(ErrHistoryDisabled))
+	}
+
+	query, err := parseHistoryQuery(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	response := &HistoryMetricsResponse{Metrics: make([]*HistoryMetric, 0)}
+	if err := history.MetricHeartbeats(query.from, query.to, func(record *analysisserver.MetricHeartbeatRecord) bool {
+		if query.nodeID != "" && analysisserver.ShortNodeIDString(record.MetricHeartbeat.OwnID) != query.nodeID {
+			return true
+		}
+		if len(response.Metrics) == query.limit {
+			response.Truncated = true
+			return false
+		}
+		response.Metrics = append(response.Metrics, newHistoryMetric(record))
+		return true
+	}); err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// historyQuery contains the common parameters of the history queries.
+type historyQuery struct {
+	from   time.Time
+	to     time.Time
+	nodeID string
+	limit  int
+}
+
+func parseHistoryQuery(c echo.Context) (query *historyQuery, err error) {
+	query = &historyQuery{nodeID: c.QueryParam("node"), limit: defaultHistoryLimit}
+	if query.to, err = parseHistoryTime(c.QueryParam("to"), time.Now()); err != nil {
+		return nil, err
+	}
+	if query.from, err = parseHistoryTime(c.QueryParam("from"), query.to.Add(-defaultHistoryRange)); err != nil {
+		return nil, err
+	}
+	if !query.from.Before(query.to) {
+		return nil, errors.Errorf("from must be before to: %w", ErrInvalidParameter)
+	}
+	if limitString := c.QueryParam("limit"); limitString != "" {
+		if query.limit, err = strconv.Atoi(limitString); err != nil || query.limit < 1 {
+			return nil, errors.Errorf("invalid limit '%s': %w", limitString, ErrInvalidParameter)
+		}
+	}
+	if query.limit > maxHistoryLimit {
+		query.limit = maxHistoryLimit
+	}
+
+	return query, nil
+}
+
+// parseHistoryTime parses the given RFC 3339 time or returns the default value if it is empty.
+func parseHistoryTime(value string, defaultValue time.Time) (time.Time, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid time '%s': %w", value, ErrInvalidParameter)
+	}
+
+	return t, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region API models ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HistoryGraphResponse is the JSON model of the autopeering graph of a network at a given time.
+type HistoryGraphResponse struct {
+	NetworkID string              `json:"networkID"`
+	Time      time.Time           `json:"time"`
+	Nodes     []*HistoryGraphNode `json:"nodes"`
+	Links     []*HistoryGraphLink `json:"links"`
+}
+
+// HistoryGraphNode is the JSON model of a node of the autopeering graph.
+type HistoryGraphNode struct {
+	ID       string    `json:"id"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// HistoryGraphLink is the JSON model of a link from a node to its outbound neighbor.
+type HistoryGraphLink struct {
+	Source   string    `json:"source"`
+	Target   string    `json:"target"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+func newHistoryGraphResponse(networkMap *analysisserver.NetworkMap, at time.Time) *HistoryGraphResponse {
+	nodes, links := networkMap.Topology()
+
+	response := &HistoryGraphResponse{
+		NetworkID: networkMap.Version(),
+		Time:      at,
+		Nodes:     make([]*HistoryGraphNode, 0, len(nodes)),
+		Links:     make([]*HistoryGraphLink, 0),
+	}
+	for nodeID, lastSeen := range nodes {
+		response.Nodes = append(response.Nodes, &HistoryGraphNode{ID: nodeID, LastSeen: lastSeen})
+	}
+	for sourceID, targets := range links {
+		for targetID, lastSeen := range targets {
+			response.Links = append(response.Links, &HistoryGraphLink{Source: sourceID, Target: targetID, LastSeen: lastSeen})
+		}
+	}
+
+	return response
+}
+
+// HistoryHeartbeatsResponse is the JSON model of the result of a heartbeat history query.
+type HistoryHeartbeatsResponse struct {
+	Heartbeats []*HistoryHeartbeat `json:"heartbeats"`
+	// Truncated is true if there are more heartbeats than the limit of the query.
+	Truncated bool `json:"truncated"`
+}
+
+// HistoryHeartbeat is the JSON model of a stored autopeering heartbeat.
+type HistoryHeartbeat struct {
+	Time        time.Time `json:"time"`
+	NetworkID   string    `json:"networkID"`
+	NodeID      string    `json:"nodeID"`
+	OutboundIDs []string  `json:"outboundIDs"`
+	InboundIDs  []string  `json:"inboundIDs"`
+}
+
+func newHistoryHeartbeat(record *analysisserver.HeartbeatRecord) *HistoryHeartbeat {
+	heartbeat := &HistoryHeartbeat{
+		Time:        record.Time,
+		NetworkID:   string(record.Heartbeat.NetworkID),
+		NodeID:      analysisserver.ShortNodeIDString(record.Heartbeat.OwnID),
+		OutboundIDs: make([]string, 0, len(record.Heartbeat.OutboundIDs)),
+		InboundIDs:  make([]string, 0, len(record.Heartbeat.InboundIDs)),
+	}
+	for _, id := range record.Heartbeat.OutboundIDs {
+		heartbeat.OutboundIDs = append(heartbeat.OutboundIDs, analysisserver.ShortNodeIDString(id))
+	}
+	for _, id := range record.Heartbeat.InboundIDs {
+		heartbeat.InboundIDs = append(heartbeat.InboundIDs, analysisserver.ShortNodeIDString(id))
+	}
+
+	return heartbeat
+}
+
+// HistoryMetricsResponse is the JSON model of the result of a metric heartbeat history query.
+type HistoryMetricsResponse struct {
+	Metrics []*HistoryMetric `json:"metrics"`
+	// Truncated is true if there are more metric heartbeats than the limit of the query.
+	Truncated bool `json:"truncated"`
+}
+
+// HistoryMetric is the JSON model of a stored metric heartbeat.
+type HistoryMetric struct {
+	Time        time.Time `json:"time"`
+	NodeID      string    `json:"nodeID"`
+	Version     string    `json:"version"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	NumCPU      int       `json:"numCPU"`
+	CPUUsage    float64   `json:"cpuUsage"`
+	MemoryUsage uint64    `json:"memoryUsage"`
+}
+
+func newHistoryMetric(record *analysisserver.MetricHeartbeatRecord) *HistoryMetric {
+	return &HistoryMetric{
+		Time:        record.Time,
+		NodeID:      analysisserver.ShortNodeIDString(record.MetricHeartbeat.OwnID),
+		Version:     record.MetricHeartbeat.Version,
+		OS:          record.MetricHeartbeat.OS,
+		Arch:        record.MetricHeartbeat.Arch,
+		NumCPU:      record.MetricHeartbeat.NumCPU,
+		CPUUsage:    record.MetricHeartbeat.CPUUsage,
+		MemoryUsage: record.MetricHeartbeat.MemoryUsage,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -20,7 +20,9 @@ type NetworkMap struct {
 	nodes map[string]time.Time
 	// maps nodeId to outgoing connections + latest arrival of heartbeat.
 	links map[string]map[string]time.Time
-	lock  sync.RWMutex
+	// whether changes of the map trigger the Events (false for maps reconstructed from the History).
+	triggerEvents bool
+	lock          sync.RWMutex
 }
 
 // NeighborMetric contains the number of inbound/outbound neighbors.
@@ -54,28 +56,38 @@ func updateAutopeeringMap(p *packet.Heartbeat) {
 		Networks[networkID] = NewNetworkMap(networkID)
 	}
 	nm := Networks[networkID]
-	nm.update(p)
+	nm.update(p, time.Now())
 }
 
 // NewNetworkMap creates a new network map with a given network version
 func NewNetworkMap(networkVersion string) *NetworkMap {
-	nm := &NetworkMap{
+	nm := newNetworkMap(networkVersion)
+	nm.triggerEvents = true
+	return nm
+}
+
+// newNetworkMap creates a network map that does not trigger any events.
+func newNetworkMap(networkVersion string) *NetworkMap {
+	return &NetworkMap{
 		version: networkVersion,
 		nodes:   make(map[string]time.Time),
 		links:   make(map[string]map[string]time.Time),
 	}
-	return nm
 }
 
-func (nm *NetworkMap) update(hb *packet.Heartbeat) {
+// Version returns the network version of the network map.
+func (nm *NetworkMap) Version() string {
+	return nm.version
+}
+
+func (nm *NetworkMap) update(hb *packet.Heartbeat, timestamp time.Time) {
 	nm.lock.Lock()
 	defer nm.lock.Unlock()
 
 	nodeIDString := ShortNodeIDString(hb.OwnID)
-	timestamp := time.Now()
 
 	// when node is new, add to graph
-	if _, isAlready := nm.nodes[nodeIDString]; !isAlready {
+	if _, isAlready := nm.nodes[nodeIDString]; !isAlready && nm.triggerEvents {
 		Events.AddNode.Trigger(&AddNodeEvent{NetworkVersion: nm.version, NodeID: nodeIDString})
 	}
 	// save it + update timestamp
@@ -86,7 +98,7 @@ func (nm *NetworkMap) update(hb *packet.Heartbeat) {
 		outgoingNeighborString := ShortNodeIDString(outgoingNeighbor)
 		// do we already know about this neighbor?
 		// if no, add it and set it online
-		if _, isAlready := nm.nodes[outgoingNeighborString]; !isAlready {
+		if _, isAlready := nm.nodes[outgoingNeighborString]; !isAlready && nm.triggerEvents {
 			// first time we see this particular node
 			Events.AddNode.Trigger(&AddNodeEvent{NetworkVersion: nm.version, NodeID: outgoingNeighborString})
 		}
@@ -100,7 +112,7 @@ func (nm *NetworkMap) update(hb *packet.Heartbeat) {
 		}
 
 		// update graph when connection hasn't been seen before
-		if _, isAlready := nm.links[nodeIDString][outgoingNeighborString]; !isAlready {
+		if _, isAlready := nm.links[nodeIDString][outgoingNeighborString]; !isAlready && nm.triggerEvents {
 			Events.ConnectNodes.Trigger(&ConnectNodesEvent{NetworkVersion: nm.version, SourceID: nodeIDString, TargetID: outgoingNeighborString})
 		}
 		// update links
@@ -112,7 +124,7 @@ func (nm *NetworkMap) update(hb *packet.Heartbeat) {
 		incomingNeighborString := ShortNodeIDString(incomingNeighbor)
 		// do we already know about this neighbor?
 		// if no, add it and set it online
-		if _, isAlready := nm.nodes[incomingNeighborString]; !isAlready {
+		if _, isAlready := nm.nodes[incomingNeighborString]; !isAlready && nm.triggerEvents {
 			// First time we see this particular node
 			Events.AddNode.Trigger(&AddNodeEvent{NetworkVersion: nm.version, NodeID: incomingNeighborString})
 		}
@@ -126,7 +138,7 @@ func (nm *NetworkMap) update(hb *packet.Heartbeat) {
 		}
 
 		// update graph when connection hasn't been seen before
-		if _, isAlready := nm.links[incomingNeighborString][nodeIDString]; !isAlready {
+		if _, isAlready := nm.links[incomingNeighborString][nodeIDString]; !isAlready && nm.triggerEvents {
 			Events.ConnectNodes.Trigger(&ConnectNodesEvent{NetworkVersion: nm.version, SourceID: incomingNeighborString, TargetID: nodeIDString})
 		}
 		// update links map
@@ -183,6 +195,12 @@ func cleanUp(interval time.Duration) {
 	}
 }
 
+// Topology returns a copy of the nodes and the links (source to targets) of the network map together with the time they
+// were seen last.
+func (nm *NetworkMap) Topology() (nodes map[string]time.Time, links map[string]map[string]time.Time) {
+	return nm.getEventsToReplay()
+}
+
 func (nm *NetworkMap) getEventsToReplay() (map[string]time.Time, map[string]map[string]time.Time) {
 	nm.lock.RLock()
 	defer nm.lock.RUnlock()
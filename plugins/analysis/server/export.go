@@ -0,0 +1,143 @@
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// region DOT //////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// WriteDOT writes the network map as a directed graph in the DOT language of Graphviz. Nodes and links are annotated
+// with the time they were seen last.
+func (nm *NetworkMap) WriteDOT(w io.Writer) error {
+	nodes, links := nm.Topology()
+
+	if _, err := fmt.Fprintf(w, "digraph %s {\n", strconv.Quote(nm.version)); err != nil {
+		return err
+	}
+	for _, nodeID := range sortedKeys(nodes) {
+		if _, err := fmt.Fprintf(w, "\t%s [lastSeen=%s];\n", strconv.Quote(nodeID), strconv.Quote(formatLastSeen(nodes[nodeID]))); err != nil {
+			return err
+		}
+	}
+	for _, sourceID := range sortedKeys(links) {
+		for _, targetID := range sortedKeys(links[sourceID]) {
+			if _, err := fmt.Fprintf(w, "\t%s -> %s [lastSeen=%s];\n", strconv.Quote(sourceID), strconv.Quote(targetID), strconv.Quote(formatLastSeen(links[sourceID][targetID]))); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := io.WriteString(w, "}\n")
+
+	return err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GraphML //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// graphMLLastSeenKey is the ID of the GraphML attribute holding the time a node or link was seen last.
+const graphMLLastSeenKey = "lastSeen"
+
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string      `xml:"id,attr"`
+	Data graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string      `xml:"source,attr"`
+	Target string      `xml:"target,attr"`
+	Data   graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the network map as a directed graph in the GraphML format. Nodes and links are annotated with the
+// time they were seen last.
+func (nm *NetworkMap) WriteGraphML(w io.Writer) error {
+	nodes, links := nm.Topology()
+
+	document := &graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  []graphMLKey{{ID: graphMLLastSeenKey, For: "all", AttrName: graphMLLastSeenKey, AttrType: "string"}},
+		Graph: graphMLGraph{ID: nm.version, EdgeDefault: "directed"},
+	}
+	for _, nodeID := range sortedKeys(nodes) {
+		document.Graph.Nodes = append(document.Graph.Nodes, graphMLNode{
+			ID:   nodeID,
+			Data: graphMLData{Key: graphMLLastSeenKey, Value: formatLastSeen(nodes[nodeID])},
+		})
+	}
+	for _, sourceID := range sortedKeys(links) {
+		for _, targetID := range sortedKeys(links[sourceID]) {
+			document.Graph.Edges = append(document.Graph.Edges, graphMLEdge{
+				Source: sourceID,
+				Target: targetID,
+				Data:   graphMLData{Key: graphMLLastSeenKey, Value: formatLastSeen(links[sourceID][targetID])},
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func formatLastSeen(lastSeen time.Time) string {
+	return lastSeen.UTC().Format(time.RFC3339Nano)
+}
+
+// sortedKeys returns the keys of the given map in ascending order, so that the exports are deterministic.
+func sortedKeys(m interface{}) (keys []string) {
+	switch typedMap := m.(type) {
+	case map[string]time.Time:
+		for key := range typedMap {
+			keys = append(keys, key)
+		}
+	case map[string]map[string]time.Time:
+		for key := range typedMap {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,446 @@
+package server
+
+import (
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+// historyBucketDuration is the time span of the records that are grouped under a common key prefix, so that time ranges
+// can be queried and pruned without scanning the whole store.
+const historyBucketDuration = time.Minute
+
+// the key prefixes of the history store.
+const (
+	historyPrefixHeartbeat byte = iota
+	historyPrefixMetricHeartbeat
+	historyPrefixOldestBucket
+)
+
+// region History //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// History is a time-series store of the received heartbeats. It keeps the autopeering heartbeats (and thus the neighbor
+// links) and the metric heartbeats for the configured retention period and allows to reconstruct the network graph at
+// any time within that period. FPC heartbeats are not part of the History, as the finalized conflicts are already
+// stored in the MongoDB of the analysis dashboard.
+type History struct {
+	store     kvstore.KVStore
+	retention time.Duration
+	pruneMu   sync.Mutex
+}
+
+// NewHistory creates a History that stores its records in the given store and keeps them for the given retention period.
+// The records that are already outdated are deleted right away.
+func NewHistory(store kvstore.KVStore, retention time.Duration) (*History, error) {
+	h := &History{
+		store:     store,
+		retention: retention,
+	}
+	if err := h.Prune(time.Now()); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// RecordHeartbeat stores the given Heartbeat that was received at the given time.
+func (h *History) RecordHeartbeat(hb *packet.Heartbeat, receivedAt time.Time) error {
+	if err := h.store.Set(historyKey(historyPrefixHeartbeat, receivedAt, hb.OwnID), (&HeartbeatRecord{Time: receivedAt, Heartbeat: hb}).Bytes()); err != nil {
+		return errors.Errorf("failed to store heartbeat of %s: %w", ShortNodeIDString(hb.OwnID), err)
+	}
+	return nil
+}
+
+// RecordMetricHeartbeat stores the given MetricHeartbeat that was received at the given time.
+func (h *History) RecordMetricHeartbeat(hb *packet.MetricHeartbeat, receivedAt time.Time) error {
+	if err := h.store.Set(historyKey(historyPrefixMetricHeartbeat, receivedAt, hb.OwnID), (&MetricHeartbeatRecord{Time: receivedAt, MetricHeartbeat: hb}).Bytes()); err != nil {
+		return errors.Errorf("failed to store metric heartbeat of %s: %w", ShortNodeIDString(hb.OwnID), err)
+	}
+	return nil
+}
+
+// Heartbeats calls the consumer for the stored Heartbeats that were received in [from, to) ordered by their time. The
+// iteration stops when the consumer returns false.
+func (h *History) Heartbeats(from, to time.Time, consumer func(record *HeartbeatRecord) bool) error {
+	return h.iterate(historyPrefixHeartbeat, from, to, func(value []byte) (historyRecord, error) {
+		record, _, err := HeartbeatRecordFromBytes(value)
+		return record, err
+	}, func(record historyRecord) bool {
+		return consumer(record.(*HeartbeatRecord))
+	})
+}
+
+// MetricHeartbeats calls the consumer for the stored MetricHeartbeats that were received in [from, to) ordered by their
+// time. The iteration stops when the consumer returns false.
+func (h *History) MetricHeartbeats(from, to time.Time, consumer func(record *MetricHeartbeatRecord) bool) error {
+	return h.iterate(historyPrefixMetricHeartbeat, from, to, func(value []byte) (historyRecord, error) {
+		record, _, err := MetricHeartbeatRecordFromBytes(value)
+		return record, err
+	}, func(record historyRecord) bool {
+		return consumer(record.(*MetricHeartbeatRecord))
+	})
+}
+
+// NetworkMap reconstructs the NetworkMap of the given network as it was at the given time. Like in the live map, a node
+// or link is part of the graph if it was reported by a heartbeat within the given window before that time (the clean
+// up period of the live map if the window is not positive). The returned map does not trigger any Events.
+func (h *History) NetworkMap(networkID string, at time.Time, window time.Duration) (*NetworkMap, error) {
+	if window <= 0 {
+		window = cleanUpPeriod
+	}
+
+	nm := newNetworkMap(networkID)
+	if err := h.Heartbeats(at.Add(-window), at.Add(time.Nanosecond), func(record *HeartbeatRecord) bool {
+		if string(record.Heartbeat.NetworkID) == networkID {
+			nm.update(record.Heartbeat, record.Time)
+		}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	return nm, nil
+}
+
+// Prune deletes the records that are older than the retention period (records are kept forever if it is not positive).
+func (h *History) Prune(now time.Time) error {
+	h.pruneMu.Lock()
+	defer h.pruneMu.Unlock()
+
+	cutoff := historyBucket(now)
+	if h.retention > 0 {
+		cutoff = historyBucket(now.Add(-h.retention))
+	}
+	oldest, exists, err := h.oldestBucket()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		// the store is empty, so we only need to remember where the records start
+		return h.setOldestBucket(cutoff)
+	}
+	if h.retention <= 0 {
+		return nil
+	}
+
+	for bucket := oldest; bucket < cutoff; bucket++ {
+		for _, prefix := range []byte{historyPrefixHeartbeat, historyPrefixMetricHeartbeat} {
+			if err := h.store.DeletePrefix(historyBucketPrefix(prefix, bucket)); err != nil {
+				return errors.Errorf("failed to prune history: %w", err)
+			}
+		}
+		// persist the progress, so that an interrupted prune does not start from the beginning again
+		if err := h.setOldestBucket(bucket + 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// iterate calls the consumer for the records of the given kind in [from, to) bucket by bucket. The records of a bucket
+// are sorted by time, since not every store iterates in the order of the keys.
+func (h *History) iterate(prefix byte, from, to time.Time, parse func(value []byte) (historyRecord, error), consumer func(record historyRecord) bool) (err error) {
+	if !from.Before(to) {
+		return nil
+	}
+
+	// do not look for records before the first or after the current bucket
+	first, last := historyBucket(from), historyBucket(to)
+	oldest, exists, err := h.oldestBucket()
+	if err != nil {
+		return err
+	}
+	if exists && oldest > first {
+		first = oldest
+	}
+	if current := historyBucket(time.Now()); current < last {
+		last = current
+	}
+
+	for bucket := first; bucket <= last; bucket++ {
+		var records []historyRecord
+		if iterateErr := h.store.Iterate(historyBucketPrefix(prefix, bucket), func(_ kvstore.Key, value kvstore.Value) bool {
+			record, parseErr := parse(value)
+			if parseErr != nil {
+				err = errors.Errorf("failed to parse history record: %w", parseErr)
+				return false
+			}
+			if receivedAt := record.time(); !receivedAt.Before(from) && receivedAt.Before(to) {
+				records = append(records, record)
+			}
+			return true
+		}); iterateErr != nil {
+			return errors.Errorf("failed to iterate history: %w", iterateErr)
+		}
+		if err != nil {
+			return err
+		}
+
+		sort.Slice(records, func(i, j int) bool {
+			return records[i].time().Before(records[j].time())
+		})
+		for _, record := range records {
+			if !consumer(record) {
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func (h *History) oldestBucket() (bucket uint64, exists bool, err error) {
+	value, err := h.store.Get(kvstore.Key{historyPrefixOldestBucket})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Errorf("failed to load the oldest history bucket: %w", err)
+	}
+	if len(value) != marshalutil.Uint64Size {
+		return 0, false, errors.Errorf("failed to parse the oldest history bucket: %w", cerrors.ErrParseBytesFailed)
+	}
+
+	return binary.BigEndian.Uint64(value), true, nil
+}
+
+func (h *History) setOldestBucket(bucket uint64) error {
+	value := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(value, bucket)
+	if err := h.store.Set(kvstore.Key{historyPrefixOldestBucket}, value); err != nil {
+		return errors.Errorf("failed to store the oldest history bucket: %w", err)
+	}
+	return nil
+}
+
+// historyBucket returns the bucket of the given time.
+func historyBucket(t time.Time) uint64 {
+	if t.UnixNano() < 0 {
+		return 0
+	}
+	return uint64(t.UnixNano()) / uint64(historyBucketDuration)
+}
+
+// historyBucketPrefix returns the iteration prefix of the records of the given kind and bucket.
+func historyBucketPrefix(prefix byte, bucket uint64) []byte {
+	key := make([]byte, 1+marshalutil.Uint64Size)
+	key[0] = prefix
+	binary.BigEndian.PutUint64(key[1:], bucket)
+	return key
+}
+
+// historyKey returns the key of the record of the given kind, time and node. The time is encoded in big endian, so that
+// the keys of a bucket are ordered by time.
+func historyKey(prefix byte, t time.Time, nodeID []byte) []byte {
+	key := historyBucketPrefix(prefix, historyBucket(t))
+	timeBytes := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(timeBytes, uint64(t.UnixNano()))
+	key = append(key, timeBytes...)
+	return append(key, nodeID...)
+}
+
+// historyRecord is the common interface of the records of the History.
+type historyRecord interface {
+	time() time.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HeartbeatRecord //////////////////////////////////////////////////////////////////////////////////////////////
+
+// HeartbeatRecord is a Heartbeat stored in the History.
+type HeartbeatRecord struct {
+	// Time is the time the Heartbeat was received.
+	Time time.Time
+	// Heartbeat is the received Heartbeat.
+	Heartbeat *packet.Heartbeat
+}
+
+// HeartbeatRecordFromBytes unmarshals a HeartbeatRecord from a sequence of bytes.
+func HeartbeatRecordFromBytes(bytes []byte) (record *HeartbeatRecord, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if record, err = HeartbeatRecordFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HeartbeatRecord from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HeartbeatRecordFromMarshalUtil unmarshals a HeartbeatRecord using a MarshalUtil (for easier unmarshaling).
+func HeartbeatRecordFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (record *HeartbeatRecord, err error) {
+	record = &HeartbeatRecord{Heartbeat: &packet.Heartbeat{}}
+	if record.Time, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if record.Heartbeat.NetworkID, err = readShortBytes(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse network ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if record.Heartbeat.OwnID, err = readShortBytes(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse own ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if record.Heartbeat.OutboundIDs, err = readIDs(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse outbound IDs (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if record.Heartbeat.InboundIDs, err = readIDs(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse inbound IDs (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the HeartbeatRecord.
+func (h *HeartbeatRecord) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteTime(h.Time)
+	writeShortBytes(marshalUtil, h.Heartbeat.NetworkID)
+	writeShortBytes(marshalUtil, h.Heartbeat.OwnID)
+	writeIDs(marshalUtil, h.Heartbeat.OutboundIDs)
+	writeIDs(marshalUtil, h.Heartbeat.InboundIDs)
+
+	return marshalUtil.Bytes()
+}
+
+func (h *HeartbeatRecord) time() time.Time {
+	return h.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MetricHeartbeatRecord ////////////////////////////////////////////////////////////////////////////////////////
+
+// MetricHeartbeatRecord is a MetricHeartbeat stored in the History.
+type MetricHeartbeatRecord struct {
+	// Time is the time the MetricHeartbeat was received.
+	Time time.Time
+	// MetricHeartbeat is the received MetricHeartbeat.
+	MetricHeartbeat *packet.MetricHeartbeat
+}
+
+// MetricHeartbeatRecordFromBytes unmarshals a MetricHeartbeatRecord from a sequence of bytes.
+func MetricHeartbeatRecordFromBytes(bytes []byte) (record *MetricHeartbeatRecord, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if record, err = MetricHeartbeatRecordFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MetricHeartbeatRecord from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MetricHeartbeatRecordFromMarshalUtil unmarshals a MetricHeartbeatRecord using a MarshalUtil (for easier unmarshaling).
+func MetricHeartbeatRecordFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (record *MetricHeartbeatRecord, err error) {
+	record = &MetricHeartbeatRecord{MetricHeartbeat: &packet.MetricHeartbeat{}}
+	if record.Time, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse time (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	fields := []*string{&record.MetricHeartbeat.Version, &record.MetricHeartbeat.OS, &record.MetricHeartbeat.Arch}
+	for _, field := range fields {
+		fieldBytes, readErr := readShortBytes(marshalUtil)
+		if readErr != nil {
+			err = errors.Errorf("failed to parse string (%v): %w", readErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+		*field = string(fieldBytes)
+	}
+	if record.MetricHeartbeat.OwnID, err = readShortBytes(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse own ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	numCPU, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = errors.Errorf("failed to parse number of CPUs (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	record.MetricHeartbeat.NumCPU = int(numCPU)
+	if record.MetricHeartbeat.CPUUsage, err = marshalUtil.ReadFloat64(); err != nil {
+		err = errors.Errorf("failed to parse CPU usage (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if record.MetricHeartbeat.MemoryUsage, err = marshalUtil.ReadUint64(); err != nil {
+		err = errors.Errorf("failed to parse memory usage (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the MetricHeartbeatRecord.
+func (m *MetricHeartbeatRecord) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteTime(m.Time)
+	writeShortBytes(marshalUtil, []byte(m.MetricHeartbeat.Version))
+	writeShortBytes(marshalUtil, []byte(m.MetricHeartbeat.OS))
+	writeShortBytes(marshalUtil, []byte(m.MetricHeartbeat.Arch))
+	writeShortBytes(marshalUtil, m.MetricHeartbeat.OwnID)
+	marshalUtil.WriteUint32(uint32(m.MetricHeartbeat.NumCPU))
+	marshalUtil.WriteFloat64(m.MetricHeartbeat.CPUUsage)
+	marshalUtil.WriteUint64(m.MetricHeartbeat.MemoryUsage)
+
+	return marshalUtil.Bytes()
+}
+
+func (m *MetricHeartbeatRecord) time() time.Time {
+	return m.Time
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region marshaling helpers ///////////////////////////////////////////////////////////////////////////////////////////
+
+// writeShortBytes writes the given bytes prefixed by their length (at most 255 bytes are written).
+func writeShortBytes(marshalUtil *marshalutil.MarshalUtil, bytes []byte) {
+	if len(bytes) > 255 {
+		bytes = bytes[:255]
+	}
+	marshalUtil.WriteUint8(uint8(len(bytes)))
+	marshalUtil.WriteBytes(bytes)
+}
+
+func readShortBytes(marshalUtil *marshalutil.MarshalUtil) ([]byte, error) {
+	length, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	return marshalUtil.ReadBytes(int(length))
+}
+
+func writeIDs(marshalUtil *marshalutil.MarshalUtil, ids [][]byte) {
+	marshalUtil.WriteUint8(uint8(len(ids)))
+	for _, id := range ids {
+		writeShortBytes(marshalUtil, id)
+	}
+}
+
+func readIDs(marshalUtil *marshalutil.MarshalUtil) ([][]byte, error) {
+	count, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([][]byte, count)
+	for i := range ids {
+		if ids[i], err = readShortBytes(marshalUtil); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
+)
+
+var (
+	nodeA = testNodeID(1)
+	nodeB = testNodeID(2)
+	nodeC = testNodeID(3)
+	nodeD = testNodeID(4)
+)
+
+func TestHistory_NetworkMap(t *testing.T) {
+	history, err := NewHistory(mapdb.NewMapDB(), 24*time.Hour)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	require.NoError(t, history.RecordHeartbeat(&packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeA, OutboundIDs: [][]byte{nodeB}}, start))
+	require.NoError(t, history.RecordHeartbeat(&packet.Heartbeat{NetworkID: []byte("v2"), OwnID: nodeD, OutboundIDs: [][]byte{nodeA}}, start))
+	require.NoError(t, history.RecordHeartbeat(&packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeC, InboundIDs: [][]byte{nodeA}}, start.Add(time.Minute)))
+
+	networkMap, err := history.NetworkMap("v1", start.Add(10*time.Second), 0)
+	require.NoError(t, err)
+	assertTopology(t, networkMap, []string{id(nodeA), id(nodeB)}, map[string][]string{id(nodeA): {id(nodeB)}})
+
+	// the heartbeat of A is outside of the default window
+	networkMap, err = history.NetworkMap("v1", start.Add(time.Minute+time.Second), 0)
+	require.NoError(t, err)
+	assertTopology(t, networkMap, []string{id(nodeA), id(nodeC)}, map[string][]string{id(nodeA): {id(nodeC)}})
+
+	networkMap, err = history.NetworkMap("v1", start.Add(time.Minute+time.Second), 2*time.Minute)
+	require.NoError(t, err)
+	assertTopology(t, networkMap, []string{id(nodeA), id(nodeB), id(nodeC)}, map[string][]string{id(nodeA): {id(nodeB), id(nodeC)}})
+
+	networkMap, err = history.NetworkMap("v2", start.Add(time.Minute+time.Second), 2*time.Minute)
+	require.NoError(t, err)
+	assertTopology(t, networkMap, []string{id(nodeA), id(nodeD)}, map[string][]string{id(nodeD): {id(nodeA)}})
+
+	networkMap, err = history.NetworkMap("v1", start.Add(-time.Second), 0)
+	require.NoError(t, err)
+	assertTopology(t, networkMap, nil, nil)
+}
+
+func TestHistory_Heartbeats(t *testing.T) {
+	history, err := NewHistory(mapdb.NewMapDB(), 24*time.Hour)
+	require.NoError(t, err)
+
+	start := time.Now().Add(-time.Hour)
+	heartbeat := &packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeA, OutboundIDs: [][]byte{nodeB, nodeC}, InboundIDs: [][]byte{nodeD}}
+	metricHeartbeat := &packet.MetricHeartbeat{Version: "v1.0.0", OwnID: nodeA, OS: "linux", Arch: "amd64", NumCPU: 4, CPUUsage: 0.5, MemoryUsage: 1024}
+	for i := 2; i >= 0; i-- {
+		require.NoError(t, history.RecordHeartbeat(heartbeat, start.Add(time.Duration(i)*time.Second)))
+		require.NoError(t, history.RecordMetricHeartbeat(metricHeartbeat, start.Add(time.Duration(i)*time.Second)))
+	}
+
+	var records []*HeartbeatRecord
+	require.NoError(t, history.Heartbeats(start, start.Add(2*time.Second), func(record *HeartbeatRecord) bool {
+		records = append(records, record)
+		return true
+	}))
+	require.Len(t, records, 2)
+	assert.True(t, records[0].Time.Equal(start))
+	assert.True(t, records[1].Time.Equal(start.Add(time.Second)))
+	assert.Equal(t, heartbeat, records[0].Heartbeat)
+
+	var metricRecords []*MetricHeartbeatRecord
+	require.NoError(t, history.MetricHeartbeats(start, start.Add(time.Hour), func(record *MetricHeartbeatRecord) bool {
+		metricRecords = append(metricRecords, record)
+		return len(metricRecords) < 2
+	}))
+	require.Len(t, metricRecords, 2)
+	assert.True(t, metricRecords[1].Time.Equal(start.Add(time.Second)))
+	assert.Equal(t, metricHeartbeat, metricRecords[0].MetricHeartbeat)
+}
+
+func TestHistory_Prune(t *testing.T) {
+	store := mapdb.NewMapDB()
+	history, err := NewHistory(store, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, history.RecordHeartbeat(&packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeA}, now.Add(-30*time.Minute)))
+	require.NoError(t, history.RecordMetricHeartbeat(&packet.MetricHeartbeat{OwnID: nodeA}, now.Add(-30*time.Minute)))
+	require.NoError(t, history.RecordHeartbeat(&packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeB}, now.Add(-10*time.Minute)))
+
+	require.NoError(t, history.Prune(now.Add(25*time.Minute)))
+	assert.Equal(t, 3, countRecords(t, store))
+
+	require.NoError(t, history.Prune(now.Add(35*time.Minute)))
+	assert.Equal(t, 1, countRecords(t, store))
+
+	// the progress of the pruning survives a restart
+	history, err = NewHistory(store, time.Hour)
+	require.NoError(t, err)
+	var ownIDs [][]byte
+	require.NoError(t, history.Heartbeats(now.Add(-2*time.Hour), now, func(record *HeartbeatRecord) bool {
+		ownIDs = append(ownIDs, record.Heartbeat.OwnID)
+		return true
+	}))
+	assert.Equal(t, [][]byte{nodeB}, ownIDs)
+}
+
+func TestNetworkMap_Export(t *testing.T) {
+	lastSeen := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	networkMap := newNetworkMap("v1")
+	networkMap.update(&packet.Heartbeat{NetworkID: []byte("v1"), OwnID: nodeA, OutboundIDs: [][]byte{nodeB}}, lastSeen)
+
+	var dot bytes.Buffer
+	require.NoError(t, networkMap.WriteDOT(&dot))
+	assert.Equal(t, `digraph "v1" {
+	"`+id(nodeA)+`" [lastSeen="2021-06-01T12:00:00Z"];
+	"`+id(nodeB)+`" [lastSeen="2021-06-01T12:00:00Z"];
+	"`+id(nodeA)+`" -> "`+id(nodeB)+`" [lastSeen="2021-06-01T12:00:00Z"];
+}
+`, dot.String())
+
+	var graphML bytes.Buffer
+	require.NoError(t, networkMap.WriteGraphML(&graphML))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="lastSeen" for="all" attr.name="lastSeen" attr.type="string"></key>
+  <graph id="v1" edgedefault="directed">
+    <node id="`+id(nodeA)+`">
+      <data key="lastSeen">2021-06-01T12:00:00Z</data>
+    </node>
+    <node id="`+id(nodeB)+`">
+      <data key="lastSeen">2021-06-01T12:00:00Z</data>
+    </node>
+    <edge source="`+id(nodeA)+`" target="`+id(nodeB)+`">
+      <data key="lastSeen">2021-06-01T12:00:00Z</data>
+    </edge>
+  </graph>
+</graphml>
+`, graphML.String())
+}
+
+func assertTopology(t *testing.T, networkMap *NetworkMap, expectedNodes []string, expectedLinks map[string][]string) {
+	nodes, links := networkMap.Topology()
+	assert.ElementsMatch(t, expectedNodes, sortedKeys(nodes))
+	assert.Len(t, links, len(expectedLinks))
+	for sourceID, targetIDs := range expectedLinks {
+		assert.ElementsMatch(t, targetIDs, sortedKeys(links[sourceID]))
+	}
+}
+
+func countRecords(t *testing.T, store kvstore.KVStore) (count int) {
+	for _, prefix := range []byte{historyPrefixHeartbeat, historyPrefixMetricHeartbeat} {
+		require.NoError(t, store.IterateKeys(kvstore.KeyPrefix{prefix}, func(kvstore.Key) bool {
+			count++
+			return true
+		}))
+	}
+	return count
+}
+
+func testNodeID(b byte) []byte {
+	nodeID := make([]byte, packet.HeartbeatPacketPeerIDSize)
+	nodeID[0] = b
+	return nodeID
+}
+
+func id(nodeID []byte) string {
+	return ShortNodeIDString(nodeID)
+}
@@ -2,7 +2,24 @@ package server
 
 import (
 	"time"
+
+	flag "github.com/spf13/pflag"
 )
 
 // the period in which we scan and delete old data.
 const cleanUpPeriod = 15 * time.Second
+
+// the period in which the outdated records of the History are deleted.
+const historyPrunePeriod = time.Hour
+
+const (
+	// CfgAnalysisServerHistoryEnabled defines whether the received heartbeats are stored in the History.
+	CfgAnalysisServerHistoryEnabled = "analysis.server.history.enabled"
+	// CfgAnalysisServerHistoryRetention defines how long the received heartbeats are kept in the History.
+	CfgAnalysisServerHistoryRetention = "analysis.server.history.retention"
+)
+
+func init() {
+	flag.Bool(CfgAnalysisServerHistoryEnabled, true, "whether to store the received heartbeats in the history")
+	flag.Duration(CfgAnalysisServerHistoryRetention, 7*24*time.Hour, "how long the received heartbeats are kept in the history (forever if 0)")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/network"
 	"github.com/iotaledger/hive.go/network/tcp"
@@ -18,9 +19,11 @@ import (
 	"github.com/iotaledger/hive.go/protocol"
 	flag "github.com/spf13/pflag"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/analysis/packet"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	dbplugin "github.com/iotaledger/goshimmer/plugins/database"
 )
 
 const (
@@ -40,11 +43,12 @@ func init() {
 
 var (
 	// plugin is the plugin instance of the analysis server plugin.
-	plugin *node.Plugin
-	once   sync.Once
-	server *tcp.TCPServer
-	prot   *protocol.Protocol
-	log    *logger.Logger
+	plugin  *node.Plugin
+	once    sync.Once
+	server  *tcp.TCPServer
+	prot    *protocol.Protocol
+	log     *logger.Logger
+	history *History
 )
 
 // Plugin gets the plugin instance.
@@ -66,6 +70,34 @@ func configure(_ *node.Plugin) {
 	Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Errorf("error in analysis server: %s", err.Error())
 	}))
+	configureHistory()
+}
+
+// HeartbeatHistory returns the History of the received heartbeats (nil if it is disabled).
+func HeartbeatHistory() *History {
+	return history
+}
+
+func configureHistory() {
+	if !config.Node().Bool(CfgAnalysisServerHistoryEnabled) {
+		return
+	}
+
+	var err error
+	if history, err = NewHistory(dbplugin.StoreRealm(kvstore.Realm{database.PrefixAnalysisHistory}), config.Node().Duration(CfgAnalysisServerHistoryRetention)); err != nil {
+		log.Fatalf("failed to create the heartbeat history: %s", err)
+	}
+
+	Events.Heartbeat.Attach(events.NewClosure(func(hb *packet.Heartbeat) {
+		if err := history.RecordHeartbeat(hb, time.Now()); err != nil {
+			Events.Error.Trigger(err)
+		}
+	}))
+	Events.MetricHeartbeat.Attach(events.NewClosure(func(hb *packet.MetricHeartbeat) {
+		if err := history.RecordMetricHeartbeat(hb, time.Now()); err != nil {
+			Events.Error.Trigger(err)
+		}
+	}))
 }
 
 func run(_ *node.Plugin) {
@@ -96,6 +128,31 @@ func run(_ *node.Plugin) {
 		log.Panicf("Failed to start as daemon: %s", err)
 	}
 	runEventsRecordManager()
+	runHistoryPruner()
+}
+
+// starts the worker that deletes the outdated records of the History periodically.
+func runHistoryPruner() {
+	if history == nil {
+		return
+	}
+
+	if err := daemon.BackgroundWorker("Analysis Server History Pruner", func(shutdownSignal <-chan struct{}) {
+		ticker := time.NewTicker(historyPrunePeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-shutdownSignal:
+				return
+			case <-ticker.C:
+				if err := history.Prune(time.Now()); err != nil {
+					Events.Error.Trigger(err)
+				}
+			}
+		}
+	}, shutdown.PriorityAnalysis); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
 }
 
 // HandleConnection handles the given connection.
@@ -147,6 +204,7 @@ func processHeartbeatPacket(data []byte) {
 		return
 	}
 	updateAutopeeringMap(heartbeatPacket)
+	Events.Heartbeat.Trigger(heartbeatPacket)
 }
 
 // processHeartbeatPacket parses the serialized data into a FPC Heartbeat packet and triggers its event.
@@ -4,6 +4,7 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
@@ -12,9 +13,12 @@ import (
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 
+	gossippkg "github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/metrics"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
@@ -23,12 +27,29 @@ var (
 	Conn *NetConnMetric
 )
 
+const (
+	// rejectedPeerTTL is the time after which a rejected peer of another network is reported again.
+	rejectedPeerTTL = time.Hour
+	// maxRejectedPeers is the maximum number of rejected peers that are remembered.
+	maxRejectedPeers = 1000
+)
+
 var (
 	// the peer selection protocol
 	peerSel     *selection.Protocol
 	peerSelOnce sync.Once
+
+	// the rejected peers of other networks (to report every peer only once per rejectedPeerTTL)
+	rejectedPeers      = make(map[identity.ID]rejectedPeer)
+	rejectedPeersMutex sync.Mutex
 )
 
+// rejectedPeer holds the network ID that a rejected peer announced and the time it was reported.
+type rejectedPeer struct {
+	networkID  string
+	reportedAt time.Time
+}
+
 // Selection returns the neighbor selection instance.
 func Selection() *selection.Protocol {
 	peerSelOnce.Do(createPeerSel)
@@ -68,7 +89,59 @@ func isValidNeighbor(p *peer.Peer) bool {
 	if gossipService.Network() != "tcp" || gossipService.Port() < 0 || gossipService.Port() > 65535 {
 		return false
 	}
-	return true
+	// in a private network only allowlisted peers are selected
+	if allowlist := gossip.Manager().Allowlist(); allowlist != nil && !allowlist.Allowed(p.ID()) {
+		return false
+	}
+	// peers of other networks are never selected
+	return isSameNetwork(p)
+}
+
+// isSameNetwork checks whether a peer announces the network ID of the local node. Peers of other networks are logged
+// and counted once for every network ID they announce.
+func isSameNetwork(p *peer.Peer) bool {
+	announced := "none"
+	networkID, err := gossippkg.AnnouncedNetworkID(p)
+	if err == nil {
+		if networkID == messagelayer.NetworkID() {
+			return true
+		}
+		announced = networkID.String()
+	}
+
+	rejectedPeersMutex.Lock()
+	defer rejectedPeersMutex.Unlock()
+
+	now := time.Now()
+	if rejected, ok := rejectedPeers[p.ID()]; ok && rejected.networkID == announced && now.Sub(rejected.reportedAt) < rejectedPeerTTL {
+		return false
+	}
+	if len(rejectedPeers) >= maxRejectedPeers {
+		evictRejectedPeers(now)
+	}
+	rejectedPeers[p.ID()] = rejectedPeer{networkID: announced, reportedAt: now}
+
+	log.Infof("Rejected peer of another network: %s / %s network-id=%s want=%s", p.Address(), p.ID(), announced, messagelayer.NetworkID())
+	metrics.Events().NetworkIDMismatch.Trigger(&metrics.NetworkIDMismatchEvent{
+		ID:        p.ID().String(),
+		NetworkID: announced,
+	})
+
+	return false
+}
+
+// evictRejectedPeers removes the rejected peers whose report is older than rejectedPeerTTL. If all of them are more
+// recent, it forgets all of them, so that the number of remembered peers never exceeds maxRejectedPeers. The caller
+// has to hold the rejectedPeersMutex.
+func evictRejectedPeers(now time.Time) {
+	for id, rejected := range rejectedPeers {
+		if now.Sub(rejected.reportedAt) >= rejectedPeerTTL {
+			delete(rejectedPeers, id)
+		}
+	}
+	if len(rejectedPeers) >= maxRejectedPeers {
+		rejectedPeers = make(map[identity.ID]rejectedPeer)
+	}
 }
 
 func start(shutdownSignal <-chan struct{}) {
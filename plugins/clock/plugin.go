@@ -1,32 +1,49 @@
 package clock
 
 import (
-	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/timeutil"
 	flag "github.com/spf13/pflag"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
 const (
 	// CfgNTPPools defines the config flag of the NTP pools.
 	CfgNTPPools = "clock.ntpPools"
+	// CfgSyncInterval defines the config flag of the interval in which the clock is re-synchronized.
+	CfgSyncInterval = "clock.syncInterval"
+	// CfgMaxDeviation defines the config flag of the maximum deviation of an NTP offset from the median of all offsets.
+	CfgMaxDeviation = "clock.maxDeviation"
+	// CfgMaxSlew defines the config flag of the maximum change of the offset per re-synchronization.
+	CfgMaxSlew = "clock.maxSlew"
+	// CfgDriftThreshold defines the config flag of the drift between the synced time and the TangleTime that raises an alarm.
+	CfgDriftThreshold = "clock.driftThreshold"
 
-	maxTries     = 3
-	syncInterval = 30 * time.Minute
+	maxTries           = 3
+	driftCheckInterval = 10 * time.Second
 )
 
 var (
 	plugin     *node.Plugin
 	pluginOnce sync.Once
 	ntpPools   []string
+
+	driftMonitor     *clock.DriftMonitor
+	driftMonitorOnce sync.Once
+
+	lastSyncResult      *clock.SyncResult
+	lastSyncResultMutex sync.RWMutex
 )
 
 // Plugin gets the clock plugin instance.
@@ -39,6 +56,26 @@ func Plugin() *node.Plugin {
 
 func init() {
 	flag.StringSlice(CfgNTPPools, []string{"0.pool.ntp.org", "1.pool.ntp.org", "2.pool.ntp.org"}, "list of NTP pools to synchronize time from")
+	flag.Duration(CfgSyncInterval, 30*time.Minute, "the interval in which the clock is re-synchronized")
+	flag.Duration(CfgMaxDeviation, 250*time.Millisecond, "the maximum deviation of an NTP offset from the median of all offsets before it is rejected as an outlier")
+	flag.Duration(CfgMaxSlew, time.Second, "the maximum change of the offset per re-synchronization (0 means no limit)")
+	flag.Duration(CfgDriftThreshold, time.Minute, "the drift between the synced time and the TangleTime that raises an alarm")
+}
+
+// DriftMonitor returns the monitor of the drift between the synced time and the TangleTime.
+func DriftMonitor() *clock.DriftMonitor {
+	driftMonitorOnce.Do(func() {
+		driftMonitor = clock.NewDriftMonitor(tangleTime, config.Node().Duration(CfgDriftThreshold))
+	})
+	return driftMonitor
+}
+
+// LastSyncResult returns the result of the last successful synchronization (nil if there was none).
+func LastSyncResult() *clock.SyncResult {
+	lastSyncResultMutex.RLock()
+	defer lastSyncResultMutex.RUnlock()
+
+	return lastSyncResult
 }
 
 func configure(plugin *node.Plugin) {
@@ -46,15 +83,25 @@ func configure(plugin *node.Plugin) {
 	if len(ntpPools) == 0 {
 		plugin.LogFatalf("%s needs to provide at least 1 NTP pool to synchronize the local clock.", CfgNTPPools)
 	}
+
+	clock.Events().DriftExceeded.Attach(events.NewClosure(func(drift time.Duration) {
+		plugin.LogWarnf("Drift between synced time and TangleTime exceeds %v: %v", config.Node().Duration(CfgDriftThreshold), drift)
+	}))
+	clock.Events().DriftRecovered.Attach(events.NewClosure(func(drift time.Duration) {
+		plugin.LogInfof("Drift between synced time and TangleTime recovered: %v", drift)
+	}))
 }
 
 func run(plugin *node.Plugin) {
 	if err := daemon.BackgroundWorker(plugin.Name, func(shutdownSignal <-chan struct{}) {
 		// sync clock on startup
-		queryNTPPool()
+		queryNTPPools()
 
-		// sync clock every 30min to counter drift
-		timeutil.NewTicker(queryNTPPool, syncInterval, shutdownSignal)
+		// re-sync clock periodically to counter drift
+		timeutil.NewTicker(queryNTPPools, config.Node().Duration(CfgSyncInterval), shutdownSignal)
+
+		// compare the synced time with the TangleTime
+		timeutil.NewTicker(func() { DriftMonitor().Measure() }, driftCheckInterval, shutdownSignal)
 
 		<-shutdownSignal
 	}, shutdown.PrioritySynchronization); err != nil {
@@ -62,17 +109,46 @@ func run(plugin *node.Plugin) {
 	}
 }
 
-// queryNTPPool queries configured ntpPools for maxTries.
-func queryNTPPool() {
+// queryNTPPools synchronizes the clock with all configured ntpPools for maxTries.
+func queryNTPPools() {
 	plugin.LogDebug("Synchronizing clock...")
 	for t := maxTries; t > 0; t-- {
-		index := rand.Int() % len(ntpPools)
-		err := clock.FetchTimeOffset(ntpPools[index])
+		result, err := clock.Synchronize(ntpPools, config.Node().Duration(CfgMaxDeviation), config.Node().Duration(CfgMaxSlew))
+		for _, host := range sortedHosts(result.Failed) {
+			plugin.LogDebugf("NTP pool %s failed: %s", host, result.Failed[host])
+		}
+		if len(result.Rejected) > 0 {
+			plugin.LogWarnf("Rejected outlier NTP pools: %v", result.Rejected)
+		}
 		if err == nil {
-			plugin.LogDebug("Synchronizing clock... done")
+			lastSyncResultMutex.Lock()
+			lastSyncResult = result
+			lastSyncResultMutex.Unlock()
+
+			plugin.LogDebugf("Synchronizing clock... done: offset=%v target=%v", result.Offset, result.Target)
 			return
 		}
+		plugin.LogDebugf("Synchronizing clock failed: %s", err)
 	}
 
 	plugin.LogWarn("error while trying to sync clock")
 }
+
+// tangleTime returns the TangleTime or the zero time if no message was confirmed yet.
+func tangleTime() time.Time {
+	t := messagelayer.Tangle().TimeManager.Time()
+	if t.Unix() == tangle.DefaultGenesisTime {
+		return time.Time{}
+	}
+
+	return t
+}
+
+func sortedHosts(hosts map[string]error) (sorted []string) {
+	for host := range hosts {
+		sorted = append(sorted, host)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
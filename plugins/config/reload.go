@@ -0,0 +1,481 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	flag "github.com/spf13/pflag"
+)
+
+// CfgConfigAuditLog defines the config flag of the file that the changes of reloadable parameters are appended to.
+const CfgConfigAuditLog = "node.configAuditLog"
+
+// maxAuditEntries defines how many of the latest changes are kept in memory.
+const maxAuditEntries = 100
+
+var (
+	// ErrParameterNotReloadable is returned when a parameter is changed that can not be changed at runtime.
+	ErrParameterNotReloadable = errors.New("parameter is not reloadable")
+	// ErrInvalidParameterValue is returned when the new value of a parameter can not be parsed or is rejected.
+	ErrInvalidParameterValue = errors.New("invalid parameter value")
+	// ErrWriteBackFailed is returned when a changed parameter could not be written back to the config file.
+	ErrWriteBackFailed = errors.New("failed to write parameter back to the config file")
+	// ErrAuditLogFailed is returned when a change of a parameter could not be appended to the audit log file.
+	ErrAuditLogFailed = errors.New("failed to write the config audit log")
+)
+
+var (
+	reloadableParameters = make(map[string]*reloadableParameter)
+	auditEntries         []*AuditEntry
+	reloadMutex          sync.Mutex
+)
+
+func init() {
+	flag.String(CfgConfigAuditLog, "configaudit.log", "the file that changes of parameters at runtime are appended to (disabled if empty)")
+}
+
+// region ReloadHandler ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ReloadHandler applies the new value of a reloadable parameter to the running node. The value has the type of the
+// parameter's flag (int, int64, uint64, float64, bool, string or time.Duration). Returning an error rejects the value.
+type ReloadHandler func(value interface{}) error
+
+// RegisterReloadable marks the parameter with the given name as changeable at runtime. The handler is called with the
+// new value whenever the parameter is changed via Reload.
+func RegisterReloadable(name string, description string, handler ReloadHandler) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	parameterFlag := flag.CommandLine.Lookup(name)
+	if parameterFlag == nil {
+		panic(fmt.Sprintf("failed to register reloadable parameter %s: flag does not exist", name))
+	}
+	if _, supported := valueParsers[parameterFlag.Value.Type()]; !supported {
+		panic(fmt.Sprintf("failed to register reloadable parameter %s: type %s is not supported", name, parameterFlag.Value.Type()))
+	}
+
+	reloadableParameters[strings.ToLower(name)] = &reloadableParameter{
+		name:        name,
+		description: description,
+		valueType:   parameterFlag.Value.Type(),
+		handler:     handler,
+	}
+}
+
+// ReloadableParameters returns the parameters that can be changed at runtime together with their current value.
+func ReloadableParameters() (parameters []*ReloadableParameter) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	parameters = make([]*ReloadableParameter, 0, len(reloadableParameters))
+	for _, parameter := range reloadableParameters {
+		parameters = append(parameters, &ReloadableParameter{
+			Name:        parameter.name,
+			Description: parameter.description,
+			Type:        parameter.valueType,
+			Value:       parameter.currentValue(),
+		})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Name < parameters[j].Name })
+
+	return parameters
+}
+
+// Reload changes the value of a reloadable parameter at runtime and optionally writes it back to the config file. The
+// raw value is either a string or a JSON value that is parsed according to the type of the parameter. Every attempt is
+// recorded in the audit log, including the rejected ones. If the audit log file can not be written, the error of an
+// otherwise successful change wraps ErrAuditLogFailed, while the error of a rejected change only mentions it.
+func Reload(name string, rawValue interface{}, user string, writeBack bool) (entry *AuditEntry, err error) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	entry = &AuditEntry{
+		Time:      time.Now(),
+		Parameter: name,
+		NewValue:  rawValue,
+		User:      user,
+	}
+	defer func() {
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		if auditErr := recordAuditEntry(entry); auditErr != nil {
+			if err == nil {
+				err = errors.Errorf("%s was changed, but %s: %w", entry.Parameter, auditErr.Error(), ErrAuditLogFailed)
+				return
+			}
+			err = errors.Errorf("%w (%s: %s)", err, ErrAuditLogFailed.Error(), auditErr.Error())
+		}
+	}()
+
+	parameter, exists := reloadableParameters[strings.ToLower(name)]
+	if !exists {
+		return entry, errors.Errorf("%s: %w", name, ErrParameterNotReloadable)
+	}
+	entry.Parameter = parameter.name
+	entry.OldValue = formatValue(parameter.currentValue())
+
+	value, err := valueParsers[parameter.valueType](rawValueString(rawValue))
+	if err != nil {
+		return entry, errors.Errorf("%s must be of type %s: %w", parameter.name, parameter.valueType, ErrInvalidParameterValue)
+	}
+	entry.NewValue = formatValue(value)
+
+	if err = parameter.handler(value); err != nil {
+		return entry, errors.Errorf("%s: %s: %w", parameter.name, err.Error(), ErrInvalidParameterValue)
+	}
+	parameter.value = value
+	parameter.reloaded = true
+
+	if !writeBack {
+		return entry, nil
+	}
+	if err = writeParameter(*configFilePath, parameter.name, formatValue(value)); err != nil {
+		return entry, errors.Errorf("%s was changed, but %s: %w", parameter.name, err.Error(), ErrWriteBackFailed)
+	}
+	entry.WrittenBack = true
+
+	return entry, nil
+}
+
+// AuditEntries returns the latest changes of reloadable parameters (oldest first).
+func AuditEntries() []*AuditEntry {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	return append([]*AuditEntry{}, auditEntries...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ReloadableParameter //////////////////////////////////////////////////////////////////////////////////////////
+
+// ReloadableParameter describes a parameter that can be changed at runtime.
+type ReloadableParameter struct {
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Type        string      `json:"type"`
+	Value       interface{} `json:"value"`
+}
+
+// reloadableParameter is the registered handler of a parameter together with the value it was last changed to. The
+// changed values are not loaded into the configuration, as it is not safe for concurrent use.
+type reloadableParameter struct {
+	name        string
+	description string
+	valueType   string
+	handler     ReloadHandler
+	value       interface{}
+	reloaded    bool
+}
+
+// currentValue returns the value the parameter was last changed to or its configured value.
+func (r *reloadableParameter) currentValue() interface{} {
+	if r.reloaded {
+		return r.value
+	}
+
+	value, err := valueParsers[r.valueType](rawValueString(Node().Get(r.name)))
+	if err != nil {
+		return Node().Get(r.name)
+	}
+
+	return value
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AuditEntry ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AuditEntry records an attempt to change a parameter at runtime.
+type AuditEntry struct {
+	Time        time.Time   `json:"time"`
+	Parameter   string      `json:"parameter"`
+	OldValue    interface{} `json:"oldValue,omitempty"`
+	NewValue    interface{} `json:"newValue"`
+	User        string      `json:"user,omitempty"`
+	WrittenBack bool        `json:"writtenBack"`
+	Error       string      `json:"error,omitempty"`
+}
+
+// recordAuditEntry keeps the entry in memory and appends it to the audit log file (if configured).
+func recordAuditEntry(entry *AuditEntry) error {
+	if auditEntries = append(auditEntries, entry); len(auditEntries) > maxAuditEntries {
+		auditEntries = auditEntries[len(auditEntries)-maxAuditEntries:]
+	}
+
+	auditLog := Node().String(CfgConfigAuditLog)
+	if auditLog == "" {
+		return nil
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Errorf("failed to marshal audit entry: %w", err)
+	}
+	file, err := os.OpenFile(auditLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Errorf("failed to open config audit log %s: %w", auditLog, err)
+	}
+	defer file.Close()
+
+	if _, err = file.Write(append(entryBytes, '\n')); err != nil {
+		return errors.Errorf("failed to write config audit log %s: %w", auditLog, err)
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region value parsing ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// valueParsers contains the parsers of the flag types that reloadable parameters can have.
+var valueParsers = map[string]func(string) (interface{}, error){
+	"int": func(s string) (interface{}, error) {
+		return strconv.Atoi(s)
+	},
+	"int64": func(s string) (interface{}, error) {
+		return strconv.ParseInt(s, 10, 64)
+	},
+	"uint64": func(s string) (interface{}, error) {
+		return strconv.ParseUint(s, 10, 64)
+	},
+	"float64": func(s string) (interface{}, error) {
+		return strconv.ParseFloat(s, 64)
+	},
+	"bool": func(s string) (interface{}, error) {
+		return strconv.ParseBool(s)
+	},
+	"string": func(s string) (interface{}, error) {
+		return s, nil
+	},
+	"duration": func(s string) (interface{}, error) {
+		return time.ParseDuration(s)
+	},
+}
+
+// rawValueString returns the string representation of a value that was decoded from JSON or loaded from the config.
+func rawValueString(rawValue interface{}) string {
+	switch typedValue := rawValue.(type) {
+	case string:
+		return typedValue
+	case float64:
+		return strconv.FormatFloat(typedValue, 'f', -1, 64)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(typedValue)
+	}
+}
+
+// formatValue returns the representation of the value that is used in the audit log and the config file.
+func formatValue(value interface{}) interface{} {
+	if duration, isDuration := value.(time.Duration); isDuration {
+		return duration.String()
+	}
+
+	return value
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region write back ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// writeParameter sets the parameter in the given JSON config file. The keys of the parameter's path are matched
+// case-insensitively, missing ones are appended. The order of the existing keys and the exact representation of the
+// numbers in the file are preserved.
+func writeParameter(configFile string, name string, value interface{}) error {
+	if !strings.EqualFold(filepath.Ext(configFile), ".json") {
+		return errors.Errorf("only JSON config files are supported, got %s", configFile)
+	}
+
+	fileMode := os.FileMode(0600)
+	settings := newJSONObject()
+	if fileInfo, err := os.Stat(configFile); err == nil {
+		fileMode = fileInfo.Mode()
+
+		configBytes, readErr := ioutil.ReadFile(configFile)
+		if readErr != nil {
+			return errors.Errorf("failed to read config file %s: %w", configFile, readErr)
+		}
+		if settings, err = parseJSONObject(configBytes); err != nil {
+			return errors.Errorf("failed to parse config file %s: %w", configFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Errorf("failed to read config file %s: %w", configFile, err)
+	}
+
+	if err := setNested(settings, strings.Split(name, "."), value); err != nil {
+		return err
+	}
+
+	configBytes, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return errors.Errorf("failed to marshal config: %w", err)
+	}
+	tmpFile := configFile + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, append(configBytes, '\n'), fileMode); err != nil {
+		return errors.Errorf("failed to write config file %s: %w", tmpFile, err)
+	}
+	if err = os.Rename(tmpFile, configFile); err != nil {
+		return errors.Errorf("failed to replace config file %s: %w", configFile, err)
+	}
+
+	return nil
+}
+
+// setNested sets the value at the given path of keys in the nested settings.
+func setNested(settings *jsonObject, path []string, value interface{}) error {
+	key := path[0]
+	for _, existingKey := range settings.keys {
+		if strings.EqualFold(existingKey, key) {
+			key = existingKey
+			break
+		}
+	}
+
+	if len(path) == 1 {
+		settings.set(key, value)
+		return nil
+	}
+
+	if _, exists := settings.values[key]; !exists {
+		settings.set(key, newJSONObject())
+	}
+	nestedSettings, isObject := settings.values[key].(*jsonObject)
+	if !isObject {
+		return errors.Errorf("config key %s is not an object", key)
+	}
+
+	return setNested(nestedSettings, path[1:], value)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region jsonObject ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// jsonObject is a JSON object that keeps the order of its keys. Its numbers are kept as json.Number, so that they are
+// marshaled exactly as they were parsed.
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// newJSONObject creates an empty jsonObject.
+func newJSONObject() *jsonObject {
+	return &jsonObject{values: make(map[string]interface{})}
+}
+
+// parseJSONObject parses the given JSON object.
+func parseJSONObject(data []byte) (*jsonObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	value, err := decodeJSONValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	object, isObject := value.(*jsonObject)
+	if !isObject {
+		return nil, errors.New("the config is not a JSON object")
+	}
+	if _, err = decoder.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+
+	return object, nil
+}
+
+// decodeJSONValue decodes the next value of the decoder, keeping the order of the keys of all nested objects.
+func decodeJSONValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := newJSONObject()
+		for decoder.More() {
+			keyToken, keyErr := decoder.Token()
+			if keyErr != nil {
+				return nil, keyErr
+			}
+			key, isString := keyToken.(string)
+			if !isString {
+				return nil, errors.Errorf("invalid object key %v", keyToken)
+			}
+			value, valueErr := decodeJSONValue(decoder)
+			if valueErr != nil {
+				return nil, valueErr
+			}
+			object.set(key, value)
+		}
+		if _, err = decoder.Token(); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case json.Delim('['):
+		array := make([]interface{}, 0)
+		for decoder.More() {
+			value, valueErr := decodeJSONValue(decoder)
+			if valueErr != nil {
+				return nil, valueErr
+			}
+			array = append(array, value)
+		}
+		if _, err = decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
+
+// set sets the value of the given key, appending the key if it does not exist yet.
+func (j *jsonObject) set(key string, value interface{}) {
+	if _, exists := j.values[key]; !exists {
+		j.keys = append(j.keys, key)
+	}
+	j.values[key] = value
+}
+
+// MarshalJSON marshals the object with its keys in their original order.
+func (j *jsonObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, key := range j.keys {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueBytes, err := json.Marshal(j.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(keyBytes)
+		buffer.WriteByte(':')
+		buffer.Write(valueBytes)
+	}
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
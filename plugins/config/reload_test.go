@@ -0,0 +1,129 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	flag "github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	auditLog := filepath.Join(dir, "audit.log")
+	require.NoError(t, Node().Set(CfgConfigAuditLog, auditLog))
+
+	flag.Int("test.difficulty", 22, "")
+	flag.Duration("test.interval", time.Second, "")
+	require.NoError(t, Node().Set("test.difficulty", 22))
+
+	var difficulty int
+	RegisterReloadable("test.difficulty", "the difficulty", func(value interface{}) error {
+		if value.(int) < 0 {
+			return errors.New("must not be negative")
+		}
+		difficulty = value.(int)
+		return nil
+	})
+	RegisterReloadable("test.interval", "the interval", func(interface{}) error { return nil })
+
+	parameters := ReloadableParameters()
+	require.Len(t, parameters, 2)
+	assert.Equal(t, &ReloadableParameter{Name: "test.difficulty", Description: "the difficulty", Type: "int", Value: 22}, parameters[0])
+
+	entry, err := Reload("Test.Difficulty", float64(10), "admin", false)
+	require.NoError(t, err)
+	assert.Equal(t, 10, difficulty)
+	assert.Equal(t, "test.difficulty", entry.Parameter)
+	assert.Equal(t, 22, entry.OldValue)
+	assert.Equal(t, 10, entry.NewValue)
+	assert.Equal(t, 10, ReloadableParameters()[0].Value)
+
+	_, err = Reload("test.difficulty", "-1", "admin", false)
+	assert.ErrorIs(t, err, ErrInvalidParameterValue)
+	_, err = Reload("test.difficulty", "abc", "admin", false)
+	assert.ErrorIs(t, err, ErrInvalidParameterValue)
+	_, err = Reload("test.unknown", "1", "admin", false)
+	assert.ErrorIs(t, err, ErrParameterNotReloadable)
+	assert.Equal(t, 10, difficulty)
+
+	entries := AuditEntries()
+	require.Len(t, entries, 4)
+	assert.Empty(t, entries[0].Error)
+	assert.NotEmpty(t, entries[3].Error)
+
+	// all attempts are appended to the audit log
+	file, err := os.Open(auditLog)
+	require.NoError(t, err)
+	defer file.Close()
+	var lines int
+	for scanner := bufio.NewScanner(file); scanner.Scan(); lines++ {
+		var loggedEntry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &loggedEntry))
+		assert.Equal(t, "admin", loggedEntry.User)
+	}
+	assert.Equal(t, 4, lines)
+}
+
+func TestReload_WriteBack(t *testing.T) {
+	require.NoError(t, Node().Set(CfgConfigAuditLog, ""))
+
+	configFile := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, ioutil.WriteFile(configFile, []byte(`{"zeta": 18446744073709551615, "Test": {"rate": "5ms", "ratio": 0.10}, "other": [1, {"b": 2, "a": 1}]}`), 0600))
+	defer func(previousConfigFilePath string) { *configFilePath = previousConfigFilePath }(*configFilePath)
+	*configFilePath = configFile
+
+	flag.Duration("test.rate", 5*time.Millisecond, "")
+	RegisterReloadable("test.rate", "the rate", func(interface{}) error { return nil })
+
+	entry, err := Reload("test.rate", "10ms", "admin", true)
+	require.NoError(t, err)
+	assert.True(t, entry.WrittenBack)
+
+	configBytes, err := ioutil.ReadFile(configFile)
+	require.NoError(t, err)
+	// the order of the keys and the representation of the numbers are preserved
+	assert.Equal(t, `{
+  "zeta": 18446744073709551615,
+  "Test": {
+    "rate": "10ms",
+    "ratio": 0.10
+  },
+  "other": [
+    1,
+    {
+      "b": 2,
+      "a": 1
+    }
+  ]
+}
+`, string(configBytes))
+
+	*configFilePath = filepath.Join(t.TempDir(), "config.yml")
+	_, err = Reload("test.rate", "20ms", "admin", true)
+	assert.ErrorIs(t, err, ErrWriteBackFailed)
+}
+
+func TestReload_AuditLogFailed(t *testing.T) {
+	// the audit log can not be created in a directory that does not exist
+	require.NoError(t, Node().Set(CfgConfigAuditLog, filepath.Join(t.TempDir(), "missing", "audit.log")))
+	defer func() { require.NoError(t, Node().Set(CfgConfigAuditLog, "")) }()
+
+	flag.Int("test.auditLimit", 1, "")
+	RegisterReloadable("test.auditLimit", "the limit", func(interface{}) error { return nil })
+
+	entry, err := Reload("test.auditLimit", "2", "admin", false)
+	assert.ErrorIs(t, err, ErrAuditLogFailed)
+	assert.Equal(t, 2, entry.NewValue)
+
+	_, err = Reload("test.auditLimit", "abc", "admin", false)
+	assert.ErrorIs(t, err, ErrInvalidParameterValue)
+	assert.Contains(t, err.Error(), ErrAuditLogFailed.Error())
+}
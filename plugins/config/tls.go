@@ -0,0 +1,64 @@
+package config
+
+import (
+	"crypto/tls"
+	"sync"
+
+	"github.com/iotaledger/hive.go/logger"
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/tlsconfig"
+)
+
+const (
+	// CfgTLSCertFile defines the config flag of the certificate file that is used by all TLS enabled servers.
+	CfgTLSCertFile = "tls.certFile"
+	// CfgTLSKeyFile defines the config flag of the private key file that is used by all TLS enabled servers.
+	CfgTLSKeyFile = "tls.keyFile"
+	// CfgTLSClientCAFile defines the config flag of the CA file that is used to verify client certificates.
+	CfgTLSClientCAFile = "tls.clientCAFile"
+	// CfgTLSRequireClientCert defines the config flag that enables mutual TLS.
+	CfgTLSRequireClientCert = "tls.requireClientCert"
+)
+
+var (
+	tlsConfig     *tls.Config
+	tlsConfigErr  error
+	tlsConfigOnce sync.Once
+)
+
+func init() {
+	flag.String(CfgTLSCertFile, "", "the PEM encoded certificate of the TLS enabled servers (web API, dashboard, txstream)")
+	flag.String(CfgTLSKeyFile, "", "the PEM encoded private key of the TLS enabled servers")
+	flag.String(CfgTLSClientCAFile, "", "the PEM encoded CA certificates that are used to verify client certificates")
+	flag.Bool(CfgTLSRequireClientCert, false, "whether clients have to authenticate with a certificate (mutual TLS)")
+}
+
+// TLS returns the TLS configuration that is shared by all servers of the node. The certificates are reloaded
+// automatically whenever their files change.
+func TLS() (*tls.Config, error) {
+	tlsConfigOnce.Do(func() {
+		reloader, err := tlsconfig.NewReloader(tlsconfig.Config{
+			CertFile:          Node().String(CfgTLSCertFile),
+			KeyFile:           Node().String(CfgTLSKeyFile),
+			ClientCAFile:      Node().String(CfgTLSClientCAFile),
+			RequireClientCert: Node().Bool(CfgTLSRequireClientCert),
+		})
+		if err != nil {
+			tlsConfigErr = err
+			return
+		}
+
+		log := logger.NewLogger("TLS")
+		reloader.ReloadHandler = func() {
+			log.Infof("Reloaded certificates from %s", Node().String(CfgTLSCertFile))
+		}
+		reloader.ReloadErrorHandler = func(err error) {
+			log.Warnf("Failed to reload certificates, keeping the previous ones: %s", err)
+		}
+
+		tlsConfig = reloader.ServerConfig()
+	})
+
+	return tlsConfig, tlsConfigErr
+}
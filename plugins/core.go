@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/faucet"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/gracefulshutdown"
+	"github.com/iotaledger/goshimmer/plugins/issuerregistry"
 	"github.com/iotaledger/goshimmer/plugins/logger"
 	"github.com/iotaledger/goshimmer/plugins/manaeventlogger"
 	"github.com/iotaledger/goshimmer/plugins/manarefresher"
@@ -36,6 +37,7 @@ var Core = node.Plugins(
 	profiling.Plugin(),
 	database.Plugin(),
 	pow.Plugin(),
+	issuerregistry.Plugin(),
 	clock.Plugin(),
 	messagelayer.Plugin(),
 	messagelayer.ManaPlugin(),
@@ -15,6 +15,8 @@ const (
 	CfgBasicAuthUsername = "dashboard.basic_auth.username"
 	// CfgBasicAuthPassword defines the config flag of the dashboard basic auth password.
 	CfgBasicAuthPassword = "dashboard.basic_auth.password"
+	// CfgTLSEnabled defines the config flag of the dashboard TLS enabler (uses the shared tls.* configuration).
+	CfgTLSEnabled = "dashboard.tls.enabled"
 )
 
 func init() {
@@ -23,4 +25,5 @@ func init() {
 	flag.Bool(CfgBasicAuthEnabled, false, "whether to enable HTTP basic auth")
 	flag.String(CfgBasicAuthUsername, "goshimmer", "HTTP basic auth username")
 	flag.String(CfgBasicAuthPassword, "goshimmer", "HTTP basic auth password")
+	flag.Bool(CfgTLSEnabled, false, "whether the dashboard is served over TLS")
 }
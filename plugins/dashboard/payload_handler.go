@@ -113,6 +113,12 @@ func ProcessPayload(p payload.Payload) interface{} {
 			ContentTitle: "GenericDataPayload",
 			Content:      p.(*payload.GenericDataPayload).Blob(),
 		}
+	case payload.TaggedDataPayloadType:
+		// tagged data payload
+		return BasicPayload{
+			ContentTitle: "TaggedDataPayload (" + string(p.(*payload.TaggedDataPayload).Tag()) + ")",
+			Content:      p.(*payload.TaggedDataPayload).Data(),
+		}
 	case ledgerstate.TransactionType:
 		return processTransactionPayload(p)
 	case statement.StatementType:
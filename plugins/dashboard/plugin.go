@@ -127,8 +127,8 @@ func worker(shutdownSignal <-chan struct{}) {
 	stopped := make(chan struct{})
 	bindAddr := config.Node().String(CfgBindAddress)
 	go func() {
-		log.Infof("%s started, bind-address=%s, basic-auth=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled))
-		if err := server.Start(bindAddr); err != nil {
+		log.Infof("%s started, bind-address=%s, basic-auth=%v, tls=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled), config.Node().Bool(CfgTLSEnabled))
+		if err := start(bindAddr); err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				log.Errorf("Error serving: %s", err)
 			}
@@ -150,6 +150,22 @@ func worker(shutdownSignal <-chan struct{}) {
 	}
 }
 
+// start starts the server on the given address and serves it over TLS if enabled.
+func start(bindAddr string) error {
+	if !config.Node().Bool(CfgTLSEnabled) {
+		return server.Start(bindAddr)
+	}
+
+	tlsConfig, err := config.TLS()
+	if err != nil {
+		return err
+	}
+	server.TLSServer.Addr = bindAddr
+	server.TLSServer.TLSConfig = tlsConfig
+
+	return server.StartServer(server.TLSServer)
+}
+
 const (
 	// MsgTypeNodeStatus is the type of the NodeStatus message.
 	MsgTypeNodeStatus byte = iota
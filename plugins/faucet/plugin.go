@@ -132,6 +132,16 @@ func configure(*node.Plugin) {
 	blacklistCapacity = config.Node().Int(CfgFaucetBlacklistCapacity)
 	Faucet()
 
+	config.RegisterReloadable(CfgFaucetTokensPerRequest, "the amount of tokens the faucet sends for each request", func(value interface{}) error {
+		if value.(int) <= 0 {
+			return errors.New("the amount of tokens must be above zero")
+		}
+		Faucet().SetTokensPerRequest(uint64(value.(int)))
+		log.Infof("changed the amount of tokens per request to %d", value.(int))
+
+		return nil
+	})
+
 	fundingWorkerPool = workerpool.New(func(task workerpool.Task) {
 		msg := task.Param(0).(*tangle.Message)
 		addr := msg.Payload().(*faucet.Request).Address()
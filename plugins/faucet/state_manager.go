@@ -107,6 +107,21 @@ func NewStateManager(
 	return res
 }
 
+// TokensPerRequest returns the amount of tokens that are sent for every request.
+func (s *StateManager) TokensPerRequest() uint64 {
+	s.RLock()
+	defer s.RUnlock()
+	return s.tokensPerRequest
+}
+
+// SetTokensPerRequest changes the amount of tokens that are sent for every request. Funding outputs that were prepared
+// before keep their amount and are used up first.
+func (s *StateManager) SetTokensPerRequest(tokensPerRequest uint64) {
+	s.Lock()
+	defer s.Unlock()
+	s.tokensPerRequest = tokensPerRequest
+}
+
 // FundingOutputsCount returns the number of available outputs that can be used to fund a request.
 func (s *StateManager) FundingOutputsCount() int {
 	s.RLock()
@@ -269,7 +284,7 @@ func (s *StateManager) prepareFaucetTransaction(destAddr ledgerstate.Address, fu
 	outputs := ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(
 		ledgerstate.NewColoredBalances(
 			map[ledgerstate.Color]uint64{
-				ledgerstate.ColorIOTA: s.tokensPerRequest,
+				ledgerstate.ColorIOTA: fundingOutput.Balance,
 			}),
 		destAddr,
 	),
@@ -7,12 +7,15 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/netutil"
 	"github.com/iotaledger/hive.go/types"
 
 	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/gossip/server"
+	"github.com/iotaledger/goshimmer/packages/metrics"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/config"
@@ -47,7 +50,34 @@ func createManager() {
 	if err := lPeer.UpdateService(service.GossipKey, "tcp", gossipPort); err != nil {
 		log.Fatalf("could not update services: %s", err)
 	}
-	mgr = gossip.NewManager(lPeer, loadMessage, log)
+	// announce the network ID so that peers of other networks are not selected (see gossip.NetworkIDKey)
+	if err := lPeer.UpdateService(gossip.NetworkIDKey, messagelayer.NetworkID().String(), 0); err != nil {
+		log.Fatalf("could not update services: %s", err)
+	}
+
+	var opts []gossip.ManagerOption
+	if config.Node().Bool(CfgGossipPrivateNetwork) {
+		allowlist, err := loadAllowlist()
+		if err != nil {
+			log.Fatalf("Invalid %s: %s", CfgGossipAllowlist, err)
+		}
+		opts = append(opts, gossip.WithAllowlist(allowlist))
+		log.Infof("Private network mode enabled: %d allowlisted peers", len(config.Node().Strings(CfgGossipAllowlist)))
+	}
+	mgr = gossip.NewManager(lPeer, loadMessage, log, opts...)
+}
+
+// loadAllowlist parses the allowlisted public keys from the config.
+func loadAllowlist() (*gossip.Allowlist, error) {
+	var keys []ed25519.PublicKey
+	for _, encodedKey := range config.Node().Strings(CfgGossipAllowlist) {
+		key, err := ed25519.PublicKeyFromString(encodedKey)
+		if err != nil {
+			return nil, errors.Errorf("failed to parse public key %s: %w", encodedKey, err)
+		}
+		keys = append(keys, key)
+	}
+	return gossip.NewAllowlist(keys...), nil
 }
 
 func start(shutdownSignal <-chan struct{}) {
@@ -71,13 +101,19 @@ func start(shutdownSignal <-chan struct{}) {
 	}
 	defer listener.Close()
 
-	srv := server.ServeTCP(lPeer, listener, log)
+	srv := server.ServeTCP(lPeer, listener, log, server.WithNetworkID(uint64(messagelayer.NetworkID())))
 	defer srv.Close()
+	srv.Events.NetworkIDMismatch.Attach(events.NewClosure(func(ev *server.NetworkIDMismatchEvent) {
+		metrics.Events().NetworkIDMismatch.Trigger(&metrics.NetworkIDMismatchEvent{
+			ID:        ev.ID.String(),
+			NetworkID: gossip.NetworkID(ev.NetworkID).String(),
+		})
+	}))
 
 	mgr.Start(srv)
 	defer mgr.Stop()
 
-	log.Infof("%s started: age-threshold=%v bind-address=%s", PluginName, ageThreshold, localAddr.String())
+	log.Infof("%s started: age-threshold=%v bind-address=%s network-id=%s", PluginName, ageThreshold, localAddr.String(), messagelayer.NetworkID())
 
 	<-shutdownSignal
 	log.Info("Stopping " + PluginName + " ...")
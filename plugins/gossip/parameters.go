@@ -13,10 +13,16 @@ const (
 	CfgGossipAgeThreshold = "gossip.ageThreshold"
 	// CfgGossipTipsBroadcastInterval the interval in which the oldest known tip is re-broadcast.
 	CfgGossipTipsBroadcastInterval = "gossip.tipsBroadcaster.interval"
+	// CfgGossipPrivateNetwork defines whether the node only accepts the allowlisted peers and the manual peers as neighbors.
+	CfgGossipPrivateNetwork = "gossip.privateNetwork"
+	// CfgGossipAllowlist defines the base58 encoded public keys of the peers that are allowed as neighbors in a private network.
+	CfgGossipAllowlist = "gossip.allowlist"
 )
 
 func init() {
 	flag.Int(CfgGossipPort, 14666, "tcp port for gossip connection")
 	flag.Duration(CfgGossipAgeThreshold, 1*time.Minute, "message age threshold for gossip")
 	flag.Duration(CfgGossipTipsBroadcastInterval, 10*time.Second, "the interval in which the oldest known tip is re-broadcast")
+	flag.Bool(CfgGossipPrivateNetwork, false, "only accept the allowlisted peers and the manual peers as neighbors")
+	flag.StringSlice(CfgGossipAllowlist, []string{}, "the base58 encoded public keys of the peers that are allowed as neighbors in a private network")
 }
@@ -0,0 +1,15 @@
+package issuerregistry
+
+import "github.com/iotaledger/hive.go/configuration"
+
+// Parameters contains the configuration parameters of the issuer registry plugin.
+var Parameters = struct {
+	// Admins defines the public keys of the admins that can update the set of authorized issuers.
+	Admins []string `usage:"the base58 encoded public keys of the admins that can update the set of authorized issuers"`
+	// Issuers defines the public keys of the authorized issuers until the first update of the registry is received.
+	Issuers []string `usage:"the base58 encoded public keys of the initially authorized issuers"`
+}{}
+
+func init() {
+	configuration.BindParameters(&Parameters, "issuerRegistry")
+}
@@ -0,0 +1,90 @@
+package issuerregistry
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/issuerregistry"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	dbplugin "github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// PluginName is the name of the issuer registry plugin.
+const PluginName = "IssuerRegistry"
+
+var (
+	// plugin is the plugin instance of the issuer registry plugin.
+	plugin     *node.Plugin
+	pluginOnce sync.Once
+	log        *logger.Logger
+
+	registry *issuerregistry.Registry
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	pluginOnce.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Disabled, configure)
+	})
+	return plugin
+}
+
+// Registry returns the issuer registry of the node (nil if the plugin is disabled).
+func Registry() *issuerregistry.Registry {
+	return registry
+}
+
+func configure(*node.Plugin) {
+	log = logger.NewLogger(PluginName)
+
+	admins, err := parsePublicKeys(Parameters.Admins)
+	if err != nil {
+		log.Fatalf("Invalid admins: %s", err)
+	}
+	if len(admins) == 0 {
+		log.Fatalf("The issuer registry requires at least one admin")
+	}
+	issuers, err := parsePublicKeys(Parameters.Issuers)
+	if err != nil {
+		log.Fatalf("Invalid issuers: %s", err)
+	}
+
+	if registry, err = issuerregistry.NewRegistry(dbplugin.StoreRealm(kvstore.Realm{database.PrefixIssuerRegistry}), admins, issuers); err != nil {
+		log.Fatalf("Failed to create the issuer registry: %s", err)
+	}
+	log.Infof("%s configured: version=%d admins=%d", PluginName, registry.Version(time.Now()), len(admins))
+
+	registry.Events.Updated.Attach(events.NewClosure(func(registryPayload *issuerregistry.Payload) {
+		log.Infof("Issuer registry version %d added by %s: %d authorized issuers from %s on", registryPayload.Version(), registryPayload.Admin(), len(registryPayload.Issuers()), registryPayload.ActivationTime())
+	}))
+
+	messagelayer.Tangle().Parser.AddMessageFilter(tangle.NewIssuerFilter(registry.IsAuthorized))
+	messagelayer.Tangle().Storage.Events.MessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+			if _, err := registry.ApplyMessage(message); err != nil {
+				log.Warnf("Failed to apply the issuer registry update of message %s: %s", messageID, err)
+			}
+		})
+	}))
+}
+
+// parsePublicKeys parses the given base58 encoded public keys.
+func parsePublicKeys(encodedKeys []string) (keys []ed25519.PublicKey, err error) {
+	for _, encodedKey := range encodedKeys {
+		key, parseErr := ed25519.PublicKeyFromString(encodedKey)
+		if parseErr != nil {
+			return nil, errors.Errorf("failed to parse public key %s: %w", encodedKey, parseErr)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
@@ -42,6 +42,15 @@ func init() {
 		if err := logger.InitGlobalLogger(config.Node()); err != nil {
 			panic(err)
 		}
+		config.RegisterReloadable(CfgLoggerLevel, "the level of the node's log messages", func(value interface{}) error {
+			var level logger.Level
+			if err := level.UnmarshalText([]byte(value.(string))); err != nil {
+				return err
+			}
+			logger.SetLevel(level)
+
+			return nil
+		})
 
 		// enable logging for the daemon
 		daemon.DebugEnabled(true)
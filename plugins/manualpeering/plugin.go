@@ -9,11 +9,14 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/config"
 
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/manualpeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	dbplugin "github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
@@ -42,7 +45,8 @@ func Plugin() *node.Plugin {
 func Manager() *manualpeering.Manager {
 	managerOnce.Do(func() {
 		lPeer := local.GetInstance()
-		manager = manualpeering.NewManager(gossip.Manager(), lPeer, logger.NewLogger(PluginName))
+		manager = manualpeering.NewManager(gossip.Manager(), lPeer, logger.NewLogger(PluginName),
+			manualpeering.WithStore(dbplugin.StoreRealm(kvstore.Realm{database.PrefixManualPeering})))
 	})
 	return manager
 }
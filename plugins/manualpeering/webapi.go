@@ -2,6 +2,7 @@ package manualpeering
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -39,7 +40,7 @@ func addPeersHandler(c echo.Context) error {
 			jsonmodels.NewErrorResponse(errors.Wrap(err, "Invalid add peers request")),
 		)
 	}
-	if err := Manager().AddPeer(peers...); err != nil {
+	if err := Manager().AddStoredPeer(peers...); err != nil {
 		plugin.Logger().Errorw(
 			"Can't add some of the peers from the HTTP request to manualpeering manager",
 			"err", err,
@@ -96,6 +97,16 @@ func getPeersHandler(c echo.Context) error {
 			jsonmodels.NewErrorResponse(errors.Wrap(err, "Invalid get peers request")),
 		)
 	}
+	// clients that can't send a body with GET requests can use the query parameter instead
+	if onlyConnected := c.QueryParam("onlyConnected"); onlyConnected != "" {
+		var err error
+		if conf.OnlyConnected, err = strconv.ParseBool(onlyConnected); err != nil {
+			return c.JSON(
+				http.StatusBadRequest,
+				jsonmodels.NewErrorResponse(errors.Wrap(err, "Invalid onlyConnected query parameter")),
+			)
+		}
+	}
 	peers := Manager().GetPeers(conf.ToOptions()...)
 	return c.JSON(http.StatusOK, peers)
 }
@@ -44,11 +44,14 @@ var (
 	osFactory          *objectstorage.Factory
 	storages           map[mana.Type]*objectstorage.ObjectStorage
 	allowedPledgeNodes map[mana.Type]AllowedPledge
+	manaAnalytics      *mana.Analytics
 	// consensusBaseManaPastVectorStorage         *objectstorage.ObjectStorage
 	// consensusBaseManaPastVectorMetadataStorage *objectstorage.ObjectStorage
 	// consensusEventsLogStorage                  *objectstorage.ObjectStorage
 	// consensusEventsLogsStorageSize             atomic.Uint32
 	onTransactionConfirmedClosure *events.Closure
+	onAnalyticsPledgeClosure      *events.Closure
+	onAnalyticsRevokeClosure      *events.Closure
 	// onPledgeEventClosure          *events.Closure
 	// onRevokeEventClosure          *events.Closure
 	// debuggingEnabled              bool
@@ -66,6 +69,8 @@ func configureManaPlugin(*node.Plugin) {
 	manaLogger = logger.NewLogger(PluginName)
 
 	onTransactionConfirmedClosure = events.NewClosure(onTransactionConfirmed)
+	onAnalyticsPledgeClosure = events.NewClosure(recordAnalyticsPledge)
+	onAnalyticsRevokeClosure = events.NewClosure(recordAnalyticsRevoke)
 	// onPledgeEventClosure = events.NewClosure(logPledgeEvent)
 	// onRevokeEventClosure = events.NewClosure(logRevokeEvent)
 
@@ -73,6 +78,7 @@ func configureManaPlugin(*node.Plugin) {
 	baseManaVectors = make(map[mana.Type]mana.BaseManaVector)
 	baseManaVectors[mana.AccessMana], _ = mana.NewBaseManaVector(mana.AccessMana)
 	baseManaVectors[mana.ConsensusMana], _ = mana.NewBaseManaVector(mana.ConsensusMana)
+	manaAnalytics = mana.NewAnalytics(ManaParameters.AnalyticsEpochDuration, ManaParameters.AnalyticsMaxHistory, ManaParameters.AnalyticsMaxEpochs)
 
 	// configure storage for each vector type
 	storages = make(map[mana.Type]*objectstorage.ObjectStorage)
@@ -103,6 +109,8 @@ func configureManaPlugin(*node.Plugin) {
 func configureEvents() {
 	// until we have the proper event...
 	Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Attach(onTransactionConfirmedClosure)
+	mana.Events().Pledged.Attach(onAnalyticsPledgeClosure)
+	mana.Events().Revoked.Attach(onAnalyticsRevokeClosure)
 	// mana.Events().Pledged.Attach(onPledgeEventClosure)
 	// mana.Events().Revoked.Attach(onRevokeEventClosure)
 }
@@ -187,6 +195,37 @@ func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 	})
 }
 
+// recordAnalyticsPledge feeds a pledge event into the mana analytics, attributing it to the addresses that funded the
+// pledging transaction.
+func recordAnalyticsPledge(ev *mana.PledgedEvent) {
+	manaAnalytics.RecordPledge(ev, pledgeSources(ev.TransactionID))
+}
+
+// recordAnalyticsRevoke feeds a revoke event into the mana analytics, deducting it from the addresses that funded the
+// transaction which created the spent input and thereby pledged the revoked mana.
+func recordAnalyticsRevoke(ev *mana.RevokedEvent) {
+	manaAnalytics.RecordRevoke(ev, pledgeSources(ev.InputID.TransactionID()))
+}
+
+// pledgeSources returns the addresses that funded the given transaction together with the amount of tokens each of
+// them contributed.
+func pledgeSources(transactionID ledgerstate.TransactionID) (sources []*mana.AddressAmount) {
+	Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		for _, input := range transaction.Essence().Inputs() {
+			Tangle().LedgerState.CachedOutput(input.(*ledgerstate.UTXOInput).ReferencedOutputID()).Consume(func(output ledgerstate.Output) {
+				var amount float64
+				output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+					amount += float64(balance)
+					return true
+				})
+				sources = append(sources, &mana.AddressAmount{Address: output.Address(), Amount: amount})
+			})
+		}
+	})
+
+	return sources
+}
+
 func runManaPlugin(_ *node.Plugin) {
 	// mana calculation coefficients can be set from config
 	ema1 := ManaParameters.EmaCoefficient1
@@ -225,6 +264,8 @@ func runManaPlugin(_ *node.Plugin) {
 				// mana.Events().Pledged.Detach(onPledgeEventClosure)
 				// mana.Events().Pledged.Detach(onRevokeEventClosure)
 				Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Detach(onTransactionConfirmedClosure)
+				mana.Events().Pledged.Detach(onAnalyticsPledgeClosure)
+				mana.Events().Revoked.Detach(onAnalyticsRevokeClosure)
 				storeManaVectors()
 				shutdownStorages()
 				return
@@ -280,6 +321,11 @@ func shutdownStorages() {
 	// consensusBaseManaPastVectorMetadataStorage.Shutdown()
 }
 
+// ManaAnalytics returns the pledge analytics of the mana plugin.
+func ManaAnalytics() *mana.Analytics {
+	return manaAnalytics
+}
+
 // GetHighestManaNodes returns the n highest type mana nodes in descending order.
 // It also updates the mana values for each node.
 // If n is zero, it returns all nodes.
@@ -28,6 +28,13 @@ var Parameters = struct {
 
 	// StartSynced defines if the node should start as synced.
 	StartSynced bool `default:"false" usage:"start as synced"`
+
+	// RecordingFile is the path to the file the bytes that enter the Parser are recorded to. Recording is disabled if
+	// it is empty.
+	RecordingFile string `usage:"the path to the file the incoming messages are recorded to (disabled if empty)"`
+
+	// MessageIndex defines if the node maintains an index of the messages by payload type, issuer and issuing time.
+	MessageIndex bool `default:"false" usage:"whether to index the messages by payload type, issuer and issuing time"`
 }{}
 
 // FPCParameters contains the configuration parameters used by the FPC consensus.
@@ -105,6 +112,12 @@ var ManaParameters = struct {
 	DebuggingEnabled bool `default:"false" usage:"if mana plugin responds to queries while not in sync"`
 	// SnapshotResetTime defines if the aMana Snapshot should be reset to the current Time.
 	SnapshotResetTime bool `default:"false" usage:"when loading snapshot reset to current time when true"`
+	// AnalyticsEpochDuration defines the length of the epochs that pledged mana is aggregated into by the analytics.
+	AnalyticsEpochDuration time.Duration `default:"1h" usage:"length of the epochs that pledged mana is aggregated into by the mana analytics"`
+	// AnalyticsMaxHistory defines the maximum amount of pledge and revoke samples kept per node by the analytics.
+	AnalyticsMaxHistory int `default:"1000" usage:"maximum amount of pledge and revoke samples kept per node by the mana analytics"`
+	// AnalyticsMaxEpochs defines the maximum amount of epochs kept by the analytics.
+	AnalyticsMaxEpochs int `default:"168" usage:"maximum amount of epochs kept by the mana analytics"`
 }{}
 
 // RateSetterParameters contains the configuration parameters used by the Rate Setter.
@@ -119,6 +132,11 @@ var SchedulerParameters = struct {
 	MaxBufferSize int `default:"100000000" usage:"maximum buffer size (in bytes)"` // 100 MB
 	// SchedulerRate defines the frequency to schedule a message.
 	Rate string `default:"5ms" usage:"message scheduling interval [time duration string]"`
+	// PayloadPriorities defines the priority classes (normal, high or critical) of payload types within a node's queue.
+	// Only the dRNG and FPC statement payloads are prioritized by default, all others (e.g. transactions) are normal.
+	PayloadPriorities []string `default:"111:critical,3:critical" usage:"priority classes of payload types within a node's queue [payloadType:class]"`
+	// MaxRestoreAge defines the maximum age of the scheduler buffer and deficits persisted at shutdown to be restored at startup.
+	MaxRestoreAge time.Duration `default:"5m" usage:"maximum age of the persisted scheduler buffer and deficits and their messages to be restored at startup (negative to discard them at shutdown)"`
 }{}
 
 func init() {
@@ -1,16 +1,26 @@
 package messagelayer
 
 import (
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
+	"github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/recorder"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/database"
 
 	"github.com/cockroachdb/errors"
@@ -18,7 +28,10 @@ import (
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/node"
+	"go.uber.org/atomic"
+	"golang.org/x/crypto/blake2b"
 )
 
 var (
@@ -34,6 +47,7 @@ var (
 var (
 	plugin     *node.Plugin
 	pluginOnce sync.Once
+	recording  *recorder.Recorder
 )
 
 // Plugin gets the plugin instance.
@@ -81,21 +95,26 @@ func configure(plugin *node.Plugin) {
 		plugin.LogInfof("node %s is blacklisted in Scheduler", nodeID.String())
 	}))
 
+	configuredSchedulerRate.Store(Tangle().Scheduler.Rate())
 	Tangle().TimeManager.Events.SyncChanged.Attach(events.NewClosure(func(ev *tangle.SyncChangedEvent) {
 		plugin.LogInfo("Sync changed: ", ev.Synced)
-		if ev.Synced {
-			// make sure that we are using the configured rate when synced
-			rate := Tangle().Options.SchedulerParams.Rate
-			Tangle().Scheduler.SetRate(rate)
-			plugin.LogInfof("Scheduler rate: %v", rate)
-		} else {
-			// increase scheduler rate
-			rate := Tangle().Options.SchedulerParams.Rate
-			rate -= rate / 2 // 50% increase
-			Tangle().Scheduler.SetRate(rate)
-			plugin.LogInfof("Scheduler rate: %v", rate)
-		}
+		applySchedulerRate(ev.Synced)
 	}))
+	// the rate setter parameters are not reloadable, as the node creates no RateSetter that could apply them
+	config.RegisterReloadable("scheduler.rate", "the message scheduling interval of the scheduler", func(value interface{}) error {
+		rate, err := time.ParseDuration(value.(string))
+		if err != nil {
+			return err
+		}
+		if rate <= 0 {
+			return errors.New("rate must be positive")
+		}
+
+		configuredSchedulerRate.Store(rate)
+		applySchedulerRate(Tangle().TimeManager.Synced())
+
+		return nil
+	})
 
 	// read snapshot file
 	if Parameters.Snapshot.File != "" {
@@ -111,15 +130,48 @@ func configure(plugin *node.Plugin) {
 		plugin.LogInfof("read snapshot from %s", Parameters.Snapshot.File)
 	}
 
+	if Parameters.RecordingFile != "" {
+		f, err := createRecordingFile(Parameters.RecordingFile, time.Now())
+		if err != nil {
+			plugin.Panic("can not create recording file:", err)
+		}
+		if recording, err = recorder.NewRecorder(f); err != nil {
+			plugin.Panic("could not start recording:", err)
+		}
+		recording.Attach(Tangle().Parser)
+		plugin.LogInfof("recording incoming messages to %s", f.Name())
+	}
+
 	fcob.LikedThreshold = time.Duration(Parameters.FCOB.QuarantineTime) * time.Second
 	fcob.LocallyFinalizedThreshold = time.Duration(Parameters.FCOB.QuarantineTime+Parameters.FCOB.QuarantineTime) * time.Second
 
 	configureApprovalWeight()
 }
 
+// createRecordingFile creates the file the incoming messages are recorded to. If the configured file already exists,
+// the recording of this run is written next to it with the start time appended to the name, so that earlier recordings
+// are never overwritten.
+func createRecordingFile(path string, startTime time.Time) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if !os.IsExist(err) {
+		return f, err
+	}
+
+	extension := filepath.Ext(path)
+	timestampedPath := strings.TrimSuffix(path, extension) + "-" + startTime.UTC().Format("20060102T150405") + extension
+
+	return os.OpenFile(timestampedPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+}
+
 func run(*node.Plugin) {
 	if err := daemon.BackgroundWorker("Tangle", func(shutdownSignal <-chan struct{}) {
 		<-shutdownSignal
+		if recording != nil {
+			if err := recording.Close(); err != nil {
+				plugin.LogErrorf("failed to close recording: %s", err)
+			}
+			plugin.LogInfof("recorded %d messages", recording.Count())
+		}
 		Tangle().Shutdown()
 	}, shutdown.PriorityTangle); err != nil {
 		plugin.Panicf("Failed to start as daemon: %s", err)
@@ -149,12 +201,15 @@ func Tangle() *tangle.Tangle {
 				Rate:                        schedulerRate(SchedulerParameters.Rate),
 				AccessManaRetrieveFunc:      accessManaRetriever,
 				TotalAccessManaRetrieveFunc: totalAccessManaRetriever,
+				PayloadPriorities:           payloadPriorities(SchedulerParameters.PayloadPriorities),
+				MaxRestoreAge:               SchedulerParameters.MaxRestoreAge,
 			}),
 			tangle.RateSetterConfig(tangle.RateSetterParams{
 				Initial: &RateSetterParameters.Initial,
 			}),
 			tangle.SyncTimeWindow(Parameters.TangleTimeWindow),
 			tangle.StartSynced(Parameters.StartSynced),
+			tangle.MessageIndex(Parameters.MessageIndex),
 		)
 
 		tangleInstance.Scheduler = tangle.NewScheduler(tangleInstance)
@@ -185,8 +240,73 @@ func ConsensusMechanism() *fcob.ConsensusMechanism {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region NetworkID ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	networkID     gossip.NetworkID
+	networkIDOnce sync.Once
+)
+
+// NetworkID returns the identifier of the network the node belongs to. It is derived from the hash of the genesis
+// snapshot and the protocol parameters, so that nodes of different networks do not peer with each other.
+func NetworkID() gossip.NetworkID {
+	networkIDOnce.Do(func() {
+		snapshotHash, err := hashSnapshot(Parameters.Snapshot.File)
+		if err != nil {
+			Plugin().Panicf("could not hash snapshot file: %s", err)
+		}
+
+		protocolParameters := marshalutil.New().
+			WriteUint32(discovery.ProtocolVersion).
+			WriteUint32(uint32(discovery.Parameters.NetworkVersion)).
+			WriteBytes([]byte(Parameters.Snapshot.GenesisNode)).
+			Bytes()
+		networkID = gossip.NewNetworkID(snapshotHash, protocolParameters)
+	})
+
+	return networkID
+}
+
+// hashSnapshot returns the hash of the snapshot file with the given path (the hash of no data if the path is empty).
+func hashSnapshot(path string) ([]byte, error) {
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return hash.Sum(nil), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if _, err = io.Copy(hash, f); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region Scheduler ///////////////////////////////////////////////////////////////////////////////////////////
 
+// configuredSchedulerRate contains the rate of the scheduler while the node is synced.
+var configuredSchedulerRate atomic.Duration
+
+// applySchedulerRate sets the rate of the scheduler depending on whether the node is synced.
+func applySchedulerRate(synced bool) {
+	rate := configuredSchedulerRate.Load()
+	if !synced {
+		// increase scheduler rate
+		rate -= rate / 2 // 50% increase
+	}
+	Tangle().Scheduler.SetRate(rate)
+	plugin.LogInfof("Scheduler rate: %v", rate)
+}
+
 func schedulerRate(durationString string) time.Duration {
 	duration, err := time.ParseDuration(durationString)
 	// if parseDuration failed, scheduler will take default value (5ms)
@@ -196,6 +316,27 @@ func schedulerRate(durationString string) time.Duration {
 	return duration
 }
 
+// payloadPriorities parses the priority classes of the payload types given as "payloadType:class".
+func payloadPriorities(entries []string) map[payload.Type]schedulerutils.Priority {
+	priorities := make(map[payload.Type]schedulerutils.Priority, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			Plugin().Panicf("invalid payload priority %s: expected payloadType:class", entry)
+		}
+		payloadType, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			Plugin().Panicf("invalid payload type in payload priority %s: %s", entry, err)
+		}
+		priority, err := schedulerutils.PriorityFromString(parts[1])
+		if err != nil {
+			Plugin().Panicf("invalid payload priority %s: %s", entry, err)
+		}
+		priorities[payload.Type(payloadType)] = priority
+	}
+	return priorities
+}
+
 func accessManaRetriever(nodeID identity.ID) float64 {
 	nodeMana, _, err := GetAccessMana(nodeID)
 	if err != nil {
@@ -0,0 +1,33 @@
+package messagelayer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRecordingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording.bin")
+	startTime := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	f, err := createRecordingFile(path, startTime)
+	require.NoError(t, err)
+	assert.Equal(t, path, f.Name())
+	_, err = f.WriteString("first run")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// the recording of the first run is kept
+	f, err = createRecordingFile(path, startTime)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "recording-20210601T120000.bin"), f.Name())
+	require.NoError(t, f.Close())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "first run", string(content))
+}
@@ -93,6 +93,9 @@ var (
 
 	// number of messages being requested by the message layer.
 	requestQueueSize atomic.Int64
+
+	// number of messages rejected by the parser because their issuer is not authorized.
+	unauthorizedIssuerRejectedCount atomic.Uint64
 )
 
 ////// Exported functions to obtain metrics from outside //////
@@ -102,6 +105,12 @@ func MessageTotalCountSinceStart() uint64 {
 	return messageTotalCount.Load()
 }
 
+// UnauthorizedIssuerRejectedCount returns the number of messages that were rejected since the start of the node
+// because their issuer is not authorized.
+func UnauthorizedIssuerRejectedCount() uint64 {
+	return unauthorizedIssuerRejectedCount.Load()
+}
+
 // MessageCountSinceStartPerPayload returns a map of message payload types and their count since the start of the node.
 func MessageCountSinceStartPerPayload() map[payload.Type]uint64 {
 	messageCountPerPayloadMutex.RLock()
@@ -18,6 +18,8 @@ var (
 	gossipCurrentRx   atomic.Uint64
 
 	analysisOutboundBytes atomic.Uint64
+
+	networkIDMismatchCount atomic.Uint64
 )
 
 // FPCInboundBytes returns the total inbound FPC traffic.
@@ -45,6 +47,11 @@ func AnalysisOutboundBytes() uint64 {
 	return analysisOutboundBytes.Load()
 }
 
+// NetworkIDMismatchCount returns the number of rejections of peers of another network since the start of the node.
+func NetworkIDMismatchCount() uint64 {
+	return networkIDMismatchCount.Load()
+}
+
 func measureGossipTraffic() {
 	g := gossipCurrentTraffic()
 	gossipCurrentRx.Store(g.BytesRead)
@@ -4,6 +4,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
@@ -73,6 +74,7 @@ func run(_ *node.Plugin) {
 				measureRequestQueueSize()
 				measureGossipTraffic()
 				measurePerComponentCounter()
+				measureSchedulerPrioritySizes()
 			}, 1*time.Second, shutdownSignal)
 		}
 
@@ -164,6 +166,7 @@ func registerLocalMetrics() {
 
 	messagelayer.Tangle().Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID tangle.MessageID) {
 		increasePerComponentCounter(Scheduler)
+		onMessageScheduled(messageID)
 	}))
 	messagelayer.Tangle().FIFOScheduler.Events.MessageScheduled.Attach(events.NewClosure(func(messageID tangle.MessageID) {
 		increasePerComponentCounter(Scheduler)
@@ -173,6 +176,12 @@ func registerLocalMetrics() {
 		increasePerComponentCounter(Booker)
 	}))
 
+	messagelayer.Tangle().Parser.Events.MessageRejected.Attach(events.NewClosure(func(_ *tangle.MessageRejectedEvent, err error) {
+		if errors.Is(err, tangle.ErrUnauthorizedIssuer) {
+			unauthorizedIssuerRejectedCount.Inc()
+		}
+	}))
+
 	// // Value payload attached
 	// valuetransfers.Tangle().Events.PayloadAttached.Attach(events.NewClosure(func(cachedPayloadEvent *valuetangle.CachedPayloadEvent) {
 	// 	cachedPayloadEvent.Payload.Release()
@@ -215,6 +224,9 @@ func registerLocalMetrics() {
 	metrics.Events().TangleTimeSynced.Attach(events.NewClosure(func(synced bool) {
 		isTangleTimeSynced.Store(synced)
 	}))
+	metrics.Events().NetworkIDMismatch.Attach(events.NewClosure(func(*metrics.NetworkIDMismatchEvent) {
+		networkIDMismatchCount.Inc()
+	}))
 
 	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupAuto).NeighborRemoved.Attach(onNeighborRemoved)
 	gossip.Manager().NeighborsEvents(gossippkg.NeighborsGroupAuto).NeighborAdded.Attach(onNeighborAdded)
@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/syncutils"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/schedulerutils"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+var (
+	// current size of the scheduler buffer per priority class [bytes].
+	schedulerPrioritySizes      [schedulerutils.NumPriorities]int
+	schedulerPrioritySizesMutex syncutils.RWMutex
+
+	// sum of the scheduling delays and number of scheduled messages per priority class (since start of the node).
+	sumSchedulingDelay        [schedulerutils.NumPriorities]time.Duration
+	scheduledCountPerPriority [schedulerutils.NumPriorities]uint64
+	schedulingDelayMutex      syncutils.RWMutex
+)
+
+// SchedulerPrioritySizes returns the size of the scheduler buffer per priority class [bytes].
+func SchedulerPrioritySizes() map[schedulerutils.Priority]int {
+	schedulerPrioritySizesMutex.RLock()
+	defer schedulerPrioritySizesMutex.RUnlock()
+
+	sizes := make(map[schedulerutils.Priority]int, schedulerutils.NumPriorities)
+	for priority, size := range schedulerPrioritySizes {
+		sizes[schedulerutils.Priority(priority)] = size
+	}
+	return sizes
+}
+
+// AvgSchedulingDelay returns the average time between the arrival and the scheduling of a message per priority class
+// since the start of the node. [milliseconds]
+func AvgSchedulingDelay() map[schedulerutils.Priority]float64 {
+	schedulingDelayMutex.RLock()
+	defer schedulingDelayMutex.RUnlock()
+
+	delays := make(map[schedulerutils.Priority]float64, schedulerutils.NumPriorities)
+	for priority, count := range scheduledCountPerPriority {
+		if count > 0 {
+			delays[schedulerutils.Priority(priority)] = float64(sumSchedulingDelay[priority].Milliseconds()) / float64(count)
+		}
+	}
+	return delays
+}
+
+func measureSchedulerPrioritySizes() {
+	sizes := messagelayer.Tangle().Scheduler.PrioritySizes()
+
+	schedulerPrioritySizesMutex.Lock()
+	defer schedulerPrioritySizesMutex.Unlock()
+	schedulerPrioritySizes = sizes
+}
+
+func onMessageScheduled(messageID tangle.MessageID) {
+	messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		priority := messagelayer.Tangle().Scheduler.Priority(message)
+		messagelayer.Tangle().Storage.MessageMetadata(messageID).Consume(func(messageMetadata *tangle.MessageMetadata) {
+			schedulingDelayMutex.Lock()
+			defer schedulingDelayMutex.Unlock()
+
+			sumSchedulingDelay[priority] += clock.Since(messageMetadata.ReceivedTime())
+			scheduledCountPerPriority[priority]++
+		})
+	})
+}
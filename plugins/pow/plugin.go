@@ -3,10 +3,12 @@ package pow
 import (
 	"sync"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 
 	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
@@ -39,9 +41,23 @@ func configure(*node.Plugin) {
 	// assure that the PoW worker is initialized
 	worker := Worker()
 
-	log.Infof("%s started: difficult=%d", PluginName, difficulty)
+	log.Infof("%s started: difficult=%d", PluginName, difficulty.Load())
 
-	messagelayer.Tangle().Parser.AddBytesFilter(tangle.NewPowFilter(worker, difficulty))
+	powFilter := tangle.NewPowFilter(worker, int(difficulty.Load()))
+	messagelayer.Tangle().Parser.AddBytesFilter(powFilter)
 	messagelayer.Tangle().MessageFactory.SetWorker(tangle.WorkerFunc(DoPOW))
 	messagelayer.Tangle().MessageFactory.SetTimeout(timeout)
+
+	config.RegisterReloadable(CfgPOWDifficulty, "the PoW difficulty of issued and received messages", func(value interface{}) error {
+		newDifficulty := value.(int)
+		if newDifficulty < 0 || newDifficulty > 8*hash.Size() {
+			return errors.Errorf("difficulty must be between 0 and %d", 8*hash.Size())
+		}
+
+		difficulty.Store(int64(newDifficulty))
+		powFilter.SetDifficulty(newDifficulty)
+		log.Infof("changed PoW difficulty to %d", newDifficulty)
+
+		return nil
+	})
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/logger"
+	"go.uber.org/atomic"
 	_ "golang.org/x/crypto/blake2b" // required by crypto.BLAKE2b_512
 
 	"github.com/iotaledger/goshimmer/packages/pow"
@@ -23,7 +24,7 @@ var (
 	hash = crypto.BLAKE2b_512
 
 	// configured via parameters
-	difficulty             int
+	difficulty             atomic.Int64
 	numWorkers             int
 	timeout                time.Duration
 	parentsRefreshInterval time.Duration
@@ -41,7 +42,7 @@ func Worker() *pow.Worker {
 	workerOnce.Do(func() {
 		log = logger.NewLogger(PluginName)
 		// load the parameters
-		difficulty = config.Node().Int(CfgPOWDifficulty)
+		difficulty.Store(config.Node().Int64(CfgPOWDifficulty))
 		numWorkers = config.Node().Int(CfgPOWNumThreads)
 		timeout = config.Node().Duration(CfgPOWTimeout)
 		parentsRefreshInterval = config.Node().Duration(CfgPOWParentsRefreshInterval)
@@ -61,11 +62,11 @@ func DoPOW(msg []byte) (uint64, error) {
 	// get the PoW worker
 	worker := Worker()
 
-	// log.Debugw("start PoW", "difficulty", difficulty, "numWorkers", numWorkers)
+	// log.Debugw("start PoW", "difficulty", difficulty.Load(), "numWorkers", numWorkers)
 
 	ctx, cancel := context.WithTimeout(context.Background(), parentsRefreshInterval)
 	defer cancel()
-	nonce, err := worker.Mine(ctx, content[:len(content)-pow.NonceBytes], difficulty)
+	nonce, err := worker.Mine(ctx, content[:len(content)-pow.NonceBytes], int(difficulty.Load()))
 
 	// log.Debugw("PoW stopped", "nonce", nonce, "err", err)
 
@@ -0,0 +1,64 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	clockpkg "github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/plugins/clock"
+)
+
+var (
+	clockOffset           prometheus.Gauge
+	clockNTPSources       *prometheus.GaugeVec
+	clockDrift            prometheus.Gauge
+	clockDriftAlarm       prometheus.Gauge
+	clockDriftAlarmsCount prometheus.Gauge
+)
+
+func registerClockMetrics() {
+	clockOffset = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "clock_offset_seconds",
+		Help: "offset of the synced time from the local time [s].",
+	})
+	clockNTPSources = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "clock_ntp_sources",
+		Help: "number of NTP sources of the last successful synchronization per state.",
+	}, []string{"state"})
+	clockDrift = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "clock_tangle_time_drift_seconds",
+		Help: "difference between the synced time and the TangleTime [s].",
+	})
+	clockDriftAlarm = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "clock_drift_alarm",
+		Help: "whether the drift between the synced time and the TangleTime exceeds the threshold.",
+	})
+	clockDriftAlarmsCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "clock_drift_alarms_count",
+		Help: "number of times the drift between the synced time and the TangleTime exceeded the threshold.",
+	})
+
+	registry.MustRegister(clockOffset)
+	registry.MustRegister(clockNTPSources)
+	registry.MustRegister(clockDrift)
+	registry.MustRegister(clockDriftAlarm)
+	registry.MustRegister(clockDriftAlarmsCount)
+
+	addCollect(collectClockMetrics)
+}
+
+func collectClockMetrics() {
+	clockOffset.Set(clockpkg.Offset().Seconds())
+	if result := clock.LastSyncResult(); result != nil {
+		clockNTPSources.WithLabelValues("accepted").Set(float64(len(result.Offsets) - len(result.Rejected)))
+		clockNTPSources.WithLabelValues("rejected").Set(float64(len(result.Rejected)))
+		clockNTPSources.WithLabelValues("failed").Set(float64(len(result.Failed)))
+	}
+	clockDrift.Set(clock.DriftMonitor().Drift().Seconds())
+	clockDriftAlarm.Set(func() float64 {
+		if clock.DriftMonitor().Alarm() {
+			return 1
+		}
+		return 0
+	}())
+	clockDriftAlarmsCount.Set(float64(clock.DriftMonitor().AlarmCount()))
+}
@@ -15,6 +15,7 @@ var (
 	gossipOutboundBytes      prometheus.Gauge
 	autopeeringInboundBytes  prometheus.Gauge
 	autopeeringOutboundBytes prometheus.Gauge
+	networkIDMismatchCount   prometheus.Gauge
 )
 
 func registerNetworkMetrics() {
@@ -46,6 +47,10 @@ func registerNetworkMetrics() {
 		Name: "traffic_analysis_outbound_bytes",
 		Help: "traffic_Analysis client TX network traffic [bytes].",
 	})
+	networkIDMismatchCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "network_id_mismatch_count",
+		Help: "number of rejections of peers of another network.",
+	})
 
 	registry.MustRegister(fpcInboundBytes)
 	registry.MustRegister(fpcOutboundBytes)
@@ -54,6 +59,7 @@ func registerNetworkMetrics() {
 	registry.MustRegister(autopeeringOutboundBytes)
 	registry.MustRegister(gossipInboundBytes)
 	registry.MustRegister(gossipOutboundBytes)
+	registry.MustRegister(networkIDMismatchCount)
 
 	addCollect(collectNetworkMetrics)
 }
@@ -66,4 +72,5 @@ func collectNetworkMetrics() {
 	autopeeringOutboundBytes.Set(float64(autopeering.Conn.TXBytes()))
 	gossipInboundBytes.Set(float64(metrics.GossipInboundBytes()))
 	gossipOutboundBytes.Set(float64(metrics.GossipOutboundBytes()))
+	networkIDMismatchCount.Set(float64(metrics.NetworkIDMismatchCount()))
 }
@@ -15,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/clock"
 	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
@@ -63,7 +64,11 @@ func configure(plugin *node.Plugin) {
 		registerNetworkMetrics()
 		registerProcessMetrics()
 		registerTangleMetrics()
+		registerSchedulerMetrics()
 		registerManaMetrics()
+		if !node.IsSkipped(clock.Plugin()) {
+			registerClockMetrics()
+		}
 	}
 
 	if config.Node().Bool(metrics.CfgMetricsGlobal) {
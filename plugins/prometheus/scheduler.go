@@ -0,0 +1,44 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+)
+
+var (
+	schedulerPrioritySize *prometheus.GaugeVec
+	schedulerAvgDelay     *prometheus.GaugeVec
+)
+
+func registerSchedulerMetrics() {
+	schedulerPrioritySize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "scheduler_buffer_size_per_priority_bytes",
+			Help: "current size of the scheduler buffer per priority class [bytes]",
+		}, []string{
+			"priority",
+		})
+
+	schedulerAvgDelay = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "scheduler_avg_scheduling_delay_per_priority_ms",
+			Help: "average time between the arrival and the scheduling of a message per priority class since the start of the node [ms]",
+		}, []string{
+			"priority",
+		})
+
+	registry.MustRegister(schedulerPrioritySize)
+	registry.MustRegister(schedulerAvgDelay)
+
+	addCollect(collectSchedulerMetrics)
+}
+
+func collectSchedulerMetrics() {
+	for priority, size := range metrics.SchedulerPrioritySizes() {
+		schedulerPrioritySize.WithLabelValues(priority.String()).Set(float64(size))
+	}
+	for priority, delay := range metrics.AvgSchedulingDelay() {
+		schedulerAvgDelay.WithLabelValues(priority.String()).Set(delay)
+	}
+}
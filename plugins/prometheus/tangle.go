@@ -16,6 +16,7 @@ var (
 	avgSolidificationTime    prometheus.Gauge
 	messageMissingCountDB    prometheus.Gauge
 	messageRequestCount      prometheus.Gauge
+	unauthorizedIssuerCount  prometheus.Gauge
 
 	transactionCounter prometheus.Gauge
 )
@@ -77,6 +78,11 @@ func registerTangleMetrics() {
 		Help: "current number requested messages by the message tangle",
 	})
 
+	unauthorizedIssuerCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_message_rejected_unauthorized_issuer_count",
+		Help: "number of messages rejected by the parser because their issuer is not authorized",
+	})
+
 	registry.MustRegister(messageTips)
 	registry.MustRegister(messagePerTypeCount)
 	registry.MustRegister(messagePerComponentCount)
@@ -87,6 +93,7 @@ func registerTangleMetrics() {
 	registry.MustRegister(messageMissingCountDB)
 	registry.MustRegister(messageRequestCount)
 	registry.MustRegister(transactionCounter)
+	registry.MustRegister(unauthorizedIssuerCount)
 
 	addCollect(collectTangleMetrics)
 }
@@ -107,5 +114,6 @@ func collectTangleMetrics() {
 	avgSolidificationTime.Set(metrics.AvgSolidificationTime())
 	messageMissingCountDB.Set(float64(metrics.MessageMissingCountDB()))
 	messageRequestCount.Set(float64(metrics.MessageRequestQueueSize()))
+	unauthorizedIssuerCount.Set(float64(metrics.UnauthorizedIssuerRejectedCount()))
 	// transactionCounter.Set(float64(metrics.ValueTransactionCounter()))
 }
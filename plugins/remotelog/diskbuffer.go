@@ -0,0 +1,138 @@
+package remotelog
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const diskBufferFileExtension = ".ndjson"
+
+// ErrBufferFull is returned when a batch does not fit into the disk buffer anymore.
+var ErrBufferFull = errors.New("disk buffer full")
+
+// diskBuffer stores the batches that could not be delivered as files of newline delimited records, so that they survive
+// restarts of the node and can be sent in their original order once the server is reachable again.
+type diskBuffer struct {
+	dir      string
+	maxSize  int64
+	size     int64
+	sequence uint64
+	mu       sync.Mutex
+}
+
+func newDiskBuffer(dir string, maxSize int64) (*diskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("could not create disk buffer '%s'. %v", dir, err)
+	}
+
+	d := &diskBuffer{dir: dir, maxSize: maxSize}
+	files, err := d.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		info, err := os.Stat(filepath.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+		d.size += info.Size()
+		if sequence, err := strconv.ParseUint(strings.TrimSuffix(file, diskBufferFileExtension), 10, 64); err == nil && sequence > d.sequence {
+			d.sequence = sequence
+		}
+	}
+
+	return d, nil
+}
+
+// write stores the records as a new batch.
+func (d *diskBuffer) write(records [][]byte) error {
+	data := joinRecords(records)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.size+int64(len(data)) > d.maxSize {
+		return ErrBufferFull
+	}
+	d.sequence++
+	if err := ioutil.WriteFile(d.path(d.sequence), data, 0o600); err != nil {
+		return err
+	}
+	d.size += int64(len(data))
+
+	return nil
+}
+
+// oldest returns the name and the records of the oldest batch (or an empty name if the buffer is empty).
+func (d *diskBuffer) oldest() (name string, records [][]byte, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	files, err := d.files()
+	if err != nil || len(files) == 0 {
+		return "", nil, err
+	}
+	name = files[0]
+
+	data, err := ioutil.ReadFile(filepath.Join(d.dir, name))
+	if err != nil {
+		return name, nil, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			records = append(records, append([]byte(nil), scanner.Bytes()...))
+		}
+	}
+	return name, records, scanner.Err()
+}
+
+// remove deletes the batch with the given name.
+func (d *diskBuffer) remove(name string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path := filepath.Join(d.dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	d.size -= info.Size()
+
+	return nil
+}
+
+// files returns the names of the buffered batches from the oldest to the newest.
+func (d *diskBuffer) files() ([]string, error) {
+	entries, err := ioutil.ReadDir(d.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), diskBufferFileExtension) {
+			files = append(files, entry.Name())
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+func (d *diskBuffer) path(sequence uint64) string {
+	return filepath.Join(d.dir, fmt.Sprintf("%020d%s", sequence, diskBufferFileExtension))
+}
@@ -1,7 +1,9 @@
-// Package remotelog is a plugin that enables log messages being sent via UDP to a central ELK stack for debugging.
+// Package remotelog is a plugin that enables log messages being sent to a central ELK stack for debugging.
 // It is disabled by default and when enabled, additionally, logger.disableEvents=false in config.json needs to be set.
 // The destination can be set via logger.remotelog.serverAddress.
-// All events according to logger.level in config.json are sent.
+// All events according to logger.level and logger.remotelog.minLevel in config.json are sent.
+// The records are sent via UDP by default, while the tcp and http transports (optionally with TLS) deliver them reliably
+// in batches and keep them in a disk buffer (logger.remotelog.bufferDir) while the server is unreachable.
 package remotelog
 
 import (
@@ -17,6 +19,7 @@ import (
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/workerpool"
 	flag "github.com/spf13/pflag"
+	"go.uber.org/atomic"
 	"gopkg.in/src-d/go-git.v4"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
@@ -29,6 +32,30 @@ import (
 const (
 	// CfgLoggerRemotelogServerAddress defines the config flag of the server address.
 	CfgLoggerRemotelogServerAddress = "logger.remotelog.serverAddress"
+	// CfgLoggerRemotelogTransport defines the config flag of the transport (udp, tcp or http).
+	CfgLoggerRemotelogTransport = "logger.remotelog.transport"
+	// CfgLoggerRemotelogTLS defines the config flag to enable TLS.
+	CfgLoggerRemotelogTLS = "logger.remotelog.tls"
+	// CfgLoggerRemotelogTLSCACert defines the config flag of the CA certificate used to verify the server.
+	CfgLoggerRemotelogTLSCACert = "logger.remotelog.tlsCACert"
+	// CfgLoggerRemotelogTLSSkipVerify defines the config flag to skip the verification of the server certificate.
+	CfgLoggerRemotelogTLSSkipVerify = "logger.remotelog.tlsSkipVerify"
+	// CfgLoggerRemotelogCompression defines the config flag to enable the compression of the batches.
+	CfgLoggerRemotelogCompression = "logger.remotelog.compression"
+	// CfgLoggerRemotelogBatchSize defines the config flag of the maximum number of records per batch.
+	CfgLoggerRemotelogBatchSize = "logger.remotelog.batchSize"
+	// CfgLoggerRemotelogFlushInterval defines the config flag of the maximum time a record is queued.
+	CfgLoggerRemotelogFlushInterval = "logger.remotelog.flushInterval"
+	// CfgLoggerRemotelogQueueSize defines the config flag of the number of records queued in memory.
+	CfgLoggerRemotelogQueueSize = "logger.remotelog.queueSize"
+	// CfgLoggerRemotelogBufferDir defines the config flag of the directory of the disk buffer.
+	CfgLoggerRemotelogBufferDir = "logger.remotelog.bufferDir"
+	// CfgLoggerRemotelogBufferMaxSize defines the config flag of the maximum size of the disk buffer.
+	CfgLoggerRemotelogBufferMaxSize = "logger.remotelog.bufferMaxSize"
+	// CfgLoggerRemotelogMinLevel defines the config flag of the minimum level of the sent log messages.
+	CfgLoggerRemotelogMinLevel = "logger.remotelog.minLevel"
+	// CfgLoggerRemotelogExcludeFields defines the config flag of the fields that are removed from all records.
+	CfgLoggerRemotelogExcludeFields = "logger.remotelog.excludeFields"
 	// CfgDisableEvents defines the config flag for disabling logger events.
 	CfgDisableEvents = "logger.disableEvents"
 	// PluginName is the name of the remote log plugin.
@@ -52,6 +79,8 @@ var (
 
 	remoteLogger     *RemoteLoggerConn
 	remoteLoggerOnce sync.Once
+	// minLevel contains the minimum logger.Level of the sent log messages.
+	minLevel = atomic.NewInt32(int32(logger.LevelDebug))
 )
 
 // Plugin gets the plugin instance.
@@ -63,7 +92,19 @@ func Plugin() *node.Plugin {
 }
 
 func init() {
-	flag.String(CfgLoggerRemotelogServerAddress, "ressims.iota.cafe:5213", "RemoteLog server address")
+	flag.String(CfgLoggerRemotelogServerAddress, "ressims.iota.cafe:5213", "RemoteLog server address (or URL for the http transport)")
+	flag.String(CfgLoggerRemotelogTransport, TransportUDP, "RemoteLog transport (udp, tcp or http)")
+	flag.Bool(CfgLoggerRemotelogTLS, false, "use TLS for the tcp transport (and HTTPS for the http transport)")
+	flag.String(CfgLoggerRemotelogTLSCACert, "", "the CA certificate (PEM) used to verify the server (system roots if empty)")
+	flag.Bool(CfgLoggerRemotelogTLSSkipVerify, false, "skip the verification of the server certificate")
+	flag.Bool(CfgLoggerRemotelogCompression, false, "gzip compress the batches of the http transport (not supported by the udp and tcp transports)")
+	flag.Int(CfgLoggerRemotelogBatchSize, 100, "maximum number of records sent at once by the tcp and http transports")
+	flag.Duration(CfgLoggerRemotelogFlushInterval, time.Second, "maximum time a record is queued by the tcp and http transports")
+	flag.Int(CfgLoggerRemotelogQueueSize, 10000, "number of records queued in memory by the tcp and http transports")
+	flag.String(CfgLoggerRemotelogBufferDir, "", "directory to buffer the records while the server is unreachable (records are dropped if empty)")
+	flag.Int64(CfgLoggerRemotelogBufferMaxSize, 100*1024*1024, "maximum size of the disk buffer in bytes")
+	flag.String(CfgLoggerRemotelogMinLevel, "", "minimum level of the sent log messages (all levels according to logger.level if empty)")
+	flag.StringSlice(CfgLoggerRemotelogExcludeFields, nil, "fields that are removed from all sent records")
 }
 
 func configure(plugin *node.Plugin) {
@@ -72,6 +113,14 @@ func configure(plugin *node.Plugin) {
 		return
 	}
 
+	if err := setMinLevel(config.Node().String(CfgLoggerRemotelogMinLevel)); err != nil {
+		plugin.LogFatalf("invalid %s: %s", CfgLoggerRemotelogMinLevel, err)
+		return
+	}
+	config.RegisterReloadable(CfgLoggerRemotelogMinLevel, "the minimum level of the log messages sent to the remote logger", func(value interface{}) error {
+		return setMinLevel(value.(string))
+	})
+
 	// initialize remote logger connection
 	RemoteLogger()
 
@@ -90,6 +139,9 @@ func configure(plugin *node.Plugin) {
 
 func run(plugin *node.Plugin) {
 	logEvent := events.NewClosure(func(level logger.Level, name string, msg string) {
+		if int32(level) < minLevel.Load() {
+			return
+		}
 		workerPool.TrySubmit(level, name, msg)
 	})
 
@@ -106,6 +158,19 @@ func run(plugin *node.Plugin) {
 	}
 }
 
+// setMinLevel sets the minimum level of the sent log messages (all levels according to logger.level if empty).
+func setMinLevel(levelString string) error {
+	level := logger.LevelDebug
+	if levelString != "" {
+		if err := level.UnmarshalText([]byte(levelString)); err != nil {
+			return err
+		}
+	}
+	minLevel.Store(int32(level))
+
+	return nil
+}
+
 // SendLogMsg sends log message to the remote logger.
 func SendLogMsg(level logger.Level, name, msg string) {
 	m := logMessage{
@@ -162,14 +227,41 @@ func getGitDir() string {
 }
 
 // RemoteLogger represents a connection to our remote log server.
+// The connection is closed after all plugins that send records to it have been shut down.
 func RemoteLogger() *RemoteLoggerConn {
 	remoteLoggerOnce.Do(func() {
-		r, err := newRemoteLoggerConn(config.Node().String(CfgLoggerRemotelogServerAddress))
+		r, err := newRemoteLoggerConn(&Options{
+			Transport:     config.Node().String(CfgLoggerRemotelogTransport),
+			ServerAddress: config.Node().String(CfgLoggerRemotelogServerAddress),
+			TLS:           config.Node().Bool(CfgLoggerRemotelogTLS),
+			TLSCACert:     config.Node().String(CfgLoggerRemotelogTLSCACert),
+			TLSSkipVerify: config.Node().Bool(CfgLoggerRemotelogTLSSkipVerify),
+			Compression:   config.Node().Bool(CfgLoggerRemotelogCompression),
+			BatchSize:     config.Node().Int(CfgLoggerRemotelogBatchSize),
+			FlushInterval: config.Node().Duration(CfgLoggerRemotelogFlushInterval),
+			QueueSize:     config.Node().Int(CfgLoggerRemotelogQueueSize),
+			BufferDir:     config.Node().String(CfgLoggerRemotelogBufferDir),
+			BufferMaxSize: config.Node().Int64(CfgLoggerRemotelogBufferMaxSize),
+			ExcludeFields: config.Node().Strings(CfgLoggerRemotelogExcludeFields),
+		})
 		if err != nil {
-			plugin.LogFatal(err)
+			Plugin().LogFatal(err)
 			return
 		}
 
+		if err := daemon.BackgroundWorker("RemoteLoggerConn", func(shutdownSignal <-chan struct{}) {
+			<-shutdownSignal
+			// the log messages are no longer sent to the remote logger at this point
+			if err := r.Close(); err != nil {
+				Plugin().LogErrorf("Failed to close the remote logger connection: %s", err)
+			}
+			if dropped := r.Dropped(); dropped > 0 {
+				Plugin().LogWarnf("%d remote log records were dropped", dropped)
+			}
+		}, shutdown.PriorityRemoteLogConn); err != nil {
+			Plugin().Panicf("Failed to start as daemon: %s", err)
+		}
+
 		remoteLogger = r
 	})
 
@@ -2,34 +2,285 @@ package remotelog
 
 import (
 	"encoding/json"
-	"fmt"
-	"net"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+// maxRetryInterval is the maximum time to wait before the delivery to an unreachable server is retried.
+const maxRetryInterval = time.Minute
+
+var (
+	// ErrQueueFull is returned when a record is dropped, because the queue is full and there is no disk buffer.
+	ErrQueueFull = errors.New("remote log queue full")
+	// ErrClosed is returned when a record is sent on a closed connection.
+	ErrClosed = errors.New("remote log connection closed")
 )
 
-// RemoteLoggerConn is a wrapper for a connection to our RemoteLog server.
+// Options defines the options of a RemoteLoggerConn.
+type Options struct {
+	// Transport is one of TransportUDP, TransportTCP or TransportHTTP.
+	Transport string
+	// ServerAddress is the host:port of the server (or the URL of the endpoint for TransportHTTP).
+	ServerAddress string
+	// TLS enables TLS for TransportTCP (and HTTPS for TransportHTTP, if ServerAddress is not a URL).
+	TLS bool
+	// TLSCACert is the PEM file of the CA certificate used to verify the server (the system roots if empty).
+	TLSCACert string
+	// TLSSkipVerify disables the verification of the server certificate.
+	TLSSkipVerify bool
+	// Compression enables the gzip compression of the batches of TransportHTTP (the other transports do not support it).
+	Compression bool
+	// BatchSize is the maximum number of records sent at once.
+	BatchSize int
+	// FlushInterval is the maximum time a record is queued before it is sent.
+	FlushInterval time.Duration
+	// QueueSize is the number of records that are queued in memory.
+	QueueSize int
+	// BufferDir is the directory of the disk buffer used when the queue is full or the server is unreachable (records
+	// are dropped in that case if empty).
+	BufferDir string
+	// BufferMaxSize is the maximum size of the disk buffer in bytes.
+	BufferMaxSize int64
+	// ExcludeFields are the top-level fields that are removed from every record.
+	ExcludeFields []string
+}
+
+// RemoteLoggerConn is a wrapper for a connection to our RemoteLog server. Records are sent right away with the UDP
+// transport, while the other transports queue them and deliver them in batches by a background worker.
 type RemoteLoggerConn struct {
-	conn net.Conn
+	options       *Options
+	transport     transport
+	excludeFields map[string]struct{}
+	diskBuffer    *diskBuffer
+	dropped       atomic.Uint64
+
+	queue          chan []byte
+	retryInterval  time.Duration
+	retryTime      time.Time
+	closed         bool
+	closedMutex    sync.RWMutex
+	shutdownSignal chan struct{}
+	done           chan struct{}
 }
 
-func newRemoteLoggerConn(address string) (*RemoteLoggerConn, error) {
-	c, err := net.Dial("udp", address)
+func newRemoteLoggerConn(options *Options) (*RemoteLoggerConn, error) {
+	t, err := newTransport(options)
 	if err != nil {
-		return nil, fmt.Errorf("could not create UDP socket to '%s'. %v", address, err)
+		return nil, err
+	}
+
+	if options.BatchSize < 1 {
+		options.BatchSize = 1
+	}
+	if options.FlushInterval <= 0 {
+		options.FlushInterval = time.Second
+	}
+
+	r := &RemoteLoggerConn{
+		options:        options,
+		transport:      t,
+		excludeFields:  make(map[string]struct{}, len(options.ExcludeFields)),
+		shutdownSignal: make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	for _, field := range options.ExcludeFields {
+		r.excludeFields[field] = struct{}{}
+	}
+	if r.batched() {
+		if options.BufferDir != "" {
+			if r.diskBuffer, err = newDiskBuffer(options.BufferDir, options.BufferMaxSize); err != nil {
+				return nil, err
+			}
+		}
+		r.queue = make(chan []byte, options.QueueSize)
+		go r.run()
+	} else {
+		close(r.done)
 	}
 
-	return &RemoteLoggerConn{conn: c}, nil
+	return r, nil
 }
 
 // Send sends a message on the RemoteLoggers connection.
 func (r *RemoteLoggerConn) Send(msg interface{}) error {
-	b, err := json.Marshal(msg)
+	b, err := r.marshal(msg)
 	if err != nil {
 		return err
 	}
-	_, err = r.conn.Write(b)
-	if err != nil {
-		return err
+
+	if !r.batched() {
+		return r.transport.send([][]byte{b})
+	}
+
+	r.closedMutex.RLock()
+	defer r.closedMutex.RUnlock()
+	if r.closed {
+		return ErrClosed
 	}
 
+	select {
+	case r.queue <- b:
+		return nil
+	default:
+	}
+	// the queue is full, so the record is moved to the disk buffer right away
+	if r.diskBuffer == nil || r.diskBuffer.write([][]byte{b}) != nil {
+		r.dropped.Inc()
+		return ErrQueueFull
+	}
 	return nil
 }
+
+// Dropped returns the number of records that were dropped, because they could neither be delivered nor buffered.
+func (r *RemoteLoggerConn) Dropped() uint64 {
+	return r.dropped.Load()
+}
+
+// Close delivers the queued records (or moves them to the disk buffer) and closes the connection.
+func (r *RemoteLoggerConn) Close() error {
+	r.closedMutex.Lock()
+	if !r.closed {
+		r.closed = true
+		if r.batched() {
+			close(r.shutdownSignal)
+		}
+	}
+	r.closedMutex.Unlock()
+
+	<-r.done
+	return r.transport.close()
+}
+
+// batched returns true if the records are delivered in batches by the background worker.
+func (r *RemoteLoggerConn) batched() bool {
+	return r.options.Transport != TransportUDP && r.options.Transport != ""
+}
+
+// marshal encodes the message as JSON without the excluded fields.
+func (r *RemoteLoggerConn) marshal(msg interface{}) ([]byte, error) {
+	b, err := json.Marshal(msg)
+	if err != nil || len(r.excludeFields) == 0 {
+		return b, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(b, &fields); err != nil {
+		// only objects can be filtered
+		return b, nil
+	}
+	for field := range r.excludeFields {
+		delete(fields, field)
+	}
+	return json.Marshal(fields)
+}
+
+// run collects the queued records and delivers them in batches.
+func (r *RemoteLoggerConn) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, r.options.BatchSize)
+	for {
+		select {
+		case record := <-r.queue:
+			if batch = append(batch, record); len(batch) >= r.options.BatchSize {
+				batch = r.flush(batch)
+			}
+		case <-ticker.C:
+			batch = r.flush(batch)
+		case <-r.shutdownSignal:
+			for {
+				select {
+				case record := <-r.queue:
+					if batch = append(batch, record); len(batch) >= r.options.BatchSize {
+						batch = r.flush(batch)
+					}
+				default:
+					r.flush(batch)
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush delivers the buffered batches followed by the given batch and returns the emptied batch. Batches that cannot be
+// delivered are moved to the disk buffer.
+func (r *RemoteLoggerConn) flush(batch [][]byte) [][]byte {
+	// do not retry too often, if the server is unreachable
+	if time.Now().Before(r.retryTime) {
+		r.buffer(batch)
+		return batch[:0]
+	}
+
+	// deliver the buffered batches first to keep the order of the records
+	if !r.deliverBuffered() {
+		r.buffer(batch)
+		return batch[:0]
+	}
+
+	if len(batch) > 0 {
+		if err := r.transport.send(batch); err != nil {
+			r.failed()
+			r.buffer(batch)
+			return batch[:0]
+		}
+	}
+	r.retryInterval = 0
+
+	return batch[:0]
+}
+
+// deliverBuffered sends the batches of the disk buffer and returns false if the server is unreachable.
+func (r *RemoteLoggerConn) deliverBuffered() bool {
+	if r.diskBuffer == nil {
+		return true
+	}
+
+	for {
+		name, records, err := r.diskBuffer.oldest()
+		if name == "" {
+			return true
+		}
+		if err != nil {
+			// the batch is corrupted, so there is no point in retrying it
+			r.dropped.Add(uint64(len(records)))
+		} else if len(records) > 0 {
+			if err = r.transport.send(records); err != nil {
+				r.failed()
+				return false
+			}
+		}
+		if err = r.diskBuffer.remove(name); err != nil {
+			// stop here instead of sending the same batch again
+			return true
+		}
+	}
+}
+
+// buffer moves the batch to the disk buffer or drops it.
+func (r *RemoteLoggerConn) buffer(batch [][]byte) {
+	if len(batch) == 0 {
+		return
+	}
+	if r.diskBuffer == nil || r.diskBuffer.write(batch) != nil {
+		r.dropped.Add(uint64(len(batch)))
+	}
+}
+
+// failed doubles the time to wait before the next delivery is attempted.
+func (r *RemoteLoggerConn) failed() {
+	r.retryInterval *= 2
+	if r.retryInterval < r.options.FlushInterval {
+		r.retryInterval = r.options.FlushInterval
+	}
+	if r.retryInterval > maxRetryInterval {
+		r.retryInterval = maxRetryInterval
+	}
+	r.retryTime = time.Now().Add(r.retryInterval)
+}
@@ -0,0 +1,259 @@
+package remotelog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+type testRecord struct {
+	Type  string `json:"type"`
+	Index int    `json:"index"`
+}
+
+func TestRemoteLoggerConn_UDP(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	r, err := newRemoteLoggerConn(&Options{ServerAddress: server.LocalAddr().String()})
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.NoError(t, r.Send(testRecord{Type: "log", Index: 1}))
+
+	buffer := make([]byte, 1024)
+	require.NoError(t, server.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err := server.ReadFrom(buffer)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"log","index":1}`, string(buffer[:n]))
+}
+
+func TestRemoteLoggerConn_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	r, err := newRemoteLoggerConn(&Options{
+		Transport:     TransportTCP,
+		ServerAddress: listener.Addr().String(),
+		BatchSize:     2,
+		FlushInterval: 50 * time.Millisecond,
+		QueueSize:     10,
+		ExcludeFields: []string{"type"},
+	})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, r.Send(testRecord{Type: "log", Index: i}))
+	}
+	// the last record is sent with the next flush
+	for i := 0; i < 3; i++ {
+		select {
+		case line := <-lines:
+			assert.JSONEq(t, `{"index":`+strconv.Itoa(i)+`}`, line)
+		case <-time.After(time.Second):
+			t.Fatalf("record %d not received", i)
+		}
+	}
+
+	require.NoError(t, r.Close())
+	assert.ErrorIs(t, r.Send(testRecord{}), ErrClosed)
+	assert.Zero(t, r.Dropped())
+}
+
+func TestTCPTransport_PartialWrite(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	records := [][]byte{[]byte(`{"index":0}`), []byte(`{"index":1}`), []byte(`{"index":2}`)}
+	// the broken connection writes the first record and a part of the second one
+	transport := &tcpTransport{address: listener.Addr().String(), conn: &brokenConn{limit: len(records[0]) + 5}}
+	assert.Error(t, transport.send(records))
+
+	// the retry resumes with the partially written record
+	require.NoError(t, transport.send(records))
+	for i := 1; i < 3; i++ {
+		select {
+		case line := <-lines:
+			assert.JSONEq(t, `{"index":`+strconv.Itoa(i)+`}`, line)
+		case <-time.After(time.Second):
+			t.Fatalf("record %d not received", i)
+		}
+	}
+	require.NoError(t, transport.close())
+}
+
+func TestNewTransport_Compression(t *testing.T) {
+	for _, transportType := range []string{TransportUDP, TransportTCP} {
+		_, err := newTransport(&Options{Transport: transportType, ServerAddress: "127.0.0.1:5213", Compression: true})
+		assert.Error(t, err)
+	}
+}
+
+func TestRemoteLoggerConn_HTTP(t *testing.T) {
+	var (
+		available atomic.Bool
+		mu        sync.Mutex
+		received  []int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, "gzip", req.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(req.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		for _, record := range readRecords(t, reader) {
+			mu.Lock()
+			received = append(received, record.Index)
+			mu.Unlock()
+		}
+	}))
+	defer server.Close()
+
+	options := &Options{
+		Transport:     TransportHTTP,
+		ServerAddress: server.URL,
+		Compression:   true,
+		BatchSize:     10,
+		FlushInterval: 10 * time.Millisecond,
+		QueueSize:     10,
+		BufferDir:     t.TempDir(),
+		BufferMaxSize: 1024 * 1024,
+	}
+
+	// the records cannot be delivered, so they are kept in the disk buffer when the connection is closed
+	r, err := newRemoteLoggerConn(options)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, r.Send(testRecord{Type: "log", Index: i}))
+	}
+	require.NoError(t, r.Close())
+	assert.Zero(t, r.Dropped())
+
+	// the buffered records are delivered first, once the server is available
+	available.Store(true)
+	r, err = newRemoteLoggerConn(options)
+	require.NoError(t, err)
+	for i := 5; i < 8; i++ {
+		require.NoError(t, r.Send(testRecord{Type: "log", Index: i}))
+	}
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 8
+	}, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, r.Close())
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, received)
+	files, err := r.diskBuffer.files()
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestDiskBuffer(t *testing.T) {
+	dir := t.TempDir()
+	d, err := newDiskBuffer(dir, 16)
+	require.NoError(t, err)
+
+	require.NoError(t, d.write([][]byte{[]byte("a"), []byte("b")}))
+	require.NoError(t, d.write([][]byte{[]byte("c")}))
+	assert.ErrorIs(t, d.write([][]byte{[]byte("0123456789abcdef")}), ErrBufferFull)
+
+	// the buffered batches survive a restart
+	d, err = newDiskBuffer(dir, 16)
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, d.size)
+
+	name, records, err := d.oldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("a"), []byte("b")}, records)
+	require.NoError(t, d.remove(name))
+
+	require.NoError(t, d.write([][]byte{[]byte("d")}))
+	name, records, err = d.oldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("c")}, records)
+	require.NoError(t, d.remove(name))
+
+	_, records, err = d.oldest()
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("d")}, records)
+}
+
+func readRecords(t *testing.T, reader io.Reader) (records []testRecord) {
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record testRecord
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+// brokenConn is a net.Conn that fails after writing limit bytes.
+type brokenConn struct {
+	net.Conn
+	limit int
+}
+
+func (b *brokenConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
+
+func (b *brokenConn) Write(data []byte) (int, error) {
+	if len(data) > b.limit {
+		return b.limit, io.ErrClosedPipe
+	}
+	return len(data), nil
+}
+
+func (b *brokenConn) Close() error {
+	return nil
+}
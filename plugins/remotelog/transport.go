@@ -0,0 +1,259 @@
+package remotelog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// TransportUDP sends every record as a single datagram.
+	TransportUDP = "udp"
+	// TransportTCP sends batches of newline delimited records over a (TLS) stream.
+	TransportTCP = "tcp"
+	// TransportHTTP posts batches of newline delimited records to a (HTTPS) endpoint.
+	TransportHTTP = "http"
+
+	// dialTimeout is the timeout to establish a connection to the server.
+	dialTimeout = 5 * time.Second
+	// writeTimeout is the timeout to send a batch to the server.
+	writeTimeout = 10 * time.Second
+)
+
+// transport sends JSON encoded records to the remote log server.
+type transport interface {
+	// send sends the records and returns an error if they could not be delivered.
+	send(records [][]byte) error
+	// close closes the connection to the server.
+	close() error
+}
+
+func newTransport(options *Options) (transport, error) {
+	switch options.Transport {
+	case TransportUDP, "":
+		if options.Compression {
+			return nil, fmt.Errorf("compression is not supported by the '%s' transport", TransportUDP)
+		}
+		c, err := net.Dial("udp", options.ServerAddress)
+		if err != nil {
+			return nil, fmt.Errorf("could not create UDP socket to '%s'. %v", options.ServerAddress, err)
+		}
+		return &udpTransport{conn: c}, nil
+	case TransportTCP:
+		if options.Compression {
+			return nil, fmt.Errorf("compression is not supported by the '%s' transport", options.Transport)
+		}
+		var tlsConfig *tls.Config
+		if options.TLS {
+			var err error
+			if tlsConfig, err = newTLSConfig(options); err != nil {
+				return nil, err
+			}
+		}
+		return &tcpTransport{address: options.ServerAddress, tlsConfig: tlsConfig}, nil
+	case TransportHTTP:
+		tlsConfig, err := newTLSConfig(options)
+		if err != nil {
+			return nil, err
+		}
+		url := options.ServerAddress
+		if !strings.Contains(url, "://") {
+			scheme := "http://"
+			if options.TLS {
+				scheme = "https://"
+			}
+			url = scheme + url
+		}
+		return &httpTransport{
+			url:         url,
+			compression: options.Compression,
+			client: &http.Client{
+				Timeout:   writeTimeout,
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown transport '%s'", options.Transport)
+	}
+}
+
+// newTLSConfig returns the TLS configuration that verifies the server with the given CA certificate (or the system
+// roots if none is set).
+func newTLSConfig(options *Options) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: options.TLSSkipVerify,
+	}
+	if options.TLSCACert != "" {
+		pem, err := ioutil.ReadFile(options.TLSCACert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA certificate '%s'. %v", options.TLSCACert, err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in '%s'", options.TLSCACert)
+		}
+	}
+	return tlsConfig, nil
+}
+
+// region udpTransport /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// udpTransport sends every record as a single datagram, so records may be lost or truncated.
+type udpTransport struct {
+	conn net.Conn
+}
+
+func (u *udpTransport) send(records [][]byte) error {
+	for _, record := range records {
+		if _, err := u.conn.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *udpTransport) close() error {
+	return u.conn.Close()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region tcpTransport /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tcpTransport sends the records as newline delimited JSON over a stream, that is (re-)established when needed. The
+// records are not compressed, since the stream is consumed line by line.
+//
+// If a batch is only written partially, the transport remembers how many of its records were written completely. When
+// the same batch is sent again, the delivery resumes with the first record that was not written completely, so that
+// the server does not receive the complete records twice. The partially written record is sent again in full, since
+// the server discards the incomplete last line of a broken connection.
+type tcpTransport struct {
+	address   string
+	tlsConfig *tls.Config
+	conn      net.Conn
+
+	// failedBatch is the batch whose last delivery failed after failedOffset bytes (a record boundary).
+	failedBatch  []byte
+	failedOffset int
+}
+
+func (t *tcpTransport) send(records [][]byte) error {
+	data := joinRecords(records)
+	offset := 0
+	if t.failedBatch != nil && bytes.Equal(t.failedBatch, data) {
+		offset = t.failedOffset
+	}
+	t.failedBatch, t.failedOffset = nil, 0
+
+	if t.conn == nil {
+		dialer := &net.Dialer{Timeout: dialTimeout}
+		var err error
+		if t.tlsConfig != nil {
+			t.conn, err = tls.DialWithDialer(dialer, "tcp", t.address, t.tlsConfig)
+		} else {
+			t.conn, err = dialer.Dial("tcp", t.address)
+		}
+		if err != nil {
+			t.conn = nil
+			t.failedBatch, t.failedOffset = data, offset
+			return fmt.Errorf("could not connect to '%s'. %v", t.address, err)
+		}
+	}
+
+	if err := t.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		t.failedBatch, t.failedOffset = data, offset
+		return t.reset(err)
+	}
+	if written, err := t.conn.Write(data[offset:]); err != nil {
+		// resume after the last record that was written completely
+		t.failedBatch, t.failedOffset = data, bytes.LastIndexByte(data[:offset+written], '\n')+1
+		return t.reset(err)
+	}
+	return nil
+}
+
+// reset closes the broken connection, so that the next send establishes a new one.
+func (t *tcpTransport) reset(err error) error {
+	_ = t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+func (t *tcpTransport) close() error {
+	if t.conn == nil {
+		return nil
+	}
+	return t.reset(nil)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region httpTransport ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// httpTransport posts the records as newline delimited JSON, optionally gzip compressed.
+type httpTransport struct {
+	url         string
+	compression bool
+	client      *http.Client
+}
+
+func (h *httpTransport) send(records [][]byte) error {
+	body := joinRecords(records)
+	if h.compression {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(body); err != nil {
+			return err
+		}
+		if err := writer.Close(); err != nil {
+			return err
+		}
+		body = compressed.Bytes()
+	}
+
+	request, err := http.NewRequest(http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	if h.compression {
+		request.Header.Set("Content-Encoding", "gzip")
+	}
+
+	response, err := h.client.Do(request)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	_ = response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("server '%s' responded with status %s", h.url, response.Status)
+	}
+	return nil
+}
+
+func (h *httpTransport) close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// joinRecords returns the records as newline delimited JSON.
+func joinRecords(records [][]byte) []byte {
+	var buffer bytes.Buffer
+	for _, record := range records {
+		buffer.Write(record)
+		buffer.WriteByte('\n')
+	}
+	return buffer.Bytes()
+}
@@ -1,6 +1,7 @@
 // Package remotelogmetrics is a plugin that enables log metrics too complex for Prometheus, but still interesting in terms of analysis and debugging.
 // It is enabled by default.
-// The destination can be set via logger.remotelog.serverAddress.
+// The destination can be set via logger.remotelog.serverAddress and the records are delivered by the same transport as
+// the ones of the remotelog plugin (see logger.remotelog.transport).
 package remotelogmetrics
 
 import (
@@ -0,0 +1,22 @@
+package spammer
+
+import "github.com/iotaledger/hive.go/configuration"
+
+// Parameters contains the configuration parameters used by the spammer.
+var Parameters = struct {
+	// Seed is the base58 encoded seed whose addresses fund the transactions issued by the spammer.
+	Seed string `usage:"the base58 encoded seed funding the spammer's transactions, value scenarios are disabled if empty"`
+
+	// AddressCount is the amount of addresses of the seed that the spammer spreads its outputs over.
+	AddressCount int `default:"10" usage:"the amount of addresses of the seed the spammer spreads its outputs over"`
+
+	// ScenarioFile is the path to a JSON file defining the scenarios of the spammer.
+	ScenarioFile string `usage:"the path to a JSON file defining the scenarios of the spammer"`
+
+	// MaxMPM is the highest rate in messages per minute that the spammer can be started with (0 = unlimited).
+	MaxMPM int `usage:"the highest rate in messages per minute the spammer can be started with (0 = unlimited)"`
+}{}
+
+func init() {
+	configuration.BindParameters(&Parameters, "spammer")
+}
@@ -3,17 +3,31 @@ package spammer
 import (
 	"sync"
 
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/mr-tron/base58"
+	"go.uber.org/atomic"
 
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/spammer"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 )
 
-var messageSpammer *spammer.Spammer
+var (
+	messageSpammer *spammer.Spammer
+	// scenarios contains the scenarios defined in the scenario file.
+	scenarios = make(map[string]*spammer.Scenario)
+	// maxMPM contains the highest rate the spammer can be started with (0 = unlimited).
+	maxMPM atomic.Int64
+)
 
 // PluginName is the name of the spammer plugin.
 const PluginName = "Spammer"
@@ -35,12 +49,49 @@ func Plugin() *node.Plugin {
 
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(PluginName)
-	messageSpammer = spammer.New(messagelayer.Tangle().IssuePayload, log)
+
+	options := []spammer.Option{spammer.WithPledgeID(local.GetInstance().ID())}
+	if Parameters.Seed != "" {
+		seedBytes, err := base58.Decode(Parameters.Seed)
+		if err != nil {
+			log.Fatalf("failed to decode seed of the spammer: %s", err)
+		}
+		options = append(options, spammer.WithOutputPool(spammer.NewOutputPool(ed25519.NewSeed(seedBytes), Parameters.AddressCount, unspentOutputs)))
+	}
+	messageSpammer = spammer.New(messagelayer.Tangle().IssuePayload, log, options...)
+
+	if Parameters.ScenarioFile != "" {
+		var err error
+		if scenarios, err = spammer.LoadScenarios(Parameters.ScenarioFile); err != nil {
+			log.Fatalf("failed to load spammer scenarios: %s", err)
+		}
+		log.Infof("loaded %d spammer scenarios from %s", len(scenarios), Parameters.ScenarioFile)
+	}
+
+	maxMPM.Store(int64(Parameters.MaxMPM))
+	config.RegisterReloadable("spammer.maxMPM", "the highest rate in messages per minute the spammer can be started with (0 = unlimited)", func(value interface{}) error {
+		if value.(int) < 0 {
+			return errors.New("limit must not be negative")
+		}
+		maxMPM.Store(int64(value.(int)))
+		log.Infof("changed the MPM limit of the spammer to %d", value.(int))
+
+		return nil
+	})
+
 	webapi.Server().GET("spammer", handleRequest)
 }
 
 func run(*node.Plugin) {
+	onMessageFinalized := events.NewClosure(messageSpammer.MessageConfirmed)
+	onMessageInvalid := events.NewClosure(messageSpammer.MessageRejected)
+
 	if err := daemon.BackgroundWorker("spammer", func(shutdownSignal <-chan struct{}) {
+		messagelayer.Tangle().ApprovalWeightManager.Events.MessageFinalized.Attach(onMessageFinalized)
+		defer messagelayer.Tangle().ApprovalWeightManager.Events.MessageFinalized.Detach(onMessageFinalized)
+		messagelayer.Tangle().Events.MessageInvalid.Attach(onMessageInvalid)
+		defer messagelayer.Tangle().Events.MessageInvalid.Detach(onMessageInvalid)
+
 		<-shutdownSignal
 
 		messageSpammer.Shutdown()
@@ -48,3 +99,16 @@ func run(*node.Plugin) {
 		log.Panicf("Failed to start as daemon: %s", err)
 	}
 }
+
+// unspentOutputs returns the unspent outputs of the given address.
+func unspentOutputs(address ledgerstate.Address) ledgerstate.Outputs {
+	cachedOutputs := messagelayer.Tangle().LedgerState.CachedOutputsOnAddress(address)
+	defer cachedOutputs.Release()
+
+	return cachedOutputs.Unwrap().Filter(func(output ledgerstate.Output) (isUnspent bool) {
+		messagelayer.Tangle().LedgerState.CachedOutputMetadata(output.ID()).Consume(func(outputMetadata *ledgerstate.OutputMetadata) {
+			isUnspent = outputMetadata.ConsumerCount() == 0
+		})
+		return
+	})
+}
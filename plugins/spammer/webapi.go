@@ -1,12 +1,15 @@
 package spammer
 
 import (
+	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/labstack/echo"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/spammer"
 )
 
 func handleRequest(c echo.Context) error {
@@ -17,9 +20,16 @@ func handleRequest(c echo.Context) error {
 
 	switch request.Cmd {
 	case "start":
+		if request.Scenario != "" {
+			return startScenario(c, request.Scenario)
+		}
+
 		if request.MPM == 0 {
 			request.MPM = 1
 		}
+		if err := checkMPM(request.MPM); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.SpammerResponse{Error: err.Error()})
+		}
 
 		// IMIF: Inter Message Issuing Function
 		switch request.IMIF {
@@ -32,12 +42,96 @@ func handleRequest(c echo.Context) error {
 		messageSpammer.Shutdown()
 		messageSpammer.Start(request.MPM, time.Minute, request.IMIF)
 		log.Infof("Started spamming messages with %d MPM and %s inter-message issuing function", request.MPM, request.IMIF)
-		return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Message: "started spamming messages"})
+		return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Message: "started spamming messages", Scenario: spammer.DefaultScenarioName})
 	case "stop":
 		messageSpammer.Shutdown()
 		log.Info("Stopped spamming messages")
 		return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Message: "stopped spamming messages"})
+	case "scenarios":
+		return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Scenario: messageSpammer.Scenario(), Scenarios: scenarioModels()})
+	case "stats":
+		return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Scenario: messageSpammer.Scenario(), Stats: statsModels()})
 	default:
 		return c.JSON(http.StatusBadRequest, jsonmodels.SpammerResponse{Error: "invalid cmd in request"})
 	}
 }
+
+func startScenario(c echo.Context, name string) error {
+	scenario, exists := scenarios[name]
+	if !exists {
+		return c.JSON(http.StatusBadRequest, jsonmodels.SpammerResponse{Error: "unknown scenario " + name})
+	}
+
+	if err := checkMPM(scenario.MPM); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.SpammerResponse{Error: err.Error()})
+	}
+
+	messageSpammer.Shutdown()
+	if err := messageSpammer.StartScenario(scenario); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.SpammerResponse{Error: err.Error()})
+	}
+	log.Infof("Started spamming messages of scenario %s with %d MPM", scenario.Name, scenario.MPM)
+	return c.JSON(http.StatusOK, jsonmodels.SpammerResponse{Message: "started spamming messages", Scenario: scenario.Name})
+}
+
+// checkMPM returns an error if the given rate exceeds the configured limit of the spammer.
+func checkMPM(mpm int) error {
+	if limit := int(maxMPM.Load()); limit > 0 && mpm > limit {
+		return fmt.Errorf("rate of %d MPM exceeds the limit of %d MPM", mpm, limit)
+	}
+
+	return nil
+}
+
+func scenarioModels() (models []*jsonmodels.SpammerScenario) {
+	models = make([]*jsonmodels.SpammerScenario, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		mix := make(map[string]int, len(scenario.Mix))
+		for kind, weight := range scenario.Mix {
+			mix[string(kind)] = weight
+		}
+		models = append(models, &jsonmodels.SpammerScenario{
+			Name:             scenario.Name,
+			MPM:              scenario.MPM,
+			IMIF:             scenario.IMIF,
+			Mix:              mix,
+			DoubleSpendRatio: scenario.DoubleSpendRatio,
+			PayloadSize:      scenario.PayloadSize,
+		})
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].Name < models[j].Name })
+
+	return models
+}
+
+func statsModels() (models []*jsonmodels.SpammerScenarioStats) {
+	running := messageSpammer.Scenario()
+
+	models = make([]*jsonmodels.SpammerScenarioStats, 0)
+	for _, stats := range messageSpammer.Stats() {
+		model := &jsonmodels.SpammerScenarioStats{
+			Scenario: stats.Scenario,
+			Running:  stats.Scenario == running,
+			Started:  stats.Started.Unix(),
+			Total:    kindStatsModel(stats.Total()),
+			Kinds:    make(map[string]jsonmodels.SpammerKindStats, len(stats.Kinds)),
+		}
+		for kind, kindStats := range stats.Kinds {
+			model.Kinds[string(kind)] = kindStatsModel(kindStats)
+		}
+		models = append(models, model)
+	}
+
+	return models
+}
+
+func kindStatsModel(kindStats *spammer.KindStats) jsonmodels.SpammerKindStats {
+	return jsonmodels.SpammerKindStats{
+		Issued:              kindStats.Issued,
+		Failed:              kindStats.Failed,
+		Confirmed:           kindStats.Confirmed,
+		Rejected:            kindStats.Rejected,
+		Dropped:             kindStats.Dropped,
+		AvgConfirmationTime: kindStats.AvgConfirmationTime().Milliseconds(),
+	}
+}
@@ -1,27 +1,40 @@
 package txstream
 
 import (
+	"crypto/tls"
 	"sync"
 
 	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	flag "github.com/spf13/pflag"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/txstream"
 	"github.com/iotaledger/goshimmer/packages/txstream/server"
 	"github.com/iotaledger/goshimmer/packages/txstream/tangleledger"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	dbplugin "github.com/iotaledger/goshimmer/plugins/database"
 )
 
 const (
 	pluginName = "TXStream"
 
-	bindAddress = "txstream.bindAddress"
+	bindAddress     = "txstream.bindAddress"
+	logSize         = "txstream.logSize"
+	webSocketEnable = "txstream.webSocket"
+	allowedOrigins  = "txstream.allowedOrigins"
+	tlsEnable       = "txstream.tls.enabled"
 )
 
 func init() {
 	flag.String(bindAddress, ":5000", "the bind address for the txstream plugin")
+	flag.Uint64(logSize, 100000, "the amount of confirmed transactions kept for clients resuming their subscriptions")
+	flag.Bool(webSocketEnable, true, "whether the txstream protocol is also served as JSON over a WebSocket on the web API")
+	flag.StringSlice(allowedOrigins, []string{}, "the origins (e.g. https://wallet.example.com) that can open WebSocket connections to the txstream (\"*\" allows all)")
+	flag.Bool(tlsEnable, false, "whether the txstream TCP port is served over TLS (uses the shared tls.* configuration)")
 }
 
 var (
@@ -29,6 +42,12 @@ var (
 	once   sync.Once
 
 	log *logger.Logger
+
+	ledger *tangleledger.TangleLedger
+	txLog  *txstream.TxLog
+
+	// webSocketShutdownSignal is closed when the node shuts down to terminate the WebSocket connections.
+	webSocketShutdownSignal = make(chan struct{})
 )
 
 // Plugin returns the plugin instance
@@ -41,19 +60,40 @@ func Plugin() *node.Plugin {
 
 func configPlugin(plugin *node.Plugin) {
 	log = logger.NewLogger(pluginName)
+
+	ledger = tangleledger.New()
+	var err error
+	txLog, err = txstream.NewTxLog(ledger, dbplugin.Store().WithRealm(kvstore.Realm{database.PrefixTXStream}), uint64(config.Node().Int64(logSize)))
+	if err != nil {
+		log.Panicf("failed to load TXStream transaction log: %v", err)
+	}
+
+	if config.Node().Bool(webSocketEnable) {
+		configureWebSocket()
+	}
 }
 
 func runPlugin(_ *node.Plugin) {
-	ledger := tangleledger.New()
-
 	bindAddress := config.Node().String(bindAddress)
 	log.Debugf("starting TXStream plugin on %s", bindAddress)
+
+	var tlsConfig *tls.Config
+	if config.Node().Bool(tlsEnable) {
+		var err error
+		if tlsConfig, err = config.TLS(); err != nil {
+			log.Errorf("failed to load TLS configuration of the TXStream server: %s", err)
+			return
+		}
+	}
+
 	err := daemon.BackgroundWorker("TXStream worker", func(shutdownSignal <-chan struct{}) {
-		err := server.Listen(ledger, bindAddress, log, shutdownSignal)
+		defer txLog.Detach()
+		err := server.Listen(ledger, txLog, bindAddress, tlsConfig, log, shutdownSignal)
 		if err != nil {
 			log.Errorf("failed to start TXStream server: %w", err)
 		}
 		<-shutdownSignal
+		close(webSocketShutdownSignal)
 	}, shutdown.PriorityTXStream)
 	if err != nil {
 		log.Errorf("failed to start TXStream daemon: %w", err)
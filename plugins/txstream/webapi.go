@@ -0,0 +1,47 @@
+package txstream
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/apitoken"
+	"github.com/iotaledger/goshimmer/packages/txstream/server"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// webSocketRoute is the web API route that serves the JSON encoded txstream protocol over a WebSocket.
+const webSocketRoute = "txstream/ws"
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: 5 * time.Second,
+}
+
+func configureWebSocket() {
+	origins := config.Node().Strings(allowedOrigins)
+	upgrader.CheckOrigin = func(r *http.Request) bool {
+		return webapi.OriginAllowed(r, origins)
+	}
+
+	webapi.Server().GET(webSocketRoute, webSocketHandler)
+	webapi.AllowQueryToken(webSocketRoute)
+	// clients can post transactions through the socket, so reading the stream is not enough to use it
+	webapi.SetRoutePermission(http.MethodGet, webSocketRoute, apitoken.ScopeIssue)
+}
+
+// webSocketHandler upgrades the request to a WebSocket and runs a txstream connection on it until the client
+// disconnects or the node shuts down.
+func webSocketHandler(c echo.Context) error {
+	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	log.Debugf("accepted websocket connection from %s", ws.RemoteAddr().String())
+
+	server.Serve(server.NewWebSocketConn(ws), log, ledger, txLog, webSocketShutdownSignal)
+
+	return nil
+}
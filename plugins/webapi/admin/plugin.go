@@ -0,0 +1,137 @@
+package admin
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// PluginName is the name of the web API admin endpoint plugin.
+const PluginName = "WebAPI admin Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API admin endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+
+	// authEnabled is true if the web API requires the clients to authenticate.
+	authEnabled bool
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	authEnabled = config.Node().Bool(webapi.CfgTokenAuthEnabled) || config.Node().Bool(webapi.CfgBasicAuthEnabled)
+	if !authEnabled {
+		plugin.LogWarnf("the admin endpoints are disabled as neither %s nor %s is enabled", webapi.CfgTokenAuthEnabled, webapi.CfgBasicAuthEnabled)
+	}
+
+	webapi.Server().GET("admin/config", requireAuth(getConfig))
+	webapi.Server().POST("admin/config", requireAuth(setConfig))
+	webapi.Server().GET("admin/config/audit", requireAuth(getConfigAudit))
+}
+
+// requireAuth refuses all requests if the clients of the web API are not authenticated, as everybody could change the
+// parameters of the node otherwise.
+func requireAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !authEnabled {
+			return c.JSON(http.StatusForbidden, jsonmodels.NewErrorResponse(errors.New("the admin endpoints require token or basic auth to be enabled")))
+		}
+
+		return next(c)
+	}
+}
+
+func getConfig(c echo.Context) error {
+	parameters := config.ReloadableParameters()
+
+	response := &jsonmodels.GetConfigResponse{Parameters: make([]*jsonmodels.ConfigParameter, 0, len(parameters))}
+	for _, parameter := range parameters {
+		response.Parameters = append(response.Parameters, &jsonmodels.ConfigParameter{
+			Name:        parameter.Name,
+			Description: parameter.Description,
+			Type:        parameter.Type,
+			Value:       parameter.Value,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func setConfig(c echo.Context) error {
+	var request jsonmodels.SetConfigRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.SetConfigResponse{Error: err.Error()})
+	}
+
+	entry, err := config.Reload(request.Parameter, request.Value, user(c), request.WriteBack)
+	if err != nil {
+		plugin.LogWarnf("%s failed to change %s to %v: %s", entry.User, entry.Parameter, entry.NewValue, err)
+
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, config.ErrParameterNotReloadable):
+			status = http.StatusNotFound
+		case errors.Is(err, config.ErrWriteBackFailed), errors.Is(err, config.ErrAuditLogFailed):
+			status = http.StatusInternalServerError
+		}
+
+		return c.JSON(status, jsonmodels.SetConfigResponse{Change: configChange(entry), Error: err.Error()})
+	}
+	plugin.LogInfof("%s changed %s from %v to %v (written back: %v)", entry.User, entry.Parameter, entry.OldValue, entry.NewValue, entry.WrittenBack)
+
+	return c.JSON(http.StatusOK, jsonmodels.SetConfigResponse{Change: configChange(entry)})
+}
+
+func getConfigAudit(c echo.Context) error {
+	entries := config.AuditEntries()
+
+	response := &jsonmodels.GetConfigAuditResponse{Changes: make([]*jsonmodels.ConfigChange, 0, len(entries))}
+	for _, entry := range entries {
+		response.Changes = append(response.Changes, configChange(entry))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// user returns the name of the client that is recorded in the audit log.
+func user(c echo.Context) string {
+	if claims := webapi.TokenClaims(c); claims != nil {
+		if claims.Subject != "" {
+			return claims.Subject + " (" + claims.ID + ")"
+		}
+		return claims.ID
+	}
+
+	if username, _, ok := c.Request().BasicAuth(); ok {
+		return username
+	}
+
+	return c.RealIP()
+}
+
+func configChange(entry *config.AuditEntry) *jsonmodels.ConfigChange {
+	return &jsonmodels.ConfigChange{
+		Time:        entry.Time.Unix(),
+		Parameter:   entry.Parameter,
+		OldValue:    entry.OldValue,
+		NewValue:    entry.NewValue,
+		User:        entry.User,
+		WrittenBack: entry.WrittenBack,
+		Error:       entry.Error,
+	}
+}
@@ -39,6 +39,7 @@ func Plugin() *node.Plugin {
 func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	webapi.Server().POST("data", broadcastData)
+	webapi.Server().GET("data/tagged", getTaggedData)
 }
 
 // broadcastData creates a message of the given payload and
@@ -50,8 +51,17 @@ func broadcastData(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, jsonmodels.DataResponse{Error: err.Error()})
 	}
 
+	var dataPayload payload.Payload = payload.NewGenericDataPayload(request.Data)
+	if request.Tag != "" {
+		taggedDataPayload, err := payload.NewTaggedDataPayload([]byte(request.Tag), request.Data)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.DataResponse{Error: err.Error()})
+		}
+		dataPayload = taggedDataPayload
+	}
+
 	issueData := func() (*tangle.Message, error) {
-		return messagelayer.Tangle().IssuePayload(payload.NewGenericDataPayload(request.Data))
+		return messagelayer.Tangle().IssuePayload(dataPayload)
 	}
 
 	// await MessageScheduled event to be triggered.
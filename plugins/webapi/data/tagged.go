@@ -0,0 +1,87 @@
+package data
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+const (
+	// defaultTaggedDataLimit is the number of messages that is returned if the query does not define a limit.
+	defaultTaggedDataLimit = 100
+	// maxTaggedDataLimit is the maximum number of messages that is returned by a single query.
+	maxTaggedDataLimit = 1000
+)
+
+// getTaggedData returns a page of the messages with a tagged data payload of the given tag ordered by their issuing
+// time.
+func getTaggedData(c echo.Context) error {
+	var request jsonmodels.GetTaggedDataRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetTaggedDataResponse{Error: err.Error()})
+	}
+
+	after, limit, err := taggedDataQuery(request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetTaggedDataResponse{Error: err.Error()})
+	}
+
+	entries, more, err := messagelayer.Tangle().Storage.TaggedMessages([]byte(request.Tag), after, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.GetTaggedDataResponse{Error: err.Error()})
+	}
+	response := jsonmodels.GetTaggedDataResponse{Messages: make([]jsonmodels.TaggedData, 0, len(entries))}
+	for _, entry := range entries {
+		messagelayer.Tangle().Storage.Message(entry.MessageID()).Consume(func(message *tangle.Message) {
+			taggedDataPayload, ok := message.Payload().(*payload.TaggedDataPayload)
+			if !ok {
+				return
+			}
+
+			response.Messages = append(response.Messages, jsonmodels.TaggedData{
+				ID:          message.ID().Base58(),
+				Tag:         string(taggedDataPayload.Tag()),
+				Data:        taggedDataPayload.Data(),
+				IssuingTime: message.IssuingTime().Unix(),
+			})
+		})
+	}
+	if more {
+		response.NextCursor = base58.Encode(entries[len(entries)-1].Bytes())
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// taggedDataQuery parses the cursor and the limit of the given request.
+func taggedDataQuery(request jsonmodels.GetTaggedDataRequest) (after *tangle.TagIndexEntry, limit int, err error) {
+	if request.Tag == "" || len(request.Tag) > payload.MaxTagLength {
+		return nil, 0, errors.Errorf("tag has to be between 1 and %d bytes long", payload.MaxTagLength)
+	}
+
+	if request.Cursor != "" {
+		cursorBytes, decodeErr := base58.Decode(request.Cursor)
+		if decodeErr != nil {
+			return nil, 0, errors.Errorf("invalid cursor '%s': %w", request.Cursor, decodeErr)
+		}
+		if after, _, err = tangle.TagIndexEntryFromBytes(cursorBytes); err != nil {
+			return nil, 0, errors.Errorf("invalid cursor '%s': %w", request.Cursor, err)
+		}
+	}
+
+	switch {
+	case request.Limit < 0 || request.Limit > maxTaggedDataLimit:
+		return nil, 0, errors.Errorf("limit has to be between 1 and %d", maxTaggedDataLimit)
+	case request.Limit == 0:
+		return after, defaultTaggedDataLimit, nil
+	default:
+		return after, request.Limit, nil
+	}
+}
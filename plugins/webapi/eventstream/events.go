@@ -0,0 +1,96 @@
+package eventstream
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi/message"
+)
+
+// newMessageEvent creates an event of the given topic for the Message with the given MessageID (nil if the Message
+// can not be loaded).
+func newMessageEvent(topic string, messageID tangle.MessageID) (e *event) {
+	messagelayer.Tangle().Storage.Message(messageID).Consume(func(msg *tangle.Message) {
+		e = newEvent(topic, time.Now().Unix())
+		jsonMessage := message.NewMessage(msg)
+		e.Message = &jsonMessage
+		e.setPayloadType(msg.Payload().Type())
+
+		if transaction, isTransaction := msg.Payload().(*ledgerstate.Transaction); isTransaction {
+			e.TransactionID = transaction.ID().Base58()
+			addTransactionOutputs(e, transaction)
+		}
+	})
+
+	return e
+}
+
+// newTransactionEvent creates an event of the given topic for the Transaction with the given TransactionID (nil if
+// the Transaction can not be loaded).
+func newTransactionEvent(topic string, transactionID ledgerstate.TransactionID) (e *event) {
+	messagelayer.Tangle().LedgerState.Transaction(transactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		e = newEvent(topic, time.Now().Unix())
+		e.TransactionID = transactionID.Base58()
+		e.Transaction = jsonmodels.NewTransaction(transaction)
+		e.setPayloadType(ledgerstate.TransactionType)
+		addTransactionOutputs(e, transaction)
+	})
+
+	return e
+}
+
+// newBranchEvent creates an event of the given topic for the Branch with the given BranchID (nil if the Branch can
+// not be loaded). Events of ConflictBranches are related to the outputs of their Transaction.
+func newBranchEvent(topic string, branchID ledgerstate.BranchID) (e *event) {
+	messagelayer.Tangle().LedgerState.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		e = newEvent(topic, time.Now().Unix())
+		jsonBranch := jsonmodels.NewBranch(branch)
+		e.Branch = &jsonBranch
+
+		if branch.Type() == ledgerstate.ConflictBranchType {
+			messagelayer.Tangle().LedgerState.Transaction(branchID.TransactionID()).Consume(func(transaction *ledgerstate.Transaction) {
+				e.TransactionID = transaction.ID().Base58()
+				e.setPayloadType(ledgerstate.TransactionType)
+				addTransactionOutputs(e, transaction)
+			})
+		}
+	})
+
+	return e
+}
+
+// newManaPledgeEvent creates an event for the given mana.PledgedEvent that is related to the outputs of the pledging
+// Transaction.
+func newManaPledgeEvent(pledge *mana.PledgedEvent) (e *event) {
+	e = newEvent(jsonmodels.EventTopicManaPledged, pledge.Time.Unix())
+	e.TransactionID = pledge.TransactionID.Base58()
+	e.ManaPledge = &jsonmodels.ManaPledge{
+		NodeID:        pledge.NodeID.String(),
+		ManaType:      pledge.ManaType.String(),
+		Amount:        pledge.Amount,
+		TransactionID: pledge.TransactionID.Base58(),
+		Time:          pledge.Time.Unix(),
+	}
+	messagelayer.Tangle().LedgerState.Transaction(pledge.TransactionID).Consume(func(transaction *ledgerstate.Transaction) {
+		e.setPayloadType(ledgerstate.TransactionType)
+		addTransactionOutputs(e, transaction)
+	})
+
+	return e
+}
+
+// addTransactionOutputs relates the event to the Outputs that are consumed and created by the given Transaction.
+// Created Outputs are loaded from the ledger (if available) to relate the event to the colors of minted tokens.
+func addTransactionOutputs(e *event, transaction *ledgerstate.Transaction) {
+	messagelayer.Tangle().LedgerState.ConsumedOutputs(transaction).Consume(e.addOutput)
+
+	for _, output := range transaction.Essence().Outputs() {
+		if !messagelayer.Tangle().LedgerState.CachedOutput(output.ID()).Consume(e.addOutput) {
+			e.addOutput(output)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package eventstream
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+var (
+	// ErrTooManySubscribers is returned if the maximum number of clients is connected to the event stream.
+	ErrTooManySubscribers = errors.New("too many subscribers")
+	// ErrShutdown is returned if a client subscribes while the event stream is shutting down.
+	ErrShutdown = errors.New("event stream is shutting down")
+)
+
+// subscriber is a client of the event stream with a channel for the events that match its Subscription.
+type subscriber struct {
+	subscription *Subscription
+	// events contains the events that have not been sent to the client yet.
+	events chan *jsonmodels.Event
+	// closed is closed when the event stream shuts down.
+	closed chan struct{}
+}
+
+// hub keeps track of the subscribers of the event stream and publishes the events to them.
+type hub struct {
+	subscribers    map[*subscriber]struct{}
+	maxSubscribers int
+	bufferSize     int
+	shutdown       bool
+	mutex          sync.RWMutex
+}
+
+// newHub creates a hub that accepts up to maxSubscribers that each buffer up to bufferSize events.
+func newHub(maxSubscribers int, bufferSize int) *hub {
+	return &hub{
+		subscribers:    make(map[*subscriber]struct{}),
+		maxSubscribers: maxSubscribers,
+		bufferSize:     bufferSize,
+	}
+}
+
+// subscribe registers a new subscriber with the given Subscription.
+func (h *hub) subscribe(subscription *Subscription) (*subscriber, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.shutdown {
+		return nil, ErrShutdown
+	}
+	if len(h.subscribers) >= h.maxSubscribers {
+		return nil, errors.Errorf("%d clients are connected: %w", len(h.subscribers), ErrTooManySubscribers)
+	}
+
+	s := &subscriber{
+		subscription: subscription,
+		events:       make(chan *jsonmodels.Event, h.bufferSize),
+		closed:       make(chan struct{}),
+	}
+	h.subscribers[s] = struct{}{}
+
+	return s, nil
+}
+
+// unsubscribe removes the given subscriber.
+func (h *hub) unsubscribe(s *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.subscribers, s)
+}
+
+// interested returns true if at least one subscriber is subscribed to the given topic.
+func (h *hub) interested(topic string) bool {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for s := range h.subscribers {
+		if s.subscription.hasTopic(topic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publish sends the event to all subscribers whose Subscription matches it. The event is dropped for subscribers that
+// don't consume their events fast enough.
+func (h *hub) publish(e *event) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for s := range h.subscribers {
+		if !s.subscription.matches(e) {
+			continue
+		}
+
+		select {
+		case s.events <- e.Event:
+		default:
+		}
+	}
+}
+
+// close disconnects all subscribers and rejects new ones.
+func (h *hub) close() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.shutdown {
+		return
+	}
+	h.shutdown = true
+
+	for s := range h.subscribers {
+		close(s.closed)
+	}
+}
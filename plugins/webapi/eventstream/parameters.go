@@ -0,0 +1,23 @@
+package eventstream
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgMaxSubscribers defines the config flag of the maximum number of clients that can be connected to the event
+	// stream at the same time.
+	CfgMaxSubscribers = "webapi.events.maxSubscribers"
+	// CfgBufferSize defines the config flag of the number of events that are buffered per client before events are
+	// dropped for a slow client.
+	CfgBufferSize = "webapi.events.bufferSize"
+	// CfgAllowedOrigins defines the config flag of the origins (besides the node's own) from which browsers can open
+	// WebSocket connections to the event stream.
+	CfgAllowedOrigins = "webapi.events.allowedOrigins"
+)
+
+func init() {
+	flag.Int(CfgMaxSubscribers, 100, "the maximum number of clients that can be connected to the event stream")
+	flag.Int(CfgBufferSize, 1000, "the number of events that are buffered per client of the event stream")
+	flag.StringSlice(CfgAllowedOrigins, []string{}, "the origins (e.g. https://explorer.example.com) that can open WebSocket connections to the event stream (\"*\" allows all)")
+}
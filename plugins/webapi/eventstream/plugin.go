@@ -0,0 +1,149 @@
+package eventstream
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// PluginName is the name of the web API event stream plugin.
+const PluginName = "WebAPI Event Stream"
+
+var (
+	// plugin is the plugin instance of the web API event stream plugin.
+	plugin *node.Plugin
+	once   sync.Once
+	log    *logger.Logger
+
+	// eventHub publishes the events to the connected clients.
+	eventHub *hub
+
+	// eventWorkerPool builds the events outside of the goroutines that trigger them.
+	eventWorkerPool        *workerpool.WorkerPool
+	eventWorkerCount       = 1
+	eventWorkerQueueSize   = 10000
+	onMessageBooked        *events.Closure
+	onMessageFinalized     *events.Closure
+	onTransactionConfirmed *events.Closure
+	onBranchRejected       *events.Closure
+	onBranchLiked          *events.Closure
+	onBranchFinalized      *events.Closure
+	onManaPledged          *events.Closure
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	log = logger.NewLogger(PluginName)
+	eventHub = newHub(config.Node().Int(CfgMaxSubscribers), config.Node().Int(CfgBufferSize))
+
+	eventWorkerPool = workerpool.New(func(task workerpool.Task) {
+		if e := buildEvent(task.Param(0).(string), task.Param(1)); e != nil {
+			eventHub.publish(e)
+		}
+		task.Return(nil)
+	}, workerpool.WorkerCount(eventWorkerCount), workerpool.QueueSize(eventWorkerQueueSize))
+
+	onMessageBooked = events.NewClosure(func(messageID tangle.MessageID) {
+		submit(jsonmodels.EventTopicMessageBooked, messageID)
+	})
+	onMessageFinalized = events.NewClosure(func(messageID tangle.MessageID) {
+		submit(jsonmodels.EventTopicMessageConfirmed, messageID)
+	})
+	onTransactionConfirmed = events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		submit(jsonmodels.EventTopicTransactionConfirmed, transactionID)
+	})
+	onBranchRejected = events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		if branch := branchDAGEvent.Branch.Unwrap(); branch != nil && branch.Type() == ledgerstate.ConflictBranchType {
+			submit(jsonmodels.EventTopicTransactionRejected, branchDAGEvent.Branch.ID().TransactionID())
+		}
+	})
+	onBranchLiked = events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		submit(jsonmodels.EventTopicBranchLiked, branchDAGEvent.Branch.ID())
+	})
+	onBranchFinalized = events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		submit(jsonmodels.EventTopicBranchFinalized, branchDAGEvent.Branch.ID())
+	})
+	onManaPledged = events.NewClosure(func(pledge *mana.PledgedEvent) {
+		submit(jsonmodels.EventTopicManaPledged, pledge)
+	})
+
+	configureWebAPI()
+}
+
+func run(_ *node.Plugin) {
+	if err := daemon.BackgroundWorker(PluginName, worker, shutdown.PriorityWebAPIEventStream); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+func worker(shutdownSignal <-chan struct{}) {
+	messagelayer.Tangle().Booker.Events.MessageBooked.Attach(onMessageBooked)
+	messagelayer.Tangle().ApprovalWeightManager.Events.MessageFinalized.Attach(onMessageFinalized)
+	messagelayer.Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchRejected.Attach(onBranchRejected)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchLiked.Attach(onBranchLiked)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchFinalized.Attach(onBranchFinalized)
+	mana.Events().Pledged.Attach(onManaPledged)
+	eventWorkerPool.Start()
+
+	<-shutdownSignal
+	log.Infof("Stopping %s ...", PluginName)
+
+	messagelayer.Tangle().Booker.Events.MessageBooked.Detach(onMessageBooked)
+	messagelayer.Tangle().ApprovalWeightManager.Events.MessageFinalized.Detach(onMessageFinalized)
+	messagelayer.Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchRejected.Detach(onBranchRejected)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchLiked.Detach(onBranchLiked)
+	messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchFinalized.Detach(onBranchFinalized)
+	mana.Events().Pledged.Detach(onManaPledged)
+	eventWorkerPool.Stop()
+	eventHub.close()
+
+	log.Infof("Stopping %s ... done", PluginName)
+}
+
+// submit queues the building of an event of the given topic if a client is subscribed to the topic. Events are dropped
+// if the queue is full.
+func submit(topic string, param interface{}) {
+	if eventHub.interested(topic) {
+		eventWorkerPool.TrySubmit(topic, param)
+	}
+}
+
+// buildEvent builds the event of the given topic from the parameter of the submitted task.
+func buildEvent(topic string, param interface{}) *event {
+	switch topic {
+	case jsonmodels.EventTopicMessageBooked, jsonmodels.EventTopicMessageConfirmed:
+		return newMessageEvent(topic, param.(tangle.MessageID))
+	case jsonmodels.EventTopicTransactionConfirmed, jsonmodels.EventTopicTransactionRejected:
+		return newTransactionEvent(topic, param.(ledgerstate.TransactionID))
+	case jsonmodels.EventTopicBranchLiked, jsonmodels.EventTopicBranchFinalized:
+		return newBranchEvent(topic, param.(ledgerstate.BranchID))
+	case jsonmodels.EventTopicManaPledged:
+		return newManaPledgeEvent(param.(*mana.PledgedEvent))
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package eventstream
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// Names of the query parameters that define a Subscription.
+const (
+	queryTopic       = "topic"
+	queryAddress     = "address"
+	queryColor       = "color"
+	queryPayloadType = "payloadType"
+)
+
+// ErrInvalidSubscription is returned if the query parameters of a Subscription are invalid.
+var ErrInvalidSubscription = errors.New("invalid subscription")
+
+// region Subscription /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Subscription contains the topics and the filters of a client of the event stream. Empty topics subscribe to all
+// topics. Each non-empty filter only lets through the events that are related to at least one of its values, so
+// events that are not related to any address, color or payload type do not pass the corresponding filter.
+type Subscription struct {
+	topics       map[string]bool
+	addresses    map[string]bool
+	colors       map[ledgerstate.Color]bool
+	payloadTypes map[payload.Type]bool
+}
+
+// NewSubscription parses a Subscription from the given query parameters. Every parameter can be repeated or contain
+// a comma separated list of values.
+func NewSubscription(query url.Values) (subscription *Subscription, err error) {
+	subscription = &Subscription{
+		topics:       make(map[string]bool),
+		addresses:    make(map[string]bool),
+		colors:       make(map[ledgerstate.Color]bool),
+		payloadTypes: make(map[payload.Type]bool),
+	}
+
+	for _, topic := range queryValues(query, queryTopic) {
+		if !isTopic(topic) {
+			return nil, errors.Errorf("unknown topic '%s': %w", topic, ErrInvalidSubscription)
+		}
+		subscription.topics[topic] = true
+	}
+	for _, addressString := range queryValues(query, queryAddress) {
+		address, addressErr := ledgerstate.AddressFromBase58EncodedString(addressString)
+		if addressErr != nil {
+			return nil, errors.Errorf("failed to parse address '%s' (%v): %w", addressString, addressErr, ErrInvalidSubscription)
+		}
+		subscription.addresses[address.Base58()] = true
+	}
+	for _, colorString := range queryValues(query, queryColor) {
+		color, colorErr := parseColor(colorString)
+		if colorErr != nil {
+			return nil, errors.Errorf("failed to parse color '%s' (%v): %w", colorString, colorErr, ErrInvalidSubscription)
+		}
+		subscription.colors[color] = true
+	}
+	for _, payloadTypeString := range queryValues(query, queryPayloadType) {
+		payloadType, parseErr := strconv.ParseUint(payloadTypeString, 10, 32)
+		if parseErr != nil {
+			return nil, errors.Errorf("failed to parse payload type '%s' (%v): %w", payloadTypeString, parseErr, ErrInvalidSubscription)
+		}
+		subscription.payloadTypes[payload.Type(payloadType)] = true
+	}
+
+	return subscription, nil
+}
+
+// hasTopic returns true if the Subscription contains the given topic.
+func (s *Subscription) hasTopic(topic string) bool {
+	return len(s.topics) == 0 || s.topics[topic]
+}
+
+// matches returns true if the given event passes the topics and the filters of the Subscription.
+func (s *Subscription) matches(e *event) bool {
+	if !s.hasTopic(e.Topic) {
+		return false
+	}
+
+	if len(s.addresses) != 0 && !intersects(s.addresses, e.addresses) {
+		return false
+	}
+
+	if len(s.colors) != 0 {
+		matched := false
+		for color := range e.colors {
+			if matched = s.colors[color]; matched {
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(s.payloadTypes) != 0 && (!e.hasPayloadType || !s.payloadTypes[e.payloadType]) {
+		return false
+	}
+
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region event ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// event is an Event of the event stream together with the attributes that are used to filter it.
+type event struct {
+	*jsonmodels.Event
+
+	addresses      map[string]bool
+	colors         map[ledgerstate.Color]bool
+	payloadType    payload.Type
+	hasPayloadType bool
+}
+
+// newEvent creates an event of the given topic.
+func newEvent(topic string, timestamp int64) *event {
+	return &event{
+		Event:     &jsonmodels.Event{Topic: topic, Time: timestamp},
+		addresses: make(map[string]bool),
+		colors:    make(map[ledgerstate.Color]bool),
+	}
+}
+
+// setPayloadType sets the payload type that the event is related to.
+func (e *event) setPayloadType(payloadType payload.Type) {
+	e.payloadType = payloadType
+	e.hasPayloadType = true
+}
+
+// addOutput relates the event to the address and the colors of the given Output.
+func (e *event) addOutput(output ledgerstate.Output) {
+	e.addresses[output.Address().Base58()] = true
+	output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+		e.colors[color] = true
+
+		return true
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// queryValues returns the values of a query parameter that is either repeated or contains comma separated values.
+func queryValues(query url.Values, name string) (values []string) {
+	for _, value := range query[name] {
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				values = append(values, element)
+			}
+		}
+	}
+
+	return values
+}
+
+// isTopic returns true if the given string is a topic of the event stream.
+func isTopic(topic string) bool {
+	for _, knownTopic := range jsonmodels.EventTopics {
+		if topic == knownTopic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseColor parses a base58 encoded Color or the name of the IOTA color.
+func parseColor(colorString string) (ledgerstate.Color, error) {
+	if colorString == ledgerstate.ColorIOTA.String() {
+		return ledgerstate.ColorIOTA, nil
+	}
+
+	return ledgerstate.ColorFromBase58EncodedString(colorString)
+}
+
+// intersects returns true if both sets contain a common element.
+func intersects(a map[string]bool, b map[string]bool) bool {
+	for element := range b {
+		if a[element] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
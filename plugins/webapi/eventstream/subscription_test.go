@@ -0,0 +1,134 @@
+package eventstream
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestNewSubscription(t *testing.T) {
+	address := randomAddress()
+	color := ledgerstate.Color{1, 2, 3}
+
+	subscription, err := NewSubscription(url.Values{
+		queryTopic:       {jsonmodels.EventTopicMessageBooked + "," + jsonmodels.EventTopicManaPledged},
+		queryAddress:     {address.Base58()},
+		queryColor:       {color.Base58(), "IOTA"},
+		queryPayloadType: {"1", "337"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{jsonmodels.EventTopicMessageBooked: true, jsonmodels.EventTopicManaPledged: true}, subscription.topics)
+	assert.Equal(t, map[string]bool{address.Base58(): true}, subscription.addresses)
+	assert.Equal(t, map[ledgerstate.Color]bool{color: true, ledgerstate.ColorIOTA: true}, subscription.colors)
+	assert.Equal(t, map[payload.Type]bool{1: true, 337: true}, subscription.payloadTypes)
+
+	for name, query := range map[string]url.Values{
+		"unknown topic":        {queryTopic: {"unknown"}},
+		"invalid address":      {queryAddress: {"invalid"}},
+		"invalid color":        {queryColor: {"0"}},
+		"invalid payload type": {queryPayloadType: {"-1"}},
+	} {
+		_, err = NewSubscription(query)
+		assert.ErrorIs(t, err, ErrInvalidSubscription, name)
+	}
+}
+
+func TestSubscription_Matches(t *testing.T) {
+	address := randomAddress()
+	color := ledgerstate.Color{1, 2, 3}
+
+	transactionEvent := newEvent(jsonmodels.EventTopicTransactionConfirmed, 0)
+	transactionEvent.setPayloadType(ledgerstate.TransactionType)
+	transactionEvent.addOutput(ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{color: 10}), address))
+
+	dataEvent := newEvent(jsonmodels.EventTopicMessageBooked, 0)
+	dataEvent.setPayloadType(payload.GenericDataPayloadType)
+
+	branchEvent := newEvent(jsonmodels.EventTopicBranchLiked, 0)
+
+	for name, test := range map[string]struct {
+		query   url.Values
+		matches []*event
+	}{
+		"all topics": {
+			query:   url.Values{},
+			matches: []*event{transactionEvent, dataEvent, branchEvent},
+		},
+		"topic": {
+			query:   url.Values{queryTopic: {jsonmodels.EventTopicMessageBooked}},
+			matches: []*event{dataEvent},
+		},
+		"address": {
+			query:   url.Values{queryAddress: {address.Base58()}},
+			matches: []*event{transactionEvent},
+		},
+		"other address": {
+			query: url.Values{queryAddress: {randomAddress().Base58()}},
+		},
+		"color": {
+			query:   url.Values{queryColor: {"IOTA", color.Base58()}},
+			matches: []*event{transactionEvent},
+		},
+		"payload type": {
+			query:   url.Values{queryPayloadType: {"0"}},
+			matches: []*event{dataEvent},
+		},
+		"topic and payload type": {
+			query: url.Values{queryTopic: {jsonmodels.EventTopicBranchLiked}, queryPayloadType: {"0"}},
+		},
+	} {
+		subscription, err := NewSubscription(test.query)
+		require.NoError(t, err, name)
+
+		var matches []*event
+		for _, e := range []*event{transactionEvent, dataEvent, branchEvent} {
+			if subscription.matches(e) {
+				matches = append(matches, e)
+			}
+		}
+		assert.Equal(t, test.matches, matches, name)
+	}
+}
+
+func TestHub(t *testing.T) {
+	h := newHub(2, 1)
+
+	all, err := h.subscribe(&Subscription{})
+	require.NoError(t, err)
+	subscription, err := NewSubscription(url.Values{queryTopic: {jsonmodels.EventTopicBranchLiked}})
+	require.NoError(t, err)
+	branches, err := h.subscribe(subscription)
+	require.NoError(t, err)
+
+	_, err = h.subscribe(&Subscription{})
+	assert.ErrorIs(t, err, ErrTooManySubscribers)
+
+	assert.True(t, h.interested(jsonmodels.EventTopicBranchLiked))
+	assert.True(t, h.interested(jsonmodels.EventTopicMessageBooked))
+	h.unsubscribe(all)
+	assert.False(t, h.interested(jsonmodels.EventTopicMessageBooked))
+
+	// the event that doesn't fit into the buffer is dropped
+	first := newEvent(jsonmodels.EventTopicBranchLiked, 1)
+	h.publish(first)
+	h.publish(newEvent(jsonmodels.EventTopicBranchLiked, 2))
+	h.publish(newEvent(jsonmodels.EventTopicMessageBooked, 3))
+	assert.Equal(t, first.Event, <-branches.events)
+	assert.Empty(t, branches.events)
+
+	h.close()
+	<-branches.closed
+	_, err = h.subscribe(&Subscription{})
+	assert.ErrorIs(t, err, ErrShutdown)
+}
+
+func randomAddress() ledgerstate.Address {
+	return ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+}
@@ -0,0 +1,159 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+const (
+	// eventsRoute is the web API route that streams the events as server-sent events.
+	eventsRoute = "events"
+	// webSocketRoute is the web API route that streams the JSON encoded events over a WebSocket.
+	webSocketRoute = "events/ws"
+
+	// keepAliveInterval is the interval in which idle connections are kept alive.
+	keepAliveInterval = 30 * time.Second
+	// writeTimeout is the time after which a write to a WebSocket fails.
+	writeTimeout = 5 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: writeTimeout,
+}
+
+func configureWebAPI() {
+	allowedOrigins := config.Node().Strings(CfgAllowedOrigins)
+	upgrader.CheckOrigin = func(r *http.Request) bool {
+		return webapi.OriginAllowed(r, allowedOrigins)
+	}
+
+	webapi.Server().GET(eventsRoute, serverSentEventsHandler)
+	webapi.Server().GET(webSocketRoute, webSocketHandler)
+	webapi.AllowQueryToken(webSocketRoute)
+}
+
+// serverSentEventsHandler streams the events that match the Subscription in the query parameters as server-sent
+// events until the client disconnects or the node shuts down.
+func serverSentEventsHandler(c echo.Context) error {
+	s, err := subscribe(c)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+	defer eventHub.unsubscribe(s)
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case e := <-s.events:
+			eventJSON, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			if _, err = fmt.Fprintf(response, "event: %s\ndata: %s\n\n", e.Topic, eventJSON); err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+		case <-s.closed:
+			return nil
+		case <-c.Request().Context().Done():
+			return nil
+		}
+		response.Flush()
+	}
+}
+
+// webSocketHandler upgrades the request to a WebSocket and streams the JSON encoded events that match the
+// Subscription in the query parameters until the client disconnects or the node shuts down.
+func webSocketHandler(c echo.Context) error {
+	s, err := subscribe(c)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		return nil
+	}
+	defer eventHub.unsubscribe(s)
+
+	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+	log.Debugf("accepted websocket connection from %s", ws.RemoteAddr().String())
+
+	// the client doesn't send anything, but reading is necessary to process control messages and to detect when the
+	// connection is closed
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case e := <-s.events:
+			if err := ws.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+				return nil
+			}
+			if err := ws.WriteJSON(e); err != nil {
+				return nil
+			}
+		case <-keepAlive.C:
+			if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return nil
+			}
+		case <-s.closed:
+			_ = ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, "node is shutting down"), time.Now().Add(writeTimeout))
+			return nil
+		case <-disconnected:
+			return nil
+		}
+	}
+}
+
+// subscribe parses the Subscription from the query parameters of the request and registers a subscriber for it. If
+// the request is rejected, the error response is written and the returned subscriber is nil.
+func subscribe(c echo.Context) (*subscriber, error) {
+	subscription, err := NewSubscription(c.QueryParams())
+	if err != nil {
+		return nil, c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	s, err := eventHub.subscribe(subscription)
+	if err != nil {
+		return nil, c.JSON(http.StatusServiceUnavailable, jsonmodels.NewErrorResponse(err))
+	}
+
+	return s, nil
+}
@@ -7,8 +7,10 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
@@ -36,10 +38,13 @@ func Plugin() *node.Plugin {
 		plugin = node.NewPlugin("WebAPI ledgerstate Endpoint", node.Enabled, func(*node.Plugin) {
 			webapi.Server().GET("ledgerstate/addresses/:address", GetAddress)
 			webapi.Server().GET("ledgerstate/addresses/:address/unspentOutputs", GetAddressUnspentOutputs)
+			webapi.Server().GET("ledgerstate/addresses/:address/transactions", GetAddressTransactions)
 			webapi.Server().POST("ledgerstate/addresses/unspentOutputs", PostAddressUnspentOutputs)
 			webapi.Server().GET("ledgerstate/branches/:branchID", GetBranch)
 			webapi.Server().GET("ledgerstate/branches/:branchID/children", GetBranchChildren)
 			webapi.Server().GET("ledgerstate/branches/:branchID/conflicts", GetBranchConflicts)
+			webapi.Server().GET("ledgerstate/colors/:color", GetColor)
+			webapi.Server().GET("ledgerstate/colors/:color/burns", GetColorBurns)
 			webapi.Server().GET("ledgerstate/outputs/:outputID", GetOutput)
 			webapi.Server().GET("ledgerstate/outputs/:outputID/consumers", GetOutputConsumers)
 			webapi.Server().GET("ledgerstate/outputs/:outputID/metadata", GetOutputMetadata)
@@ -49,6 +54,7 @@ func Plugin() *node.Plugin {
 			webapi.Server().GET("ledgerstate/transactions/:transactionID/consensus", GetTransactionConsensusMetadata)
 			webapi.Server().GET("ledgerstate/transactions/:transactionID/attachments", GetTransactionAttachments)
 			webapi.Server().POST("ledgerstate/transactions", PostTransaction)
+			webapi.Server().POST("ledgerstate/transactions/dryRun", DryRunTransaction)
 		})
 	})
 
@@ -97,6 +103,124 @@ func GetAddressUnspentOutputs(c echo.Context) error {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressTransactions ///////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// defaultAddressTransactionsLimit defines the page size that is used if the request does not specify a limit.
+	defaultAddressTransactionsLimit = 100
+
+	// maxAddressTransactionsLimit defines the maximum page size of the GetAddressTransactions endpoint.
+	maxAddressTransactionsLimit = 1000
+)
+
+// GetAddressTransactions is the handler for the /ledgerstate/addresses/:address/transactions endpoint. It returns the
+// Transactions that created Outputs on or consumed Outputs of the Address, newest first. The pages are requested with the
+// cursor of the previous page, so the index only needs to be iterated from there up to the end of the requested page.
+func GetAddressTransactions(c echo.Context) error {
+	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	var request jsonmodels.GetAddressTransactionsRequest
+	if err = c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+	switch {
+	case request.Limit == 0:
+		request.Limit = defaultAddressTransactionsLimit
+	case request.Limit < 0 || request.Limit > maxAddressTransactionsLimit:
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("limit (%d) must be within [1, %d]", request.Limit, maxAddressTransactionsLimit)))
+	}
+	switch request.Direction {
+	case "", "incoming", "outgoing":
+	default:
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("unsupported direction '%s'", request.Direction)))
+	}
+	switch request.InclusionState {
+	case "", "pending", "confirmed", "rejected":
+	default:
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("unsupported inclusion state '%s'", request.InclusionState)))
+	}
+	var after *ledgerstate.AddressTransactionMapping
+	if request.Cursor != "" {
+		if after, err = addressTransactionsCursorFromString(address, request.Cursor); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+		}
+	}
+
+	transactions := make([]*jsonmodels.AddressTransaction, 0)
+	var lastMapping *ledgerstate.AddressTransactionMapping
+	more := false
+	if err = messagelayer.Tangle().LedgerState.UTXODAG.AddressTransactionMappings(address, after, func(addressTransactionMapping *ledgerstate.AddressTransactionMapping) bool {
+		direction := addressTransactionMapping.Direction()
+		if request.Direction == "incoming" && !direction.Incoming() || request.Direction == "outgoing" && !direction.Outgoing() {
+			return true
+		}
+
+		// the inclusion state is only determined for the entries that are candidates of the page
+		inclusionState, inclusionStateErr := messagelayer.Tangle().LedgerState.TransactionInclusionState(addressTransactionMapping.TransactionID())
+		if inclusionStateErr != nil {
+			return true
+		}
+		if request.InclusionState != "" && request.InclusionState != jsonmodels.InclusionStateName(inclusionState) {
+			return true
+		}
+
+		if len(transactions) == request.Limit {
+			more = true
+			return false
+		}
+		transactions = append(transactions, jsonmodels.NewAddressTransaction(addressTransactionMapping, inclusionState))
+		lastMapping = addressTransactionMapping
+
+		return true
+	}); err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	response := &jsonmodels.GetAddressTransactionsResponse{
+		Address:      jsonmodels.NewAddress(address),
+		Transactions: transactions,
+		Limit:        request.Limit,
+	}
+	if more {
+		response.NextCursor = addressTransactionsCursor(lastMapping)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// addressTransactionsCursor returns the cursor that continues the pagination after the given AddressTransactionMapping.
+func addressTransactionsCursor(addressTransactionMapping *ledgerstate.AddressTransactionMapping) string {
+	return base58.Encode(marshalutil.New(marshalutil.Int64Size + ledgerstate.TransactionIDLength).
+		WriteInt64(addressTransactionMapping.Timestamp().UnixNano()).
+		Write(addressTransactionMapping.TransactionID()).
+		Bytes())
+}
+
+// addressTransactionsCursorFromString parses a cursor that was returned by addressTransactionsCursor.
+func addressTransactionsCursorFromString(address ledgerstate.Address, cursor string) (addressTransactionMapping *ledgerstate.AddressTransactionMapping, err error) {
+	cursorBytes, err := base58.Decode(cursor)
+	if err != nil {
+		return nil, errors.Errorf("failed to decode cursor '%s': %w", cursor, err)
+	}
+
+	marshalUtil := marshalutil.New(cursorBytes)
+	timestamp, err := marshalUtil.ReadInt64()
+	if err != nil {
+		return nil, errors.Errorf("invalid cursor '%s': %w", cursor, err)
+	}
+	transactionID, err := ledgerstate.TransactionIDFromMarshalUtil(marshalUtil)
+	if err != nil {
+		return nil, errors.Errorf("invalid cursor '%s': %w", cursor, err)
+	}
+
+	return ledgerstate.NewAddressTransactionMapping(address, transactionID, time.Unix(0, timestamp), 0), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PostAddressUnspentOutputs /////////////////////////////////////////////////////////////////////////////////////
 
 // PostAddressUnspentOutputs is the handler for the /ledgerstate/addresses/unspentOutputs endpoint.
@@ -236,6 +360,43 @@ func GetBranchConflicts(c echo.Context) (err error) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetColor /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColor is the handler for the /ledgerstate/colors/:color endpoint.
+func GetColor(c echo.Context) (err error) {
+	color, err := colorFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	if messagelayer.Tangle().LedgerState.UTXODAG.CachedColorSupply(color).Consume(func(colorSupply *ledgerstate.ColorSupply) {
+		err = c.JSON(http.StatusOK, jsonmodels.NewColorSupply(colorSupply))
+	}) {
+		return
+	}
+
+	return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(fmt.Errorf("failed to load supply of Color with %s", color)))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region GetColorBurns ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetColorBurns is the handler for the /ledgerstate/colors/:color/burns endpoint.
+func GetColorBurns(c echo.Context) (err error) {
+	color, err := colorFromContext(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	cachedColorBurns := messagelayer.Tangle().LedgerState.UTXODAG.CachedColorBurns(color)
+	defer cachedColorBurns.Release()
+
+	return c.JSON(http.StatusOK, jsonmodels.NewGetColorBurnsResponse(color, cachedColorBurns.Unwrap()))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetOutput ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // GetOutput is the handler for the /ledgerstate/outputs/:outputID endpoint.
@@ -421,6 +582,87 @@ func branchIDFromContext(c echo.Context) (branchID ledgerstate.BranchID, err err
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region colorFromContext /////////////////////////////////////////////////////////////////////////////////////////////
+
+// colorFromContext determines the Color from the color parameter in an echo.Context.
+func colorFromContext(c echo.Context) (color ledgerstate.Color, err error) {
+	color, err = ledgerstate.ColorFromBase58EncodedString(c.Param("color"))
+	if err == nil && (color == ledgerstate.ColorIOTA || color == ledgerstate.ColorMint) {
+		err = errors.Errorf("%s is not a minted Color", color)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region DryRunTransaction ////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// syntaxInvalidViolation is reported if the transaction bytes can not be parsed.
+	syntaxInvalidViolation = "SyntaxInvalid"
+
+	// accessPledgeIDNotAllowedViolation is reported if the node does not accept access mana pledges to the given node.
+	accessPledgeIDNotAllowedViolation = "AccessPledgeIDNotAllowed"
+
+	// consensusPledgeIDNotAllowedViolation is reported if the node does not accept consensus mana pledges to the given
+	// node.
+	consensusPledgeIDNotAllowedViolation = "ConsensusPledgeIDNotAllowed"
+
+	// timestampTooOldViolation is reported if the transaction is older than the MaxReattachmentTime.
+	timestampTooOldViolation = "TimestampTooOld"
+)
+
+// DryRunTransaction is the handler for the /ledgerstate/transactions/dryRun endpoint. It runs the same validation as
+// PostTransaction against the current ledger state and reports every violation without booking or gossiping anything.
+func DryRunTransaction(c echo.Context) error {
+	var request jsonmodels.PostTransactionRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, &jsonmodels.DryRunTransactionResponse{Error: err.Error()})
+	}
+
+	violations := make([]*jsonmodels.TransactionViolation, 0)
+	addViolation := func(violationType string, message string, args ...interface{}) {
+		violations = append(violations, &jsonmodels.TransactionViolation{
+			Type:       violationType,
+			InputIndex: ledgerstate.NoInputIndex,
+			Message:    fmt.Sprintf(message, args...),
+		})
+	}
+
+	tx, _, err := ledgerstate.TransactionFromBytes(request.TransactionBytes)
+	if err != nil {
+		addViolation(syntaxInvalidViolation, "%s", err)
+
+		return c.JSON(http.StatusOK, &jsonmodels.DryRunTransactionResponse{Violations: violations})
+	}
+
+	allowedAccessMana := messagelayer.GetAllowedPledgeNodes(mana.AccessMana)
+	if allowedAccessMana.IsFilterEnabled && !allowedAccessMana.Allowed.Has(tx.Essence().AccessPledgeID()) {
+		addViolation(accessPledgeIDNotAllowedViolation, "not allowed to pledge access mana to %s", tx.Essence().AccessPledgeID().String())
+	}
+	allowedConsensusMana := messagelayer.GetAllowedPledgeNodes(mana.ConsensusMana)
+	if allowedConsensusMana.IsFilterEnabled && !allowedConsensusMana.Allowed.Has(tx.Essence().ConsensusPledgeID()) {
+		addViolation(consensusPledgeIDNotAllowedViolation, "not allowed to pledge consensus mana to %s", tx.Essence().ConsensusPledgeID().String())
+	}
+
+	for _, violation := range messagelayer.Tangle().LedgerState.ValidateTransaction(tx) {
+		violations = append(violations, jsonmodels.NewTransactionViolation(violation))
+	}
+
+	if tx.Essence().Timestamp().Before(clock.SyncedTime().Add(-tangle.MaxReattachmentTimeMin)) {
+		addViolation(timestampTooOldViolation, "transaction timestamp is older than MaxReattachmentTime (%s) and cannot be issued", tangle.MaxReattachmentTimeMin)
+	}
+
+	return c.JSON(http.StatusOK, &jsonmodels.DryRunTransactionResponse{
+		TransactionID: tx.ID().Base58(),
+		Valid:         len(violations) == 0,
+		Violations:    violations,
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region postTransaction //////////////////////////////////////////////////////////////////////////////////////////////
 
 const maxBookedAwaitTime = 5 * time.Second
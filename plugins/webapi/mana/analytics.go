@@ -0,0 +1,190 @@
+package mana
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	manaPlugin "github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// getManaHistoryHandler handles a mana/analytics/history request.
+func getManaHistoryHandler(c echo.Context) error {
+	var request jsonmodels.GetManaHistoryRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+	nodeID, err := mana.IDFromStr(request.NodeID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+	if request.NodeID == "" {
+		nodeID = local.GetInstance().ID()
+	}
+	manaType, err := analyticsManaType(request.ManaType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaHistoryResponse{Error: err.Error()})
+	}
+
+	var start, end time.Time
+	if request.StartTime != 0 {
+		start = time.Unix(request.StartTime, 0)
+	}
+	if request.EndTime != 0 {
+		end = time.Unix(request.EndTime, 0)
+	}
+
+	analytics := manaPlugin.ManaAnalytics()
+	history := make([]jsonmodels.ManaHistoryPoint, 0)
+	for _, point := range analytics.History(manaType, nodeID, start, end) {
+		history = append(history, jsonmodels.ManaHistoryPoint{
+			Time:          point.Time.Unix(),
+			Delta:         point.Delta,
+			Balance:       point.Balance,
+			TransactionID: point.TransactionID.Base58(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaHistoryResponse{
+		ShortNodeID: nodeID.String(),
+		NodeID:      base58.Encode(nodeID.Bytes()),
+		ManaType:    manaType.String(),
+		Balance:     analytics.Balance(manaType, nodeID),
+		History:     history,
+	})
+}
+
+// getManaPledgersHandler handles a mana/analytics/pledgers request.
+func getManaPledgersHandler(c echo.Context) error {
+	var request jsonmodels.GetManaPledgersRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgersResponse{Error: err.Error()})
+	}
+	nodeID, err := mana.IDFromStr(request.NodeID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgersResponse{Error: err.Error()})
+	}
+	if request.NodeID == "" {
+		nodeID = local.GetInstance().ID()
+	}
+	manaType, err := analyticsManaType(request.ManaType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgersResponse{Error: err.Error()})
+	}
+
+	pledgers := make([]jsonmodels.AddressPledged, 0)
+	for _, pledger := range manaPlugin.ManaAnalytics().TopPledgers(manaType, nodeID, request.Number) {
+		pledgers = append(pledgers, jsonmodels.AddressPledged{
+			Address: pledger.Address.Base58(),
+			Mana:    pledger.Amount,
+		})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaPledgersResponse{
+		ShortNodeID: nodeID.String(),
+		NodeID:      base58.Encode(nodeID.Bytes()),
+		ManaType:    manaType.String(),
+		Pledgers:    pledgers,
+	})
+}
+
+// getManaPledgeesHandler handles a mana/analytics/pledgees request.
+func getManaPledgeesHandler(c echo.Context) error {
+	var request jsonmodels.GetManaPledgeesRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgeesResponse{Error: err.Error()})
+	}
+	address, err := ledgerstate.AddressFromBase58EncodedString(request.Address)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgeesResponse{Error: err.Error()})
+	}
+	manaType, err := analyticsManaType(request.ManaType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaPledgeesResponse{Error: err.Error()})
+	}
+
+	pledgees := make([]mana.NodeStr, 0)
+	for _, pledgee := range manaPlugin.ManaAnalytics().TopPledgees(manaType, address, request.Number) {
+		pledgees = append(pledgees, pledgee.ToNodeStr())
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaPledgeesResponse{
+		Address:  address.Base58(),
+		ManaType: manaType.String(),
+		Pledgees: pledgees,
+	})
+}
+
+// getManaEpochsHandler handles a mana/analytics/epochs request.
+func getManaEpochsHandler(c echo.Context) error {
+	var request jsonmodels.GetManaEpochsRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaEpochsResponse{Error: err.Error()})
+	}
+	manaType, err := analyticsManaType(request.ManaType)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaEpochsResponse{Error: err.Error()})
+	}
+
+	analytics := manaPlugin.ManaAnalytics()
+	epochs := request.Epochs
+	if len(epochs) == 0 {
+		epochs = analytics.Epochs(manaType)
+	}
+
+	summaries := make([]jsonmodels.ManaEpochSummary, 0, len(epochs))
+	for _, epoch := range epochs {
+		distribution := analytics.EpochDistribution(manaType, epoch)
+		summary := jsonmodels.ManaEpochSummary{
+			Epoch:     epoch,
+			StartTime: time.Unix(0, epoch*int64(analytics.EpochDuration())).Unix(),
+			EndTime:   time.Unix(0, (epoch+1)*int64(analytics.EpochDuration())).Unix(),
+			Gini:      distribution.Gini(),
+			Nodes:     make([]mana.NodeStr, 0, len(distribution)),
+		}
+		for _, node := range distribution.SortedNodes(0) {
+			summary.Total += node.Mana
+			summary.Nodes = append(summary.Nodes, node.ToNodeStr())
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaEpochsResponse{
+		ManaType: manaType.String(),
+		Epochs:   summaries,
+	})
+}
+
+// getManaGiniHandler handles a mana/analytics/gini request.
+func getManaGiniHandler(c echo.Context) error {
+	t := time.Now()
+	access, tAccess, err := manaPlugin.GetManaMap(mana.AccessMana, t)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaGiniResponse{Error: err.Error()})
+	}
+	consensus, tConsensus, err := manaPlugin.GetManaMap(mana.ConsensusMana, t)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetManaGiniResponse{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.GetManaGiniResponse{
+		Access:             access.Gini(),
+		AccessTimestamp:    tAccess.Unix(),
+		Consensus:          consensus.Gini(),
+		ConsensusTimestamp: tConsensus.Unix(),
+	})
+}
+
+// analyticsManaType parses the mana type of an analytics request. It defaults to consensus mana.
+func analyticsManaType(manaType string) (mana.Type, error) {
+	if manaType == "" {
+		return mana.ConsensusMana, nil
+	}
+	return mana.TypeFromString(manaType)
+}
@@ -37,6 +37,11 @@ func configure(_ *node.Plugin) {
 	webapi.Server().GET("mana/allowedManaPledge", allowedManaPledgeHandler)
 	webapi.Server().GET("mana/delegated", GetDelegatedMana)
 	webapi.Server().GET("mana/delegated/outputs", GetDelegatedOutputs)
+	webapi.Server().GET("mana/analytics/history", getManaHistoryHandler)
+	webapi.Server().GET("mana/analytics/pledgers", getManaPledgersHandler)
+	webapi.Server().GET("mana/analytics/pledgees", getManaPledgeesHandler)
+	webapi.Server().GET("mana/analytics/epochs", getManaEpochsHandler)
+	webapi.Server().GET("mana/analytics/gini", getManaGiniHandler)
 	// webapi.Server().GET("/mana/consensus/past", getPastConsensusManaVectorHandler)
 	// webapi.Server().GET("/mana/consensus/logs", getEventLogsHandler)
 	// webapi.Server().GET("/mana/consensus/metadata", getPastConsensusVectorMetadataHandler)
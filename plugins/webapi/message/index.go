@@ -0,0 +1,113 @@
+package message
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+const (
+	// defaultMessageIndexLimit is the number of messages that is returned if the query does not define a limit.
+	defaultMessageIndexLimit = 100
+	// maxMessageIndexLimit is the maximum number of messages that is returned by a single query.
+	maxMessageIndexLimit = 1000
+)
+
+// region GetMessageIndex //////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetMessageIndex is the handler for the /messages/index endpoint. It returns a page of the messages of a payload type
+// (and optionally an issuer and a time range) ordered by their issuing time.
+func GetMessageIndex(c echo.Context) error {
+	if !messagelayer.Parameters.MessageIndex {
+		return c.JSON(http.StatusNotImplemented, jsonmodels.GetMessageIndexResponse{Error: "the message index is disabled"})
+	}
+
+	var request jsonmodels.GetMessageIndexRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetMessageIndexResponse{Error: err.Error()})
+	}
+
+	query, err := messageIndexQuery(request)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.GetMessageIndexResponse{Error: err.Error()})
+	}
+
+	entries, more, err := messagelayer.Tangle().Storage.QueryMessageIndex(query)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.GetMessageIndexResponse{Error: err.Error()})
+	}
+	response := jsonmodels.GetMessageIndexResponse{Messages: make([]jsonmodels.IndexedMessage, 0, len(entries))}
+	for _, entry := range entries {
+		messagelayer.Tangle().Storage.MessageMetadata(entry.MessageID()).Consume(func(messageMetadata *tangle.MessageMetadata) {
+			response.Messages = append(response.Messages, jsonmodels.IndexedMessage{
+				ID:              entry.MessageID().Base58(),
+				PayloadType:     entry.PayloadType().String(),
+				IssuerPublicKey: entry.Issuer().String(),
+				IssuingTime:     entry.IssuingTime().Unix(),
+				Metadata:        NewMessageMetadata(messageMetadata),
+			})
+		})
+	}
+	if more {
+		response.NextCursor = base58.Encode(entries[len(entries)-1].Bytes())
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// messageIndexQuery parses the tangle.MessageIndexQuery from the given request.
+func messageIndexQuery(request jsonmodels.GetMessageIndexRequest) (query tangle.MessageIndexQuery, err error) {
+	payloadType, err := strconv.ParseUint(request.PayloadType, 10, 32)
+	if err != nil {
+		return query, errors.Errorf("invalid payloadType '%s': %w", request.PayloadType, err)
+	}
+	query.PayloadType = payload.Type(payloadType)
+
+	if request.Issuer != "" {
+		issuer, parseErr := ed25519.PublicKeyFromString(request.Issuer)
+		if parseErr != nil {
+			return query, errors.Errorf("invalid issuer '%s': %w", request.Issuer, parseErr)
+		}
+		query.Issuer = &issuer
+	}
+
+	if request.From != 0 {
+		query.From = time.Unix(request.From, 0)
+	}
+	if request.To != 0 {
+		query.To = time.Unix(request.To, 0)
+	}
+
+	if request.Cursor != "" {
+		cursorBytes, decodeErr := base58.Decode(request.Cursor)
+		if decodeErr != nil {
+			return query, errors.Errorf("invalid cursor '%s': %w", request.Cursor, decodeErr)
+		}
+		if query.After, _, err = tangle.MessageIndexEntryFromBytes(cursorBytes); err != nil {
+			return query, errors.Errorf("invalid cursor '%s': %w", request.Cursor, err)
+		}
+	}
+
+	switch {
+	case request.Limit < 0 || request.Limit > maxMessageIndexLimit:
+		return query, errors.Errorf("limit has to be between 1 and %d", maxMessageIndexLimit)
+	case request.Limit == 0:
+		query.Limit = defaultMessageIndexLimit
+	default:
+		query.Limit = request.Limit
+	}
+
+	return query, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
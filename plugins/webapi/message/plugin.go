@@ -31,6 +31,7 @@ var (
 func Plugin() *node.Plugin {
 	once.Do(func() {
 		plugin = node.NewPlugin("WebAPI message Endpoint", node.Enabled, func(*node.Plugin) {
+			webapi.Server().GET("messages/index", GetMessageIndex)
 			webapi.Server().GET("messages/:messageID", GetMessage)
 			webapi.Server().GET("messages/:messageID/metadata", GetMessageMetadata)
 			webapi.Server().GET("messages/:messageID/consensus", GetMessageConsensusMetadata)
@@ -53,26 +54,7 @@ func GetMessage(c echo.Context) (err error) {
 	}
 
 	if messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
-		err = c.JSON(http.StatusOK, jsonmodels.Message{
-			ID:              message.ID().Base58(),
-			StrongParents:   message.StrongParents().ToStrings(),
-			WeakParents:     message.WeakParents().ToStrings(),
-			StrongApprovers: messagelayer.Tangle().Utils.ApprovingMessageIDs(message.ID(), tangle.StrongApprover).ToStrings(),
-			WeakApprovers:   messagelayer.Tangle().Utils.ApprovingMessageIDs(message.ID(), tangle.WeakApprover).ToStrings(),
-			IssuerPublicKey: message.IssuerPublicKey().String(),
-			IssuingTime:     message.IssuingTime().Unix(),
-			SequenceNumber:  message.SequenceNumber(),
-			PayloadType:     message.Payload().Type().String(),
-			TransactionID: func() string {
-				if message.Payload().Type() == ledgerstate.TransactionType {
-					return message.Payload().(*ledgerstate.Transaction).ID().Base58()
-				}
-
-				return ""
-			}(),
-			Payload:   message.Payload().Bytes(),
-			Signature: message.Signature().String(),
-		})
+		err = c.JSON(http.StatusOK, NewMessage(message))
 	}) {
 		return
 	}
@@ -80,6 +62,30 @@ func GetMessage(c echo.Context) (err error) {
 	return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(fmt.Errorf("failed to load Message with %s", messageID)))
 }
 
+// NewMessage returns the JSON model of the given tangle.Message.
+func NewMessage(message *tangle.Message) jsonmodels.Message {
+	return jsonmodels.Message{
+		ID:              message.ID().Base58(),
+		StrongParents:   message.StrongParents().ToStrings(),
+		WeakParents:     message.WeakParents().ToStrings(),
+		StrongApprovers: messagelayer.Tangle().Utils.ApprovingMessageIDs(message.ID(), tangle.StrongApprover).ToStrings(),
+		WeakApprovers:   messagelayer.Tangle().Utils.ApprovingMessageIDs(message.ID(), tangle.WeakApprover).ToStrings(),
+		IssuerPublicKey: message.IssuerPublicKey().String(),
+		IssuingTime:     message.IssuingTime().Unix(),
+		SequenceNumber:  message.SequenceNumber(),
+		PayloadType:     message.Payload().Type().String(),
+		TransactionID: func() string {
+			if message.Payload().Type() == ledgerstate.TransactionType {
+				return message.Payload().(*ledgerstate.Transaction).ID().Base58()
+			}
+
+			return ""
+		}(),
+		Payload:   message.Payload().Bytes(),
+		Signature: message.Signature().String(),
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region GetMessageMetadata ///////////////////////////////////////////////////////////////////////////////////////////
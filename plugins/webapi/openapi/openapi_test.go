@@ -0,0 +1,284 @@
+package openapi
+
+import (
+	"encoding/json"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/openapi"
+)
+
+const (
+	// pluginsDir is the directory that contains the plugins that register routes at the web API.
+	pluginsDir = "../.."
+	// documentFile is the checked in OpenAPI document of all routes.
+	documentFile = "../../../docs/apis/openapi.json"
+)
+
+var update = flag.Bool("update", false, "update the checked in OpenAPI document")
+
+// TestRoutesMatchSource makes sure that every route that is registered at the web API is described in the routes table
+// and that the table doesn't describe routes that don't exist (anymore).
+func TestRoutesMatchSource(t *testing.T) {
+	registered := registeredRoutes(t)
+	require.NotEmpty(t, registered)
+
+	described := make(map[string]bool)
+	for _, route := range routes {
+		key := routeKey(route.Method, route.Path)
+		assert.False(t, described[key], "%s is described twice", key)
+		described[key] = true
+	}
+
+	for key, position := range registered {
+		assert.True(t, described[key], "%s (registered at %s) is not described in the routes table", key, position)
+	}
+	for key := range described {
+		_, exists := registered[key]
+		assert.True(t, exists, "%s is described in the routes table but not registered", key)
+	}
+}
+
+// TestQueryParameters makes sure that the documented query parameters are bound by echo, which matches the field names
+// case-insensitively if a field has no query tag.
+func TestQueryParameters(t *testing.T) {
+	for _, route := range routes {
+		if route.Query == nil {
+			continue
+		}
+		assert.Equal(t, http.MethodGet, route.Method, "%s %s: query parameters are only bound for GET requests", route.Method, route.Path)
+
+		queryType := reflect.TypeOf(route.Query)
+		for i := 0; i < queryType.NumField(); i++ {
+			field := queryType.Field(i)
+			if field.Tag.Get("query") != "" {
+				continue
+			}
+			jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+			assert.True(t, jsonName == "" || strings.EqualFold(jsonName, field.Name), "%s %s: query parameter %s is not bound to field %s", route.Method, route.Path, jsonName, field.Name)
+		}
+	}
+}
+
+// TestDocument makes sure that the OpenAPI document is valid and that the checked in document is up to date (run the
+// tests with -update to regenerate it).
+func TestDocument(t *testing.T) {
+	echoRoutes := make([]*echo.Route, 0, len(routes))
+	for _, route := range routes {
+		echoRoutes = append(echoRoutes, &echo.Route{Method: route.Method, Path: route.Path})
+	}
+	document, err := Document(echoRoutes)
+	require.NoError(t, err)
+
+	operationIDs := make(map[string]bool)
+	for path, pathItem := range document.Paths {
+		for method, operation := range pathItem {
+			assert.False(t, operationIDs[operation.OperationID], "duplicate operation ID %s", operation.OperationID)
+			operationIDs[operation.OperationID] = true
+			assert.NotEmpty(t, operation.Summary, "%s %s has no summary", method, path)
+			assert.NotEmpty(t, operation.Tags, "%s %s has no tag", method, path)
+
+			for _, segment := range strings.Split(path, "/") {
+				if strings.HasPrefix(segment, "{") {
+					assert.True(t, hasParameter(operation, strings.Trim(segment, "{}")), "%s %s does not declare path parameter %s", method, path, segment)
+				}
+			}
+		}
+	}
+
+	// the checked in document doesn't depend on the version of the node
+	document.Info.Version = "latest"
+	documentJSON, err := json.MarshalIndent(document, "", "  ")
+	require.NoError(t, err)
+	for _, ref := range references(documentJSON) {
+		_, exists := document.Components.Schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+		assert.True(t, exists, "reference %s can not be resolved", ref)
+	}
+
+	documentJSON = append(documentJSON, '\n')
+	if *update {
+		require.NoError(t, ioutil.WriteFile(documentFile, documentJSON, 0o600))
+	}
+	checkedIn, err := ioutil.ReadFile(documentFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(checkedIn), string(documentJSON), "%s is outdated, run the tests with -update", documentFile)
+}
+
+func TestDocument_UndescribedRoute(t *testing.T) {
+	document, err := Document([]*echo.Route{
+		{Method: http.MethodGet, Path: "/info"},
+		{Method: http.MethodGet, Path: "/unknown/:id"},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, document.Paths, 2)
+	assert.Equal(t, "getInfo", document.Paths["/info"]["get"].OperationID)
+	assert.Equal(t, "id", document.Paths["/unknown/{id}"]["get"].Parameters[0].Name)
+	assert.Equal(t, []openapi.Tag{{Name: "info", Description: tags["info"]}}, document.Tags)
+}
+
+// registeredRoutes parses the source code of the plugins and returns the routes that are registered at the web API
+// together with the position of their registration.
+func registeredRoutes(t *testing.T) map[string]string {
+	registered := make(map[string]string)
+	fileSet := token.NewFileSet()
+
+	err := filepath.Walk(pluginsDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+
+		packages, err := parser.ParseDir(fileSet, path, func(info os.FileInfo) bool {
+			return !strings.HasSuffix(info.Name(), "_test.go")
+		}, 0)
+		if err != nil {
+			return err
+		}
+
+		for packageName, pkg := range packages {
+			constants := stringConstants(pkg)
+			for _, file := range pkg.Files {
+				ast.Inspect(file, func(node ast.Node) bool {
+					call, isCall := node.(*ast.CallExpr)
+					if !isCall || len(call.Args) < 2 {
+						return true
+					}
+					selector, isSelector := call.Fun.(*ast.SelectorExpr)
+					if !isSelector || !isHTTPMethod(selector.Sel.Name) || !isWebAPIServer(selector.X, packageName) {
+						return true
+					}
+
+					routePath, resolved := stringValue(call.Args[0], constants)
+					require.True(t, resolved, "route at %s can not be resolved", fileSet.Position(call.Pos()))
+					registered[routeKey(selector.Sel.Name, routePath)] = fileSet.Position(call.Pos()).String()
+
+					return true
+				})
+			}
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return registered
+}
+
+// isWebAPIServer returns true if the expression is webapi.Server() (or the server variable inside the webapi package).
+func isWebAPIServer(expression ast.Expr, packageName string) bool {
+	switch expression := expression.(type) {
+	case *ast.CallExpr:
+		selector, isSelector := expression.Fun.(*ast.SelectorExpr)
+		if !isSelector || selector.Sel.Name != "Server" {
+			return false
+		}
+		ident, isIdent := selector.X.(*ast.Ident)
+		return isIdent && ident.Name == "webapi"
+	case *ast.Ident:
+		return packageName == "webapi" && expression.Name == "server"
+	default:
+		return false
+	}
+}
+
+// isHTTPMethod returns true if the given name is the name of a route registering method of echo.
+func isHTTPMethod(name string) bool {
+	switch name {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// stringConstants returns the values of the string constants that are declared in the given package.
+func stringConstants(pkg *ast.Package) map[string]string {
+	constants := make(map[string]string)
+	for _, file := range pkg.Files {
+		for _, declaration := range file.Decls {
+			genDecl, isGenDecl := declaration.(*ast.GenDecl)
+			if !isGenDecl || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, name := range valueSpec.Names {
+					if i < len(valueSpec.Values) {
+						if value, resolved := stringValue(valueSpec.Values[i], nil); resolved {
+							constants[name.Name] = value
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return constants
+}
+
+// stringValue returns the value of a string literal or a string constant.
+func stringValue(expression ast.Expr, constants map[string]string) (string, bool) {
+	switch expression := expression.(type) {
+	case *ast.BasicLit:
+		value, err := strconv.Unquote(expression.Value)
+		return value, err == nil && expression.Kind == token.STRING
+	case *ast.Ident:
+		value, exists := constants[expression.Name]
+		return value, exists
+	default:
+		return "", false
+	}
+}
+
+// hasParameter returns true if the operation declares a parameter with the given name.
+func hasParameter(operation *openapi.Operation, name string) bool {
+	for _, parameter := range operation.Parameters {
+		if parameter.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// references returns all $ref values of the given JSON document.
+func references(documentJSON []byte) (refs []string) {
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for key, child := range value {
+				if ref, isString := child.(string); isString && key == "$ref" {
+					refs = append(refs, ref)
+					continue
+				}
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range value {
+				walk(child)
+			}
+		}
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(documentJSON, &decoded); err == nil {
+		walk(decoded)
+	}
+
+	return refs
+}
@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/openapi"
+	"github.com/iotaledger/goshimmer/plugins/banner"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// PluginName is the name of the web API OpenAPI endpoint plugin.
+const PluginName = "WebAPI OpenAPI Endpoint"
+
+// route is the route that serves the OpenAPI document.
+const route = "openapi.json"
+
+var (
+	// plugin is the plugin instance of the web API OpenAPI endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+	log    *logger.Logger
+
+	// document is the OpenAPI document of the routes that are registered at the web API.
+	document     *openapi.Document
+	documentOnce sync.Once
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	log = logger.NewLogger(PluginName)
+	webapi.Server().GET(route, getDocument)
+}
+
+// getDocument returns the OpenAPI document of the web API. It is built on the first request, when all plugins have
+// registered their routes.
+func getDocument(c echo.Context) error {
+	documentOnce.Do(func() {
+		var err error
+		if document, err = Document(webapi.Server().Routes()); err != nil {
+			log.Errorf("Failed to build OpenAPI document: %s", err)
+		}
+	})
+	if document == nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(errors.New("failed to build OpenAPI document")))
+	}
+
+	return c.JSON(http.StatusOK, document)
+}
+
+// Document builds the OpenAPI document of the given registered routes. Routes that are not described in the routes
+// table are contained without parameters and response types.
+func Document(registeredRoutes []*echo.Route) (*openapi.Document, error) {
+	descriptions := make(map[string]openapi.Route)
+	for _, description := range routes {
+		descriptions[routeKey(description.Method, description.Path)] = description
+	}
+
+	sortedRoutes := make([]*echo.Route, len(registeredRoutes))
+	copy(sortedRoutes, registeredRoutes)
+	sort.Slice(sortedRoutes, func(i, j int) bool {
+		return routeKey(sortedRoutes[i].Method, sortedRoutes[i].Path) < routeKey(sortedRoutes[j].Method, sortedRoutes[j].Path)
+	})
+
+	builder := newBuilder()
+	usedTags := make(map[string]bool)
+	for _, registeredRoute := range sortedRoutes {
+		description, documented := descriptions[routeKey(registeredRoute.Method, registeredRoute.Path)]
+		if !documented {
+			description = openapi.Route{Method: registeredRoute.Method, Path: registeredRoute.Path}
+		}
+		if err := builder.AddRoute(description); err != nil {
+			return nil, err
+		}
+		usedTags[description.Tag] = true
+	}
+	for tag, tagDescription := range tags {
+		if usedTags[tag] {
+			builder.AddTag(tag, tagDescription)
+		}
+	}
+
+	return builder.Document(), nil
+}
+
+// newBuilder creates the Builder of the OpenAPI document of the web API.
+func newBuilder() *openapi.Builder {
+	return openapi.NewBuilder(openapi.Info{
+		Title:       "GoShimmer web API",
+		Description: "The web API of a GoShimmer node.",
+		Version:     banner.SimplifiedAppVersion,
+	}, jsonmodels.ErrorResponse{}).
+		AddSecurityScheme("basicAuth", &openapi.SecurityScheme{Type: "http", Scheme: "basic", Description: "HTTP basic auth (webapi.basic_auth)"}, false).
+		AddSecurityScheme("bearerAuth", &openapi.SecurityScheme{Type: "http", Scheme: "bearer", BearerFormat: "JWT", Description: "scoped API token (webapi.tokenAuth)"}, false)
+}
+
+// routeKey returns the key of a route in the routes table.
+func routeKey(method string, path string) string {
+	return strings.ToUpper(method) + " /" + strings.TrimPrefix(path, "/")
+}
@@ -0,0 +1,177 @@
+package openapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/manualpeering"
+	"github.com/iotaledger/goshimmer/packages/openapi"
+	"github.com/iotaledger/goshimmer/plugins/chat"
+	"github.com/iotaledger/goshimmer/plugins/networkdelay"
+	"github.com/iotaledger/goshimmer/plugins/webapi/mana"
+	toolsmessage "github.com/iotaledger/goshimmer/plugins/webapi/tools/message"
+	"github.com/iotaledger/goshimmer/plugins/webapi/weightprovider"
+)
+
+// region query parameters /////////////////////////////////////////////////////////////////////////////////////////////
+
+// neighborsQuery contains the query parameters of the autopeering/neighbors route.
+type neighborsQuery struct {
+	Known string `query:"known"`
+}
+
+// nHighestQuery contains the query parameters of the mana/*/nhighest routes.
+type nHighestQuery struct {
+	Number uint `query:"number"`
+}
+
+// orphanageQuery contains the query parameters of the tools/message/orphanage route.
+type orphanageQuery struct {
+	MsgID string `query:"msgID"`
+}
+
+// manualPeersQuery contains the query parameters of the manualpeering/peers route.
+type manualPeersQuery struct {
+	OnlyConnected bool `query:"onlyConnected"`
+}
+
+// eventsQuery contains the query parameters of the events routes.
+type eventsQuery struct {
+	Topic       []string `query:"topic"`
+	Address     []string `query:"address"`
+	Color       []string `query:"color"`
+	PayloadType []uint32 `query:"payloadType"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region routes ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tags contains the descriptions of the tags that group the routes.
+var tags = map[string]string{
+	"info":           "Information about the node",
+	"messages":       "Messages of the Tangle",
+	"ledgerstate":    "Addresses, outputs, branches and transactions of the ledger",
+	"mana":           "Access and consensus mana",
+	"drng":           "Distributed random number generator",
+	"faucet":         "Funding requests",
+	"autopeering":    "Neighbors selected by autopeering",
+	"manualpeering":  "Manually configured peers",
+	"weightprovider": "Weights of the nodes that are used to confirm messages",
+	"events":         "Live stream of message, ledger and mana events",
+	"tools":          "Debugging and diagnostic tools",
+	"admin":          "Runtime configuration of the node",
+	"apps":           "Applications built on top of the Tangle",
+}
+
+// routes contains the descriptions of all routes of the web API. Every route that is registered at the web API has to
+// be listed here (enforced by the tests of this package).
+var routes = []openapi.Route{
+	// info
+	{Method: http.MethodGet, Path: "/", Summary: "Returns INDEX", Tag: "info", ResponseContentType: openapi.ContentTypeText},
+	{Method: http.MethodGet, Path: "/openapi.json", Summary: "Returns the OpenAPI document of the web API", Tag: "info", Response: map[string]interface{}{}},
+	{Method: http.MethodGet, Path: "/info", Summary: "Returns the status of the node", Tag: "info", Response: jsonmodels.InfoResponse{}},
+	{Method: http.MethodGet, Path: "/healthz", Summary: "Returns 200 if the node is synced and has neighbors (503 otherwise)", Tag: "info"},
+	{Method: http.MethodGet, Path: "/snapshot", Summary: "Creates a snapshot of the ledger and returns it as a file", Tag: "info", ResponseContentType: openapi.ContentTypeBinary},
+
+	// messages
+	{Method: http.MethodGet, Path: "/messages/index", Summary: "Returns a page of the messages of a payload type, issuer and time range (requires messageLayer.messageIndex)", Tag: "messages", Query: jsonmodels.GetMessageIndexRequest{}, Response: jsonmodels.GetMessageIndexResponse{}},
+	{Method: http.MethodGet, Path: "/messages/:messageID", Summary: "Returns a message", Tag: "messages", Response: jsonmodels.Message{}},
+	{Method: http.MethodGet, Path: "/messages/:messageID/metadata", Summary: "Returns the metadata of a message", Tag: "messages", Response: jsonmodels.MessageMetadata{}},
+	{Method: http.MethodGet, Path: "/messages/:messageID/consensus", Summary: "Returns the consensus metadata of a message", Tag: "messages", Response: jsonmodels.MessageConsensusMetadata{}},
+	{Method: http.MethodPost, Path: "/messages/payload", Summary: "Issues a message with the given payload", Tag: "messages", Body: jsonmodels.PostPayloadRequest{}, Response: jsonmodels.PostPayloadResponse{}},
+	{Method: http.MethodPost, Path: "/data", Summary: "Issues a message with a data payload", Tag: "messages", Body: jsonmodels.DataRequest{}, Response: jsonmodels.DataResponse{}},
+	{Method: http.MethodGet, Path: "/data/tagged", Summary: "Returns the messages with a tagged data payload of a tag", Tag: "messages", Query: jsonmodels.GetTaggedDataRequest{}, Response: jsonmodels.GetTaggedDataResponse{}},
+
+	// ledgerstate
+	{Method: http.MethodGet, Path: "/ledgerstate/addresses/:address", Summary: "Returns the outputs of an address", Tag: "ledgerstate", Response: jsonmodels.GetAddressResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/addresses/:address/unspentOutputs", Summary: "Returns the unspent outputs of an address", Tag: "ledgerstate", Response: jsonmodels.GetAddressResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/addresses/:address/transactions", Summary: "Returns the transaction history of an address", Tag: "ledgerstate", Query: jsonmodels.GetAddressTransactionsRequest{}, Response: jsonmodels.GetAddressTransactionsResponse{}},
+	{Method: http.MethodPost, Path: "/ledgerstate/addresses/unspentOutputs", Summary: "Returns the unspent outputs of several addresses", Tag: "ledgerstate", Body: jsonmodels.PostAddressesUnspentOutputsRequest{}, Response: jsonmodels.PostAddressesUnspentOutputsResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/branches/:branchID", Summary: "Returns a branch", Tag: "ledgerstate", Response: jsonmodels.Branch{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/branches/:branchID/children", Summary: "Returns the children of a branch", Tag: "ledgerstate", Response: jsonmodels.GetBranchChildrenResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/branches/:branchID/conflicts", Summary: "Returns the conflicts of a branch", Tag: "ledgerstate", Response: jsonmodels.GetBranchConflictsResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/colors/:color", Summary: "Returns the supply of a color", Tag: "ledgerstate", Response: jsonmodels.ColorSupply{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/colors/:color/burns", Summary: "Returns the burn history of a color", Tag: "ledgerstate", Response: jsonmodels.GetColorBurnsResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/outputs/:outputID", Summary: "Returns an output", Tag: "ledgerstate", Response: jsonmodels.Output{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/outputs/:outputID/consumers", Summary: "Returns the consumers of an output", Tag: "ledgerstate", Response: jsonmodels.GetOutputConsumersResponse{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/outputs/:outputID/metadata", Summary: "Returns the metadata of an output", Tag: "ledgerstate", Response: jsonmodels.OutputMetadata{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/transactions/:transactionID", Summary: "Returns a transaction", Tag: "ledgerstate", Response: jsonmodels.Transaction{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/transactions/:transactionID/metadata", Summary: "Returns the metadata of a transaction", Tag: "ledgerstate", Response: jsonmodels.TransactionMetadata{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/transactions/:transactionID/inclusionState", Summary: "Returns the inclusion state of a transaction", Tag: "ledgerstate", Response: jsonmodels.TransactionInclusionState{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/transactions/:transactionID/consensus", Summary: "Returns the consensus metadata of a transaction", Tag: "ledgerstate", Response: jsonmodels.TransactionConsensusMetadata{}},
+	{Method: http.MethodGet, Path: "/ledgerstate/transactions/:transactionID/attachments", Summary: "Returns the messages that contain a transaction", Tag: "ledgerstate", Response: jsonmodels.GetTransactionAttachmentsResponse{}},
+	{Method: http.MethodPost, Path: "/ledgerstate/transactions", Summary: "Issues a transaction", Tag: "ledgerstate", Body: jsonmodels.PostTransactionRequest{}, Response: jsonmodels.PostTransactionResponse{}},
+	{Method: http.MethodPost, Path: "/ledgerstate/transactions/dryRun", Summary: "Validates a transaction without issuing it", Tag: "ledgerstate", Body: jsonmodels.PostTransactionRequest{}, Response: jsonmodels.DryRunTransactionResponse{}},
+
+	// mana
+	{Method: http.MethodGet, Path: "/mana", Summary: "Returns the mana of a node (the own node if no nodeID is given)", Tag: "mana", Query: jsonmodels.GetManaRequest{}, Response: jsonmodels.GetManaResponse{}},
+	{Method: http.MethodGet, Path: "/mana/all", Summary: "Returns the mana of all nodes", Tag: "mana", Response: jsonmodels.GetAllManaResponse{}},
+	{Method: http.MethodGet, Path: "/mana/access/nhighest", Summary: "Returns the nodes with the highest access mana", Tag: "mana", Query: nHighestQuery{}, Response: jsonmodels.GetNHighestResponse{}},
+	{Method: http.MethodGet, Path: "/mana/consensus/nhighest", Summary: "Returns the nodes with the highest consensus mana", Tag: "mana", Query: nHighestQuery{}, Response: jsonmodels.GetNHighestResponse{}},
+	{Method: http.MethodGet, Path: "/mana/percentile", Summary: "Returns the mana percentile of a node", Tag: "mana", Query: jsonmodels.GetPercentileRequest{}, Response: jsonmodels.GetPercentileResponse{}},
+	{Method: http.MethodGet, Path: "/mana/access/online", Summary: "Returns the access mana of the online nodes", Tag: "mana", Response: jsonmodels.GetOnlineResponse{}},
+	{Method: http.MethodGet, Path: "/mana/consensus/online", Summary: "Returns the consensus mana of the online nodes", Tag: "mana", Response: jsonmodels.GetOnlineResponse{}},
+	{Method: http.MethodGet, Path: "/mana/pending", Summary: "Returns the mana that would be pledged by spending an output", Tag: "mana", Query: jsonmodels.PendingRequest{}, Response: jsonmodels.PendingResponse{}},
+	{Method: http.MethodGet, Path: "/mana/allowedManaPledge", Summary: "Returns the node IDs that are accepted as mana pledge targets", Tag: "mana", Response: jsonmodels.AllowedManaPledgeResponse{}},
+	{Method: http.MethodGet, Path: "/mana/delegated", Summary: "Returns the amount of mana that is delegated to the node", Tag: "mana", Response: mana.GetDelegatedManaResponse{}},
+	{Method: http.MethodGet, Path: "/mana/delegated/outputs", Summary: "Returns the outputs that delegate mana to the node", Tag: "mana", Response: mana.GetDelegatedOutputsResponse{}},
+	{Method: http.MethodGet, Path: "/mana/analytics/history", Summary: "Returns the pledge and revoke history of a node", Tag: "mana", Query: jsonmodels.GetManaHistoryRequest{}, Response: jsonmodels.GetManaHistoryResponse{}},
+	{Method: http.MethodGet, Path: "/mana/analytics/pledgers", Summary: "Returns the addresses that pledged the most mana to a node", Tag: "mana", Query: jsonmodels.GetManaPledgersRequest{}, Response: jsonmodels.GetManaPledgersResponse{}},
+	{Method: http.MethodGet, Path: "/mana/analytics/pledgees", Summary: "Returns the nodes that received the most mana from an address", Tag: "mana", Query: jsonmodels.GetManaPledgeesRequest{}, Response: jsonmodels.GetManaPledgeesResponse{}},
+	{Method: http.MethodGet, Path: "/mana/analytics/epochs", Summary: "Returns the per epoch distribution of pledged mana", Tag: "mana", Query: jsonmodels.GetManaEpochsRequest{}, Response: jsonmodels.GetManaEpochsResponse{}},
+	{Method: http.MethodGet, Path: "/mana/analytics/gini", Summary: "Returns the Gini coefficients of the mana distributions", Tag: "mana", Response: jsonmodels.GetManaGiniResponse{}},
+
+	// drng
+	{Method: http.MethodPost, Path: "/drng/collectiveBeacon", Summary: "Issues a collective beacon", Tag: "drng", Body: jsonmodels.CollectiveBeaconRequest{}, Response: jsonmodels.CollectiveBeaconResponse{}},
+	{Method: http.MethodGet, Path: "/drng/info/committee", Summary: "Returns the dRNG committees", Tag: "drng", Response: jsonmodels.CommitteeResponse{}},
+	{Method: http.MethodGet, Path: "/drng/info/randomness", Summary: "Returns the latest randomness of the dRNG instances", Tag: "drng", Response: jsonmodels.RandomnessResponse{}},
+
+	// faucet
+	{Method: http.MethodPost, Path: "/faucet", Summary: "Requests funds from the faucet", Tag: "faucet", Body: jsonmodels.FaucetRequest{}, Response: jsonmodels.FaucetResponse{}},
+
+	// peering
+	{Method: http.MethodGet, Path: "/autopeering/neighbors", Summary: "Returns the neighbors of the node (and the known peers if known=1)", Tag: "autopeering", Query: neighborsQuery{}, Response: jsonmodels.GetNeighborsResponse{}},
+	{Method: http.MethodGet, Path: "/manualpeering/peers", Summary: "Returns the manually configured peers", Tag: "manualpeering", Query: manualPeersQuery{}, Response: []*manualpeering.KnownPeer{}},
+	{Method: http.MethodPost, Path: "/manualpeering/peers", Summary: "Adds manually configured peers", Tag: "manualpeering", Body: []*manualpeering.KnownPeerToAdd{}, Status: http.StatusNoContent},
+	{Method: http.MethodDelete, Path: "/manualpeering/peers", Summary: "Removes manually configured peers", Tag: "manualpeering", Body: []*jsonmodels.PeerToRemove{}, Status: http.StatusNoContent},
+
+	// weightprovider
+	{Method: http.MethodGet, Path: "/weightprovider/activenodes", Summary: "Returns the active nodes and the time they were last seen", Tag: "weightprovider", Response: map[string]time.Time{}},
+	{Method: http.MethodGet, Path: "/weightprovider/weights", Summary: "Returns the weights of the relevant supporters", Tag: "weightprovider", Response: weightprovider.Weights{}},
+
+	// events
+	{Method: http.MethodGet, Path: "/events", Summary: "Streams the events of the subscribed topics as server-sent events", Tag: "events", Query: eventsQuery{}, Response: jsonmodels.Event{}, ResponseContentType: openapi.ContentTypeEventStream},
+	{Method: http.MethodGet, Path: "/events/ws", Summary: "Streams the events of the subscribed topics as JSON over a WebSocket", Tag: "events", Query: eventsQuery{}, Status: http.StatusSwitchingProtocols},
+
+	// tools
+	{Method: http.MethodGet, Path: "/tools/message/pastcone", Summary: "Checks if the past cone of a message exists on the node", Tag: "tools", Query: jsonmodels.PastconeRequest{}, Response: jsonmodels.PastconeResponse{}},
+	{Method: http.MethodGet, Path: "/tools/message/missing", Summary: "Returns the IDs of the missing messages", Tag: "tools", Response: jsonmodels.MissingResponse{}},
+	{Method: http.MethodGet, Path: "/tools/message/approval", Summary: "Writes the first approval analysis to a file on the node", Tag: "tools", Response: toolsmessage.ApprovalResponse{}},
+	{Method: http.MethodGet, Path: "/tools/message/orphanage", Summary: "Writes the orphanage analysis of a message to a file on the node", Tag: "tools", Query: orphanageQuery{}, Response: toolsmessage.OrphanageResponse{}},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/messages", Summary: "Returns diagnostic information about all messages", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/messages/firstweakreferences", Summary: "Returns diagnostic information about the first weak references", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/messages/rank/:rank", Summary: "Returns diagnostic information about the messages starting at a rank", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/utxodag", Summary: "Returns diagnostic information about the UTXO DAG", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/branches", Summary: "Returns diagnostic information about all branches", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/branches/lazybooked", Summary: "Returns diagnostic information about the lazy booked branches", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/branches/invalid", Summary: "Returns diagnostic information about the invalid branches", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/tips", Summary: "Returns diagnostic information about all tips", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/tips/strong", Summary: "Returns diagnostic information about the strong tips", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/tips/weak", Summary: "Returns diagnostic information about the weak tips", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+	{Method: http.MethodGet, Path: "/tools/diagnostic/drng", Summary: "Returns diagnostic information about the dRNG messages", Tag: "tools", ResponseContentType: openapi.ContentTypeCSV},
+
+	// admin
+	{Method: http.MethodGet, Path: "/admin/config", Summary: "Returns the parameters that can be changed at runtime", Tag: "admin", Response: jsonmodels.GetConfigResponse{}},
+	{Method: http.MethodPost, Path: "/admin/config", Summary: "Changes a parameter at runtime and optionally writes it back to the config file", Tag: "admin", Body: jsonmodels.SetConfigRequest{}, Response: jsonmodels.SetConfigResponse{}},
+	{Method: http.MethodGet, Path: "/admin/config/audit", Summary: "Returns the latest changes of parameters at runtime", Tag: "admin", Response: jsonmodels.GetConfigAuditResponse{}},
+
+	// apps
+	{Method: http.MethodGet, Path: "/spammer", Summary: "Controls the message spammer", Tag: "apps", Query: jsonmodels.SpammerRequest{}, Response: jsonmodels.SpammerResponse{}},
+	{Method: http.MethodPost, Path: "/chat", Summary: "Issues a chat message", Tag: "apps", Body: chat.Request{}, Response: chat.Response{}},
+	{Method: http.MethodPost, Path: "/networkdelay", Summary: "Issues a network delay message", Tag: "apps", Response: networkdelay.Response{}},
+	{Method: http.MethodGet, Path: "/txstream/ws", Summary: "Serves the txstream protocol as JSON over a WebSocket", Tag: "apps", Status: http.StatusSwitchingProtocols},
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
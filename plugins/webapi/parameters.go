@@ -13,6 +13,16 @@ const (
 	CfgBasicAuthUsername = "webapi.basic_auth.username"
 	// CfgBasicAuthPassword defines the config flag of the webapi basic auth password.
 	CfgBasicAuthPassword = "webapi.basic_auth.password"
+	// CfgTokenAuthEnabled defines the config flag of the webapi token auth enabler.
+	CfgTokenAuthEnabled = "webapi.tokenAuth.enabled"
+	// CfgTokenAuthSecret defines the config flag of the secret that is used to sign and verify API tokens.
+	CfgTokenAuthSecret = "webapi.tokenAuth.secret"
+	// CfgTokenAuthRevocationFile defines the config flag of the file that contains the IDs of revoked API tokens.
+	CfgTokenAuthRevocationFile = "webapi.tokenAuth.revocationFile"
+	// CfgTokenAuthPublicRoutes defines the config flag of the routes that can be accessed without a token.
+	CfgTokenAuthPublicRoutes = "webapi.tokenAuth.publicRoutes"
+	// CfgTLSEnabled defines the config flag of the webapi TLS enabler (uses the shared tls.* configuration).
+	CfgTLSEnabled = "webapi.tls.enabled"
 )
 
 func init() {
@@ -20,4 +30,9 @@ func init() {
 	flag.Bool(CfgBasicAuthEnabled, false, "whether to enable HTTP basic auth")
 	flag.String(CfgBasicAuthUsername, "goshimmer", "HTTP basic auth username")
 	flag.String(CfgBasicAuthPassword, "goshimmer", "HTTP basic auth password")
+	flag.Bool(CfgTokenAuthEnabled, false, "whether to require scoped API tokens (replaces HTTP basic auth)")
+	flag.String(CfgTokenAuthSecret, "", "the secret that is used to sign and verify API tokens")
+	flag.String(CfgTokenAuthRevocationFile, "revokedtokens.txt", "the file that contains the IDs of revoked API tokens")
+	flag.StringSlice(CfgTokenAuthPublicRoutes, []string{"GET /healthz", "GET /info", "GET /openapi.json"}, "the routes that can be accessed without a token")
+	flag.Bool(CfgTLSEnabled, false, "whether the web API is served over TLS")
 }
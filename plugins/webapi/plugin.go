@@ -50,8 +50,13 @@ func Server() *echo.Echo {
 			AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
 		}))
 
+		// if enabled, configure token auth (it replaces the basic-auth)
+		if config.Node().Bool(CfgTokenAuthEnabled) {
+			server.Use(tokenAuth())
+		}
+
 		// if enabled, configure basic-auth
-		if config.Node().Bool(CfgBasicAuthEnabled) {
+		if config.Node().Bool(CfgBasicAuthEnabled) && !config.Node().Bool(CfgTokenAuthEnabled) {
 			server.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
 				if username == config.Node().String(CfgBasicAuthUsername) &&
 					password == config.Node().String(CfgBasicAuthPassword) {
@@ -122,8 +127,8 @@ func worker(shutdownSignal <-chan struct{}) {
 	stopped := make(chan struct{})
 	bindAddr := config.Node().String(CfgBindAddress)
 	go func() {
-		log.Infof("%s started, bind-address=%s, basic-auth=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled))
-		if err := server.Start(bindAddr); err != nil {
+		log.Infof("%s started, bind-address=%s, basic-auth=%v, token-auth=%v, tls=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled) && !config.Node().Bool(CfgTokenAuthEnabled), config.Node().Bool(CfgTokenAuthEnabled), config.Node().Bool(CfgTLSEnabled))
+		if err := start(bindAddr); err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				log.Errorf("Error serving: %s", err)
 			}
@@ -144,3 +149,19 @@ func worker(shutdownSignal <-chan struct{}) {
 		log.Errorf("Error stopping: %s", err)
 	}
 }
+
+// start starts the server on the given address and serves it over TLS if enabled.
+func start(bindAddr string) error {
+	if !config.Node().Bool(CfgTLSEnabled) {
+		return server.Start(bindAddr)
+	}
+
+	tlsConfig, err := config.TLS()
+	if err != nil {
+		return err
+	}
+	server.TLSServer.Addr = bindAddr
+	server.TLSServer.TLSConfig = tlsConfig
+
+	return server.StartServer(server.TLSServer)
+}
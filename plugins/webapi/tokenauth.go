@@ -0,0 +1,179 @@
+package webapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/apitoken"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/config"
+)
+
+// tokenClaimsContextKey is the key that is used to store the Claims of the API token in the echo.Context.
+const tokenClaimsContextKey = "apiTokenClaims"
+
+var (
+	// routePermissions maps the registered routes ("METHOD /path") to the Scope that is required to access them. Routes
+	// that are not listed require the ScopeRead for GET requests and the ScopeAdmin for all other methods.
+	routePermissions = map[string]apitoken.Scope{
+		"POST /data":                                 apitoken.ScopeIssue,
+		"POST /messages/payload":                     apitoken.ScopeIssue,
+		"POST /ledgerstate/transactions":             apitoken.ScopeIssue,
+		"POST /ledgerstate/transactions/dryRun":      apitoken.ScopeRead,
+		"POST /ledgerstate/addresses/unspentOutputs": apitoken.ScopeRead,
+		"POST /chat":                                 apitoken.ScopeIssue,
+		"POST /networkdelay":                         apitoken.ScopeIssue,
+		"POST /faucet":                               apitoken.ScopeFaucet,
+		"POST /drng/collectiveBeacon":                apitoken.ScopeAdmin,
+		"GET /spammer":                               apitoken.ScopeAdmin,
+		"GET /snapshot":                              apitoken.ScopeAdmin,
+	}
+
+	// adminRoutePrefixes contains the path prefixes of routes that always require the ScopeAdmin.
+	adminRoutePrefixes = []string{"/tools/", "/admin/"}
+
+	// queryTokenRoutes contains the routes that accept the token in the query parameter. Tokens in URLs end up in logs and
+	// browser histories, so this is restricted to the WebSocket routes whose clients can not set headers.
+	queryTokenRoutes = make(map[string]bool)
+
+	routePermissionsMutex sync.RWMutex
+)
+
+// SetRoutePermission defines the Scope that is required to access the given route if token auth is enabled. It allows
+// plugins to override the default permission of the routes they register.
+func SetRoutePermission(method string, path string, scope apitoken.Scope) {
+	routePermissionsMutex.Lock()
+	defer routePermissionsMutex.Unlock()
+
+	routePermissions[routeKey(method, path)] = scope
+}
+
+// AllowQueryToken allows clients of the given GET route to pass the API token in the token query parameter instead of the
+// Authorization header. It is meant for WebSocket routes, as browsers can not set headers on WebSocket connections.
+func AllowQueryToken(path string) {
+	routePermissionsMutex.Lock()
+	defer routePermissionsMutex.Unlock()
+
+	queryTokenRoutes[routeKey(http.MethodGet, path)] = true
+}
+
+// TokenClaims returns the Claims of the API token that was used to authorize the request (nil if token auth is
+// disabled or the route is public).
+func TokenClaims(c echo.Context) *apitoken.Claims {
+	claims, _ := c.Get(tokenClaimsContextKey).(*apitoken.Claims)
+
+	return claims
+}
+
+// requiredScope returns the Scope that is required to access the given route.
+func requiredScope(method string, path string) apitoken.Scope {
+	routePermissionsMutex.RLock()
+	defer routePermissionsMutex.RUnlock()
+
+	if scope, exists := routePermissions[routeKey(method, path)]; exists {
+		return scope
+	}
+
+	for _, prefix := range adminRoutePrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return apitoken.ScopeAdmin
+		}
+	}
+
+	if method == http.MethodGet || method == http.MethodHead {
+		return apitoken.ScopeRead
+	}
+
+	return apitoken.ScopeAdmin
+}
+
+// tokenAuth returns the middleware that enforces the scopes, the revocation and the rate limit of API tokens.
+func tokenAuth() echo.MiddlewareFunc {
+	secret := []byte(config.Node().String(CfgTokenAuthSecret))
+	if len(secret) == 0 {
+		panic(fmt.Sprintf("%s must be set if %s is enabled", CfgTokenAuthSecret, CfgTokenAuthEnabled))
+	}
+
+	revocationList, err := apitoken.NewRevocationList(config.Node().String(CfgTokenAuthRevocationFile))
+	if err != nil {
+		panic(err)
+	}
+
+	publicRoutes := make(map[string]bool)
+	for _, publicRoute := range config.Node().Strings(CfgTokenAuthPublicRoutes) {
+		if fields := strings.Fields(publicRoute); len(fields) == 2 {
+			publicRoutes[routeKey(fields[0], fields[1])] = true
+		}
+	}
+
+	return newTokenAuthMiddleware(secret, revocationList, publicRoutes)
+}
+
+// newTokenAuthMiddleware creates the middleware that checks the tokens signed with the given secret.
+func newTokenAuthMiddleware(secret []byte, revocationList *apitoken.RevocationList, publicRoutes map[string]bool) echo.MiddlewareFunc {
+	rateLimiter := apitoken.NewRateLimiter()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if method == http.MethodOptions || publicRoutes[routeKey(method, c.Path())] {
+				return next(c)
+			}
+
+			token := tokenFromRequest(c)
+			if token == "" {
+				return c.JSON(http.StatusUnauthorized, jsonmodels.NewErrorResponse(errors.New("missing API token")))
+			}
+
+			claims, err := apitoken.Parse(secret, token)
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, jsonmodels.NewErrorResponse(err))
+			}
+			if revocationList.IsRevoked(claims.ID) {
+				return c.JSON(http.StatusUnauthorized, jsonmodels.NewErrorResponse(errors.Errorf("token %s: %w", claims.ID, apitoken.ErrTokenRevoked)))
+			}
+
+			if scope := requiredScope(method, c.Path()); !claims.Scopes.Allows(scope) {
+				return c.JSON(http.StatusForbidden, jsonmodels.NewErrorResponse(errors.Errorf("token %s does not grant the '%s' scope", claims.ID, scope)))
+			}
+
+			if !rateLimiter.Allow(claims.ID, claims.RateLimit) {
+				return c.JSON(http.StatusTooManyRequests, jsonmodels.NewErrorResponse(errors.Errorf("rate limit of token %s (%d requests per minute) exceeded", claims.ID, claims.RateLimit)))
+			}
+
+			c.Set(tokenClaimsContextKey, claims)
+
+			return next(c)
+		}
+	}
+}
+
+// tokenFromRequest extracts the API token from the Authorization header (Bearer scheme) or, for the routes registered
+// with AllowQueryToken, the token query parameter.
+func tokenFromRequest(c echo.Context) string {
+	if authorization := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	}
+
+	routePermissionsMutex.RLock()
+	defer routePermissionsMutex.RUnlock()
+	if !queryTokenRoutes[routeKey(c.Request().Method, c.Path())] {
+		return ""
+	}
+
+	return c.QueryParam("token")
+}
+
+// routeKey returns the key of the given route in the permission tables.
+func routeKey(method string, path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return strings.ToUpper(method) + " " + path
+}
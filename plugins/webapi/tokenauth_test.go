@@ -0,0 +1,104 @@
+package webapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/apitoken"
+)
+
+func TestRequiredScope(t *testing.T) {
+	// routes that are not listed
+	assert.Equal(t, apitoken.ScopeRead, requiredScope(http.MethodGet, "/messages/:id"))
+	assert.Equal(t, apitoken.ScopeRead, requiredScope(http.MethodHead, "/messages/:id"))
+	assert.Equal(t, apitoken.ScopeAdmin, requiredScope(http.MethodPost, "/messages/:id"))
+	assert.Equal(t, apitoken.ScopeAdmin, requiredScope(http.MethodDelete, "/manualpeering/peers"))
+
+	// admin prefixes
+	assert.Equal(t, apitoken.ScopeAdmin, requiredScope(http.MethodGet, "/tools/message/pastcone"))
+	assert.Equal(t, apitoken.ScopeAdmin, requiredScope(http.MethodGet, "/admin/config"))
+
+	// explicit entries
+	assert.Equal(t, apitoken.ScopeIssue, requiredScope(http.MethodPost, "/data"))
+	assert.Equal(t, apitoken.ScopeRead, requiredScope(http.MethodPost, "/ledgerstate/transactions/dryRun"))
+	assert.Equal(t, apitoken.ScopeFaucet, requiredScope(http.MethodPost, "/faucet"))
+	assert.Equal(t, apitoken.ScopeAdmin, requiredScope(http.MethodGet, "/spammer"))
+
+	// routes registered by plugins
+	SetRoutePermission(http.MethodGet, "test/ws", apitoken.ScopeIssue)
+	defer deleteRoutePermission(http.MethodGet, "test/ws")
+	assert.Equal(t, apitoken.ScopeIssue, requiredScope(http.MethodGet, "/test/ws"))
+}
+
+func TestTokenAuth(t *testing.T) {
+	secret := []byte("secret")
+	revocationList, err := apitoken.NewRevocationList("")
+	require.NoError(t, err)
+
+	server := echo.New()
+	server.Use(newTokenAuthMiddleware(secret, revocationList, map[string]bool{routeKey(http.MethodGet, "/info"): true}))
+	handler := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	server.GET("/info", handler)
+	server.GET("/messages/:id", handler)
+	server.POST("/data", handler)
+	server.GET("/test/ws", handler)
+
+	SetRoutePermission(http.MethodGet, "test/ws", apitoken.ScopeIssue)
+	defer deleteRoutePermission(http.MethodGet, "test/ws")
+	AllowQueryToken("test/ws")
+	defer deleteQueryTokenRoute("test/ws")
+
+	issue := func(scopes ...apitoken.Scope) string {
+		claims, err := apitoken.NewClaims("test", scopes, 0, 0)
+		require.NoError(t, err)
+		token, err := apitoken.Issue(secret, claims)
+		require.NoError(t, err)
+		return token
+	}
+	readToken := issue(apitoken.ScopeRead)
+	issueToken := issue(apitoken.ScopeRead, apitoken.ScopeIssue)
+
+	status := func(method string, target string, token string) int {
+		request := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/info", ""))
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodGet, "/messages/1", ""))
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodGet, "/messages/1", "invalid"))
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/messages/1", readToken))
+
+	// a read token must not be able to use the issue routes
+	assert.Equal(t, http.StatusForbidden, status(http.MethodPost, "/data", readToken))
+	assert.Equal(t, http.StatusOK, status(http.MethodPost, "/data", issueToken))
+	assert.Equal(t, http.StatusForbidden, status(http.MethodGet, "/test/ws", readToken))
+	assert.Equal(t, http.StatusForbidden, status(http.MethodGet, "/test/ws?token="+readToken, ""))
+	assert.Equal(t, http.StatusOK, status(http.MethodGet, "/test/ws?token="+issueToken, ""))
+
+	// the query parameter is ignored on the other routes
+	assert.Equal(t, http.StatusUnauthorized, status(http.MethodGet, "/messages/1?token="+readToken, ""))
+}
+
+func deleteRoutePermission(method string, path string) {
+	routePermissionsMutex.Lock()
+	defer routePermissionsMutex.Unlock()
+
+	delete(routePermissions, routeKey(method, path))
+}
+
+func deleteQueryTokenRoute(path string) {
+	routePermissionsMutex.Lock()
+	defer routePermissionsMutex.Unlock()
+
+	delete(queryTokenRoutes, routeKey(http.MethodGet, path))
+}
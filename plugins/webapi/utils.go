@@ -3,6 +3,9 @@ package webapi
 import (
 	"encoding/json"
 	"io"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/labstack/echo"
@@ -16,3 +19,27 @@ func ParseJSONRequest(c echo.Context, dest interface{}) error {
 	}
 	return nil
 }
+
+// OriginAllowed checks if a WebSocket connection may be opened from the origin of the request. Requests without an
+// Origin header do not come from browsers and are allowed (they are authorized like every other request), while
+// browsers are restricted to the node's own origin and the allowed origins, so that other websites can not use the
+// WebSocket with the credentials of the user.
+func OriginAllowed(r *http.Request, allowedOrigins []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowedOrigin := range allowedOrigins {
+		if allowedOrigin == "*" || strings.EqualFold(strings.TrimSuffix(allowedOrigin, "/"), origin) {
+			return true
+		}
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(originURL.Host, r.Host)
+}
@@ -0,0 +1,26 @@
+package webapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOriginAllowed(t *testing.T) {
+	request := func(origin string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "http://node.example.com:8080/events/ws", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return r
+	}
+
+	assert.True(t, OriginAllowed(request(""), nil))
+	assert.True(t, OriginAllowed(request("http://node.example.com:8080"), nil))
+	assert.False(t, OriginAllowed(request("https://evil.example.com"), nil))
+	assert.True(t, OriginAllowed(request("https://explorer.example.com"), []string{"https://explorer.example.com/"}))
+	assert.False(t, OriginAllowed(request("https://evil.example.com"), []string{"https://explorer.example.com"}))
+	assert.True(t, OriginAllowed(request("https://evil.example.com"), []string{"*"}))
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/apitoken"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+	}
+
+	switch os.Args[1] {
+	case "issue":
+		issue(os.Args[2:])
+	case "revoke":
+		revoke(os.Args[2:])
+	default:
+		printUsage()
+	}
+}
+
+func issue(args []string) {
+	command := flag.NewFlagSet("issue", flag.ExitOnError)
+	secret := command.String("secret", os.Getenv("GOSHIMMER_API_TOKEN_SECRET"), "the secret of the node (webapi.tokenAuth.secret), defaults to $GOSHIMMER_API_TOKEN_SECRET")
+	subject := command.String("subject", "", "a human readable name of the owner of the token")
+	scopes := command.String("scopes", string(apitoken.ScopeRead), "comma separated list of scopes: read, issue, faucet, admin")
+	ttl := command.Duration("ttl", 0, "the time until the token expires, 0 issues a token that does not expire")
+	rateLimit := command.Int("rateLimit", 0, "the maximum number of requests per minute, 0 disables the rate limit")
+	if err := command.Parse(args); err != nil {
+		fail(err)
+	}
+
+	if *secret == "" {
+		fail(fmt.Errorf("the secret has to be set"))
+	}
+	parsedScopes, err := apitoken.ScopesFromString(*scopes)
+	if err != nil {
+		fail(err)
+	}
+
+	claims, err := apitoken.NewClaims(*subject, parsedScopes, *ttl, *rateLimit)
+	if err != nil {
+		fail(err)
+	}
+	token, err := apitoken.Issue([]byte(*secret), claims)
+	if err != nil {
+		fail(err)
+	}
+
+	fmt.Println("Token ID:  ", claims.ID)
+	fmt.Println("Scopes:    ", claims.Scopes)
+	if claims.ExpiresAt != 0 {
+		fmt.Println("Expires at:", time.Unix(claims.ExpiresAt, 0).Format(time.RFC3339))
+	}
+	fmt.Println("Token:     ", token)
+}
+
+func revoke(args []string) {
+	command := flag.NewFlagSet("revoke", flag.ExitOnError)
+	revocationFile := command.String("revocationFile", "revokedtokens.txt", "the revocation list of the node (webapi.tokenAuth.revocationFile)")
+	id := command.String("id", "", "the ID of the token to revoke")
+	token := command.String("token", "", "the token to revoke (alternative to id)")
+	if err := command.Parse(args); err != nil {
+		fail(err)
+	}
+
+	if *id == "" && *token != "" {
+		claims, err := apitoken.ParseUnverified(*token)
+		if err != nil {
+			fail(err)
+		}
+		*id = claims.ID
+	}
+	if *id == "" {
+		fail(fmt.Errorf("either id or token has to be set"))
+	}
+
+	revocationList, err := apitoken.NewRevocationList(*revocationFile)
+	if err != nil {
+		fail(err)
+	}
+	if err = revocationList.Revoke(*id); err != nil {
+		fail(err)
+	}
+
+	fmt.Printf("Token %s revoked, the node picks up the change automatically.\n", *id)
+}
+
+func printUsage() {
+	fmt.Println("USAGE:")
+	fmt.Println("  api-token issue --secret=<secret> --scopes=read,issue [--subject=<name>] [--ttl=720h] [--rateLimit=60]")
+	fmt.Println("  api-token revoke --revocationFile=<path> (--id=<token ID> | --token=<token>)")
+	os.Exit(1)
+}
+
+func fail(err error) {
+	fmt.Println("Error:", err)
+	os.Exit(1)
+}
@@ -11,6 +11,7 @@ import (
 type configuration struct {
 	WebAPI               string           `json:"WebAPI,omitempty"`
 	BasicAuth            client.BasicAuth `json:"basic_auth,omitempty"`
+	APIToken             string           `json:"apiToken,omitempty"`
 	ReuseAddresses       bool             `json:"reuse_addresses"`
 	FaucetPowDifficulty  int              `json:"faucetPowDifficulty"`
 	AssetRegistryNetwork string           `json:"assetRegistryNetwork"`
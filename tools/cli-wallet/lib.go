@@ -34,6 +34,11 @@ func loadWallet() *wallet.Wallet {
 		options = append(options, client.WithBasicAuth(config.BasicAuth.Credentials()))
 	}
 
+	// configure the API token
+	if config.APIToken != "" {
+		options = append(options, client.WithAPIToken(config.APIToken))
+	}
+
 	if assetRegistry != nil {
 		// we do have an asset registry parsed
 		if config.AssetRegistryNetwork != assetRegistry.Network() && registryservice.Networks[config.AssetRegistryNetwork] {
@@ -160,6 +165,12 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("        consolidate available funds under one wallet address")
 		fmt.Println("  claim-conditional")
 		fmt.Println("        claim (move) conditionally owned funds into the wallet")
+		fmt.Println("  swap-initiate")
+		fmt.Println("        lock funds in a hash-time-locked output for an atomic swap")
+		fmt.Println("  swap-claim")
+		fmt.Println("        claim a hash-time-locked output by revealing its preimage")
+		fmt.Println("  swap-refund")
+		fmt.Println("        refund a hash-time-locked output after its deadline")
 		fmt.Println("  request-funds")
 		fmt.Println("        request funds from the testnet-faucet")
 		fmt.Println("  create-asset")
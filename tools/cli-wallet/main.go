@@ -38,6 +38,9 @@ func main() {
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
 	consolidateFundsCommand := flag.NewFlagSet("consolidate-funds", flag.ExitOnError)
 	claimConditionalFundsCommand := flag.NewFlagSet("claim-conditional", flag.ExitOnError)
+	initiateSwapCommand := flag.NewFlagSet("swap-initiate", flag.ExitOnError)
+	claimSwapCommand := flag.NewFlagSet("swap-claim", flag.ExitOnError)
+	refundSwapCommand := flag.NewFlagSet("swap-refund", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
 	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
@@ -67,6 +70,12 @@ func main() {
 		execConsolidateFundsCommand(consolidateFundsCommand, wallet)
 	case "claim-conditional":
 		execClaimConditionalCommand(claimConditionalFundsCommand, wallet)
+	case "swap-initiate":
+		execInitiateSwapCommand(initiateSwapCommand, wallet)
+	case "swap-claim":
+		execClaimSwapCommand(claimSwapCommand, wallet)
+	case "swap-refund":
+		execRefundSwapCommand(refundSwapCommand, wallet)
 	case "create-asset":
 		execCreateAssetCommand(createAssetCommand, wallet)
 	case "asset-info":
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/claimswapoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/initiateswapoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/refundswapoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execInitiateSwapCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	addressPtr := command.String("dest-addr", "", "address of the counterparty that can claim the swapped funds")
+	amountPtr := command.Int64("amount", 0, "the amount of tokens that are locked for the counterparty")
+	colorPtr := command.String("color", "IOTA", "(optional) color of the tokens to lock")
+	deadlinePtr := command.Int64("deadline", 0, "unix timestamp until which the counterparty can claim the funds, afterwards they can be refunded")
+	preimagePtr := command.String("preimage", "", "(optional) base58 encoded secret that locks the funds, a random one is generated if neither preimage nor hash-lock are set")
+	hashLockPtr := command.String("hash-lock", "", "(optional) base58 encoded hash lock of the counterparty's secret when responding to a swap")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *addressPtr == "" {
+		printUsage(command, "dest-addr has to be set")
+	}
+	if *amountPtr <= 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+	if *deadlinePtr <= 0 {
+		printUsage(command, "deadline has to be set")
+	}
+	if *preimagePtr != "" && *hashLockPtr != "" {
+		printUsage(command, "only one of preimage and hash-lock can be set")
+	}
+
+	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	var color ledgerstate.Color
+	switch *colorPtr {
+	case "IOTA":
+		color = ledgerstate.ColorIOTA
+	default:
+		color, err = ledgerstate.ColorFromBase58EncodedString(*colorPtr)
+		if err != nil {
+			printUsage(command, err.Error())
+		}
+	}
+
+	options := []initiateswapoptions.InitiateSwapOption{
+		initiateswapoptions.Destination(address.Address{
+			AddressBytes: destinationAddress.Array(),
+		}, uint64(*amountPtr), color),
+		initiateswapoptions.Deadline(time.Unix(*deadlinePtr, 0)),
+		initiateswapoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		initiateswapoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+	if *preimagePtr != "" {
+		preimage, decodeErr := base58.Decode(*preimagePtr)
+		if decodeErr != nil {
+			printUsage(command, fmt.Sprintf("wrong preimage: %s", decodeErr.Error()))
+		}
+		options = append(options, initiateswapoptions.Preimage(preimage))
+	}
+	if *hashLockPtr != "" {
+		hashLock, parseErr := ledgerstate.HashLockFromBase58EncodedString(*hashLockPtr)
+		if parseErr != nil {
+			printUsage(command, fmt.Sprintf("wrong hash lock: %s", parseErr.Error()))
+		}
+		options = append(options, initiateswapoptions.HashLock(hashLock))
+	}
+
+	fmt.Println("Initiating swap...")
+	tx, preimage, hashLock, err := cliWallet.InitiateSwap(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	for index, output := range tx.Essence().Outputs() {
+		if output.Type() == ledgerstate.ExtendedLockedOutputType {
+			fmt.Println("Swap Output ID: ", ledgerstate.NewOutputID(tx.ID(), uint16(index)).Base58())
+		}
+	}
+	fmt.Println("Hash Lock:      ", hashLock.Base58())
+	if len(preimage) != 0 {
+		fmt.Println("Preimage:       ", base58.Encode(preimage), "(keep secret until the counterparty locked its funds)")
+	}
+	fmt.Println("Initiating swap... [DONE]")
+}
+
+func execClaimSwapCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outputIDPtr := command.String("id", "", "output ID of the hash-time-locked output to claim")
+	preimagePtr := command.String("preimage", "", "base58 encoded secret that unlocks the hash lock")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *outputIDPtr == "" {
+		printUsage(command, "id has to be set")
+	}
+	if *preimagePtr == "" {
+		printUsage(command, "preimage has to be set")
+	}
+	preimage, err := base58.Decode(*preimagePtr)
+	if err != nil {
+		printUsage(command, fmt.Sprintf("wrong preimage: %s", err.Error()))
+	}
+
+	fmt.Println("Claiming swap...")
+	_, err = cliWallet.ClaimSwap(
+		claimswapoptions.OutputID(*outputIDPtr),
+		claimswapoptions.Preimage(preimage),
+		claimswapoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		claimswapoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Claiming swap... [DONE]")
+}
+
+func execRefundSwapCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outputIDPtr := command.String("id", "", "output ID of the expired hash-time-locked output to refund")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *outputIDPtr == "" {
+		printUsage(command, "id has to be set")
+	}
+
+	fmt.Println("Refunding swap...")
+	_, err = cliWallet.RefundSwap(
+		refundswapoptions.OutputID(*outputIDPtr),
+		refundswapoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		refundswapoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Refunding swap... [DONE]")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/recorder"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+var (
+	recordingFile = flag.String("recording", "./recording.bin", "the recording to replay")
+	snapshotFile  = flag.String("snapshot", "", "the snapshot the recording was made on (optional)")
+	speed         = flag.Float64("speed", 0, "the speed of the replay relative to the recording, 0 replays as fast as possible")
+	order         = flag.String("order", string(recorder.OrderRecorded), "the order of the replay: recorded, shuffled or reversed")
+	seed          = flag.Int64("seed", 0, "the seed used to shuffle the recording")
+	quiet         = flag.Duration("quiet", 2*time.Second, "the time without progress after which the replay is considered done")
+	timeout       = flag.Duration("timeout", time.Minute, "the maximum time to wait for the Tangle to process the replayed messages")
+)
+
+func main() {
+	flag.Parse()
+
+	f, err := os.Open(*recordingFile)
+	if err != nil {
+		log.Fatalf("failed to open recording: %s", err)
+	}
+	reader, err := recorder.NewReader(f)
+	if err != nil {
+		log.Fatal(err)
+	}
+	records, err := reader.ReadAll()
+	_ = f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("read %d records from %s", len(records), *recordingFile)
+
+	t := tangle.New(
+		tangle.Store(mapdb.NewMapDB()),
+		tangle.StartSynced(true),
+		tangle.SchedulerConfig(tangle.SchedulerParams{
+			MaxBufferSize:               1024 * 1024,
+			Rate:                        time.Second / 5000,
+			AccessManaRetrieveFunc:      func(identity.ID) float64 { return 1 },
+			TotalAccessManaRetrieveFunc: func() float64 { return 1 },
+		}),
+	)
+	defer t.Shutdown()
+	t.Setup()
+
+	t.Events.Error.Attach(events.NewClosure(func(err error) {
+		log.Printf("error in Tangle: %s", err)
+	}))
+
+	if *snapshotFile != "" {
+		loadSnapshot(t, *snapshotFile)
+	}
+
+	replayer := recorder.NewReplayer(t, recorder.Speed(*speed), recorder.Ordering(recorder.Order(*order)), recorder.Seed(*seed))
+	defer replayer.Detach()
+
+	start := time.Now()
+	if err = replayer.Replay(records); err != nil {
+		log.Fatal(err)
+	}
+	if !replayer.WaitIdle(*quiet, *timeout) {
+		log.Printf("the Tangle did not become idle within %s", *timeout)
+	}
+
+	stats := replayer.Stats()
+	log.Printf("replayed %d records in %s", stats.Replayed, time.Since(start))
+	log.Printf("parsed: %d, bytes rejected: %d, message rejected: %d", stats.Parsed, stats.BytesRejected, stats.MessageRejected)
+	log.Printf("stored: %d, solid: %d, booked: %d, invalid: %d", stats.Stored, stats.Solid, stats.Booked, stats.Invalid)
+}
+
+func loadSnapshot(t *tangle.Tangle, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open snapshot: %s", err)
+	}
+	defer f.Close()
+
+	snapshot := &ledgerstate.Snapshot{}
+	if _, err = snapshot.ReadFrom(f); err != nil {
+		log.Fatalf("failed to read snapshot: %s", err)
+	}
+	if err = t.LedgerState.LoadSnapshot(snapshot); err != nil {
+		log.Fatalf("failed to load snapshot: %s", err)
+	}
+}
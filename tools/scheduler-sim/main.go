@@ -0,0 +1,81 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/schedulersim"
+)
+
+var (
+	scenarioFile = flag.String("scenario", "", "the JSON file of the scenario to simulate (uses the default scenario if empty)")
+	jsonFile     = flag.String("json", "", "the file the JSON report is written to")
+	csvDir       = flag.String("csv", "", "the directory the CSV reports (nodes.csv and blacklistings.csv) are written to")
+	seed         = flag.Int64("seed", 0, "overrides the seed of the scenario (if not 0)")
+	writeDefault = flag.String("write-default", "", "writes the default scenario to the given file and exits")
+)
+
+func main() {
+	flag.Parse()
+
+	if *writeDefault != "" {
+		writeFile(*writeDefault, func(f *os.File) error { return schedulersim.DefaultScenario().WriteJSON(f) })
+		return
+	}
+
+	scenario := schedulersim.DefaultScenario()
+	if *scenarioFile != "" {
+		f, err := os.Open(*scenarioFile)
+		if err != nil {
+			log.Fatalf("failed to open scenario: %s", err)
+		}
+		scenario, err = schedulersim.ReadScenario(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *seed != 0 {
+		scenario.Seed = *seed
+	}
+
+	report, err := schedulersim.Run(scenario)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, n := range report.Nodes {
+		log.Printf("%-20s %-9s mana=%.3f throughput=%.3f latency(p50/p99)=%.0f/%.0fms discarded=%d dropped=%d blacklisted=%d",
+			n.Issuer, n.Behavior, n.ManaShare, n.ThroughputShare, n.LatencyP50, n.LatencyP99, n.Discarded, n.Dropped, n.Blacklisted)
+	}
+	log.Printf("fairness index: %.3f (all issuers: %.3f), blacklistings: %d", report.Fairness.Index, report.Fairness.IndexAll, len(report.Blacklistings))
+
+	if *jsonFile != "" {
+		writeFile(*jsonFile, func(f *os.File) error { return report.WriteJSON(f) })
+	}
+	if *csvDir != "" {
+		if err := os.MkdirAll(*csvDir, 0o755); err != nil {
+			log.Fatalf("failed to create CSV directory: %s", err)
+		}
+		writeFile(filepath.Join(*csvDir, "nodes.csv"), func(f *os.File) error { return report.WriteNodesCSV(f) })
+		writeFile(filepath.Join(*csvDir, "blacklistings.csv"), func(f *os.File) error { return report.WriteBlacklistingsCSV(f) })
+	}
+}
+
+func writeFile(path string, write func(f *os.File) error) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatalf("failed to create %s: %s", path, err)
+	}
+	if err = write(f); err != nil {
+		_ = f.Close()
+		log.Fatalf("failed to write %s: %s", path, err)
+	}
+	if err = f.Close(); err != nil {
+		log.Fatalf("failed to close %s: %s", path, err)
+	}
+	log.Printf("wrote %s", path)
+}